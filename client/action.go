@@ -22,6 +22,7 @@ import (
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -46,6 +47,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/protocolwrapper"
 	"dubbo.apache.org/dubbo-go/v3/proxy"
+	"dubbo.apache.org/dubbo-go/v3/registry/migration"
 )
 
 func getEnv(key, fallback string) string {
@@ -208,6 +210,11 @@ func (refOpts *ReferenceOptions) refer(srv common.RPCService, info *ClientInfo)
 	// this protocol would be destroyed in graceful_shutdown
 	// please refer to (https://github.com/apache/dubbo-go/issues/2429)
 	graceful_shutdown.RegisterProtocol(ref.Protocol)
+	for _, proto := range ref.ProtocolPreference {
+		if proto != ref.Protocol {
+			graceful_shutdown.RegisterProtocol(proto)
+		}
+	}
 }
 
 func processURL(ref *global.ReferenceConfig, registries map[string]*global.RegistryConfig, cfgURL *common.URL) ([]*common.URL, error) {
@@ -229,6 +236,8 @@ func processUserSpecifiedURLs(ref *global.ReferenceConfig, cfgURL *common.URL) (
 			2. "registry://localhost:2181" is a registry url.
 		 Then, refOpts.URL looks like a string separated by semicolon: "tri://localhost:10000;registry://localhost:2181".
 		 The result of urlStrings is a string array: []string{"tri://localhost:10000", "registry://localhost:2181"}.
+		 A direct url may also be a unix domain socket like "unix:///var/run/dubbo/greet.sock", which is referred by
+		 the protocol of the reference.
 	*/
 	var urls []*common.URL
 	urlStrings := gxstrings.RegSplit(ref.URL, "\\s*[;]+\\s*")
@@ -238,6 +247,10 @@ func processUserSpecifiedURLs(ref *global.ReferenceConfig, cfgURL *common.URL) (
 		if err != nil {
 			return nil, fmt.Errorf("url configuration error,  please check your configuration, user specified URL %v refer error, error message is %v ", urlStr, err.Error())
 		}
+		if strings.HasPrefix(urlStr, constant.UnixScheme+"://") {
+			serviceURL.SetUnixSocket(serviceURL.Location + serviceURL.Path)
+			serviceURL.Path = ""
+		}
 
 		urls = append(urls, buildReferenceURL(serviceURL, ref, cfgURL))
 	}
@@ -295,8 +308,8 @@ func buildInvoker(urls []*common.URL, ref *global.ReferenceConfig) (base.Invoker
 		return nil, perrors.New("invoker list is empty")
 	}
 
-	invokers := make([]base.Invoker, len(urls))
-	for i, u := range urls {
+	invokers, regURL, urls := migration.ReferAll(urls)
+	for _, u := range urls {
 		if u.Protocol == constant.ServiceRegistryProtocol {
 			invoker = extension.GetProtocol(constant.RegistryProtocol).Refer(u)
 		} else {
@@ -310,7 +323,7 @@ func buildInvoker(urls []*common.URL, ref *global.ReferenceConfig) (base.Invoker
 		if u.Protocol == constant.RegistryProtocol {
 			regURL = u
 		}
-		invokers[i] = invoker
+		invokers = append(invokers, invoker)
 	}
 
 	var resInvoker base.Invoker
@@ -408,6 +421,9 @@ func (refOpts *ReferenceOptions) getURLMap() url.Values {
 	urlMap.Set(constant.ProvidedBy, ref.ProvidedBy)
 	urlMap.Set(constant.SerializationKey, ref.Serialization)
 	urlMap.Set(constant.TracingConfigKey, ref.TracingKey)
+	if len(ref.ProtocolPreference) > 0 {
+		urlMap.Set(constant.ProtocolPreferenceKey, strings.Join(ref.ProtocolPreference, constant.CommaSeparator))
+	}
 
 	urlMap.Set(constant.ReleaseKey, "dubbo-golang-"+constant.Version)
 	urlMap.Set(constant.SideKey, (common.RoleType(common.CONSUMER)).Role())
@@ -450,6 +466,9 @@ func (refOpts *ReferenceOptions) getURLMap() url.Values {
 		if len(v.RequestTimeout) != 0 {
 			urlMap.Set("methods."+v.Name+"."+constant.TimeoutKey, v.RequestTimeout)
 		}
+		if len(v.Criticality) != 0 {
+			urlMap.Set("methods."+v.Name+"."+constant.CriticalityKey, v.Criticality)
+		}
 	}
 
 	return urlMap
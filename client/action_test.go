@@ -174,6 +174,26 @@ func TestProcessURLWithMultipleDirectUserURLs(t *testing.T) {
 	require.Equal(t, "true", urls[1].GetParam("peer", ""))
 }
 
+func TestProcessURLWithUnixSocketURL(t *testing.T) {
+	ref := &global.ReferenceConfig{
+		InterfaceName: "com.example.Service",
+		Protocol:      constant.DubboProtocol,
+		URL:           "unix:///var/run/dubbo/greet.sock?serialization=hessian2",
+	}
+	cfgURL := common.NewURLWithOptions(common.WithPath(ref.InterfaceName))
+
+	urls, err := processURL(ref, nil, cfgURL)
+	require.NoError(t, err)
+	require.Len(t, urls, 1)
+	require.Equal(t, constant.DubboProtocol, urls[0].Protocol)
+	require.Equal(t, "/"+ref.InterfaceName, urls[0].Path)
+	require.Equal(t, "unix:/var/run/dubbo/greet.sock", urls[0].Location)
+	path, ok := urls[0].UnixSocket()
+	require.True(t, ok)
+	require.Equal(t, "/var/run/dubbo/greet.sock", path)
+	require.Equal(t, "hessian2", urls[0].GetParam(constant.SerializationKey, ""))
+}
+
 func TestProcessURLRejectsInvalidUserURL(t *testing.T) {
 	ref := &global.ReferenceConfig{
 		InterfaceName: "com.example.Service",
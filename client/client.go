@@ -26,6 +26,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/filter/criticality"
 	"dubbo.apache.org/dubbo-go/v3/filter/generic"
 	"dubbo.apache.org/dubbo-go/v3/global"
 	"dubbo.apache.org/dubbo-go/v3/metadata"
@@ -76,6 +77,9 @@ func (conn *Connection) call(ctx context.Context, reqs []any, resp any, methodNa
 	for _, opt := range opts {
 		opt(options)
 	}
+	if options.Criticality == "" {
+		options.Criticality = conn.criticality(ctx, methodName)
+	}
 	inv, err := generateInvocation(ctx, methodName, reqs, resp, callType, options)
 	if err != nil {
 		return nil, err
@@ -83,6 +87,25 @@ func (conn *Connection) call(ctx context.Context, reqs []any, resp any, methodNa
 	return conn.refOpts.invoker.Invoke(ctx, inv), nil
 }
 
+// criticality returns the criticality inherited from the context, or the one configured for the method.
+func (conn *Connection) criticality(ctx context.Context, methodName string) string {
+	if c, ok := criticality.FromContext(ctx); ok {
+		return c.String()
+	}
+	if conn.refOpts == nil || conn.refOpts.Reference == nil {
+		return ""
+	}
+	for _, method := range conn.refOpts.Reference.MethodsConfig {
+		if method.Name != methodName || method.Criticality == "" {
+			continue
+		}
+		if c, ok := criticality.Parse(method.Criticality); ok {
+			return c.String()
+		}
+	}
+	return ""
+}
+
 func (conn *Connection) CallUnary(ctx context.Context, reqs []any, resp any, methodName string, opts ...CallOption) error {
 	res, err := conn.call(ctx, reqs, resp, methodName, constant.CallUnary, opts...)
 	if err != nil {
@@ -115,6 +138,13 @@ func (conn *Connection) CallBidiStream(ctx context.Context, methodName string, o
 	return res.Result(), res.Error()
 }
 
+// Destroy destroys the invoker of the reference, the calls of the connection fail afterwards.
+func (conn *Connection) Destroy() {
+	if conn.refOpts.invoker != nil {
+		conn.refOpts.invoker.Destroy()
+	}
+}
+
 func (cli *Client) NewService(service any, opts ...ReferenceOption) (*Connection, error) {
 	if service == nil {
 		return nil, errors.New("service must not be nil")
@@ -145,6 +175,12 @@ func (cli *Client) NewService(service any, opts ...ReferenceOption) (*Connection
 //	}
 //	result, err := genericService.Invoke(ctx, "QueryUser", []string{"org.apache.dubbo.samples.User"}, []hessian.Object{user})
 func (cli *Client) NewGenericService(referenceStr string, opts ...ReferenceOption) (*generic.GenericService, error) {
+	genericService, _, err := cli.DialGenericService(referenceStr, opts...)
+	return genericService, err
+}
+
+// DialGenericService is NewGenericService returning the connection of the reference as well, which destroys it.
+func (cli *Client) DialGenericService(referenceStr string, opts ...ReferenceOption) (*generic.GenericService, *Connection, error) {
 	finalOpts := []ReferenceOption{
 		WithIDL(constant.NONIDL),
 		WithGeneric(),
@@ -153,12 +189,12 @@ func (cli *Client) NewGenericService(referenceStr string, opts ...ReferenceOptio
 	finalOpts = append(finalOpts, opts...)
 
 	genericService := generic.NewGenericService(referenceStr)
-	_, err := cli.DialWithService(referenceStr, genericService, finalOpts...)
+	conn, err := cli.DialWithService(referenceStr, genericService, finalOpts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return genericService, nil
+	return genericService, conn, nil
 }
 
 func (cli *Client) Dial(interfaceName string, opts ...ReferenceOption) (*Connection, error) {
@@ -268,6 +304,10 @@ func generateInvocation(ctx context.Context, methodName string, reqs []any, resp
 			}
 		}
 	}
+	// the criticality of the call overrides the one copied from the context
+	if opts.Criticality != "" {
+		attachments[constant.CriticalityKey] = opts.Criticality
+	}
 
 	inv := invocation.NewRPCInvocationWithOptions(
 		invocation.WithMethodName(methodName),
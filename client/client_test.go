@@ -31,6 +31,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/filter/criticality"
 	"dubbo.apache.org/dubbo-go/v3/global"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
@@ -266,3 +267,41 @@ func TestFilterRegistriesByIDsReturnsAllWhenIDsEmpty(t *testing.T) {
 	require.Contains(t, filtered, "r1")
 	require.Contains(t, filtered, "r2")
 }
+
+func TestConnectionCallCriticality(t *testing.T) {
+	invoker := &fakeInvoker{res: &result.RPCResult{}}
+	conn := &Connection{refOpts: &ReferenceOptions{
+		invoker: invoker,
+		Reference: &global.ReferenceConfig{MethodsConfig: []*global.MethodConfig{
+			{Name: "Report", Criticality: "sheddable"},
+		}},
+	}}
+	criticalityOf := func() string {
+		c, _ := invoker.lastInvocation.GetAttachment(constant.CriticalityKey)
+		return c
+	}
+
+	var resp string
+	// the method config
+	_, err := conn.call(context.Background(), []any{"req"}, &resp, "Report", constant.CallUnary)
+	require.NoError(t, err)
+	require.Equal(t, constant.CriticalitySheddable, criticalityOf())
+
+	// inherited from the context
+	ctx := context.WithValue(context.Background(), constant.AttachmentKey,
+		map[string]any{constant.CriticalityKey: []string{constant.CriticalityCritical}})
+	_, err = conn.call(ctx, []any{"req"}, &resp, "Report", constant.CallUnary)
+	require.NoError(t, err)
+	require.Equal(t, constant.CriticalityCritical, criticalityOf())
+
+	// the call option overrides the others
+	_, err = conn.call(ctx, []any{"req"}, &resp, "Report", constant.CallUnary, WithCallCriticality(criticality.Default))
+	require.NoError(t, err)
+	require.Equal(t, constant.CriticalityDefault, criticalityOf())
+
+	// absent by default
+	_, err = conn.call(context.Background(), []any{"req"}, &resp, "Ping", constant.CallUnary)
+	require.NoError(t, err)
+	_, ok := invoker.lastInvocation.GetAttachment(constant.CriticalityKey)
+	require.False(t, ok)
+}
@@ -18,18 +18,25 @@
 package client
 
 import (
+	"slices"
 	"strconv"
 	"time"
 )
 
 import (
 	"github.com/creasty/defaults"
+
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
 )
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	commonCfg "dubbo.apache.org/dubbo-go/v3/common/config"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/filter/criticality"
 	"dubbo.apache.org/dubbo-go/v3/global"
 	"dubbo.apache.org/dubbo-go/v3/graceful_shutdown"
 	"dubbo.apache.org/dubbo-go/v3/internal"
@@ -125,6 +132,15 @@ func (refOpts *ReferenceOptions) init(opts ...ReferenceOption) error {
 		}
 	}
 
+	// init protocol preference, the most preferred protocol becomes the protocol of the reference
+	if len(refConf.ProtocolPreference) > 0 {
+		refConf.ProtocolPreference = supportedProtocols(refConf.ProtocolPreference)
+		if len(refConf.ProtocolPreference) == 0 {
+			return perrors.Errorf("none of the preferred protocols of %s is supported", refConf.InterfaceName)
+		}
+		refConf.Protocol = refConf.ProtocolPreference[0]
+	}
+
 	// init protocol
 	if refConf.Protocol == "" {
 		refConf.Protocol = constant.TriProtocol
@@ -407,6 +423,30 @@ func WithProtocol(protocol string) ReferenceOption {
 	}
 }
 
+// WithProtocolPreference consumes the providers through the first of @protocols they support,
+// falling back to the next ones when it is unavailable. It overrides WithProtocol.
+func WithProtocolPreference(protocols ...string) ReferenceOption {
+	return func(opts *ReferenceOptions) {
+		opts.Reference.ProtocolPreference = protocols
+	}
+}
+
+// supportedProtocols drops the protocols which are not imported by the consumer, keeping the order.
+func supportedProtocols(protocols []string) []string {
+	registered := extension.GetAllProtocolNames()
+	supported := make([]string, 0, len(protocols))
+	for _, proto := range protocols {
+		if !slices.Contains(registered, proto) {
+			logger.Warnf("protocol %s is not supported by the consumer, please check if it is imported", proto)
+			continue
+		}
+		if !slices.Contains(supported, proto) {
+			supported = append(supported, proto)
+		}
+	}
+	return supported
+}
+
 func WithRequestTimeout(timeout time.Duration) ReferenceOption {
 	return func(opts *ReferenceOptions) {
 		opts.Reference.RequestTimeout = timeout.String()
@@ -1001,6 +1041,7 @@ func SetClientRouters(routers []*global.RouterConfig) ClientOption {
 type CallOptions struct {
 	RequestTimeout string
 	Retries        string
+	Criticality    string
 }
 
 type CallOption func(*CallOptions)
@@ -1022,3 +1063,11 @@ func WithCallRetries(retries int) CallOption {
 		opts.Retries = strconv.Itoa(retries)
 	}
 }
+
+// WithCallCriticality sets the criticality of one specific call, it is one of CRITICAL, DEFAULT and SHEDDABLE.
+// The providers shed the requests of lower criticality first when they are at capacity.
+func WithCallCriticality(c criticality.Criticality) CallOption {
+	return func(opts *CallOptions) {
+		opts.Criticality = c.String()
+	}
+}
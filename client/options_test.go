@@ -29,7 +29,9 @@ import (
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/global"
+	"dubbo.apache.org/dubbo-go/v3/protocol/protocolwrapper"
 	"dubbo.apache.org/dubbo-go/v3/registry"
 )
 
@@ -1230,6 +1232,49 @@ func TestWithProtocol(t *testing.T) {
 	processReferenceOptionsInitCases(t, cases)
 }
 
+func TestWithProtocolPreference(t *testing.T) {
+	extension.SetProtocol("preference-new", protocolwrapper.NewMockProtocolFilter)
+	extension.SetProtocol("preference-old", protocolwrapper.NewMockProtocolFilter)
+	defer extension.UnregisterProtocol("preference-new")
+	defer extension.UnregisterProtocol("preference-old")
+
+	cases := []referenceOptionsInitCase{
+		{
+			desc: "config ProtocolPreference",
+			opts: []ReferenceOption{
+				WithProtocolDubbo(),
+				WithProtocolPreference("preference-new", "preference-old"),
+			},
+			verify: func(t *testing.T, refOpts *ReferenceOptions, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "preference-new", refOpts.Reference.Protocol)
+				assert.Equal(t, []string{"preference-new", "preference-old"}, refOpts.Reference.ProtocolPreference)
+			},
+		},
+		{
+			desc: "drop unsupported protocols",
+			opts: []ReferenceOption{
+				WithProtocolPreference("preference-unknown", "preference-old", "preference-old"),
+			},
+			verify: func(t *testing.T, refOpts *ReferenceOptions, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "preference-old", refOpts.Reference.Protocol)
+				assert.Equal(t, []string{"preference-old"}, refOpts.Reference.ProtocolPreference)
+			},
+		},
+		{
+			desc: "no supported protocol",
+			opts: []ReferenceOption{
+				WithProtocolPreference("preference-unknown"),
+			},
+			verify: func(t *testing.T, refOpts *ReferenceOptions, err error) {
+				require.Error(t, err)
+			},
+		},
+	}
+	processReferenceOptionsInitCases(t, cases)
+}
+
 func TestWithRequestTimeout(t *testing.T) {
 	cases := []referenceOptionsInitCase{
 		{
@@ -23,8 +23,6 @@ import (
 )
 
 import (
-	"github.com/dubbogo/gost/log/logger"
-
 	perrors "github.com/pkg/errors"
 )
 
@@ -35,6 +33,7 @@ import (
 	clsutils "dubbo.apache.org/dubbo-go/v3/cluster/utils"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	dubbologger "dubbo.apache.org/dubbo-go/v3/logger"
 	protocolbase "dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
@@ -86,21 +85,21 @@ func (ivk *adaptiveServiceClusterInvoker) Invoke(ctx context.Context, invocation
 		}
 	}
 	if remainingStr == "" {
-		logger.Errorf("[adasvc cluster] The %s field type of value %v should be string.",
+		dubbologger.CtxErrorf(ctx, "[adasvc cluster] The %s field type of value %v should be string.",
 			constant.AdaptiveServiceRemainingKey, remainingIface)
 		return res
 	}
 	remaining, err := strconv.Atoi(remainingStr)
 	if err != nil {
-		logger.Warnf("the remaining is unexpected, we need a int type, but we got %s, err: %v.", remainingStr, err)
+		dubbologger.CtxWarnf(ctx, "the remaining is unexpected, we need a int type, but we got %s, err: %v.", remainingStr, err)
 		return res
 	}
-	logger.Debugf("[adasvc cluster] The server status was received successfully, %s: %#v",
+	dubbologger.CtxDebugf(ctx, "[adasvc cluster] The server status was received successfully, %s: %#v",
 		constant.AdaptiveServiceRemainingKey, remainingStr)
 	err = metrics.LocalMetrics.SetMethodMetrics(invoker.GetURL(),
 		invocation.MethodName(), metrics.HillClimbing, uint64(remaining))
 	if err != nil {
-		logger.Warnf("adaptive service metrics update is failed, err: %v", err)
+		dubbologger.CtxWarnf(ctx, "adaptive service metrics update is failed, err: %v", err)
 		return &result.RPCResult{Err: err}
 	}
 
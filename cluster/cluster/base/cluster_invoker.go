@@ -18,6 +18,11 @@
 // Package base implements invoker for the manipulation of cluster strategy.
 package base
 
+import (
+	"errors"
+	"fmt"
+)
+
 import (
 	"github.com/dubbogo/gost/log/logger"
 
@@ -35,6 +40,22 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 )
 
+// ErrNoProviderAvailable is matched by errors.Is with the errors of the invocations without any provider available.
+var ErrNoProviderAvailable = errors.New("no provider available")
+
+// noProviderError keeps the detailed message of CheckInvokers while matching ErrNoProviderAvailable
+type noProviderError struct {
+	msg string
+}
+
+func (e *noProviderError) Error() string {
+	return e.msg
+}
+
+func (e *noProviderError) Is(target error) bool {
+	return target == ErrNoProviderAvailable
+}
+
 type BaseClusterInvoker struct {
 	Directory      directory.Directory
 	AvailableCheck bool
@@ -72,9 +93,9 @@ func (invoker *BaseClusterInvoker) IsAvailable() bool {
 func (invoker *BaseClusterInvoker) CheckInvokers(invokers []base.Invoker, invocation base.Invocation) error {
 	if len(invokers) == 0 {
 		ip := common.GetLocalIp()
-		return perrors.Errorf("Failed to invoke the method %v. No provider available for the service %v from "+
+		return perrors.WithStack(&noProviderError{msg: fmt.Sprintf("Failed to invoke the method %v. No provider available for the service %v from "+
 			"registry %v on the consumer %v using the dubbo version %v .Please check if the providers have been started and registered.",
-			invocation.MethodName(), invoker.Directory.GetURL().SubURL.Key(), invoker.Directory.GetURL().String(), ip, constant.Version)
+			invocation.MethodName(), invoker.Directory.GetURL().SubURL.Key(), invoker.Directory.GetURL().String(), ip, constant.Version)})
 	}
 	return nil
 }
@@ -18,6 +18,7 @@
 package base
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 )
@@ -28,6 +29,7 @@ import (
 
 import (
 	clusterpkg "dubbo.apache.org/dubbo-go/v3/cluster/cluster"
+	"dubbo.apache.org/dubbo-go/v3/cluster/directory/static"
 	"dubbo.apache.org/dubbo-go/v3/cluster/loadbalance/random"
 	"dubbo.apache.org/dubbo-go/v3/common"
 	protocolbase "dubbo.apache.org/dubbo-go/v3/protocol/base"
@@ -73,3 +75,16 @@ func TestStickyNormalWhenError(t *testing.T) {
 	result1 := base.DoSelect(random.NewRandomLoadBalance(), invocation.NewRPCInvocation(baseClusterInvokerMethodName, nil, nil), invokers, invoked)
 	assert.NotEqual(t, result, result1)
 }
+
+func TestCheckInvokersNoProvider(t *testing.T) {
+	url, _ := common.NewURL(fmt.Sprintf(baseClusterInvokerFormat, 1))
+	url.SubURL = url
+	base := NewBaseClusterInvoker(static.NewDirectory([]protocolbase.Invoker{clusterpkg.NewMockInvoker(url, 1)}))
+	tmpInvocation := invocation.NewRPCInvocation(baseClusterInvokerMethodName, nil, nil)
+
+	err := base.CheckInvokers(nil, tmpInvocation)
+	assert.ErrorIs(t, err, ErrNoProviderAvailable)
+	assert.Contains(t, err.Error(), "No provider available for the service")
+	assert.False(t, errors.Is(errors.New("No provider available"), ErrNoProviderAvailable))
+	assert.NoError(t, base.CheckInvokers([]protocolbase.Invoker{clusterpkg.NewMockInvoker(url, 1)}, tmpInvocation))
+}
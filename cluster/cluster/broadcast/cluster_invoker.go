@@ -21,13 +21,10 @@ import (
 	"context"
 )
 
-import (
-	"github.com/dubbogo/gost/log/logger"
-)
-
 import (
 	"dubbo.apache.org/dubbo-go/v3/cluster/cluster/base"
 	"dubbo.apache.org/dubbo-go/v3/cluster/directory"
+	dubbologger "dubbo.apache.org/dubbo-go/v3/logger"
 	protocolbase "dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
@@ -58,7 +55,7 @@ func (invoker *broadcastClusterInvoker) Invoke(ctx context.Context, invocation p
 	for _, ivk := range invokers {
 		res = ivk.Invoke(ctx, invocation)
 		if res.Error() != nil {
-			logger.Warnf("broadcast invoker invoke err: %v when use invoker: %v\n", res.Error(), ivk)
+			dubbologger.CtxWarnf(ctx, "broadcast invoker invoke err: %v when use invoker: %v\n", res.Error(), ivk)
 			err = res.Error()
 		}
 	}
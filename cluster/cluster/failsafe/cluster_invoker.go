@@ -21,15 +21,12 @@ import (
 	"context"
 )
 
-import (
-	"github.com/dubbogo/gost/log/logger"
-)
-
 import (
 	"dubbo.apache.org/dubbo-go/v3/cluster/cluster/base"
 	"dubbo.apache.org/dubbo-go/v3/cluster/directory"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	dubbologger "dubbo.apache.org/dubbo-go/v3/logger"
 	protocolbase "dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
@@ -77,7 +74,7 @@ func (invoker *failsafeClusterInvoker) Invoke(ctx context.Context, invocation pr
 	res = ivk.Invoke(ctx, invocation)
 	if res.Error() != nil {
 		// ignore
-		logger.Errorf("Failsafe ignore exception: %v.\n", res.Error().Error())
+		dubbologger.CtxErrorf(ctx, "Failsafe ignore exception: %v.\n", res.Error().Error())
 		return &result.RPCResult{}
 	}
 	return res
@@ -41,3 +41,10 @@ type Directory interface {
 type ClosingInstanceRemover interface {
 	RemoveClosingInstance(instanceKey string) bool
 }
+
+// AddressesNotifier is an optional directory capability used by the service discovery migration.
+// Implementations return the addresses of the cached service instances and call the listener after they change.
+type AddressesNotifier interface {
+	Addresses() []string
+	SetAddressesChangedListener(listener func())
+}
@@ -0,0 +1,259 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package healthcheck actively probes the providers of a reference, so the unhealthy ones are taken out of
+// the directory before calls fail on them or the registry drops them. It is used by the registry directory,
+// for both interface level registries and application level service discovery.
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsHealthCheck "dubbo.apache.org/dubbo-go/v3/metrics/healthcheck"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+)
+
+// Checker probes every added invoker periodically by its HealthChecker, which is the grpc health Check for
+// triple and the heartbeat for dubbo. A provider is unhealthy after UnhealthyThreshold consecutive failed probes,
+// and healthy again after HealthyThreshold consecutive succeeded probes. Methods of a nil Checker are no-ops,
+// so the caller does not need to check whether the health check is enabled.
+type Checker struct {
+	cfg      *Config
+	onChange func()
+
+	mux     sync.Mutex
+	targets map[string]*target
+	stopped bool
+}
+
+type target struct {
+	key     string
+	invoker base.Invoker
+	checker base.HealthChecker
+	done    chan struct{}
+
+	mux       sync.RWMutex
+	healthy   bool
+	successes int
+	failures  int
+}
+
+// NewChecker returns a Checker configured by the url, or nil if the health check is not enabled by the url.
+// onChange is called without any lock held whenever a provider becomes unhealthy or healthy.
+func NewChecker(url *common.URL, onChange func()) *Checker {
+	if !Enabled(url) {
+		return nil
+	}
+	return newChecker(ConfigFromURL(url), onChange)
+}
+
+func newChecker(cfg *Config, onChange func()) *Checker {
+	if onChange == nil {
+		onChange = func() {}
+	}
+	return &Checker{
+		cfg:      cfg,
+		onChange: onChange,
+		targets:  make(map[string]*target),
+	}
+}
+
+// Add starts probing the invoker with the key, an invoker previously added with the same key is replaced.
+// Invokers without HealthChecker are ignored and always healthy.
+func (c *Checker) Add(key string, invoker base.Invoker) {
+	if c == nil || invoker == nil {
+		return
+	}
+	checker, ok := invoker.(base.HealthChecker)
+	if !ok {
+		return
+	}
+	t := &target{key: key, invoker: invoker, checker: checker, done: make(chan struct{}), healthy: true}
+
+	c.mux.Lock()
+	if c.stopped {
+		c.mux.Unlock()
+		return
+	}
+	old := c.targets[key]
+	c.targets[key] = t
+	c.mux.Unlock()
+
+	if old != nil {
+		old.stop()
+	}
+	go c.run(t)
+}
+
+// Remove stops probing the invoker with the key.
+func (c *Checker) Remove(key string) {
+	if c == nil {
+		return
+	}
+	c.mux.Lock()
+	t := c.targets[key]
+	delete(c.targets, key)
+	c.mux.Unlock()
+
+	if t != nil {
+		t.stop()
+		t.publishRemoved()
+	}
+}
+
+// IsHealthy returns false only if the invoker with the key is probed and unhealthy.
+func (c *Checker) IsHealthy(key string) bool {
+	if c == nil {
+		return true
+	}
+	c.mux.Lock()
+	t := c.targets[key]
+	c.mux.Unlock()
+	if t == nil {
+		return true
+	}
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	return t.healthy
+}
+
+// Stop stops probing all the invokers, the Checker can not be used any more.
+func (c *Checker) Stop() {
+	if c == nil {
+		return
+	}
+	c.mux.Lock()
+	targets := c.targets
+	c.targets = make(map[string]*target)
+	c.stopped = true
+	c.mux.Unlock()
+
+	for _, t := range targets {
+		t.stop()
+		t.publishRemoved()
+	}
+}
+
+func (c *Checker) run(t *target) {
+	// the first probe is spread over an interval, so the providers notified together are not probed together
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(c.cfg.Interval)) + 1))
+	defer timer.Stop()
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-timer.C:
+		}
+		if !c.probe(t) {
+			return
+		}
+		timer.Reset(c.cfg.nextInterval())
+	}
+}
+
+// probe probes the target once and updates its health state, it returns false if the target can not be probed.
+func (c *Checker) probe(t *target) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	start := time.Now()
+	err := t.checker.HealthCheck(ctx)
+	cancel()
+	if errors.Is(err, base.ErrHealthCheckUnsupported) {
+		logger.Infof("[HealthCheck] %s does not support health check, stop probing it", t.key)
+		return false
+	}
+	changed, healthy := t.update(err == nil, c.cfg)
+	url := t.invoker.GetURL()
+	if !t.publish(metricsHealthCheck.NewProbeEvent(url.Service(), url.Location, err == nil, healthy, start)) {
+		// the invoker is removed or replaced while being probed
+		return false
+	}
+	if !changed {
+		if err != nil {
+			logger.Debugf("[HealthCheck] probe %s failed: %v", url.Location, err)
+		}
+		return true
+	}
+	if healthy {
+		logger.Infof("[HealthCheck] provider %s of %s is healthy again", url.Location, url.Service())
+	} else {
+		logger.Warnf("[HealthCheck] provider %s of %s is unhealthy after %d failed probes, last error: %v",
+			url.Location, url.Service(), c.cfg.UnhealthyThreshold, err)
+	}
+	c.onChange()
+	return true
+}
+
+// update records the result of a probe, it returns whether the health state is changed and the new state.
+func (t *target) update(succ bool, cfg *Config) (bool, bool) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if succ {
+		t.successes++
+		t.failures = 0
+		if !t.healthy && t.successes >= cfg.HealthyThreshold {
+			t.healthy = true
+			return true, true
+		}
+	} else {
+		t.failures++
+		t.successes = 0
+		if t.healthy && t.failures >= cfg.UnhealthyThreshold {
+			t.healthy = false
+			return true, false
+		}
+	}
+	return false, t.healthy
+}
+
+// stop stops probing the target, no probe result of it is published after stop returns
+func (t *target) stop() {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	close(t.done)
+}
+
+// publish publishes the probe event unless the target is stopped, it returns false if the target is stopped.
+func (t *target) publish(event metrics.MetricsEvent) bool {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	select {
+	case <-t.done:
+		return false
+	default:
+	}
+	metrics.Publish(event)
+	return true
+}
+
+// publishRemoved publishes that the target is not probed any more, so the metrics of its health state are removed.
+// It is not published for a target replaced by Add, as the new one with the same key reports the same metrics.
+func (t *target) publishRemoved() {
+	url := t.invoker.GetURL()
+	metrics.Publish(metricsHealthCheck.NewRemovedEvent(url.Service(), url.Location))
+}
@@ -0,0 +1,187 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsHealthCheck "dubbo.apache.org/dubbo-go/v3/metrics/healthcheck"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+)
+
+type probedInvoker struct {
+	*base.BaseInvoker
+	err    atomic.Value // error
+	probes atomic.Int32
+}
+
+func newProbedInvoker(t *testing.T) *probedInvoker {
+	url, err := common.NewURL("tri://127.0.0.1:20000/org.example.DemoService")
+	assert.NoError(t, err)
+	ivk := &probedInvoker{BaseInvoker: base.NewBaseInvoker(url)}
+	ivk.setErr(nil)
+	return ivk
+}
+
+func (p *probedInvoker) setErr(err error) {
+	p.err.Store(&err)
+}
+
+func (p *probedInvoker) HealthCheck(ctx context.Context) error {
+	p.probes.Add(1)
+	return *p.err.Load().(*error)
+}
+
+func testConfig() *Config {
+	return &Config{
+		Interval:           5 * time.Millisecond,
+		Timeout:            time.Second,
+		Jitter:             0.2,
+		UnhealthyThreshold: 3,
+		HealthyThreshold:   2,
+	}
+}
+
+func TestNewChecker(t *testing.T) {
+	url, _ := common.NewURL("tri://127.0.0.1:20000/org.example.DemoService")
+	assert.Nil(t, NewChecker(url, nil))
+
+	url.SetParam(constant.HealthCheckEnabledKey, "true")
+	url.SetParam(constant.HealthCheckIntervalKey, "1s")
+	url.SetParam(constant.HealthCheckUnhealthyThresholdKey, "5")
+	url.SetParam(constant.HealthCheckHealthyThresholdKey, "-1")
+	url.SetParam(constant.HealthCheckJitterKey, "2")
+	checker := NewChecker(url, nil)
+	assert.NotNil(t, checker)
+	assert.Equal(t, time.Second, checker.cfg.Interval)
+	assert.Equal(t, defaultTimeout, checker.cfg.Timeout)
+	assert.Equal(t, 5, checker.cfg.UnhealthyThreshold)
+	assert.Equal(t, defaultHealthyThreshold, checker.cfg.HealthyThreshold)
+	assert.InDelta(t, defaultJitter, checker.cfg.Jitter, 0.001)
+}
+
+func TestNilChecker(t *testing.T) {
+	var checker *Checker
+	checker.Add("key", newProbedInvoker(t))
+	checker.Remove("key")
+	checker.Stop()
+	assert.True(t, checker.IsHealthy("key"))
+}
+
+func TestCheckerThresholds(t *testing.T) {
+	var changes atomic.Int32
+	checker := newChecker(testConfig(), func() { changes.Add(1) })
+	defer checker.Stop()
+
+	ivk := newProbedInvoker(t)
+	checker.Add("key", ivk)
+	assert.Eventually(t, func() bool { return ivk.probes.Load() >= 3 }, time.Second, time.Millisecond)
+	assert.True(t, checker.IsHealthy("key"))
+	assert.Equal(t, int32(0), changes.Load())
+
+	ivk.setErr(errors.New("not serving"))
+	assert.Eventually(t, func() bool { return !checker.IsHealthy("key") }, time.Second, time.Millisecond)
+	assert.Equal(t, int32(1), changes.Load())
+
+	ivk.setErr(nil)
+	assert.Eventually(t, func() bool { return checker.IsHealthy("key") }, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), changes.Load())
+}
+
+func TestCheckerRemove(t *testing.T) {
+	checker := newChecker(testConfig(), nil)
+	defer checker.Stop()
+
+	ivk := newProbedInvoker(t)
+	ivk.setErr(errors.New("not serving"))
+	checker.Add("key", ivk)
+	assert.Eventually(t, func() bool { return !checker.IsHealthy("key") }, time.Second, time.Millisecond)
+
+	checker.Remove("key")
+	assert.True(t, checker.IsHealthy("key"))
+	time.Sleep(20 * time.Millisecond)
+	probes := ivk.probes.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, probes, ivk.probes.Load())
+}
+
+func TestCheckerRemovePublishes(t *testing.T) {
+	events := make(chan metrics.MetricsEvent, 1024)
+	metrics.Subscribe(constant.MetricsHealthCheck, events)
+	defer metrics.Unsubscribe(constant.MetricsHealthCheck)
+	checker := newChecker(testConfig(), nil)
+	defer checker.Stop()
+
+	ivk := newProbedInvoker(t)
+	checker.Add("key", ivk)
+	assert.Eventually(t, func() bool { return len(events) > 0 }, time.Second, time.Millisecond)
+	checker.Remove("key")
+	time.Sleep(20 * time.Millisecond)
+
+	var last *metricsHealthCheck.ProbeEvent
+	for len(events) > 0 {
+		event := (<-events).(*metricsHealthCheck.ProbeEvent)
+		assert.False(t, last != nil && last.Removed, "no probe is published after the removal")
+		last = event
+	}
+	assert.True(t, last.Removed)
+	assert.Equal(t, ivk.GetURL().Location, last.Address)
+	assert.Equal(t, ivk.GetURL().Service(), last.Interface)
+}
+
+func TestCheckerUnsupported(t *testing.T) {
+	checker := newChecker(testConfig(), nil)
+	defer checker.Stop()
+
+	ivk := newProbedInvoker(t)
+	ivk.setErr(base.ErrHealthCheckUnsupported)
+	checker.Add("key", ivk)
+	assert.Eventually(t, func() bool { return ivk.probes.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, int32(1), ivk.probes.Load())
+	assert.True(t, checker.IsHealthy("key"))
+
+	// invokers without HealthChecker are not probed
+	checker.Add("plain", base.NewBaseInvoker(ivk.GetURL()))
+	assert.True(t, checker.IsHealthy("plain"))
+}
+
+func TestNextInterval(t *testing.T) {
+	cfg := testConfig()
+	cfg.Interval = time.Second
+	for i := 0; i < 100; i++ {
+		d := cfg.nextInterval()
+		assert.GreaterOrEqual(t, d, 800*time.Millisecond)
+		assert.LessOrEqual(t, d, 1200*time.Millisecond)
+	}
+	cfg.Jitter = 0
+	assert.Equal(t, time.Second, cfg.nextInterval())
+}
@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package healthcheck
+
+import (
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+)
+
+const (
+	defaultInterval           = 10 * time.Second
+	defaultTimeout            = 3 * time.Second
+	defaultJitter             = 0.2
+	defaultUnhealthyThreshold = 3
+	defaultHealthyThreshold   = 2
+)
+
+// Config of the active health check, it is set by the `params` of the reference config.
+type Config struct {
+	// Interval is the mean period between two probes to a provider.
+	Interval time.Duration
+	// Timeout bounds a single probe.
+	Timeout time.Duration
+	// Jitter randomizes every interval by ±Jitter*Interval, so the probes of many consumers do not line up.
+	Jitter float64
+	// UnhealthyThreshold is the number of consecutive failed probes to mark a provider unhealthy.
+	UnhealthyThreshold int
+	// HealthyThreshold is the number of consecutive succeeded probes to restore an unhealthy provider.
+	HealthyThreshold int
+}
+
+// Enabled returns whether the active health check is enabled by the url.
+func Enabled(url *common.URL) bool {
+	return url != nil && url.GetParamBool(constant.HealthCheckEnabledKey, false)
+}
+
+// ConfigFromURL returns the config set by the url params, unset fields keep the defaults.
+func ConfigFromURL(url *common.URL) *Config {
+	jitter := defaultJitter
+	if v := url.GetParam(constant.HealthCheckJitterKey, ""); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 && f < 1 {
+			jitter = f
+		} else {
+			logger.Warnf("[HealthCheck] invalid %s: %s, use default %v", constant.HealthCheckJitterKey, v, defaultJitter)
+		}
+	}
+	return &Config{
+		Interval:           url.GetParamPositiveDuration(constant.HealthCheckIntervalKey, defaultInterval),
+		Timeout:            url.GetParamPositiveDuration(constant.HealthCheckTimeoutKey, defaultTimeout),
+		Jitter:             jitter,
+		UnhealthyThreshold: url.GetParamPositiveInt(constant.HealthCheckUnhealthyThresholdKey, defaultUnhealthyThreshold),
+		HealthyThreshold:   url.GetParamPositiveInt(constant.HealthCheckHealthyThresholdKey, defaultHealthyThreshold),
+	}
+}
+
+// nextInterval returns the interval with jitter applied.
+func (c *Config) nextInterval() time.Duration {
+	if c.Jitter == 0 {
+		return c.Interval
+	}
+	delta := (rand.Float64()*2 - 1) * c.Jitter * float64(c.Interval)
+	return c.Interval + time.Duration(delta)
+}
@@ -20,6 +20,7 @@ package consistenthashing
 import (
 	"encoding/json"
 	"hash/crc32"
+	"math"
 	"regexp"
 )
 
@@ -61,19 +62,23 @@ func (lb *conshashLoadBalance) Select(invokers []base.Invoker, invocation base.I
 	methodName := invocation.MethodName()
 	key := invokers[0].GetURL().ServiceKey() + "." + methodName
 
-	// hash the invokers
+	// hash the invokers together with their warm-up progress, so that the ring
+	// is rebuilt while providers are warming up
 	var bs []byte
-	for _, invoker := range invokers {
+	warmups := make([]int, len(invokers))
+	for i, invoker := range invokers {
 		b, err := json.Marshal(invoker)
 		if err != nil {
 			return nil
 		}
 		bs = append(bs, b...)
+		warmups[i] = int(math.Ceil(loadbalance.GetWarmupRatio(invoker.GetURL()) * 100))
+		bs = append(bs, byte(warmups[i]))
 	}
 	hashCode := crc32.ChecksumIEEE(bs)
 	selector, ok := selectors[key]
 	if !ok || selector.hashCode != hashCode {
-		selectors[key] = newSelector(invokers, methodName, hashCode)
+		selectors[key] = newSelector(invokers, warmups, methodName, hashCode)
 		selector = selectors[key]
 	}
 	return selector.Select(invocation)
@@ -59,7 +59,7 @@ func (s *consistentHashSelectorSuite) SetupTest() {
 	var invokers []base.Invoker
 	url, _ := common.NewURL(url20000)
 	invokers = append(invokers, base.NewBaseInvoker(url))
-	s.selector = newSelector(invokers, "echo", 999944)
+	s.selector = newSelector(invokers, []int{100}, "echo", 999944)
 }
 
 func (s *consistentHashSelectorSuite) TestToKey() {
@@ -78,6 +78,20 @@ func (s *consistentHashSelectorSuite) TestSelectForKey() {
 	s.Equal(url8081Short+"?", result.GetURL().String())
 }
 
+func (s *consistentHashSelectorSuite) TestWarmupReplicas() {
+	warm, _ := common.NewURL(url8080)
+	cold, _ := common.NewURL(url8081)
+	invokers := []base.Invoker{base.NewBaseInvoker(warm), base.NewBaseInvoker(cold)}
+	sel := newSelector(invokers, []int{100, 10}, "echo", 1)
+
+	counts := make(map[base.Invoker]int)
+	for _, invoker := range sel.virtualInvokers {
+		counts[invoker]++
+	}
+	s.Equal(160, counts[invokers[0]])
+	s.Equal(16, counts[invokers[1]])
+}
+
 func TestConsistentHashLoadBalanceSuite(t *testing.T) {
 	suite.Run(t, new(consistentHashLoadBalanceSuite))
 }
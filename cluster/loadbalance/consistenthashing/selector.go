@@ -42,7 +42,9 @@ type selector struct {
 	argumentIndex   []int
 }
 
-func newSelector(invokers []base.Invoker, methodName string,
+// newSelector builds the hash ring. Every invoker gets the share of replicaNum
+// virtual nodes given by its warm-up percentage in @warmups.
+func newSelector(invokers []base.Invoker, warmups []int, methodName string,
 	hashCode uint32) *selector {
 
 	selector := &selector{}
@@ -58,10 +60,11 @@ func newSelector(invokers []base.Invoker, methodName string,
 		}
 		selector.argumentIndex = append(selector.argumentIndex, i)
 	}
-	for _, invoker := range invokers {
+	for idx, invoker := range invokers {
 		u := invoker.GetURL()
 		address := u.Ip + ":" + u.Port
-		for i := 0; i < selector.replicaNum/4; i++ {
+		groups := max(selector.replicaNum/4*warmups[idx]/100, 1)
+		for i := 0; i < groups; i++ {
 			digest := md5.Sum([]byte(address + strconv.Itoa(i)))
 			for j := 0; j < 4; j++ {
 				key := selector.hash(digest, j)
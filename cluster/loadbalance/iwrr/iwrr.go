@@ -71,10 +71,11 @@ func (item *iwrrQueue) empty() bool {
 
 // InterleavedweightedRoundRobin struct
 type interleavedweightedRoundRobin struct {
-	current *iwrrQueue
-	next    *iwrrQueue
-	step    int64
-	mu      sync.Mutex
+	current  *iwrrQueue
+	next     *iwrrQueue
+	step     int64
+	invokers []base.Invoker
+	mu       sync.Mutex
 }
 
 func NewInterleavedweightedRoundRobin(invokers []base.Invoker, invocation base.Invocation) *interleavedweightedRoundRobin {
@@ -95,10 +96,24 @@ func NewInterleavedweightedRoundRobin(invokers []base.Invoker, invocation base.I
 		})
 	}
 	iwrrp.step = step
+	iwrrp.invokers = invokers
 
 	return iwrrp
 }
 
+// sameInvokers reports whether the queues were built from @invokers.
+func (iwrr *interleavedweightedRoundRobin) sameInvokers(invokers []base.Invoker) bool {
+	if len(iwrr.invokers) != len(invokers) {
+		return false
+	}
+	for i := range invokers {
+		if iwrr.invokers[i] != invokers[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (iwrr *interleavedweightedRoundRobin) Pick(invocation base.Invocation) base.Invoker {
 	iwrr.mu.Lock()
 	defer iwrr.mu.Unlock()
@@ -117,6 +132,8 @@ func (iwrr *interleavedweightedRoundRobin) Pick(invocation base.Invocation) base
 		if weight < 0 {
 			weight = 0
 		}
+		// the weight changes while the provider warms up, keep the step a divisor of every weight
+		iwrr.step = gcdInt(iwrr.step, weight)
 		entry.weight = weight
 		iwrr.next.push(entry)
 	}
@@ -17,6 +17,10 @@
 
 package iwrr
 
+import (
+	"sync"
+)
+
 import (
 	"dubbo.apache.org/dubbo-go/v3/cluster/loadbalance"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
@@ -28,7 +32,11 @@ func init() {
 	extension.SetLoadbalance(constant.LoadBalanceKeyInterleavedWeightedRoundRobin, newInterleavedWeightedRoundRobinBalance)
 }
 
-type interleavedWeightedRoundRobinBalance struct{}
+type interleavedWeightedRoundRobinBalance struct {
+	// schedules keeps the queues of every service method between calls,
+	// otherwise each call would start a new round and ignore the weights.
+	schedules sync.Map // map[string]*interleavedweightedRoundRobin
+}
 
 // newInterleavedWeightedRoundRobinBalance returns a interleaved weighted round robin load balance.
 func newInterleavedWeightedRoundRobinBalance() loadbalance.LoadBalance {
@@ -45,6 +53,13 @@ func (lb *interleavedWeightedRoundRobinBalance) Select(invokers []base.Invoker,
 		return invokers[0]
 	}
 
+	key := invokers[0].GetURL().ServiceKey() + "." + invocation.MethodName()
+	if cached, ok := lb.schedules.Load(key); ok {
+		if iwrrp := cached.(*interleavedweightedRoundRobin); iwrrp.sameInvokers(invokers) {
+			return iwrrp.Pick(invocation)
+		}
+	}
 	iwrrp := NewInterleavedweightedRoundRobin(invokers, invocation)
+	lb.schedules.Store(key, iwrrp)
 	return iwrrp.Pick(invocation)
 }
@@ -19,7 +19,9 @@ package iwrr
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
+	"time"
 )
 
 import (
@@ -76,3 +78,37 @@ func TestIWrrRoundRobinByWeight(t *testing.T) {
 
 	assert.Equal(t, loop, sum)
 }
+
+func TestIWrrRoundRobinRatio(t *testing.T) {
+	loadBalance := newInterleavedWeightedRoundRobinBalance()
+
+	url1, _ := common.NewURL("dubbo://192.168.1.1:20000/org.apache.demo.HelloService?weight=1")
+	url2, _ := common.NewURL("dubbo://192.168.1.2:20000/org.apache.demo.HelloService?weight=3")
+	invokers := []base.Invoker{base.NewBaseInvoker(url1), base.NewBaseInvoker(url2)}
+
+	selected := make(map[base.Invoker]int)
+	for i := 0; i < 400; i++ {
+		selected[loadBalance.Select(invokers, &invocation.RPCInvocation{})]++
+	}
+
+	assert.Equal(t, 100, selected[invokers[0]])
+	assert.Equal(t, 300, selected[invokers[1]])
+}
+
+func TestIWrrRoundRobinWarmup(t *testing.T) {
+	loadBalance := newInterleavedWeightedRoundRobinBalance()
+
+	warm, _ := common.NewURL("dubbo://192.168.1.1:20000/org.apache.demo.HelloService")
+	cold, _ := common.NewURL("dubbo://192.168.1.2:20000/org.apache.demo.HelloService",
+		common.WithParamsValue(constant.RemoteTimestampKey, strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)),
+		common.WithParamsValue(constant.WarmupKey, "10m"))
+	invokers := []base.Invoker{base.NewBaseInvoker(warm), base.NewBaseInvoker(cold)}
+
+	selected := make(map[base.Invoker]int)
+	for i := 0; i < 1100; i++ {
+		selected[loadBalance.Select(invokers, &invocation.RPCInvocation{})]++
+	}
+
+	// the cold provider runs at about a tenth of its weight
+	assert.InDelta(t, 100, selected[invokers[1]], 20)
+}
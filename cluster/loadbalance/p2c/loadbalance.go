@@ -154,8 +154,13 @@ func (l *p2cLoadBalance) Select(invokers []base.Invoker, invocation base.Invocat
 
 	logger.Debugf("[P2C select] The invoker[%d] remaining is %d, and the invoker[%d] is %d.", i, remainingI, j, remainingJ)
 
+	// Scale the remaining capacity by the effective weight, so that a provider
+	// which is still warming up only wins against a comparable warmed-up one.
+	scoreI := float64(remainingI) * float64(loadbalance.GetWeight(invokers[i], invocation))
+	scoreJ := float64(remainingJ) * float64(loadbalance.GetWeight(invokers[j], invocation))
+
 	// For the remaining capacity, the bigger, the better.
-	if remainingI > remainingJ {
+	if scoreI > scoreJ {
 		logger.Debugf("[P2C select] The invoker[%d] was selected.", i)
 		return invokers[i]
 	}
@@ -18,7 +18,9 @@
 package p2c
 
 import (
+	"strconv"
 	"testing"
+	"time"
 )
 
 import (
@@ -30,6 +32,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/cluster/metrics"
 	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	protoinvoc "dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 )
@@ -204,4 +207,36 @@ func TestLoadBalance(t *testing.T) {
 		assert.Equal(t, ivkArr[1].GetURL().String(), ivk.GetURL().String())
 	})
 
+	t.Run("warming up invoker", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		m := metrics.NewMockMetrics(ctrl)
+		metrics.LocalMetrics = m
+
+		// url0 has more remaining capacity, but started only a minute ago out of a ten minutes warm-up
+		started := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+		url0, _ := common.NewURL("dubbo://192.168.1.0:20000/com.ikurento.user.UserProvider",
+			common.WithParamsValue(constant.RemoteTimestampKey, started),
+			common.WithParamsValue(constant.WarmupKey, "600"))
+		url1, _ := common.NewURL("dubbo://192.168.1.1:20000/com.ikurento.user.UserProvider")
+
+		m.EXPECT().
+			GetMethodMetrics(gomock.Eq(url0), gomock.Eq(invocation.MethodName()), gomock.Eq(metrics.HillClimbing)).
+			Times(1).
+			Return(uint64(10), nil)
+		m.EXPECT().
+			GetMethodMetrics(gomock.Eq(url1), gomock.Eq(invocation.MethodName()), gomock.Eq(metrics.HillClimbing)).
+			Times(1).
+			Return(uint64(5), nil)
+
+		ivkArr := []base.Invoker{
+			base.NewBaseInvoker(url0),
+			base.NewBaseInvoker(url1),
+		}
+
+		ivk := lb.Select(ivkArr, invocation)
+
+		assert.Equal(t, ivkArr[1].GetURL().String(), ivk.GetURL().String())
+	})
 }
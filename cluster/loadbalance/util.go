@@ -18,10 +18,12 @@
 package loadbalance
 
 import (
+	"strconv"
 	"time"
 )
 
 import (
+	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 )
@@ -44,19 +46,14 @@ func GetWeight(invoker base.Invoker, invocation base.Invocation) int64 {
 		weight = constant.DefaultWeight
 	}
 
-	// Warm‑up adjustment (same logic as before).
+	// Warm‑up adjustment: the weight grows linearly with the provider uptime.
 	if weight > 0 {
-		now := time.Now().Unix()
-		ts := url.GetParamInt(constant.RemoteTimestampKey, now)
-		if uptime := now - ts; uptime > 0 {
-			warm := url.GetParamInt(constant.WarmupKey, constant.DefaultWarmup)
-			if uptime < warm {
-				calc := float64(uptime) * float64(weight) / float64(warm)
-				if calc < 1 {
-					weight = 1
-				} else if int64(calc) <= weight {
-					weight = int64(calc)
-				}
+		if uptime, warm := warmupProgress(url); uptime < warm {
+			calc := float64(uptime) * float64(weight) / float64(warm)
+			if calc < 1 {
+				weight = 1
+			} else if int64(calc) <= weight {
+				weight = int64(calc)
 			}
 		}
 	}
@@ -66,3 +63,49 @@ func GetWeight(invoker base.Invoker, invocation base.Invocation) int64 {
 	}
 	return weight
 }
+
+// GetWarmupRatio returns the share of its full weight the provider behind @url
+// should receive, in (0, 1]. It is 1 once the provider has finished warming up,
+// and is meant for load balancers that do not select by weight directly.
+func GetWarmupRatio(url *common.URL) float64 {
+	uptime, warm := warmupProgress(url)
+	if uptime >= warm {
+		return 1
+	}
+	ratio := float64(uptime) / float64(warm)
+	if ratio < minWarmupRatio {
+		ratio = minWarmupRatio
+	}
+	return ratio
+}
+
+// GetWarmup returns the warm-up window in seconds published by the provider.
+// Both plain seconds ("600") and Go durations ("10m") are accepted.
+func GetWarmup(url *common.URL) int64 {
+	raw := url.GetParam(constant.WarmupKey, "")
+	if raw == "" {
+		return constant.DefaultWarmup
+	}
+	if sec, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return sec
+	}
+	if d, err := time.ParseDuration(raw); err == nil {
+		return int64(d / time.Second)
+	}
+	return constant.DefaultWarmup
+}
+
+// minWarmupRatio keeps a provider that has just started reachable.
+const minWarmupRatio = 0.01
+
+// warmupProgress returns the provider uptime and its warm-up window, both in seconds.
+// An uptime not smaller than the window means the provider is fully warmed up.
+func warmupProgress(url *common.URL) (uptime int64, warm int64) {
+	now := time.Now().Unix()
+	ts := url.GetParamInt(constant.RemoteTimestampKey, now)
+	if uptime = now - ts; uptime <= 0 {
+		// unknown or skewed start time, treat the provider as warmed up
+		return 0, 0
+	}
+	return uptime, GetWarmup(url)
+}
@@ -26,6 +26,8 @@ import (
 import (
 	"github.com/dubbogo/gost/log/logger"
 
+	perrors "github.com/pkg/errors"
+
 	"gopkg.in/yaml.v2"
 )
 
@@ -35,11 +37,19 @@ import (
 	conf "dubbo.apache.org/dubbo-go/v3/common/config"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/validation"
 	"dubbo.apache.org/dubbo-go/v3/global"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
+func init() {
+	validation.SetValidator(constant.AffinityRuleSuffix, validation.ValidatorFunc(func(_, rule string) error {
+		_, err := parseRule(rule)
+		return err
+	}))
+}
+
 type ServiceAffinityRoute struct {
 	affinityRoute
 }
@@ -126,7 +136,10 @@ func (s *ApplicationAffinityRoute) Notify(invokers []base.Invoker) {
 
 	if providerApplication != s.application {
 		if s.application != "" {
-			dynamicConfiguration.RemoveListener(strings.Join([]string{s.application, constant.AffinityRuleSuffix}, ""), s)
+			formerKey := strings.Join([]string{s.application, constant.AffinityRuleSuffix}, "")
+			dynamicConfiguration.RemoveListener(formerKey, s)
+			// the pipeline knows the embedded affinityRoute, which checks the events
+			validation.RemoveListener(formerKey, &s.affinityRoute)
 		}
 		s.application = providerApplication
 
@@ -151,6 +164,10 @@ type affinityRoute struct {
 }
 
 func (a *affinityRoute) Process(event *config_center.ConfigChangeEvent) {
+	if err := validation.Check(a, event); err != nil {
+		return
+	}
+
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	a.matcher, a.enabled, a.key, a.ratio = nil, false, "", 0
@@ -158,29 +175,15 @@ func (a *affinityRoute) Process(event *config_center.ConfigChangeEvent) {
 	switch event.ConfigType {
 	case remoting.EventTypeDel:
 	case remoting.EventTypeAdd, remoting.EventTypeUpdate:
-		cfg, err := parseConfig(event.Value.(string))
+		rule, err := parseRule(event.Value.(string))
 		if err != nil {
-			logger.Errorf("Failed to parse affinity config, key=%s, err=%v", a.key, err)
-			return
-		}
-
-		if cfg.AffinityAware.Ratio < 0 || cfg.AffinityAware.Ratio > 100 {
-			logger.Errorf("Failed to parse affinity config, affinity.ratio=%d, expect 0-100", a.ratio)
+			logger.Errorf("Failed to parse affinity config, key=%s, err=%v", event.Key, err)
 			return
 		}
-
-		key := strings.TrimSpace(cfg.AffinityAware.Key)
-		if !cfg.Enabled || key == "" {
+		if rule.matcher == nil {
 			return
 		}
-		rule := strings.Join([]string{key, key}, "=$")
-		f, err := condition.NewFieldMatcher(rule)
-		if err != nil {
-			logger.Errorf("Failed to parse affinity config, key=%s, rule=%s ,err=%v", a.key, rule, err)
-			return
-		}
-
-		a.matcher, a.enabled, a.key, a.ratio = &f, true, key, cfg.AffinityAware.Ratio
+		a.matcher, a.enabled, a.key, a.ratio = rule.matcher, true, rule.key, rule.ratio
 	}
 }
 
@@ -223,6 +226,34 @@ func (a *affinityRoute) Notify(_ []base.Invoker) {
 	panic("this function should not be called")
 }
 
+// affinityRule is a parsed affinity rule, matcher is nil if the rule is disabled
+type affinityRule struct {
+	matcher *condition.FieldMatcher
+	key     string
+	ratio   int32
+}
+
+// parseRule parses and checks an affinity rule
+func parseRule(c string) (*affinityRule, error) {
+	cfg, err := parseConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.AffinityAware.Ratio < 0 || cfg.AffinityAware.Ratio > 100 {
+		return nil, perrors.Errorf("affinity.ratio=%d, expect 0-100", cfg.AffinityAware.Ratio)
+	}
+	key := strings.TrimSpace(cfg.AffinityAware.Key)
+	if !cfg.Enabled || key == "" {
+		return &affinityRule{}, nil
+	}
+	rule := strings.Join([]string{key, key}, "=$")
+	f, err := condition.NewFieldMatcher(rule)
+	if err != nil {
+		return nil, perrors.WithMessagef(err, "rule=%s", rule)
+	}
+	return &affinityRule{matcher: &f, key: key, ratio: cfg.AffinityAware.Ratio}, nil
+}
+
 func parseConfig(c string) (global.AffinityRouter, error) {
 	res := global.AffinityRouter{}
 	err := yaml.Unmarshal([]byte(c), &res)
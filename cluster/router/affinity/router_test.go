@@ -18,6 +18,7 @@
 package affinity
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -30,6 +31,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/validation"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
@@ -247,3 +249,26 @@ func Test_newApplicationAffinityRouter(t *testing.T) {
 	assert.NotNil(t, router)
 	assert.Equal(t, "test-app", router.currentApplication)
 }
+
+func TestAffinityRouteValidation(t *testing.T) {
+	rule := func(ratio int) string {
+		return fmt.Sprintf("configVersion: v3.1\nscope: service\nkey: service.apache.com\nenabled: true\n"+
+			"affinityAware:\n  key: region\n  ratio: %d", ratio)
+	}
+	key := "com.foo.BarService::" + constant.AffinityRuleSuffix
+	assert.NoError(t, validation.GetValidator(key).Validate(key, rule(20)))
+	assert.Error(t, validation.GetValidator(key).Validate(key, rule(101)))
+
+	a := &affinityRoute{}
+	a.Process(&config_center.ConfigChangeEvent{Key: key, Value: rule(20), ConfigType: remoting.EventTypeUpdate})
+	assert.True(t, a.enabled)
+	assert.Equal(t, int32(20), a.ratio)
+
+	// the invalid rule is rejected, the applied one is kept
+	a.Process(&config_center.ConfigChangeEvent{Key: key, Value: rule(101), ConfigType: remoting.EventTypeUpdate})
+	assert.True(t, a.enabled)
+	assert.Equal(t, int32(20), a.ratio)
+	history := validation.Default().History(key)
+	assert.Len(t, history, 1)
+	assert.Equal(t, rule(20), history[0].Value)
+}
@@ -36,11 +36,20 @@ import (
 	conf "dubbo.apache.org/dubbo-go/v3/common/config"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/validation"
 	"dubbo.apache.org/dubbo-go/v3/global"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
+func init() {
+	validation.SetValidator(constant.ConditionRouterRuleSuffix, validation.ValidatorFunc(func(_, rule string) error {
+		_, _, _, err := generateCondition(rule)
+		return err
+	}))
+}
+
 // for version 3.0-
 type stateRouters []*StateRouter
 
@@ -103,6 +112,10 @@ type DynamicRouter struct {
 	force           bool
 	enable          bool
 	conditionRouter condRouter
+	// invokers and consumer are the last notified invokers and the url of the reference, which the new
+	// rules are dry-run against before they are applied
+	invokers []base.Invoker
+	consumer *common.URL
 }
 
 func (d *DynamicRouter) Route(invokers []base.Invoker, url *common.URL, invocation base.Invocation) []base.Invoker {
@@ -177,6 +190,10 @@ func (d *DynamicRouter) SetStaticConfig(cfg *global.RouterConfig) {
 // It does not merge with static rules bootstrapped via SetStaticConfig; any later
 // dynamic update replaces the current static-derived state.
 func (d *DynamicRouter) Process(event *config_center.ConfigChangeEvent) {
+	if err := validation.Check(d, event); err != nil {
+		return
+	}
+
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
@@ -193,6 +210,37 @@ func (d *DynamicRouter) Process(event *config_center.ConfigChangeEvent) {
 	}
 }
 
+// DryRun routes the last notified invokers by the rule of the event without applying it.
+// The rule is matched with the url of the reference and an invocation without method and attachments.
+func (d *DynamicRouter) DryRun(event *config_center.ConfigChangeEvent) (*validation.DryRunResult, error) {
+	d.mu.RLock()
+	invokers, consumer := d.invokers, d.consumer
+	d.mu.RUnlock()
+
+	result := &validation.DryRunResult{Total: len(invokers), Remaining: len(invokers)}
+	if event.ConfigType == remoting.EventTypeDel || len(invokers) == 0 || consumer == nil {
+		return result, nil
+	}
+	cr, force, enable, err := generateCondition(event.Value.(string))
+	if err != nil {
+		return nil, err
+	}
+	if !enable || cr == nil {
+		return result, nil
+	}
+	inv := invocation.NewRPCInvocation("", nil, nil)
+	result.Remaining = len(cr.route(invokers, consumer, inv))
+	// the traffic disabled by the rule is intended to be empty
+	result.Force = force || inv.GetAttachmentInterface(constant.TrafficDisableKey) != nil
+	return result, nil
+}
+
+func (d *DynamicRouter) setInvokers(invokers []base.Invoker) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.invokers = invokers
+}
+
 /*
 to check configVersion, here need decode twice.
 From a performance perspective, decoding from a string and decoding from a map[string]any
@@ -324,6 +372,13 @@ func NewServiceRouter() *ServiceRouter {
 	return &ServiceRouter{}
 }
 
+// newServiceRouterWithURL returns the ServiceRouter of the reference of the url
+func newServiceRouterWithURL(url *common.URL) *ServiceRouter {
+	s := NewServiceRouter()
+	s.consumer = referenceURL(url)
+	return s
+}
+
 // SetStaticConfig applies config only when scope is service and Conditions are present.
 func (s *ServiceRouter) SetStaticConfig(cfg *global.RouterConfig) {
 	if cfg == nil || cfg.Scope != constant.RouterScopeService || len(cfg.Conditions) == 0 {
@@ -345,6 +400,7 @@ func (s *ServiceRouter) Notify(invokers []base.Invoker) {
 		logger.Error("Failed to notify a dynamically condition rule, because url is empty")
 		return
 	}
+	s.setInvokers(invokers)
 
 	dynamicConfiguration := conf.GetEnvInstance().GetDynamicConfiguration()
 	if dynamicConfiguration == nil {
@@ -382,6 +438,7 @@ func NewApplicationRouter(url *common.URL) *ApplicationRouter {
 	a := &ApplicationRouter{
 		currentApplication: applicationName,
 	}
+	a.consumer = referenceURL(url)
 
 	dynamicConfiguration := conf.GetEnvInstance().GetDynamicConfiguration()
 	if dynamicConfiguration != nil && applicationName != "" {
@@ -411,6 +468,7 @@ func (a *ApplicationRouter) Notify(invokers []base.Invoker) {
 		logger.Error("Failed to notify a dynamically condition rule, because url is empty")
 		return
 	}
+	a.setInvokers(invokers)
 
 	dynamicConfiguration := conf.GetEnvInstance().GetDynamicConfiguration()
 	if dynamicConfiguration == nil {
@@ -426,7 +484,9 @@ func (a *ApplicationRouter) Notify(invokers []base.Invoker) {
 
 	if providerApplication != a.application {
 		if a.application != "" {
-			dynamicConfiguration.RemoveListener(strings.Join([]string{a.application, constant.ConditionRouterRuleSuffix}, ""), a)
+			formerKey := strings.Join([]string{a.application, constant.ConditionRouterRuleSuffix}, "")
+			dynamicConfiguration.RemoveListener(formerKey, a)
+			validation.RemoveListener(formerKey, a)
 		}
 
 		key := strings.Join([]string{providerApplication, constant.ConditionRouterRuleSuffix}, "")
@@ -445,6 +505,14 @@ func (a *ApplicationRouter) Notify(invokers []base.Invoker) {
 	}
 }
 
+// referenceURL returns the url of the reference from the url of the router chain
+func referenceURL(url *common.URL) *common.URL {
+	if url != nil && url.SubURL != nil {
+		return url.SubURL
+	}
+	return url
+}
+
 func removeDuplicates(rules []*global.ConditionRule) {
 	for i := 0; i < len(rules); i++ {
 		if rules[i] == nil {
@@ -38,8 +38,8 @@ func NewServiceConditionRouterFactory() router.PriorityRouterFactory {
 }
 
 // NewPriorityRouter constructs a new ServiceRouter
-func (s *ServiceRouteFactory) NewPriorityRouter(_ *common.URL) (router.PriorityRouter, error) {
-	return NewServiceRouter(), nil
+func (s *ServiceRouteFactory) NewPriorityRouter(url *common.URL) (router.PriorityRouter, error) {
+	return newServiceRouterWithURL(url), nil
 }
 
 // AppConditionRouterFactory router factory
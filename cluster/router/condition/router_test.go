@@ -18,6 +18,7 @@
 package condition
 
 import (
+	"strconv"
 	"sync"
 	"testing"
 )
@@ -1478,3 +1479,35 @@ conditions:
 		})
 	}
 }
+
+func TestDynamicRouterDryRun(t *testing.T) {
+	rule := func(host string, force bool) string {
+		return "configVersion: v3.1\nscope: service\nkey: com.foo.BarService\nforce: " + strconv.FormatBool(force) +
+			"\nenabled: true\nconditions:\n  - from:\n      match: region=hangzhou\n    to:\n      - match: host=" + host + "\n"
+	}
+	registryURL, _ := common.NewURL("registry://127.0.0.1:2181")
+	registryURL.SubURL, _ = common.NewURL(localConsumerAddr + region)
+	r := newServiceRouterWithURL(registryURL)
+	provider, _ := common.NewURL(localProviderAddr)
+	r.setInvokers([]base.Invoker{base.NewBaseInvoker(provider)})
+
+	result, err := r.DryRun(&config_center.ConfigChangeEvent{Key: "com.foo.BarService.condition-router", Value: rule("127.0.0.1", false), ConfigType: remoting.EventTypeUpdate})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Total)
+	assert.Equal(t, 1, result.Remaining)
+
+	result, err = r.DryRun(&config_center.ConfigChangeEvent{Key: "com.foo.BarService.condition-router", Value: rule("10.0.0.9", true), ConfigType: remoting.EventTypeUpdate})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Remaining)
+	assert.True(t, result.Force)
+
+	// the rule routing all the invokers away is rejected without force
+	r.Process(&config_center.ConfigChangeEvent{Key: "com.foo.BarService.dry-run.condition-router", Value: rule("10.0.0.9", false), ConfigType: remoting.EventTypeUpdate})
+	assert.Nil(t, r.conditionRouter)
+	r.Process(&config_center.ConfigChangeEvent{Key: "com.foo.BarService.dry-run.condition-router", Value: rule("10.0.0.9", true), ConfigType: remoting.EventTypeUpdate})
+	assert.NotNil(t, r.conditionRouter)
+
+	// the invalid rule is rejected, and the applied one is kept
+	r.Process(&config_center.ConfigChangeEvent{Key: "com.foo.BarService.dry-run.condition-router", Value: "configVersion: [", ConfigType: remoting.EventTypeUpdate})
+	assert.NotNil(t, r.conditionRouter)
+}
@@ -0,0 +1,212 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package instance
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+import (
+	"github.com/google/cel-go/cel"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+)
+
+const (
+	celMethodVar      = `method`
+	celArgumentsVar   = `arguments`
+	celAttachmentsVar = `attachments`
+	celInvokerVar     = `invoker`
+
+	// celCostLimit bounds the runtime cost of a single evaluation, so that a
+	// rule pushed from the config center can never stall the routing path.
+	celCostLimit = 100000
+)
+
+// celInstances evaluates a CEL expression against every invoker and keeps
+// the ones for which the expression returns true. Unlike javascript, CEL is
+// not Turing complete, has no side effects and has a bounded cost, so no
+// runtime pool and no invoker wrapper is needed.
+type celInstances struct {
+	env     *cel.Env
+	pgLock  sync.RWMutex
+	program map[string]*celProgram // rawScript to compiled program
+}
+
+type celProgram struct {
+	pg    cel.Program
+	count int32
+}
+
+func (p *celProgram) addCount(i int) int {
+	return int(atomic.AddInt32(&p.count, int32(i)))
+}
+
+func newCelInstances() *celInstances {
+	env, err := cel.NewEnv(
+		cel.Variable(celMethodVar, cel.StringType),
+		cel.Variable(celArgumentsVar, cel.ListType(cel.DynType)),
+		cel.Variable(celAttachmentsVar, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(celInvokerVar, cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	return &celInstances{
+		env:     env,
+		program: map[string]*celProgram{},
+	}
+}
+
+func (i *celInstances) Run(rawScript string, invokers []base.Invoker, invocation base.Invocation) ([]base.Invoker, error) {
+	i.pgLock.RLock()
+	pg, ok := i.program[rawScript]
+	i.pgLock.RUnlock()
+
+	if !ok || len(invokers) == 0 {
+		return invokers, nil
+	}
+
+	activation := map[string]any{
+		celMethodVar:      invocation.MethodName(),
+		celArgumentsVar:   celArguments(invocation),
+		celAttachmentsVar: celAttachments(invocation),
+	}
+
+	result := make([]base.Invoker, 0, len(invokers))
+	for _, invoker := range invokers {
+		activation[celInvokerVar] = celInvoker(invoker.GetURL())
+		out, _, err := pg.pg.Eval(activation)
+		if err != nil {
+			return invokers, err
+		}
+		matched, ok := out.Value().(bool)
+		if !ok {
+			return invokers, fmt.Errorf("cel expression must return bool, but got %s", out.Type().TypeName())
+		}
+		if matched {
+			result = append(result, invoker)
+		}
+	}
+	return result, nil
+}
+
+func (i *celInstances) Compile(rawScript string) error {
+	i.pgLock.RLock()
+	pg, ok := i.program[rawScript]
+	i.pgLock.RUnlock()
+	if ok {
+		pg.addCount(1)
+		return nil
+	}
+
+	i.pgLock.Lock()
+	defer i.pgLock.Unlock()
+	// double check to avoid race
+	if pg, ok = i.program[rawScript]; ok {
+		pg.addCount(1)
+		return nil
+	}
+
+	ast, iss := i.env.Compile(strings.TrimSpace(rawScript))
+	if iss != nil && iss.Err() != nil {
+		return iss.Err()
+	}
+	// dyn typed expressions, such as the values of the untyped maps, are checked by Run
+	if outputType := ast.OutputType(); outputType != cel.BoolType && outputType != cel.DynType {
+		return fmt.Errorf("cel expression must return bool, but returns %s", outputType)
+	}
+	newPg, err := i.env.Program(ast, cel.CostLimit(celCostLimit))
+	if err != nil {
+		return err
+	}
+	i.program[rawScript] = &celProgram{pg: newPg, count: 1}
+	return nil
+}
+
+func (i *celInstances) Destroy(rawScript string) {
+	i.pgLock.Lock()
+	if pg, ok := i.program[rawScript]; ok {
+		if pg.addCount(-1) == 0 {
+			delete(i.program, rawScript)
+		}
+	}
+	i.pgLock.Unlock()
+}
+
+func celArguments(invocation base.Invocation) []any {
+	args := invocation.Arguments()
+	if args == nil {
+		return []any{}
+	}
+	return args
+}
+
+func celAttachments(invocation base.Invocation) map[string]any {
+	attachments := make(map[string]any, len(invocation.Attachments()))
+	for k, v := range invocation.Attachments() {
+		// attachments delivered over the wire are usually []string with a single value
+		if vs, ok := v.([]string); ok && len(vs) == 1 {
+			attachments[k] = vs[0]
+			continue
+		}
+		attachments[k] = v
+	}
+	return attachments
+}
+
+// celInvoker exposes the invoker url to the expression. Params carry the
+// url parameters, metadata carries the string typed url attributes, which is
+// where instance metadata lands when application level discovery is used.
+func celInvoker(url *common.URL) map[string]any {
+	params := make(map[string]string)
+	metadata := make(map[string]string)
+	if url == nil {
+		return map[string]any{"params": params, "metadata": metadata}
+	}
+	url.RangeParams(func(key, value string) bool {
+		params[key] = value
+		return true
+	})
+	url.RangeAttributes(func(key string, value any) bool {
+		if s, ok := value.(string); ok {
+			metadata[key] = s
+		}
+		return true
+	})
+	return map[string]any{
+		"protocol":  url.Protocol,
+		"ip":        url.Ip,
+		"port":      url.Port,
+		"address":   url.Address(),
+		"path":      url.Path,
+		"interface": url.Interface(),
+		"group":     url.Group(),
+		"version":   url.Version(),
+		"tag":       url.GetParam(constant.Tagkey, ""),
+		"params":    params,
+		"metadata":  metadata,
+	}
+}
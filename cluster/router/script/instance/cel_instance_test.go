@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package instance
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+)
+
+func TestCelInstances_Compile(t *testing.T) {
+	ins, err := GetInstances("cel")
+	require.NoError(t, err)
+
+	// syntax error
+	assert.Error(t, ins.Compile(`invoker.port ==`))
+	// unknown variable is a type-checking error
+	assert.Error(t, ins.Compile(`unknown == "20000"`))
+	// non bool result
+	assert.Error(t, ins.Compile(`method`))
+
+	// dyn result is checked at runtime
+	require.NoError(t, ins.Compile(`attachments["canary"]`))
+	ins.Destroy(`attachments["canary"]`)
+
+	script := `invoker.port != "20000"`
+	require.NoError(t, ins.Compile(script))
+	// compiled only once, the second compile increases the reference count
+	require.NoError(t, ins.Compile(script))
+	ins.Destroy(script)
+	ins.Destroy(script)
+}
+
+func TestCelInstances_Run(t *testing.T) {
+	ins := newCelInstances()
+	invokers, inv, _ := getRouteArgs()
+
+	tests := []struct {
+		name   string
+		script string
+		ports  []string
+	}{
+		{
+			name:   "filter by port",
+			script: `invoker.port != "20000"`,
+			ports:  []string{"20001", "20002"},
+		},
+		{
+			name:   "filter by method and params",
+			script: `method == "GetUser" && invoker.params["pid"] == "1448"`,
+			ports:  []string{"20001"},
+		},
+		{
+			name:   "filter by attachment",
+			script: `attachments["attachmentKey"] == "attachmentValue" && invoker.port == "20002"`,
+			ports:  []string{"20002"},
+		},
+		{
+			name:   "no match",
+			script: `size(arguments) > 0`,
+			ports:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, ins.Compile(tt.script))
+			defer ins.Destroy(tt.script)
+
+			res, err := ins.Run(tt.script, invokers, inv)
+			require.NoError(t, err)
+			ports := make([]string, 0, len(res))
+			for _, invoker := range res {
+				ports = append(ports, invoker.GetURL().Port)
+			}
+			assert.Equal(t, tt.ports, ports)
+		})
+	}
+}
+
+func TestCelInstances_RunDyn(t *testing.T) {
+	ins := newCelInstances()
+	invokers, _, _ := getRouteArgs()
+	script := `attachments["canary"]`
+	require.NoError(t, ins.Compile(script))
+
+	res, err := ins.Run(script, invokers, invocation.NewRPCInvocation("GetUser", nil, map[string]any{"canary": true}))
+	require.NoError(t, err)
+	assert.Len(t, res, len(invokers))
+
+	_, err = ins.Run(script, invokers, invocation.NewRPCInvocation("GetUser", nil, map[string]any{"canary": "yes"}))
+	assert.Error(t, err)
+}
+
+func TestCelInstances_RunArgumentsAndMetadata(t *testing.T) {
+	ins := newCelInstances()
+	u := url1()
+	u.SetAttribute("zone", "hangzhou")
+	invokers := []base.Invoker{base.NewBaseInvoker(u), base.NewBaseInvoker(url2())}
+	inv := invocation.NewRPCInvocation("GetUser", []any{"vip", int64(3)}, nil)
+
+	script := `arguments[0] == "vip" && "zone" in invoker.metadata && invoker.metadata["zone"] == "hangzhou"`
+	require.NoError(t, ins.Compile(script))
+	res, err := ins.Run(script, invokers, inv)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, "20000", res[0].GetURL().Port)
+}
+
+func TestCelInstances_RunCostLimit(t *testing.T) {
+	ins := newCelInstances()
+	invokers, inv, _ := getRouteArgs()
+
+	script := `[1,2,3,4,5,6,7,8,9,10].all(a, [1,2,3,4,5,6,7,8,9,10].all(b, [1,2,3,4,5,6,7,8,9,10].all(c, ` +
+		`[1,2,3,4,5,6,7,8,9,10].all(d, [1,2,3,4,5,6,7,8,9,10].all(e, a+b+c+d+e > 0)))))`
+	require.NoError(t, ins.Compile(script))
+	res, err := ins.Run(script, invokers, inv)
+	assert.Error(t, err)
+	// the original invokers are kept when the evaluation fails
+	assert.Equal(t, invokers, res)
+}
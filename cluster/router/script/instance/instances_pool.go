@@ -32,6 +32,7 @@ import (
 func init() {
 	factory = make(map[string]ScriptInstances)
 	setInstances(`javascript`, newJsInstances())
+	setInstances(`cel`, newCelInstances())
 }
 
 type ScriptInstances interface {
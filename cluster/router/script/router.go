@@ -25,6 +25,8 @@ import (
 import (
 	"github.com/dubbogo/gost/log/logger"
 
+	perrors "github.com/pkg/errors"
+
 	"gopkg.in/yaml.v2"
 )
 
@@ -34,12 +36,38 @@ import (
 	conf "dubbo.apache.org/dubbo-go/v3/common/config"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/validation"
 	"dubbo.apache.org/dubbo-go/v3/global"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
+func init() {
+	validation.SetValidator(constant.ScriptRouterRuleSuffix, validation.ValidatorFunc(func(_, rule string) error {
+		cfg, err := parseRoute(rule)
+		if err != nil {
+			return err
+		}
+		if err = checkRoute(cfg); err != nil {
+			return err
+		}
+		in, err := ins.GetInstances(cfg.ScriptType)
+		if err != nil {
+			return err
+		}
+		// the program is only compiled to check the script, it is released at once
+		// and compiled again when the rule is applied
+		if err = in.Compile(cfg.Script); err != nil {
+			return err
+		}
+		in.Destroy(cfg.Script)
+		return nil
+	}))
+}
+
 // ScriptRouter only takes effect on consumers and only supports application granular management.
+// The `type` of the rule selects the engine: `javascript` runs the script once against all invokers,
+// `cel` evaluates a boolean expression per invoker and keeps the invokers for which it returns true.
 type ScriptRouter struct {
 	applicationName string
 
@@ -66,22 +94,42 @@ func parseRoute(routeContent string) (*global.RouterConfig, error) {
 	return routerConfig, nil
 }
 
-func (s *ScriptRouter) Process(event *config_center.ConfigChangeEvent) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	rawConf, ok := event.Value.(string)
-	if !ok {
-		panic(ok)
+// checkRoute checks the fields a script rule must set
+func checkRoute(cfg *global.RouterConfig) error {
+	if cfg.ScriptType == "" {
+		return perrors.New("`type` field must be set in config")
 	}
-	cfg, err := parseRoute(rawConf)
-	if err != nil {
-		logger.Errorf("Parse route cfg failed: %v", err)
+	if cfg.Script == "" {
+		return perrors.New("`script` field must be set in config")
+	}
+	if cfg.Key == "" {
+		return perrors.New("`applicationName` field must be set in config")
+	}
+	if cfg.Enabled == nil {
+		return perrors.New("`enabled` field must be set in config")
+	}
+	return nil
+}
+
+func (s *ScriptRouter) Process(event *config_center.ConfigChangeEvent) {
+	if err := validation.Check(s, event); err != nil {
 		return
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	switch event.ConfigType {
 	case remoting.EventTypeAdd, remoting.EventTypeUpdate:
+		rawConf, ok := event.Value.(string)
+		if !ok {
+			panic(ok)
+		}
+		cfg, err := parseRoute(rawConf)
+		if err != nil {
+			logger.Errorf("Parse route cfg failed: %v", err)
+			return
+		}
 		//destroy old instance
 		if s.enabled && s.scriptType != "" {
 			in, err := ins.GetInstances(s.scriptType)
@@ -92,16 +140,8 @@ func (s *ScriptRouter) Process(event *config_center.ConfigChangeEvent) {
 			}
 		}
 		// check new config
-		if cfg.ScriptType == "" {
-			logger.Errorf("`type` field must be set in config")
-			return
-		}
-		if cfg.Script == "" {
-			logger.Errorf("`script` field must be set in config")
-			return
-		}
-		if cfg.Key == "" {
-			logger.Errorf("`applicationName` field must be set in config")
+		if err = checkRoute(cfg); err != nil {
+			logger.Errorf("%v", err)
 			return
 		}
 		if !*cfg.Enabled {
@@ -205,7 +245,9 @@ func (s *ScriptRouter) Notify(invokers []base.Invoker) {
 	)
 	if providerApplication != s.applicationName {
 		if s.applicationName != "" {
-			dynamicConfiguration.RemoveListener(strings.Join([]string{s.applicationName, constant.ScriptRouterRuleSuffix}, ""), s)
+			formerKey := strings.Join([]string{s.applicationName, constant.ScriptRouterRuleSuffix}, "")
+			dynamicConfiguration.RemoveListener(formerKey, s)
+			validation.RemoveListener(formerKey, s)
 		}
 
 		listenTarget = strings.Join([]string{providerApplication, constant.ScriptRouterRuleSuffix}, "")
@@ -215,6 +257,11 @@ func (s *ScriptRouter) Notify(invokers []base.Invoker) {
 		if err != nil {
 			logger.Errorf("Failed to query Script rule, applicationName=%s, listening=%s, err=%v", s.applicationName, listenTarget, err)
 		}
-		s.Process(&config_center.ConfigChangeEvent{Key: listenTarget, Value: value, ConfigType: remoting.EventTypeUpdate})
+		configType := remoting.EventTypeUpdate
+		if value == "" {
+			// the provider application has no rule, the rule of the former one is removed
+			configType = remoting.EventTypeDel
+		}
+		s.Process(&config_center.ConfigChangeEvent{Key: listenTarget, Value: value, ConfigType: configType})
 	}
 }
@@ -28,7 +28,9 @@ import (
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/validation"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
@@ -205,6 +207,49 @@ script: |
   	}
   	return result;
   }(invokers,invocation,context));
+`},
+			args: func() args {
+				res := args{}
+				res.invokers, res.invocation, _ = getRouteCheckArgs()
+				return res
+			}(),
+			want: func(invokers []base.Invoker) bool {
+				expect_invokers, _, _ := getRouteCheckArgs()
+				return checkInvokersSame(invokers, expect_invokers)
+			},
+		}, {
+			name: "cel test",
+			fields: fields{cfgContent: `configVersion: v3.0
+key: dubbo.io
+type: cel
+enabled: true
+script: |
+  method == "GetUser" && invoker.port != "20000"
+`},
+			args: func() args {
+				res := args{}
+				res.invokers, res.invocation, _ = getRouteCheckArgs()
+				return res
+			}(),
+			want: func(invokers []base.Invoker) bool {
+				if len(invokers) != 2 {
+					return false
+				}
+				for _, invoker := range invokers {
+					if invoker.GetURL().Port == "20000" {
+						return false
+					}
+				}
+				return true
+			},
+		}, {
+			name: "cel type check failed",
+			fields: fields{cfgContent: `configVersion: v3.0
+key: dubbo.io
+type: cel
+enabled: true
+script: |
+  method + 1 > 0
 `},
 			args: func() args {
 				res := args{}
@@ -227,6 +272,33 @@ script: |
 	}
 }
 
+func TestScriptRouterValidation(t *testing.T) {
+	rule := func(scriptType, script string) string {
+		return "configVersion: v3.0\nkey: dubbo.io\ntype: " + scriptType + "\nenabled: true\nscript: |\n  " + script + "\n"
+	}
+	key := "BDTService" + constant.ScriptRouterRuleSuffix
+	validator := validation.GetValidator(key)
+	assert.NoError(t, validator.Validate(key, rule("cel", `invoker.port != "20000"`)))
+	assert.Error(t, validator.Validate(key, rule("cel", "method + 1 > 0")))
+	assert.Error(t, validator.Validate(key, rule("javascript", "(function route(")))
+	assert.Error(t, validator.Validate(key, rule("errorType", "true")))
+	assert.Error(t, validator.Validate(key, "configVersion: v3.0\nkey: dubbo.io\ntype: cel\nscript: 'true'\n"))
+
+	s := NewScriptRouter()
+	s.Process(&config_center.ConfigChangeEvent{Key: key, Value: rule("cel", `invoker.port != "20000"`), ConfigType: remoting.EventTypeUpdate})
+	invokers, inv, _ := getRouteCheckArgs()
+	assert.Len(t, s.Route(invokers, nil, inv), 2)
+
+	// the invalid rule is rejected, the applied one is kept
+	s.Process(&config_center.ConfigChangeEvent{Key: key, Value: rule("cel", "method + 1 > 0"), ConfigType: remoting.EventTypeUpdate})
+	invokers, inv, _ = getRouteCheckArgs()
+	assert.Len(t, s.Route(invokers, nil, inv), 2)
+
+	s.Process(&config_center.ConfigChangeEvent{Key: key, Value: "", ConfigType: remoting.EventTypeDel})
+	invokers, inv, _ = getRouteCheckArgs()
+	assert.Len(t, s.Route(invokers, nil, inv), 3)
+}
+
 func checkInvokersSame(invokers []base.Invoker, otherInvokers []base.Invoker) bool {
 	k := map[string]struct{}{}
 	for _, invoker := range otherInvokers {
@@ -37,6 +37,14 @@ func NewTagRouterFactory() router.PriorityRouterFactory {
 }
 
 // NewPriorityRouter construct a new PriorityRouter
-func (f *RouteFactory) NewPriorityRouter(_ *common.URL) (router.PriorityRouter, error) {
-	return NewTagPriorityRouter()
+func (f *RouteFactory) NewPriorityRouter(url *common.URL) (router.PriorityRouter, error) {
+	r, err := NewTagPriorityRouter()
+	if err != nil {
+		return nil, err
+	}
+	if url != nil && url.SubURL != nil {
+		url = url.SubURL
+	}
+	r.consumer = url
+	return r, nil
 }
@@ -20,11 +20,14 @@ package tag
 import (
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 import (
 	"github.com/dubbogo/gost/log/logger"
 
+	perrors "github.com/pkg/errors"
+
 	"gopkg.in/yaml.v2"
 )
 
@@ -33,13 +36,33 @@ import (
 	conf "dubbo.apache.org/dubbo-go/v3/common/config"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/validation"
 	"dubbo.apache.org/dubbo-go/v3/global"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
+func init() {
+	validation.SetValidator(constant.TagRouterRuleSuffix, validation.ValidatorFunc(func(_, rule string) error {
+		routerConfig, err := parseRoute(rule)
+		if err != nil {
+			return err
+		}
+		for i, tag := range routerConfig.Tags {
+			if tag.Name == "" {
+				return perrors.Errorf("name of tag %d is empty", i)
+			}
+		}
+		return nil
+	}))
+}
+
 type PriorityRouter struct {
 	routerConfigs sync.Map
+	// invokers are the last notified invokers, which the new rules are dry-run against before they are applied
+	invokers atomic.Pointer[[]base.Invoker]
+	consumer *common.URL
 }
 
 func NewTagPriorityRouter() (*PriorityRouter, error) {
@@ -80,6 +103,7 @@ func (p *PriorityRouter) Notify(invokers []base.Invoker) {
 	if len(invokers) == 0 {
 		return
 	}
+	p.invokers.Store(&invokers)
 	application := invokers[0].GetURL().GetParam(constant.ApplicationKey, "")
 	if application == "" {
 		logger.Warn("url application is empty, tag router will not be enabled")
@@ -129,6 +153,9 @@ func (p *PriorityRouter) SetStaticConfig(cfg *global.RouterConfig) {
 // It does not merge with static rules bootstrapped via SetStaticConfig; any later
 // dynamic update replaces the current static-derived state.
 func (p *PriorityRouter) Process(event *config_center.ConfigChangeEvent) {
+	if err := validation.Check(p, event); err != nil {
+		return
+	}
 	if event.ConfigType == remoting.EventTypeDel {
 		p.routerConfigs.Delete(event.Key)
 		return
@@ -143,6 +170,36 @@ func (p *PriorityRouter) Process(event *config_center.ConfigChangeEvent) {
 	logger.Infof("[tag router]Parse tag router config success,routerConfig=%+v", routerConfig)
 }
 
+// DryRun routes the last notified invokers of the application of the rule by the rule without applying it,
+// the requests without tag are routed to the addresses of the tags in the rule.
+func (p *PriorityRouter) DryRun(event *config_center.ConfigChangeEvent) (*validation.DryRunResult, error) {
+	var invokers []base.Invoker
+	if ptr := p.invokers.Load(); ptr != nil && len(*ptr) != 0 {
+		application := (*ptr)[0].GetURL().GetParam(constant.ApplicationKey, "")
+		if strings.Join([]string{application, constant.TagRouterRuleSuffix}, "") == event.Key {
+			invokers = *ptr
+		}
+	}
+	result := &validation.DryRunResult{Total: len(invokers), Remaining: len(invokers)}
+	if event.ConfigType == remoting.EventTypeDel || len(invokers) == 0 {
+		return result, nil
+	}
+	routerConfig, err := parseRoute(event.Value.(string))
+	if err != nil {
+		return nil, err
+	}
+	if (routerConfig.Enabled != nil && !*routerConfig.Enabled) || !*routerConfig.Valid {
+		return result, nil
+	}
+	url := p.consumer
+	if url == nil {
+		url = common.NewURLWithOptions()
+	}
+	result.Remaining = len(dynamicTag(invokers, url, invocation.NewRPCInvocation("", nil, nil), *routerConfig))
+	result.Force = routerConfig.Force != nil && *routerConfig.Force
+	return result, nil
+}
+
 func parseRoute(routeContent string) (*global.RouterConfig, error) {
 	routeDecoder := yaml.NewDecoder(strings.NewReader(routeContent))
 	routerConfig := &global.RouterConfig{}
@@ -18,6 +18,7 @@
 package tag
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -37,6 +38,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/global"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
 var (
@@ -546,3 +548,38 @@ func TestRouteNilDefaults(t *testing.T) {
 
 	assert.Len(t, result, 3)
 }
+
+func TestPriorityRouterDryRun(t *testing.T) {
+	rule := func(force bool, addresses ...string) string {
+		return "force: " + strconv.FormatBool(force) + "\nenabled: true\nkey: app\ntags:\n  - name: gray\n    addresses: [" + strings.Join(addresses, ", ") + "]\n"
+	}
+	p, err := NewTagPriorityRouter()
+	require.NoError(t, err)
+	provider1, _ := common.NewURL("dubbo://192.168.0.1:20000/com.xxx.xxx.UserProvider?application=app")
+	provider2, _ := common.NewURL("dubbo://192.168.0.2:20000/com.xxx.xxx.UserProvider?application=app")
+	invokers := []base.Invoker{base.NewBaseInvoker(provider1), base.NewBaseInvoker(provider2)}
+	p.invokers.Store(&invokers)
+
+	result, err := p.DryRun(&config_center.ConfigChangeEvent{Key: "app.tag-router", Value: rule(false, "192.168.0.1:20000"), ConfigType: remoting.EventTypeUpdate})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Total)
+	assert.Equal(t, 1, result.Remaining)
+
+	// the rules of other applications are not dry-run against the invokers
+	result, err = p.DryRun(&config_center.ConfigChangeEvent{Key: "other.tag-router", Value: rule(false, "192.168.0.1:20000", "192.168.0.2:20000"), ConfigType: remoting.EventTypeUpdate})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Total)
+
+	// the rule leaving no address for the requests without tag is rejected without force
+	p.Process(&config_center.ConfigChangeEvent{Key: "app.tag-router", Value: rule(false, "192.168.0.1:20000", "192.168.0.2:20000"), ConfigType: remoting.EventTypeUpdate})
+	_, ok := p.routerConfigs.Load("app.tag-router")
+	assert.False(t, ok)
+	p.Process(&config_center.ConfigChangeEvent{Key: "app.tag-router", Value: rule(true, "192.168.0.1:20000", "192.168.0.2:20000"), ConfigType: remoting.EventTypeUpdate})
+	_, ok = p.routerConfigs.Load("app.tag-router")
+	assert.True(t, ok)
+
+	// the tag without name is invalid
+	p.Process(&config_center.ConfigChangeEvent{Key: "app.tag-router", Value: "force: true\ntags:\n  - addresses: [192.168.0.1:20000]\n", ConfigType: remoting.EventTypeUpdate})
+	value, _ := p.routerConfigs.Load("app.tag-router")
+	assert.Equal(t, "gray", value.(global.RouterConfig).Tags[0].Name)
+}
@@ -15,33 +15,28 @@
  * limitations under the License.
  */
 
-package metadata
+package wasm
 
 import (
-	"log"
+	"dubbo.apache.org/dubbo-go/v3/cluster/router"
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
 )
 
-// MetaData meta data from registration center
-type MetaData interface {
-	ShowRegistryCenterChildren() (map[string][]string, error)
-	ShowMetadataCenterChildren() (map[string][]string, error)
+func init() {
+	extension.SetRouterFactory(constant.WasmRouterFactoryKey, NewWasmRouterFactory)
 }
 
-// Factory metaData factory function
-type Factory func(name string, zkAddrs []string) MetaData
+// RouteFactory router factory
+type RouteFactory struct{}
 
-var factMap = make(map[string]Factory)
-
-// Register a factory into factMap
-func Register(name string, fact Factory) {
-	if _, ok := factMap[name]; ok {
-		log.Printf("duplicate name: %s", name)
-	}
-	factMap[name] = fact
+// NewWasmRouterFactory constructs a new PriorityRouterFactory
+func NewWasmRouterFactory() router.PriorityRouterFactory {
+	return &RouteFactory{}
 }
 
-// GetFactory get a factory function from factMap
-func GetFactory(name string) (fact Factory, ok bool) {
-	fact, ok = factMap[name]
-	return
+// NewPriorityRouter construct a new PriorityRouter
+func (f *RouteFactory) NewPriorityRouter(url *common.URL) (router.PriorityRouter, error) {
+	return NewWasmRouter(url)
 }
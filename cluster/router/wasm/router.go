@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package wasm provides a router selecting invokers by the route function of a WebAssembly module.
+package wasm
+
+import (
+	"context"
+	"runtime"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/wasm"
+)
+
+const routeFunc = "route"
+
+// WasmRouter runs the route function of the module configured by the `wasm.router.*` params of the
+// consumer url. The guest selects invokers by select_invoker and returns 0, any other return value
+// or a failure of the module keeps all invokers.
+type WasmRouter struct {
+	url    *common.URL
+	module *wasm.Module
+}
+
+// NewWasmRouter creates a router, the router is a no-op if no module is configured.
+func NewWasmRouter(url *common.URL) (*WasmRouter, error) {
+	r := &WasmRouter{url: url}
+	if url == nil {
+		return r, nil
+	}
+	m, err := wasm.ModuleFromURL(url, constant.WasmRouterPrefix)
+	if err != nil {
+		return nil, err
+	}
+	r.module = m
+	if m != nil {
+		// routers are never destroyed, so the module is released once the router is collected
+		runtime.AddCleanup(r, (*wasm.Module).Release, m)
+	}
+	return r, nil
+}
+
+// Route selects the invokers by the module.
+func (r *WasmRouter) Route(invokers []base.Invoker, _ *common.URL, invocation base.Invocation) []base.Invoker {
+	if len(invokers) == 0 || r.module == nil || !r.module.HasFunction(routeFunc) {
+		return invokers
+	}
+
+	state := &wasm.CallState{
+		Method:      invocation.MethodName(),
+		Attachments: wasm.CopyAttachments(invocation.Attachments()),
+		Invokers:    make([]*common.URL, 0, len(invokers)),
+	}
+	for _, invoker := range invokers {
+		state.Invokers = append(state.Invokers, invoker.GetURL())
+	}
+
+	ret, err := r.module.Call(context.Background(), routeFunc, state)
+	if err != nil {
+		logger.Warnf("[Wasm Router] %v", err)
+		return invokers
+	}
+	if ret != 0 {
+		return invokers
+	}
+
+	selected := make(map[int]struct{}, len(state.Selected))
+	res := make([]base.Invoker, 0, len(state.Selected))
+	for _, idx := range state.Selected {
+		if _, ok := selected[idx]; ok {
+			continue
+		}
+		selected[idx] = struct{}{}
+		res = append(res, invokers[idx])
+	}
+	return res
+}
+
+// URL Return URL in router
+func (r *WasmRouter) URL() *common.URL {
+	return r.url
+}
+
+// Priority Return Priority in router
+func (r *WasmRouter) Priority() int64 {
+	return 0
+}
+
+// Notify the router the invoker list
+func (r *WasmRouter) Notify(_ []base.Invoker) {
+}
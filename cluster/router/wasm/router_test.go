@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wasm
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+)
+
+func getRouteArgs() []base.Invoker {
+	u1, _ := common.NewURL("dubbo://127.0.0.1:20000/UserProvider?zone=hangzhou")
+	u2, _ := common.NewURL("dubbo://127.0.0.1:20001/UserProvider")
+	u3, _ := common.NewURL("dubbo://127.0.0.1:20002/UserProvider?zone=shanghai")
+	return []base.Invoker{base.NewBaseInvoker(u1), base.NewBaseInvoker(u2), base.NewBaseInvoker(u3)}
+}
+
+func consumerURL(file string) *common.URL {
+	u, _ := common.NewURL("consumer://127.0.0.1/UserProvider")
+	u.SetParam(constant.WasmRouterPrefix+constant.WasmPathSuffix, filepath.Join("..", "..", "..", "wasm", "testdata", file))
+	return u
+}
+
+func TestWasmRouterRoute(t *testing.T) {
+	r, err := NewWasmRouterFactory().NewPriorityRouter(consumerURL("router.wasm"))
+	require.NoError(t, err)
+
+	invokers := getRouteArgs()
+	res := r.Route(invokers, nil, invocation.NewRPCInvocation("GetUser", nil, nil))
+	require.Len(t, res, 2)
+	assert.Equal(t, "20000", res[0].GetURL().Port)
+	assert.Equal(t, "20002", res[1].GetURL().Port)
+}
+
+func TestWasmRouterKeepAll(t *testing.T) {
+	invokers := getRouteArgs()
+	inv := invocation.NewRPCInvocation("GetUser", nil, nil)
+
+	// no module configured
+	r, err := NewWasmRouter(common.NewURLWithOptions())
+	require.NoError(t, err)
+	assert.Equal(t, invokers, r.Route(invokers, nil, inv))
+
+	// the module runs out of time
+	u := consumerURL("loop.wasm")
+	u.SetParam(constant.WasmRouterPrefix+constant.WasmTimeoutSuffix, "10ms")
+	r, err = NewWasmRouter(u)
+	require.NoError(t, err)
+	assert.Equal(t, invokers, r.Route(invokers, nil, inv))
+
+	// the module can not be loaded
+	_, err = NewWasmRouter(consumerURL("absent.wasm"))
+	assert.Error(t, err)
+}
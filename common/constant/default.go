@@ -116,6 +116,9 @@ const (
 	// graceful shutdown
 	DefaultGracefulShutdownTimeout = 10 * time.Second
 
+	// DefaultWarmupTimeout is the longest time waiting for the warm-up hooks before the registry publication
+	DefaultWarmupTimeout = 3 * time.Minute
+
 	// MaxWheelTimeSpan consumer side max wait time for heartbeat-period
 	MaxWheelTimeSpan = 900e9 // 900s, 15 minute
 	// DefaultHeartbeatTimeout consumer default heartbeat timeout
@@ -52,6 +52,7 @@ const (
 	AnyhostKey             = "anyhost"
 	PortKey                = "port"
 	ProtocolKey            = "protocol"
+	ProtocolPreferenceKey  = "protocol-preference"
 	PathSeparator          = "/"
 	DotSeparator           = "."
 	CommaSeparator         = ","
@@ -67,6 +68,15 @@ const (
 	KeepAliveInterval = "keep-alive-interval"
 	KeepAliveTimeout  = "keep-alive-timeout"
 
+	// TransporterKey selects the remoting implementation of the dubbo protocol
+	TransporterKey    = "transporter"
+	GettyTransporter  = "getty"
+	NativeTransporter = "native"
+
+	// UnixSocketKey is the param of the socket path of a url addressing a unix domain socket
+	UnixSocketKey = "unix-socket"
+	UnixScheme    = "unix"
+
 	// TODO: remove IDLMode after old triple removed
 	IDLMode = "IDL-mode"
 
@@ -117,11 +127,13 @@ const (
 	SeataFilterKey                       = "seata"
 	SentinelProviderFilterKey            = "sentinel-provider"
 	SentinelConsumerFilterKey            = "sentinel-consumer"
+	ThrottleFilterKey                    = "throttle"
 	TokenFilterKey                       = "token"
 	TpsLimitFilterKey                    = "tps"
 	TracingFilterKey                     = "tracing"
 	OTELServerTraceKey                   = "otelServerTrace"
 	OTELClientTraceKey                   = "otelClientTrace"
+	WasmFilterKey                        = "wasm"
 )
 
 const (
@@ -131,6 +143,8 @@ const (
 	LoadbalanceKey                     = "loadbalance"
 	WeightKey                          = "weight"
 	WarmupKey                          = "warmup"
+	RegisterAfterWarmupKey             = "register-after-warmup"
+	WarmupTimeoutKey                   = "warmup-timeout"
 	RetriesKey                         = "retries"
 	StickyKey                          = "sticky"
 	BeanName                           = "bean.name"
@@ -176,6 +190,11 @@ const (
 	DubboGoCtxKey = DubboCtxKey("dubbogo-ctx")
 )
 
+const (
+	// TimeoutAttachmentKey carries the remaining time budget of a call in milliseconds, it is compatible with java dubbo
+	TimeoutAttachmentKey = "_TO"
+)
+
 // metadata report keys
 const (
 	MetadataReportNamespaceKey = "metadata-report.namespace"
@@ -208,6 +227,10 @@ const (
 	RegistryTypeInterface   = "interface"
 	RegistryTypeService     = "service"
 	RegistryTypeAll         = "all"
+
+	RegistryEmptyProtectionKey  = "registry.empty-protection"
+	RegistryAddressCacheKey     = "registry.address-cache"
+	RegistryAddressCacheFileKey = "registry.address-cache.file"
 )
 
 const (
@@ -279,6 +302,22 @@ const (
 	ConfiguratorSuffix = ".configurators"
 )
 
+// Use for the migration from interface-level to application-level service discovery
+const (
+	MigrationRuleSuffix   = ".migration"
+	MigrationRuleGroup    = "MIGRATION"
+	MigrationStepKey      = "migration.step"
+	MigrationThresholdKey = "migration.threshold"
+)
+
+// Use for the routes of the HTTP gateway in the config center
+const (
+	DefaultGatewayRoutesKey   = "dubbo.gateway.routes"
+	DefaultGatewayRoutesGroup = Dubbo
+	DefaultGatewayAddress     = ":8080"
+	DefaultGatewayMaxBodySize = 4 << 20
+)
+
 const (
 	NacosKey                  = "nacos"
 	NacosGroupKey             = "nacos.group"
@@ -322,6 +361,21 @@ const (
 	EtcdV3Key = "etcdv3"
 )
 
+const (
+	KubernetesKey = "kubernetes"
+	// KubernetesConfigKubeconfigKey is the kubeconfig file of the kubernetes config center, the in-cluster config is
+	// used if it is not set
+	KubernetesConfigKubeconfigKey = "kubeconfig"
+	// KubernetesConfigManagedByLabel marks the ConfigMaps of the configurations of the kubernetes config center
+	KubernetesConfigManagedByLabel = "app.kubernetes.io/managed-by"
+	KubernetesConfigManagedBy      = "dubbo-go"
+	// KubernetesConfigKeyAnnotation and KubernetesConfigGroupAnnotation are the key and the group of the
+	// configuration in its ConfigMap, whose name is derived from them
+	KubernetesConfigKeyAnnotation   = "dubbo.apache.org/config-key"
+	KubernetesConfigGroupAnnotation = "dubbo.apache.org/config-group"
+	KubernetesConfigContentKey      = "content"
+)
+
 const (
 	// PassThroughProxyFactoryKey is key of proxy factory with raw data input service
 	PassThroughProxyFactoryKey = "dubbo-raw"
@@ -360,9 +414,20 @@ const (
 	Scope                             = "scope"
 	Wildcard                          = "wildcard"
 	MeshRouterFactoryKey              = "mesh"
+	WasmRouterFactoryKey              = "wasm"
 	DefaultRouteConditionSubSetWeight = 100
 )
 
+// Wasm extension
+const (
+	WasmFilterPrefix           = "wasm.filter"
+	WasmRouterPrefix           = "wasm.router"
+	WasmPathSuffix             = ".path"
+	WasmConfigKeySuffix        = ".config-key"
+	WasmMemoryLimitPagesSuffix = ".memory-limit-pages"
+	WasmTimeoutSuffix          = ".timeout"
+)
+
 // Auth filter
 const (
 	ServiceAuthKey              = "auth"              // name of service filter
@@ -410,6 +475,8 @@ const (
 	DefaultMetadataStorageType             = "local"
 	RemoteMetadataStorageType              = "remote"
 	ServiceInstanceEndpoints               = "dubbo.endpoints"
+	ServiceInstanceStartTimestamp          = "dubbo.start-timestamp" // provider start time in seconds, used by warm-up
+	ServiceInstanceWarmup                  = "dubbo.warmup"          // provider warm-up window in seconds
 	MetadataServicePrefix                  = "dubbo.metadata-service."
 	MetadataServiceURLParamsPropertyName   = MetadataServicePrefix + "url-params"
 	MetadataServiceURLsPropertyName        = MetadataServicePrefix + "urls"
@@ -434,6 +501,48 @@ const (
 	AdaptiveServiceInflightKey  = "adaptive-service.inflight"
 	AdaptiveServiceEnabledKey   = "adaptive-service.enabled"
 	AdaptiveServiceIsEnabled    = "1"
+	// AdaptiveServiceLimiterKey selects the limiter of a service: hill-climbing(default), gradient2, vegas or aimd
+	AdaptiveServiceLimiterKey = "adaptive-service.limiter"
+)
+
+// TPS limit
+const (
+	// TPSLimitKeyKey selects the key the requests are limited by besides the service or the method, it is
+	// "application" for the consumer application, "attachment:<name>" for an attachment value, or
+	// "argument:<index>" for a request argument
+	TPSLimitKeyKey = "tps.limit.key"
+	// RemoteApplicationKey is the attachment key of the consumer application
+	RemoteApplicationKey = "remote.application"
+
+	TPSLimitRedisAddressKey      = "tps.limit.redis.address"
+	TPSLimitRedisUsernameKey     = "tps.limit.redis.username"
+	TPSLimitRedisPasswordKey     = "tps.limit.redis.password"
+	TPSLimitRedisDBKey           = "tps.limit.redis.db"
+	TPSLimitRedisTimeoutKey      = "tps.limit.redis.timeout"
+	TPSLimitRedisKeyPrefixKey    = "tps.limit.redis.key-prefix"
+	TPSLimitRedisFallbackRateKey = "tps.limit.redis.fallback-rate"
+)
+
+// Criticality
+const (
+	// CriticalityKey is the attachment key of the request criticality, it is also the method config key of references
+	CriticalityKey       = "criticality"
+	CriticalityCritical  = "CRITICAL"
+	CriticalityDefault   = "DEFAULT"
+	CriticalitySheddable = "SHEDDABLE"
+	// CriticalitySheddableThresholdKey and CriticalityDefaultThresholdKey are the fractions of the provider limits
+	// available to the requests of the criticality, the requests over the fraction are shed first
+	CriticalitySheddableThresholdKey = "criticality.sheddable.threshold"
+	CriticalityDefaultThresholdKey   = "criticality.default.threshold"
+)
+
+// Throttle Filter
+const (
+	// ThrottleKKey is the K of the client side adaptive throttling, requests are rejected locally once they are more
+	// than K times of the ones accepted by the providers
+	ThrottleKKey             = "throttle.k"
+	ThrottleWindowSecondsKey = "throttle.window-seconds"
+	ThrottleWindowPanesKey   = "throttle.window-panes"
 )
 
 // reflection service
@@ -448,6 +557,16 @@ const (
 	HealthCheckServiceInterface = "grpc.health.v1.Health"
 )
 
+// consumer active health check
+const (
+	HealthCheckEnabledKey            = "health-check.enabled"
+	HealthCheckIntervalKey           = "health-check.interval"
+	HealthCheckTimeoutKey            = "health-check.timeout"
+	HealthCheckJitterKey             = "health-check.jitter"
+	HealthCheckUnhealthyThresholdKey = "health-check.unhealthy-threshold"
+	HealthCheckHealthyThresholdKey   = "health-check.healthy-threshold"
+)
+
 const (
 	LoggerLevelKey          = "logger.level"
 	LoggerDriverKey         = "logger.driver"
@@ -459,6 +578,16 @@ const (
 	LoggerFileMaxAgeKey     = "logger.file.max-age"
 	LoggerFileLocalTimeKey  = "logger.file.local-time"
 	LoggerFileCompressKey   = "logger.file.compress"
+	LoggerLevelsKey         = "logger.levels"
+)
+
+// keys of the attributes the logger takes from the context of a log
+const (
+	LogTraceIDKey    = "trace_id"
+	LogSpanIDKey     = "span_id"
+	LogServiceKey    = "service"
+	LogMethodKey     = "method"
+	LogRemoteAddrKey = "remote_addr"
 )
 
 // metrics key
@@ -467,6 +596,7 @@ const (
 	RegistryEnabledKey                   = "metrics.registry.enabled"
 	ConfigCenterEnabledKey               = "metrics.config-center.enabled"
 	RpcEnabledKey                        = "metrics.rpc.enabled"
+	HealthCheckMetricsEnabledKey         = "metrics.health-check.enabled"
 	AggregationEnabledKey                = "aggregation.enabled"
 	AggregationBucketNumKey              = "aggregation.bucket.num"
 	AggregationTimeWindowSecondsKey      = "aggregation.time.window.seconds"
@@ -496,6 +626,13 @@ const (
 	DefaultEntrySize     = 100
 )
 
+// default registry address cache config
+const (
+	DefaultRegistryCacheName     = "dubbo.registry"
+	DefaultRegistryCacheFileName = "dubbo.registry."
+	DefaultRegistryCacheSize     = 1000
+)
+
 // priority
 const (
 	DefaultPriority = 0
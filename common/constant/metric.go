@@ -28,6 +28,7 @@ const (
 	MetricsApp          = "dubbo.metrics.app"
 	MetricsConfigCenter = "dubbo.metrics.configCenter"
 	MetricsRpc          = "dubbo.metrics.rpc"
+	MetricsHealthCheck  = "dubbo.metrics.healthCheck"
 )
 
 const (
@@ -45,6 +46,11 @@ const (
 	TagGroup              = "group"
 	TagVersion            = "version"
 	TagErrorCode          = "error"
+	TagAddress            = "address"
+	TagLimiter            = "limiter"
+	TagCriticality        = "criticality"
+	TagReason             = "reason"
+	TagSource             = "source"
 )
 const (
 	MetricNamespace                     = "dubbo"
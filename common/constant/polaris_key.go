@@ -40,3 +40,10 @@ const (
 	PluginPolarisRouterFactory = "polaris-router"
 	PluginPolarisReportFilter  = "polaris-report"
 )
+
+const (
+	// PolarisConfigOpenAPIKey is the address of the open api of polaris, which the config center publishes, removes
+	// and lists the config files by, it's the host of the config center address with port 8090 by default
+	PolarisConfigOpenAPIKey         = "polaris.open-api"
+	PolarisConfigDefaultOpenAPIPort = "8090"
+)
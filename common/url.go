@@ -564,6 +564,30 @@ func (c *URL) SetParam(key string, value string) {
 	c.params.Set(key, value)
 }
 
+// SetUnixSocket addresses the url to the unix domain socket of path, the location of the url turns to "unix:<path>".
+func (c *URL) SetUnixSocket(path string) {
+	c.Ip = ""
+	c.Port = ""
+	c.Location = constant.UnixScheme + ":" + path
+	c.SetParam(constant.UnixSocketKey, path)
+}
+
+// UnixSocket returns the path of the unix domain socket the url addresses.
+func (c *URL) UnixSocket() (string, bool) {
+	path := c.GetParam(constant.UnixSocketKey, "")
+	return path, path != ""
+}
+
+// ParseUnixSocket returns the socket path of a unix domain socket address like "unix:///path/to/sock" or "unix:/path/to/sock".
+func ParseUnixSocket(addr string) (string, bool) {
+	path, ok := strings.CutPrefix(addr, constant.UnixScheme+":")
+	if !ok {
+		return "", false
+	}
+	path = strings.TrimPrefix(path, "//")
+	return path, path != ""
+}
+
 // CompareAndSwapParam will set the key-value pair into URL when the current value equals the expected value.
 // It returns true if the value was set successfully, false otherwise.
 // This is a thread-safe compare-and-swap operation.
@@ -721,6 +745,14 @@ func (c *URL) GetParamByIntValue(key string, d int) int {
 	return int(r)
 }
 
+// GetParamPositiveInt gets positive int value by @key, @d is returned if the value is missing, invalid or not positive
+func (c *URL) GetParamPositiveInt(key string, d int) int {
+	if r := c.GetParamByIntValue(key, d); r > 0 {
+		return r
+	}
+	return d
+}
+
 // GetMethodParamInt gets int method param
 func (c *URL) GetMethodParamInt(method string, key string, d int64) int64 {
 	r, err := strconv.ParseInt(c.GetParam("methods."+method+"."+key, ""), 10, 64)
@@ -853,10 +885,14 @@ func (c *URL) MergeURL(anotherUrl *URL) *URL {
 		}
 	}
 
-	// remote timestamp
-	if v, ok := c.GetNonDefaultParam(constant.TimestampKey); !ok {
-		params[constant.RemoteTimestampKey] = []string{v}
-		params[constant.TimestampKey] = []string{anotherUrl.GetParam(constant.TimestampKey, "")}
+	// remote timestamp, the provider start time is kept for warm-up
+	if v, ok := c.GetNonDefaultParam(constant.TimestampKey); ok {
+		if _, exist := params[constant.RemoteTimestampKey]; !exist {
+			params[constant.RemoteTimestampKey] = []string{v}
+		}
+		if local := anotherUrl.GetParam(constant.TimestampKey, ""); local != "" {
+			params[constant.TimestampKey] = []string{local}
+		}
 	}
 
 	// finally execute methodConfigMergeFcn
@@ -1049,6 +1085,14 @@ func (c *URL) GetParamDuration(s string, d string) time.Duration {
 	return 3 * time.Second
 }
 
+// GetParamPositiveDuration gets positive duration by @key, @d is returned if the value is missing, invalid or not positive
+func (c *URL) GetParamPositiveDuration(key string, d time.Duration) time.Duration {
+	if t, err := time.ParseDuration(c.GetParam(key, "")); err == nil && t > 0 {
+		return t
+	}
+	return d
+}
+
 func GetSubscribeName(url *URL) string {
 	var buffer bytes.Buffer
 
@@ -380,6 +380,20 @@ func TestMergeUrl(t *testing.T) {
 	assert.Equal(t, "1", mergedUrl.GetParam(constant.MethodKeys+".testMethod."+constant.RetriesKey, ""))
 }
 
+func TestMergeUrlRemoteTimestamp(t *testing.T) {
+	referenceUrl, _ := NewURL("mock1://127.0.0.1:1111?timestamp=200")
+	serviceUrl, _ := NewURL("mock2://127.0.0.1:20000?timestamp=100")
+
+	mergedUrl := serviceUrl.MergeURL(referenceUrl)
+	assert.Equal(t, "100", mergedUrl.GetParam(constant.RemoteTimestampKey, ""))
+	assert.Equal(t, "200", mergedUrl.GetParam(constant.TimestampKey, ""))
+
+	// a start time already published by the provider instance wins
+	serviceUrl, _ = NewURL("mock2://127.0.0.1:20000?timestamp=100&remote.timestamp=50")
+	mergedUrl = serviceUrl.MergeURL(referenceUrl)
+	assert.Equal(t, "50", mergedUrl.GetParam(constant.RemoteTimestampKey, ""))
+}
+
 func TestURLSetParams(t *testing.T) {
 	u1, err := NewURL("dubbo://127.0.0.1:20000/com.ikurento.user.UserProvider?interface=com.ikurento.user.UserProvider&group=&version=2.6.0&configVersion=1.0")
 	require.NoError(t, err)
@@ -929,6 +943,21 @@ func TestGetParamByIntValue(t *testing.T) {
 	assert.Equal(t, 99, v2)
 }
 
+func TestGetParamPositiveInt(t *testing.T) {
+	params := url.Values{}
+	params.Set("key", "456")
+	params.Set("zero", "0")
+	params.Set("invalid", "abc")
+
+	u := &URL{}
+	u.SetParams(params)
+
+	assert.Equal(t, 456, u.GetParamPositiveInt("key", 1))
+	assert.Equal(t, 1, u.GetParamPositiveInt("zero", 1))
+	assert.Equal(t, 1, u.GetParamPositiveInt("invalid", 1))
+	assert.Equal(t, 1, u.GetParamPositiveInt("missing", 1))
+}
+
 func TestGetMethodParamIntValue(t *testing.T) {
 	params := url.Values{}
 	params.Set("methods.GetValue.timeout", "100")
@@ -965,6 +994,21 @@ func TestGetMethodParamInt64(t *testing.T) {
 	assert.Equal(t, int64(5), v3)
 }
 
+func TestGetParamPositiveDuration(t *testing.T) {
+	params := url.Values{}
+	params.Set("timeout", "5s")
+	params.Set("negative", "-1s")
+	params.Set("invalid", "abc")
+
+	u := &URL{}
+	u.SetParams(params)
+
+	assert.Equal(t, 5*time.Second, u.GetParamPositiveDuration("timeout", time.Second))
+	assert.Equal(t, time.Second, u.GetParamPositiveDuration("negative", time.Second))
+	assert.Equal(t, time.Second, u.GetParamPositiveDuration("invalid", time.Second))
+	assert.Equal(t, time.Second, u.GetParamPositiveDuration("missing", time.Second))
+}
+
 func TestGetParamDuration(t *testing.T) {
 	params := url.Values{}
 	params.Set("timeout", "5s")
@@ -1421,3 +1465,27 @@ func TestCloneThreadSafe(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestUnixSocket(t *testing.T) {
+	for _, addr := range []string{"unix:///tmp/dubbo.sock", "unix:/tmp/dubbo.sock"} {
+		path, ok := ParseUnixSocket(addr)
+		assert.True(t, ok)
+		assert.Equal(t, "/tmp/dubbo.sock", path)
+	}
+	_, ok := ParseUnixSocket("127.0.0.1:20000")
+	assert.False(t, ok)
+	_, ok = ParseUnixSocket("unix://")
+	assert.False(t, ok)
+
+	u := NewURLWithOptions(WithProtocol("tri"), WithIp("127.0.0.1"), WithPort("20000"))
+	_, ok = u.UnixSocket()
+	assert.False(t, ok)
+	u.SetUnixSocket("/tmp/dubbo.sock")
+	path, ok := u.UnixSocket()
+	assert.True(t, ok)
+	assert.Equal(t, "/tmp/dubbo.sock", path)
+	assert.Equal(t, "unix:/tmp/dubbo.sock", u.Location)
+	assert.Empty(t, u.Ip)
+	assert.Empty(t, u.Port)
+	assert.Equal(t, "unix:/tmp/dubbo.sock", u.Clone().Location)
+}
@@ -94,6 +94,7 @@ func compatProtocolConfig(c *global.ProtocolConfig) *config.ProtocolConfig {
 		Port:                 c.Port,
 		Params:               c.Params,
 		TripleConfig:         compatTripleConfig(c.TripleConfig),
+		Transporter:          c.Transporter,
 		MaxServerSendMsgSize: c.MaxServerSendMsgSize,
 		MaxServerRecvMsgSize: c.MaxServerRecvMsgSize,
 	}
@@ -205,6 +206,8 @@ func compatProviderConfig(c *global.ProviderConfig) *config.ProviderConfig {
 		ConfigType:             c.ConfigType,
 		AdaptiveService:        c.AdaptiveService,
 		AdaptiveServiceVerbose: c.AdaptiveServiceVerbose,
+		RegisterAfterWarmup:    c.RegisterAfterWarmup,
+		WarmupTimeout:          c.WarmupTimeout,
 	}
 }
 
@@ -276,6 +279,7 @@ func compatMethodConfig(c *global.MethodConfig) *config.MethodConfig {
 		ExecuteLimitRejectedHandler: c.ExecuteLimitRejectedHandler,
 		Sticky:                      c.Sticky,
 		RequestTimeout:              c.RequestTimeout,
+		Criticality:                 c.Criticality,
 	}
 }
 
@@ -349,6 +353,7 @@ func compatMethod(m []*global.MethodConfig) []*config.MethodConfig {
 			ExecuteLimitRejectedHandler: method.ExecuteLimitRejectedHandler,
 			Sticky:                      method.Sticky,
 			RequestTimeout:              method.RequestTimeout,
+			Criticality:                 method.Criticality,
 		})
 	}
 	return methods
@@ -424,6 +429,7 @@ func compatLoggerConfig(c *global.LoggerConfig) *config.LoggerConfig {
 		Format:   c.Format,
 		Appender: c.Appender,
 		File:     compatFile(c.File),
+		Levels:   c.Levels,
 	}
 }
 
@@ -617,6 +623,7 @@ func CompatGlobalProtocolConfig(c *config.ProtocolConfig) *global.ProtocolConfig
 		Port:                 c.Port,
 		Params:               c.Params,
 		TripleConfig:         compatGlobalTripleConfig(c.TripleConfig),
+		Transporter:          c.Transporter,
 		MaxServerSendMsgSize: c.MaxServerSendMsgSize,
 		MaxServerRecvMsgSize: c.MaxServerRecvMsgSize,
 	}
@@ -758,6 +765,8 @@ func compatGlobalProviderConfig(c *config.ProviderConfig) *global.ProviderConfig
 		ConfigType:             c.ConfigType,
 		AdaptiveService:        c.AdaptiveService,
 		AdaptiveServiceVerbose: c.AdaptiveServiceVerbose,
+		RegisterAfterWarmup:    c.RegisterAfterWarmup,
+		WarmupTimeout:          c.WarmupTimeout,
 	}
 }
 
@@ -829,6 +838,7 @@ func compatGlobalMethodConfig(c *config.MethodConfig) *global.MethodConfig {
 		ExecuteLimitRejectedHandler: c.ExecuteLimitRejectedHandler,
 		Sticky:                      c.Sticky,
 		RequestTimeout:              c.RequestTimeout,
+		Criticality:                 c.Criticality,
 	}
 }
 
@@ -902,6 +912,7 @@ func compatGlobalMethod(m []*config.MethodConfig) []*global.MethodConfig {
 			ExecuteLimitRejectedHandler: method.ExecuteLimitRejectedHandler,
 			Sticky:                      method.Sticky,
 			RequestTimeout:              method.RequestTimeout,
+			Criticality:                 method.Criticality,
 		})
 	}
 	return methods
@@ -1021,6 +1032,7 @@ func compatGlobalLoggerConfig(c *config.LoggerConfig) *global.LoggerConfig {
 		Format:   c.Format,
 		Appender: c.Appender,
 		File:     compatGlobalFile(c.File),
+		Levels:   c.Levels,
 	}
 }
 
@@ -31,6 +31,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	_ "dubbo.apache.org/dubbo-go/v3/logger/core/logrus"
+	_ "dubbo.apache.org/dubbo-go/v3/logger/core/slog"
 	"dubbo.apache.org/dubbo-go/v3/logger/core/zap"
 )
 
@@ -19,7 +19,9 @@ package config
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 import (
@@ -51,6 +53,10 @@ type LoggerConfig struct {
 
 	// logger file
 	File *File `yaml:"file"`
+
+	// levels of packages and their sub packages, keyed by package path, eg: dubbo.apache.org/dubbo-go/v3/registry: debug,
+	// supported by the slog driver
+	Levels map[string]string `yaml:"levels" json:"levels,omitempty" property:"levels"`
 }
 
 type File struct {
@@ -112,6 +118,14 @@ func (l *LoggerConfig) toURL() *common.URL {
 		common.WithParamsValue(constant.LoggerFileMaxAgeKey, strconv.Itoa(l.File.MaxAge)),
 		common.WithParamsValue(constant.LoggerFileCompressKey, strconv.FormatBool(*l.File.Compress)),
 	)
+	if len(l.Levels) > 0 {
+		levels := make([]string, 0, len(l.Levels))
+		for pkg, level := range l.Levels {
+			levels = append(levels, pkg+"="+level)
+		}
+		sort.Strings(levels)
+		url.SetParam(constant.LoggerLevelsKey, strings.Join(levels, ","))
+	}
 	return url
 }
 
@@ -173,6 +187,14 @@ func (lcb *LoggerConfigBuilder) SetFileCompress(compress bool) *LoggerConfigBuil
 	return lcb
 }
 
+func (lcb *LoggerConfigBuilder) SetPackageLevel(pkg, level string) *LoggerConfigBuilder {
+	if lcb.loggerConfig.Levels == nil {
+		lcb.loggerConfig.Levels = make(map[string]string)
+	}
+	lcb.loggerConfig.Levels[pkg] = level
+	return lcb
+}
+
 // Build return config and set default value if nil
 func (lcb *LoggerConfigBuilder) Build() *LoggerConfig {
 	if err := defaults.Set(lcb.loggerConfig); err != nil {
@@ -28,6 +28,10 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+)
+
 func TestLoggerInit(t *testing.T) {
 	t.Run("empty use default", func(t *testing.T) {
 		err := Load(WithPath("./testdata/config/logger/empty_log.yaml"))
@@ -84,3 +88,16 @@ func TestNewLoggerConfigBuilder(t *testing.T) {
 	assert.True(t, *config.File.Compress)
 	assert.Equal(t, 5, config.File.MaxBackups)
 }
+
+func TestLoggerConfigPackageLevels(t *testing.T) {
+	lc := NewLoggerConfigBuilder().
+		SetDriver("slog").
+		SetPackageLevel("dubbo.apache.org/dubbo-go/v3/registry", "debug").
+		SetPackageLevel("dubbo.apache.org/dubbo-go/v3/cluster", "warn").
+		Build()
+	u := lc.toURL()
+	assert.Equal(t, "dubbo.apache.org/dubbo-go/v3/cluster=warn,dubbo.apache.org/dubbo-go/v3/registry=debug",
+		u.GetParam(constant.LoggerLevelsKey, ""))
+	require.NoError(t, lc.Init())
+	assert.True(t, logger.SetLoggerLevel("info"))
+}
@@ -46,6 +46,8 @@ type MethodConfig struct {
 	ExecuteLimitRejectedHandler string `yaml:"execute.limit.rejected.handler" json:"execute.limit.rejected.handler,omitempty" property:"execute.limit.rejected.handler"`
 	Sticky                      bool   `yaml:"sticky"   json:"sticky,omitempty" property:"sticky"`
 	RequestTimeout              string `yaml:"timeout"  json:"timeout,omitempty" property:"timeout"`
+	// Criticality is the criticality of the requests of the method, it is one of CRITICAL, DEFAULT and SHEDDABLE
+	Criticality string `yaml:"criticality" json:"criticality,omitempty" property:"criticality"`
 }
 
 // Prefix builds the configuration key prefix for this method.
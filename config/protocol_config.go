@@ -34,6 +34,9 @@ type ProtocolConfig struct {
 
 	TripleConfig *TripleConfig `yaml:"triple" json:"triple,omitempty" property:"triple"`
 
+	// Transporter is the remoting implementation of the dubbo protocol, getty (default) or native.
+	Transporter string `yaml:"transporter" json:"transporter,omitempty" property:"transporter"`
+
 	// MaxServerSendMsgSize max size of server send message, 1mb=1000kb=1000000b 1mib=1024kb=1048576b.
 	// more detail to see https://pkg.go.dev/github.com/dustin/go-humanize#pkg-constants
 	MaxServerSendMsgSize string `yaml:"max-server-send-msg-size" json:"max-server-send-msg-size,omitempty"`
@@ -85,6 +88,11 @@ func (pcb *ProtocolConfigBuilder) SetParams(params any) *ProtocolConfigBuilder {
 	return pcb
 }
 
+func (pcb *ProtocolConfigBuilder) SetTransporter(transporter string) *ProtocolConfigBuilder {
+	pcb.protocolConfig.Transporter = transporter
+	return pcb
+}
+
 func (pcb *ProtocolConfigBuilder) SetMaxServerSendMsgSize(maxServerSendMsgSize string) *ProtocolConfigBuilder {
 	pcb.protocolConfig.MaxServerSendMsgSize = maxServerSendMsgSize
 	return pcb
@@ -57,6 +57,10 @@ type ProviderConfig struct {
 	// adaptive service
 	AdaptiveService        bool `yaml:"adaptive-service" json:"adaptive-service" property:"adaptive-service"`
 	AdaptiveServiceVerbose bool `yaml:"adaptive-service-verbose" json:"adaptive-service-verbose" property:"adaptive-service-verbose"`
+	// RegisterAfterWarmup delays the registry publication until the warm-up hooks of metrics/probe are done
+	RegisterAfterWarmup bool `yaml:"register-after-warmup" json:"register-after-warmup" property:"register-after-warmup"`
+	// WarmupTimeout is the longest time waiting for the warm-up hooks before the registry publication
+	WarmupTimeout string `default:"3m" yaml:"warmup-timeout" json:"warmup-timeout,omitempty" property:"warmup-timeout"`
 
 	rootConfig *RootConfig
 }
@@ -121,6 +125,8 @@ func (c *ProviderConfig) Init(rc *RootConfig) error {
 		}
 
 		serviceConfig.adaptiveService = c.AdaptiveService
+		serviceConfig.registerAfterWarmup = c.RegisterAfterWarmup
+		serviceConfig.warmupTimeout = c.WarmupTimeout
 	}
 
 	for k, v := range rc.Protocols {
@@ -191,6 +197,8 @@ func (c *ProviderConfig) Load() {
 				logger.Errorf("Service with registeredTypeName = %s init failed with error = %#v", registeredTypeName, err)
 			}
 			serviceConfig.adaptiveService = c.AdaptiveService
+			serviceConfig.registerAfterWarmup = c.RegisterAfterWarmup
+			serviceConfig.warmupTimeout = c.WarmupTimeout
 		}
 		serviceConfig.id = registeredTypeName
 		serviceConfig.Implement(service)
@@ -43,6 +43,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/protocolwrapper"
 	"dubbo.apache.org/dubbo-go/v3/proxy"
+	"dubbo.apache.org/dubbo-go/v3/registry/migration"
 )
 
 // ReferenceConfig is the configuration of service consumer
@@ -242,8 +243,8 @@ func (rc *ReferenceConfig) Refer(srv any) {
 		invoker base.Invoker
 		regURL  *common.URL
 	)
-	invokers := make([]base.Invoker, len(rc.urls))
-	for i, u := range rc.urls {
+	invokers, regURL, urls := migration.ReferAll(rc.urls)
+	for _, u := range urls {
 		if u.Protocol == constant.ServiceRegistryProtocol {
 			invoker = extension.GetProtocol(constant.RegistryProtocol).Refer(u)
 		} else {
@@ -254,7 +255,7 @@ func (rc *ReferenceConfig) Refer(srv any) {
 			invoker = protocolwrapper.BuildInvokerChain(invoker, constant.ReferenceFilterKey)
 		}
 
-		invokers[i] = invoker
+		invokers = append(invokers, invoker)
 		if u.Protocol == constant.RegistryProtocol {
 			regURL = u
 		}
@@ -386,6 +387,9 @@ func (rc *ReferenceConfig) getURLMap() url.Values {
 		if len(v.RequestTimeout) != 0 {
 			urlMap.Set("methods."+v.Name+"."+constant.TimeoutKey, v.RequestTimeout)
 		}
+		if len(v.Criticality) != 0 {
+			urlMap.Set("methods."+v.Name+"."+constant.CriticalityKey, v.Criticality)
+		}
 	}
 
 	return urlMap
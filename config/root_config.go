@@ -20,6 +20,7 @@ package config
 import (
 	"fmt"
 	"sync"
+	"time"
 )
 
 import (
@@ -34,6 +35,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/metrics/probe"
 	"dubbo.apache.org/dubbo-go/v3/registry/exposed_tmp"
 )
 
@@ -220,6 +222,15 @@ func (rc *RootConfig) Start() {
 		if err := initMetadata(rc); err != nil {
 			panic(err)
 		}
+		if rc.Provider != nil && rc.Provider.RegisterAfterWarmup {
+			timeout, err := time.ParseDuration(rc.Provider.WarmupTimeout)
+			if err != nil || timeout <= 0 {
+				timeout = constant.DefaultWarmupTimeout
+			}
+			if err := probe.AwaitWarmupTimeout(timeout); err != nil {
+				panic(err)
+			}
+		}
 		if err := exposed_tmp.RegisterServiceInstance(); err != nil {
 			panic(err)
 		}
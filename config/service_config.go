@@ -93,6 +93,11 @@ type ServiceConfig struct {
 
 	metadataType string
 	rc           *RootConfig
+
+	// registerAfterWarmup delays the registry publication until the warm-up hooks are done
+	registerAfterWarmup bool
+	// warmupTimeout is the longest time waiting for the warm-up hooks
+	warmupTimeout string
 }
 
 // Prefix returns dubbo.service.${InterfaceName}.
@@ -214,6 +219,9 @@ func (s *ServiceConfig) IsExport() bool {
 func getRandomPort(protocolConfigs []*ProtocolConfig) *list.List {
 	ports := list.New()
 	for _, proto := range protocolConfigs {
+		if _, isUnix := common.ParseUnixSocket(proto.Ip); isUnix {
+			continue
+		}
 		if port, err := strconv.Atoi(proto.Port); err != nil {
 			logger.Infof(
 				"%s will be assgined to a random port, since the port is an invalid number",
@@ -267,8 +275,9 @@ func (s *ServiceConfig) Export() error {
 			return formatErr
 		}
 
+		unixSocket, isUnix := common.ParseUnixSocket(protocolConf.Ip)
 		port := protocolConf.Port
-		if num, err := strconv.Atoi(protocolConf.Port); err != nil || num <= 0 {
+		if num, err := strconv.Atoi(protocolConf.Port); !isUnix && (err != nil || num <= 0) {
 			port = nextPort.Value.(string)
 			nextPort = nextPort.Next()
 		}
@@ -287,7 +296,11 @@ func (s *ServiceConfig) Export() error {
 			// fix https://github.com/apache/dubbo-go/issues/2176
 			common.WithParamsValue(constant.MaxServerSendMsgSize, protocolConf.MaxServerSendMsgSize),
 			common.WithParamsValue(constant.MaxServerRecvMsgSize, protocolConf.MaxServerRecvMsgSize),
+			common.WithParamsValue(constant.TransporterKey, protocolConf.Transporter),
 		)
+		if isUnix {
+			ivkURL.SetUnixSocket(unixSocket)
+		}
 		info := GetProviderServiceInfo(s.id)
 		if info != nil {
 			ivkURL.SetAttribute(constant.ServiceInfoKey, info)
@@ -304,7 +317,11 @@ func (s *ServiceConfig) Export() error {
 			return nil
 		}
 
-		if len(regUrls) > 0 {
+		if len(regUrls) > 0 && isUnix {
+			// a unix domain socket is reachable by local consumers only, so it is never published to registries
+			logger.Infof("The service %v on unix domain socket %s is exported locally without registries", s.Interface, unixSocket)
+		}
+		if len(regUrls) > 0 && !isUnix {
 			s.cacheMutex.Lock()
 			if s.cacheProtocol == nil {
 				logger.Debugf(fmt.Sprintf("First load the registry protocol, url is {%v}!", ivkURL))
@@ -401,6 +418,10 @@ func (s *ServiceConfig) getUrlMap() url.Values {
 	urlMap.Set(constant.ClusterKey, s.Cluster)
 	urlMap.Set(constant.LoadbalanceKey, s.Loadbalance)
 	urlMap.Set(constant.WarmupKey, s.Warmup)
+	if s.registerAfterWarmup {
+		urlMap.Set(constant.RegisterAfterWarmupKey, "true")
+		urlMap.Set(constant.WarmupTimeoutKey, s.warmupTimeout)
+	}
 	urlMap.Set(constant.RetriesKey, s.Retries)
 	if s.Group != "" {
 		urlMap.Set(constant.GroupKey, s.Group)
@@ -0,0 +1,427 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcdv3
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	gxset "github.com/dubbogo/gost/container/set"
+	gxetcd "github.com/dubbogo/gost/database/kv/etcd/v3"
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/parser"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsConfigCenter "dubbo.apache.org/dubbo-go/v3/metrics/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+	"dubbo.apache.org/dubbo-go/v3/remoting/etcdv3"
+)
+
+const (
+	pathSeparator   = "/"
+	defaultRootPath = "/dubbo/config"
+	// retryDelay is the interval to resync the configurations once the watch breaks
+	retryDelay = 3 * time.Second
+)
+
+type etcdDynamicConfiguration struct {
+	config_center.BaseDynamicConfiguration
+	url      *common.URL
+	rootPath string
+	group    string
+	timeout  time.Duration
+	wg       sync.WaitGroup
+	cltLock  sync.Mutex
+	done     chan struct{}
+	once     sync.Once
+	client   *gxetcd.Client
+	store    store
+	parser   parser.ConfigurationParser
+
+	listenerLock sync.RWMutex
+	listeners    map[string]map[config_center.ConfigurationListener]struct{}
+
+	// cache and revision are only accessed by the watching goroutine
+	cache    map[string]string
+	revision int64
+	synced   bool
+}
+
+func newEtcdDynamicConfiguration(url *common.URL) (*etcdDynamicConfiguration, error) {
+	c := newConfiguration(url)
+	if err := c.validateClient(); err != nil {
+		logger.Errorf("[Etcd ConfigCenter] etcd client start error, error message is %v", err)
+		return nil, err
+	}
+	c.store = &etcdStore{client: c.rawClient}
+	logger.Infof("[Etcd ConfigCenter] New Etcd ConfigCenter with url = %+v", url)
+	c.start()
+	return c, nil
+}
+
+func newConfiguration(url *common.URL) *etcdDynamicConfiguration {
+	timeout, err := time.ParseDuration(url.GetParam(constant.ConfigTimeoutKey, config_center.DefaultConfigTimeout))
+	if err != nil {
+		logger.Warnf("[Etcd ConfigCenter] invalid timeout %s, use default %s", url.GetParam(constant.ConfigTimeoutKey, ""), config_center.DefaultConfigTimeout)
+		timeout, _ = time.ParseDuration(config_center.DefaultConfigTimeout)
+	}
+	return &etcdDynamicConfiguration{
+		url:       url,
+		rootPath:  strings.TrimRight(url.GetParam(constant.ConfigRootPathParamKey, defaultRootPath), pathSeparator),
+		group:     url.GetParam(constant.ConfigGroupKey, config_center.DefaultGroup),
+		timeout:   timeout,
+		done:      make(chan struct{}),
+		listeners: make(map[string]map[config_center.ConfigurationListener]struct{}),
+		cache:     make(map[string]string),
+	}
+}
+
+func (c *etcdDynamicConfiguration) validateClient() error {
+	return etcdv3.ValidateClient(c,
+		gxetcd.WithName(gxetcd.RegistryETCDV3Client),
+		gxetcd.WithTimeout(c.timeout),
+		gxetcd.WithEndpoints(strings.Split(c.url.Location, ",")...),
+	)
+}
+
+func (c *etcdDynamicConfiguration) rawClient() *clientv3.Client {
+	c.cltLock.Lock()
+	defer c.cltLock.Unlock()
+	if c.client == nil {
+		return nil
+	}
+	return c.client.GetRawClient()
+}
+
+// start watches the root path in the background
+func (c *etcdDynamicConfiguration) start() {
+	c.wg.Add(1)
+	go c.run()
+}
+
+// AddListener adds listener for the key in the group of options, or the default group
+func (c *etcdDynamicConfiguration) AddListener(key string, listener config_center.ConfigurationListener, opts ...config_center.Option) {
+	path := c.getPath(key, c.groupOf(opts))
+	c.listenerLock.Lock()
+	defer c.listenerLock.Unlock()
+	if _, ok := c.listeners[path]; !ok {
+		c.listeners[path] = make(map[config_center.ConfigurationListener]struct{})
+	}
+	c.listeners[path][listener] = struct{}{}
+}
+
+// RemoveListener removes listener for the key in the group of options, or the default group
+func (c *etcdDynamicConfiguration) RemoveListener(key string, listener config_center.ConfigurationListener, opts ...config_center.Option) {
+	path := c.getPath(key, c.groupOf(opts))
+	c.listenerLock.Lock()
+	defer c.listenerLock.Unlock()
+	if listeners, ok := c.listeners[path]; ok {
+		delete(listeners, listener)
+		if len(listeners) == 0 {
+			delete(c.listeners, path)
+		}
+	}
+}
+
+func (c *etcdDynamicConfiguration) GetProperties(key string, opts ...config_center.Option) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	value, ok, err := c.store.get(ctx, c.getPath(key, c.groupOf(opts)))
+	if err != nil {
+		return "", perrors.WithStack(err)
+	}
+	if !ok {
+		logger.Warnf("[Etcd ConfigCenter] query rule fail, key=%s, group=%s, config not found", key, c.groupOf(opts))
+	}
+	return value, nil
+}
+
+// GetInternalProperty For etcd, getConfig and getConfigs have the same meaning.
+func (c *etcdDynamicConfiguration) GetInternalProperty(key string, opts ...config_center.Option) (string, error) {
+	return c.GetProperties(key, opts...)
+}
+
+func (c *etcdDynamicConfiguration) GetRule(key string, opts ...config_center.Option) (string, error) {
+	return c.GetProperties(key, opts...)
+}
+
+// PublishConfig will put the value into etcd with specific path
+func (c *etcdDynamicConfiguration) PublishConfig(key string, group string, value string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if err := c.store.put(ctx, c.getPath(key, group), value); err != nil {
+		return perrors.WithStack(err)
+	}
+	return nil
+}
+
+// RemoveConfig will remove the config with the (key, group) pair
+func (c *etcdDynamicConfiguration) RemoveConfig(key string, group string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if err := c.store.delete(ctx, c.getPath(key, group)); err != nil {
+		return perrors.WithStack(err)
+	}
+	return nil
+}
+
+// GetConfigKeysByGroup will return all keys with the group
+func (c *etcdDynamicConfiguration) GetConfigKeysByGroup(group string) (*gxset.HashSet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	prefix := c.getPath("", group) + pathSeparator
+	kvs, _, err := c.store.list(ctx, prefix)
+	if err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	if len(kvs) == 0 {
+		return nil, perrors.New("could not find keys with group: " + group)
+	}
+	set := gxset.NewSet()
+	for path := range kvs {
+		set.Add(strings.TrimPrefix(path, prefix))
+	}
+	return set, nil
+}
+
+func (c *etcdDynamicConfiguration) Parser() parser.ConfigurationParser {
+	return c.parser
+}
+
+func (c *etcdDynamicConfiguration) SetParser(p parser.ConfigurationParser) {
+	c.parser = p
+}
+
+func (c *etcdDynamicConfiguration) Client() *gxetcd.Client {
+	return c.client
+}
+
+func (c *etcdDynamicConfiguration) SetClient(client *gxetcd.Client) {
+	c.client = client
+}
+
+func (c *etcdDynamicConfiguration) ClientLock() *sync.Mutex {
+	return &c.cltLock
+}
+
+func (c *etcdDynamicConfiguration) WaitGroup() *sync.WaitGroup {
+	return &c.wg
+}
+
+func (c *etcdDynamicConfiguration) Done() chan struct{} {
+	return c.done
+}
+
+// RestartCallBack recreates the client once it loses the connection with etcd
+func (c *etcdDynamicConfiguration) RestartCallBack() bool {
+	if err := c.validateClient(); err != nil {
+		logger.Warnf("[Etcd ConfigCenter] reconnect to etcd error: %v", err)
+		return false
+	}
+	return true
+}
+
+func (c *etcdDynamicConfiguration) GetURL() *common.URL {
+	return c.url
+}
+
+func (c *etcdDynamicConfiguration) IsAvailable() bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (c *etcdDynamicConfiguration) Destroy() {
+	c.once.Do(func() {
+		close(c.done)
+		c.wg.Wait()
+		c.cltLock.Lock()
+		defer c.cltLock.Unlock()
+		if c.client != nil {
+			c.client.Close()
+			c.client = nil
+		}
+	})
+}
+
+// run resyncs the configurations under the root path and watches them since the synced revision,
+// it resyncs again once the watch breaks, e.g. the revision is compacted or the leader is lost
+func (c *etcdDynamicConfiguration) run() {
+	defer c.wg.Done()
+	for c.IsAvailable() {
+		if err := c.resync(); err != nil {
+			logger.Warnf("[Etcd ConfigCenter] sync configurations under %s error: %v", c.rootPath, err)
+			if !c.wait(retryDelay) {
+				return
+			}
+			if _, ok := c.store.(*etcdStore); ok {
+				c.RestartCallBack()
+			}
+			continue
+		}
+		if !c.watch() || !c.wait(retryDelay) {
+			return
+		}
+	}
+}
+
+// wait returns false if the configuration is destroyed during the delay
+func (c *etcdDynamicConfiguration) wait(delay time.Duration) bool {
+	select {
+	case <-c.done:
+		return false
+	case <-time.After(delay):
+		return true
+	}
+}
+
+// resync lists the configurations under the root path, and notifies the differences from the cached ones
+func (c *etcdDynamicConfiguration) resync() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	kvs, revision, err := c.store.list(ctx, c.rootPath+pathSeparator)
+	if err != nil {
+		return err
+	}
+	if c.synced {
+		for path, value := range kvs {
+			if old, ok := c.cache[path]; !ok {
+				c.notify(path, value, remoting.EventTypeAdd)
+			} else if old != value {
+				c.notify(path, value, remoting.EventTypeUpdate)
+			}
+		}
+		for path := range c.cache {
+			if _, ok := kvs[path]; !ok {
+				c.notify(path, "", remoting.EventTypeDel)
+			}
+		}
+	}
+	c.cache = kvs
+	c.revision = revision
+	c.synced = true
+	return nil
+}
+
+// watch dispatches the events since the synced revision, it returns false if the configuration is destroyed
+func (c *etcdDynamicConfiguration) watch() bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := c.store.watch(ctx, c.rootPath+pathSeparator, c.revision+1)
+	for {
+		select {
+		case <-c.done:
+			return false
+		case resp, ok := <-ch:
+			if !ok {
+				logger.Warnf("[Etcd ConfigCenter] watch on %s is closed", c.rootPath)
+				return true
+			}
+			if resp.CompactRevision != 0 {
+				logger.Warnf("[Etcd ConfigCenter] revision %d is compacted to %d, resync configurations", c.revision+1, resp.CompactRevision)
+				return true
+			}
+			if err := resp.Err(); err != nil {
+				logger.Warnf("[Etcd ConfigCenter] watch on %s error: %v", c.rootPath, err)
+				return true
+			}
+			for _, ev := range resp.Events {
+				c.dispatch(ev)
+			}
+			if resp.Header.Revision > c.revision {
+				c.revision = resp.Header.Revision
+			}
+		}
+	}
+}
+
+func (c *etcdDynamicConfiguration) dispatch(ev *clientv3.Event) {
+	if ev.Kv == nil {
+		return
+	}
+	path := string(ev.Kv.Key)
+	if ev.Kv.ModRevision > c.revision {
+		c.revision = ev.Kv.ModRevision
+	}
+	switch ev.Type {
+	case mvccpb.PUT:
+		value := string(ev.Kv.Value)
+		eventType := remoting.EventTypeUpdate
+		if _, ok := c.cache[path]; !ok || ev.IsCreate() {
+			eventType = remoting.EventTypeAdd
+		}
+		c.cache[path] = value
+		c.notify(path, value, eventType)
+	case mvccpb.DELETE:
+		delete(c.cache, path)
+		c.notify(path, "", remoting.EventTypeDel)
+	}
+}
+
+func (c *etcdDynamicConfiguration) notify(path, value string, eventType remoting.EventType) {
+	group, key, ok := strings.Cut(strings.TrimPrefix(path, c.rootPath+pathSeparator), pathSeparator)
+	if !ok {
+		return
+	}
+	defer metrics.Publish(metricsConfigCenter.NewIncMetricEvent(key, group, eventType, metricsConfigCenter.Etcd))
+
+	c.listenerLock.RLock()
+	listeners := make([]config_center.ConfigurationListener, 0, len(c.listeners[path]))
+	for listener := range c.listeners[path] {
+		listeners = append(listeners, listener)
+	}
+	c.listenerLock.RUnlock()
+	for _, listener := range listeners {
+		listener.Process(&config_center.ConfigChangeEvent{Key: key, Value: value, ConfigType: eventType})
+	}
+}
+
+func (c *etcdDynamicConfiguration) groupOf(opts []config_center.Option) string {
+	if group := config_center.NewOptions(opts...).Center.Group; len(group) != 0 {
+		return group
+	}
+	return c.group
+}
+
+func (c *etcdDynamicConfiguration) getPath(key string, group string) string {
+	if len(group) == 0 {
+		group = c.group
+	}
+	if len(key) == 0 {
+		return c.rootPath + pathSeparator + group
+	}
+	return c.rootPath + pathSeparator + group + pathSeparator + key
+}
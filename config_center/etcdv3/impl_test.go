@@ -0,0 +1,280 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcdv3
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+// memoryStore is an in-memory store which emits watch events like etcd
+type memoryStore struct {
+	lock     sync.Mutex
+	kvs      map[string]*mvccpb.KeyValue
+	revision int64
+	watchers []chan clientv3.WatchResponse
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{kvs: make(map[string]*mvccpb.KeyValue)}
+}
+
+func (s *memoryStore) get(_ context.Context, key string) (string, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if kv, ok := s.kvs[key]; ok {
+		return string(kv.Value), true, nil
+	}
+	return "", false, nil
+}
+
+func (s *memoryStore) list(_ context.Context, prefix string) (map[string]string, int64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	kvs := make(map[string]string)
+	for key, kv := range s.kvs {
+		if strings.HasPrefix(key, prefix) {
+			kvs[key] = string(kv.Value)
+		}
+	}
+	return kvs, s.revision, nil
+}
+
+func (s *memoryStore) put(_ context.Context, key, value string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.revision++
+	kv := &mvccpb.KeyValue{Key: []byte(key), Value: []byte(value), ModRevision: s.revision, CreateRevision: s.revision, Version: 1}
+	if old, ok := s.kvs[key]; ok {
+		kv.CreateRevision = old.CreateRevision
+		kv.Version = old.Version + 1
+	}
+	s.kvs[key] = kv
+	s.emit(clientv3.WatchResponse{Events: []*clientv3.Event{{Type: mvccpb.PUT, Kv: kv}}})
+	return nil
+}
+
+func (s *memoryStore) delete(_ context.Context, key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.kvs[key]; !ok {
+		return nil
+	}
+	s.revision++
+	delete(s.kvs, key)
+	s.emit(clientv3.WatchResponse{Events: []*clientv3.Event{{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte(key), ModRevision: s.revision}}}})
+	return nil
+}
+
+// putSilently changes the store without notifying the watchers, like the changes missed during a compaction
+func (s *memoryStore) putSilently(key, value string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.revision++
+	s.kvs[key] = &mvccpb.KeyValue{Key: []byte(key), Value: []byte(value), ModRevision: s.revision, CreateRevision: s.revision, Version: 1}
+}
+
+func (s *memoryStore) compact() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.emit(clientv3.WatchResponse{CompactRevision: s.revision})
+}
+
+func (s *memoryStore) emit(resp clientv3.WatchResponse) {
+	resp.Header = etcdserverpb.ResponseHeader{Revision: s.revision}
+	for _, ch := range s.watchers {
+		ch <- resp
+	}
+	if resp.CompactRevision != 0 {
+		for _, ch := range s.watchers {
+			close(ch)
+		}
+		s.watchers = nil
+	}
+}
+
+func (s *memoryStore) watch(_ context.Context, _ string, _ int64) clientv3.WatchChan {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	ch := make(chan clientv3.WatchResponse, 16)
+	s.watchers = append(s.watchers, ch)
+	return ch
+}
+
+func (s *memoryStore) watching() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.watchers) > 0
+}
+
+type mockListener struct {
+	events chan *config_center.ConfigChangeEvent
+}
+
+func newMockListener() *mockListener {
+	return &mockListener{events: make(chan *config_center.ConfigChangeEvent, 16)}
+}
+
+func (l *mockListener) Process(event *config_center.ConfigChangeEvent) {
+	l.events <- event
+}
+
+func (l *mockListener) next(t *testing.T) *config_center.ConfigChangeEvent {
+	select {
+	case event := <-l.events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no config change event received")
+		return nil
+	}
+}
+
+func newTestConfiguration(t *testing.T, s store) *etcdDynamicConfiguration {
+	url, err := common.NewURL("registry://127.0.0.1:2379", common.WithParamsValue(constant.ConfigTimeoutKey, "3s"))
+	require.NoError(t, err)
+	c := newConfiguration(url)
+	c.store = s
+	c.start()
+	t.Cleanup(c.Destroy)
+	return c
+}
+
+func TestPublishAndGetConfig(t *testing.T) {
+	c := newTestConfiguration(t, newMemoryStore())
+
+	require.NoError(t, c.PublishConfig("app.condition-router", "dubbo", "enabled: true"))
+	require.NoError(t, c.PublishConfig("app.tag-router", "dubbo", "force: true"))
+	require.NoError(t, c.PublishConfig("dubbo.properties", "other", "a=b"))
+
+	rule, err := c.GetRule("app.condition-router")
+	require.NoError(t, err)
+	assert.Equal(t, "enabled: true", rule)
+
+	rule, err = c.GetRule("dubbo.properties", config_center.WithGroup("other"))
+	require.NoError(t, err)
+	assert.Equal(t, "a=b", rule)
+
+	rule, err = c.GetProperties("absent")
+	require.NoError(t, err)
+	assert.Empty(t, rule)
+
+	keys, err := c.GetConfigKeysByGroup("dubbo")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []any{"app.condition-router", "app.tag-router"}, keys.Values())
+
+	require.NoError(t, c.RemoveConfig("app.tag-router", "dubbo"))
+	keys, err = c.GetConfigKeysByGroup("dubbo")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []any{"app.condition-router"}, keys.Values())
+
+	_, err = c.GetConfigKeysByGroup("absent")
+	assert.Error(t, err)
+}
+
+func TestListener(t *testing.T) {
+	s := newMemoryStore()
+	c := newTestConfiguration(t, s)
+	listener := newMockListener()
+	c.AddListener("org.apache.dubbo.UserProvider.configurators", listener, config_center.WithGroup(constant.Dubbo))
+	require.Eventually(t, s.watching, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", constant.Dubbo, "v1"))
+	event := listener.next(t)
+	assert.Equal(t, "org.apache.dubbo.UserProvider.configurators", event.Key)
+	assert.Equal(t, "v1", event.Value)
+	assert.Equal(t, remoting.EventTypeAdd, event.ConfigType)
+
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", constant.Dubbo, "v2"))
+	event = listener.next(t)
+	assert.Equal(t, "v2", event.Value)
+	assert.Equal(t, remoting.EventTypeUpdate, event.ConfigType)
+
+	// the config of another group is not dispatched to the listener
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", "other", "v3"))
+
+	require.NoError(t, c.RemoveConfig("org.apache.dubbo.UserProvider.configurators", constant.Dubbo))
+	event = listener.next(t)
+	assert.Equal(t, remoting.EventTypeDel, event.ConfigType)
+
+	c.RemoveListener("org.apache.dubbo.UserProvider.configurators", listener, config_center.WithGroup(constant.Dubbo))
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", constant.Dubbo, "v4"))
+	select {
+	case event = <-listener.events:
+		t.Fatalf("unexpected event %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestResyncAfterCompaction(t *testing.T) {
+	s := newMemoryStore()
+	require.NoError(t, s.put(context.Background(), "/dubbo/config/dubbo/removed.tag-router", "r"))
+	require.NoError(t, s.put(context.Background(), "/dubbo/config/dubbo/updated.tag-router", "u1"))
+
+	c := newTestConfiguration(t, s)
+	listener := newMockListener()
+	c.AddListener("removed.tag-router", listener)
+	c.AddListener("updated.tag-router", listener)
+	c.AddListener("added.tag-router", listener)
+	require.Eventually(t, s.watching, 5*time.Second, 10*time.Millisecond)
+
+	// changes missed by the broken watch are recovered by the resync
+	s.lock.Lock()
+	delete(s.kvs, "/dubbo/config/dubbo/removed.tag-router")
+	s.lock.Unlock()
+	s.putSilently("/dubbo/config/dubbo/updated.tag-router", "u2")
+	s.putSilently("/dubbo/config/dubbo/added.tag-router", "a")
+	s.compact()
+
+	events := make(map[string]*config_center.ConfigChangeEvent)
+	for i := 0; i < 3; i++ {
+		event := listener.next(t)
+		events[event.Key] = event
+	}
+	assert.Equal(t, remoting.EventTypeDel, events["removed.tag-router"].ConfigType)
+	assert.Equal(t, remoting.EventTypeUpdate, events["updated.tag-router"].ConfigType)
+	assert.Equal(t, "u2", events["updated.tag-router"].Value)
+	assert.Equal(t, remoting.EventTypeAdd, events["added.tag-router"].ConfigType)
+	assert.Equal(t, "a", events["added.tag-router"].Value)
+}
+
+func TestGetPath(t *testing.T) {
+	c := &etcdDynamicConfiguration{rootPath: "/root", group: "dubbo"}
+	assert.Equal(t, "/root/g/k", c.getPath("k", "g"))
+	assert.Equal(t, "/root/g", c.getPath("", "g"))
+	assert.Equal(t, "/root/dubbo/k", c.getPath("k", ""))
+}
@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcdv3
+
+import (
+	"context"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// store is the key-value store of the configurations
+type store interface {
+	// get returns the value of the key, and whether the key exists
+	get(ctx context.Context, key string) (string, bool, error)
+	// list returns the key-values under the prefix and the revision of the store they are read at
+	list(ctx context.Context, prefix string) (map[string]string, int64, error)
+	put(ctx context.Context, key, value string) error
+	delete(ctx context.Context, key string) error
+	// watch watches the changes of the keys under the prefix since the revision
+	watch(ctx context.Context, prefix string, revision int64) clientv3.WatchChan
+}
+
+// etcdStore is the store on the raw client of etcd, which is replaced once the client reconnects
+type etcdStore struct {
+	client func() *clientv3.Client
+}
+
+func (s *etcdStore) rawClient() (*clientv3.Client, error) {
+	if client := s.client(); client != nil {
+		return client, nil
+	}
+	return nil, perrors.New("etcd client is closed")
+}
+
+func (s *etcdStore) get(ctx context.Context, key string) (string, bool, error) {
+	client, err := s.rawClient()
+	if err != nil {
+		return "", false, err
+	}
+	resp, err := client.Get(ctx, key)
+	if err != nil {
+		return "", false, err
+	}
+	if len(resp.Kvs) == 0 {
+		return "", false, nil
+	}
+	return string(resp.Kvs[0].Value), true, nil
+}
+
+func (s *etcdStore) list(ctx context.Context, prefix string) (map[string]string, int64, error) {
+	client, err := s.rawClient()
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := client.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, 0, err
+	}
+	kvs := make(map[string]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		kvs[string(kv.Key)] = string(kv.Value)
+	}
+	return kvs, resp.Header.Revision, nil
+}
+
+func (s *etcdStore) put(ctx context.Context, key, value string) error {
+	client, err := s.rawClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.Put(ctx, key, value)
+	return err
+}
+
+func (s *etcdStore) delete(ctx context.Context, key string) error {
+	client, err := s.rawClient()
+	if err != nil {
+		return err
+	}
+	_, err = client.Delete(ctx, key)
+	return err
+}
+
+func (s *etcdStore) watch(ctx context.Context, prefix string, revision int64) clientv3.WatchChan {
+	client, err := s.rawClient()
+	if err != nil {
+		ch := make(chan clientv3.WatchResponse)
+		close(ch)
+		return ch
+	}
+	// the watch fails fast instead of hanging on a member which loses the leader
+	return client.Watch(clientv3.WithRequireLeader(ctx), prefix, clientv3.WithPrefix(), clientv3.WithRev(revision))
+}
@@ -0,0 +1,405 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	gxset "github.com/dubbogo/gost/container/set"
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+
+	v1 "k8s.io/api/core/v1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/parser"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsConfigCenter "dubbo.apache.org/dubbo-go/v3/metrics/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+const (
+	defaultNamespace = "default"
+	// namespaceFile is the namespace of the pod when running in cluster
+	namespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	// maxNameLength is the max length of the name of a ConfigMap, which is a DNS subdomain
+	maxNameLength = 253
+)
+
+// kubernetesDynamicConfiguration stores every configuration in a ConfigMap, since the keys of the
+// configurations may contain characters which are invalid for the data keys of a ConfigMap.
+// The original key and group are kept in the annotations, and the value in the data.
+type kubernetesDynamicConfiguration struct {
+	config_center.BaseDynamicConfiguration
+	url       *common.URL
+	namespace string
+	group     string
+	timeout   time.Duration
+	client    kubernetes.Interface
+	informers informers.SharedInformerFactory
+	parser    parser.ConfigurationParser
+	done      chan struct{}
+	once      sync.Once
+
+	listenerLock sync.RWMutex
+	listeners    map[string]map[config_center.ConfigurationListener]struct{}
+}
+
+func newKubernetesDynamicConfiguration(url *common.URL) (*kubernetesDynamicConfiguration, error) {
+	var (
+		cfg *rest.Config
+		err error
+	)
+	if kubeconfig := url.GetParam(constant.KubernetesConfigKubeconfigKey, ""); len(kubeconfig) != 0 {
+		cfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		cfg, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, perrors.WithMessage(err, "load kubernetes client config")
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, perrors.WithMessage(err, "new kubernetes client")
+	}
+	return newWithClient(url, client)
+}
+
+func newWithClient(url *common.URL, client kubernetes.Interface) (*kubernetesDynamicConfiguration, error) {
+	timeout, err := time.ParseDuration(url.GetParam(constant.ConfigTimeoutKey, config_center.DefaultConfigTimeout))
+	if err != nil {
+		logger.Warnf("[Kubernetes ConfigCenter] invalid timeout %s, use default %s", url.GetParam(constant.ConfigTimeoutKey, ""), config_center.DefaultConfigTimeout)
+		timeout, _ = time.ParseDuration(config_center.DefaultConfigTimeout)
+	}
+	c := &kubernetesDynamicConfiguration{
+		url:       url,
+		namespace: getNamespace(url),
+		group:     url.GetParam(constant.ConfigGroupKey, config_center.DefaultGroup),
+		timeout:   timeout,
+		client:    client,
+		done:      make(chan struct{}),
+		listeners: make(map[string]map[config_center.ConfigurationListener]struct{}),
+	}
+	if err = c.start(); err != nil {
+		c.Destroy()
+		return nil, err
+	}
+	logger.Infof("[Kubernetes ConfigCenter] New Kubernetes ConfigCenter with namespace %s, url = %+v", c.namespace, url)
+	return c, nil
+}
+
+// getNamespace returns the namespace of the url, or the namespace of the pod, or the default namespace
+func getNamespace(url *common.URL) string {
+	if namespace := url.GetParam(constant.ConfigNamespaceKey, ""); len(namespace) != 0 {
+		return namespace
+	}
+	if data, err := os.ReadFile(namespaceFile); err == nil {
+		if namespace := strings.TrimSpace(string(data)); len(namespace) != 0 {
+			return namespace
+		}
+	}
+	return defaultNamespace
+}
+
+// start watches the ConfigMaps of the configurations by an informer
+func (c *kubernetesDynamicConfiguration) start() error {
+	c.informers = informers.NewSharedInformerFactoryWithOptions(c.client, 0,
+		informers.WithNamespace(c.namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = managedBySelector()
+		}),
+	)
+	informer := c.informers.Core().V1().ConfigMaps().Informer()
+	registration, err := informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj any, isInInitialList bool) {
+			// the existing configurations are read by GetRule, only the changes after watching are notified
+			if !isInInitialList {
+				c.onChange(obj, remoting.EventTypeAdd)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			oldConfigMap, ok1 := oldObj.(*v1.ConfigMap)
+			newConfigMap, ok2 := newObj.(*v1.ConfigMap)
+			if ok1 && ok2 && oldConfigMap.Data[constant.KubernetesConfigContentKey] == newConfigMap.Data[constant.KubernetesConfigContentKey] {
+				return
+			}
+			c.onChange(newObj, remoting.EventTypeUpdate)
+		},
+		DeleteFunc: func(obj any) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			c.onChange(obj, remoting.EventTypeDel)
+		},
+	})
+	if err != nil {
+		return perrors.WithStack(err)
+	}
+	c.informers.Start(c.done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(ctx.Done(), registration.HasSynced) {
+		return perrors.Errorf("wait for ConfigMaps in namespace %s synced timeout", c.namespace)
+	}
+	return nil
+}
+
+func (c *kubernetesDynamicConfiguration) onChange(obj any, eventType remoting.EventType) {
+	configMap, ok := obj.(*v1.ConfigMap)
+	if !ok {
+		return
+	}
+	key, group := configMap.Annotations[constant.KubernetesConfigKeyAnnotation], configMap.Annotations[constant.KubernetesConfigGroupAnnotation]
+	if len(key) == 0 || len(group) == 0 {
+		return
+	}
+	var value string
+	if eventType != remoting.EventTypeDel {
+		value = configMap.Data[constant.KubernetesConfigContentKey]
+	}
+	defer metrics.Publish(metricsConfigCenter.NewIncMetricEvent(key, group, eventType, metricsConfigCenter.Kubernetes))
+
+	c.listenerLock.RLock()
+	listeners := make([]config_center.ConfigurationListener, 0, len(c.listeners[listenerKey(key, group)]))
+	for listener := range c.listeners[listenerKey(key, group)] {
+		listeners = append(listeners, listener)
+	}
+	c.listenerLock.RUnlock()
+	for _, listener := range listeners {
+		listener.Process(&config_center.ConfigChangeEvent{Key: key, Value: value, ConfigType: eventType})
+	}
+}
+
+// AddListener adds listener for the key in the group of options, or the default group
+func (c *kubernetesDynamicConfiguration) AddListener(key string, listener config_center.ConfigurationListener, opts ...config_center.Option) {
+	lk := listenerKey(key, c.groupOf(opts))
+	c.listenerLock.Lock()
+	defer c.listenerLock.Unlock()
+	if _, ok := c.listeners[lk]; !ok {
+		c.listeners[lk] = make(map[config_center.ConfigurationListener]struct{})
+	}
+	c.listeners[lk][listener] = struct{}{}
+}
+
+// RemoveListener removes listener for the key in the group of options, or the default group
+func (c *kubernetesDynamicConfiguration) RemoveListener(key string, listener config_center.ConfigurationListener, opts ...config_center.Option) {
+	lk := listenerKey(key, c.groupOf(opts))
+	c.listenerLock.Lock()
+	defer c.listenerLock.Unlock()
+	if listeners, ok := c.listeners[lk]; ok {
+		delete(listeners, listener)
+		if len(listeners) == 0 {
+			delete(c.listeners, lk)
+		}
+	}
+}
+
+func (c *kubernetesDynamicConfiguration) GetProperties(key string, opts ...config_center.Option) (string, error) {
+	group := c.groupOf(opts)
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	configMap, err := c.client.CoreV1().ConfigMaps(c.namespace).Get(ctx, configMapName(key, group), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		logger.Warnf("[Kubernetes ConfigCenter] query rule fail, key=%s, group=%s, config not found", key, group)
+		return "", nil
+	}
+	if err != nil {
+		return "", perrors.WithStack(err)
+	}
+	return configMap.Data[constant.KubernetesConfigContentKey], nil
+}
+
+// GetInternalProperty For kubernetes, getConfig and getConfigs have the same meaning.
+func (c *kubernetesDynamicConfiguration) GetInternalProperty(key string, opts ...config_center.Option) (string, error) {
+	return c.GetProperties(key, opts...)
+}
+
+func (c *kubernetesDynamicConfiguration) GetRule(key string, opts ...config_center.Option) (string, error) {
+	return c.GetProperties(key, opts...)
+}
+
+// PublishConfig creates or updates the ConfigMap of the (key, group) pair
+func (c *kubernetesDynamicConfiguration) PublishConfig(key string, group string, value string) error {
+	if len(group) == 0 {
+		group = c.group
+	}
+	name := configMapName(key, group)
+	configMaps := c.client.CoreV1().ConfigMaps(c.namespace)
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			_, err = configMaps.Create(ctx, &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   c.namespace,
+					Labels:      map[string]string{constant.KubernetesConfigManagedByLabel: constant.KubernetesConfigManagedBy},
+					Annotations: map[string]string{constant.KubernetesConfigKeyAnnotation: key, constant.KubernetesConfigGroupAnnotation: group},
+				},
+				Data: map[string]string{constant.KubernetesConfigContentKey: value},
+			}, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// created concurrently, retry to update it
+				return apierrors.NewConflict(v1.Resource("configmaps"), name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string, 1)
+		}
+		configMap.Data[constant.KubernetesConfigContentKey] = value
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	})
+	return perrors.WithStack(err)
+}
+
+// RemoveConfig will remove the config with the (key, group) pair
+func (c *kubernetesDynamicConfiguration) RemoveConfig(key string, group string) error {
+	if len(group) == 0 {
+		group = c.group
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	err := c.client.CoreV1().ConfigMaps(c.namespace).Delete(ctx, configMapName(key, group), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return perrors.WithStack(err)
+	}
+	return nil
+}
+
+// GetConfigKeysByGroup will return all keys with the group
+func (c *kubernetesDynamicConfiguration) GetConfigKeysByGroup(group string) (*gxset.HashSet, error) {
+	if len(group) == 0 {
+		group = c.group
+	}
+	configMaps, err := c.informers.Core().V1().ConfigMaps().Lister().ConfigMaps(c.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	set := gxset.NewSet()
+	for _, configMap := range configMaps {
+		if configMap.Annotations[constant.KubernetesConfigGroupAnnotation] == group {
+			set.Add(configMap.Annotations[constant.KubernetesConfigKeyAnnotation])
+		}
+	}
+	if set.Empty() {
+		return nil, perrors.New("could not find keys with group: " + group)
+	}
+	return set, nil
+}
+
+func (c *kubernetesDynamicConfiguration) Parser() parser.ConfigurationParser {
+	return c.parser
+}
+
+func (c *kubernetesDynamicConfiguration) SetParser(p parser.ConfigurationParser) {
+	c.parser = p
+}
+
+func (c *kubernetesDynamicConfiguration) GetURL() *common.URL {
+	return c.url
+}
+
+func (c *kubernetesDynamicConfiguration) IsAvailable() bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (c *kubernetesDynamicConfiguration) Destroy() {
+	c.once.Do(func() {
+		close(c.done)
+		if c.informers != nil {
+			c.informers.Shutdown()
+		}
+	})
+}
+
+func (c *kubernetesDynamicConfiguration) groupOf(opts []config_center.Option) string {
+	if group := config_center.NewOptions(opts...).Center.Group; len(group) != 0 {
+		return group
+	}
+	return c.group
+}
+
+func listenerKey(key, group string) string {
+	return group + "/" + key
+}
+
+func managedBySelector() string {
+	return labels.SelectorFromSet(labels.Set{constant.KubernetesConfigManagedByLabel: constant.KubernetesConfigManagedBy}).String()
+}
+
+// configMapName returns a valid and unique ConfigMap name of the (key, group) pair, which is the
+// readable part of the pair followed by the hash of it.
+func configMapName(key, group string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(group + "/" + key))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+
+	var sb strings.Builder
+	for _, r := range strings.ToLower(group + "." + key) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('-')
+		}
+	}
+	name := strings.Trim(sb.String(), "-")
+	if len(name) > maxNameLength-len(suffix) {
+		name = strings.TrimRight(name[:maxNameLength-len(suffix)], "-")
+	}
+	if len(name) == 0 {
+		return suffix[1:]
+	}
+	return name + suffix
+}
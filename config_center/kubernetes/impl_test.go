@@ -0,0 +1,205 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "k8s.io/api/core/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+type mockListener struct {
+	events chan *config_center.ConfigChangeEvent
+}
+
+func newMockListener() *mockListener {
+	return &mockListener{events: make(chan *config_center.ConfigChangeEvent, 16)}
+}
+
+func (l *mockListener) Process(event *config_center.ConfigChangeEvent) {
+	l.events <- event
+}
+
+func (l *mockListener) next(t *testing.T) *config_center.ConfigChangeEvent {
+	select {
+	case event := <-l.events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no config change event received")
+		return nil
+	}
+}
+
+// newTestConfiguration returns the configuration on a fake clientset, and waits for the informer watching,
+// since the fake clientset drops the changes before the watch is started.
+func newTestConfiguration(t *testing.T, objects ...runtime.Object) (*kubernetesDynamicConfiguration, *fake.Clientset) {
+	client := fake.NewSimpleClientset(objects...)
+	watching := make(chan struct{})
+	client.PrependWatchReactor("configmaps", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		w, err := client.Tracker().Watch(action.GetResource(), action.GetNamespace())
+		close(watching)
+		return true, w, err
+	})
+
+	url, err := common.NewURL("registry://127.0.0.1:6443",
+		common.WithParamsValue(constant.ConfigNamespaceKey, "dubbo-system"),
+		common.WithParamsValue(constant.ConfigTimeoutKey, "5s"))
+	require.NoError(t, err)
+	c, err := newWithClient(url, client)
+	require.NoError(t, err)
+	t.Cleanup(c.Destroy)
+
+	select {
+	case <-watching:
+	case <-time.After(5 * time.Second):
+		t.Fatal("informer does not watch ConfigMaps")
+	}
+	return c, client
+}
+
+func TestPublishAndGetConfig(t *testing.T) {
+	c, client := newTestConfiguration(t)
+
+	require.NoError(t, c.PublishConfig("app.condition-router", "dubbo", "enabled: true"))
+	require.NoError(t, c.PublishConfig("app.tag-router", "dubbo", "force: true"))
+	require.NoError(t, c.PublishConfig("dubbo.properties", "other", "a=b"))
+
+	configMap, err := client.CoreV1().ConfigMaps("dubbo-system").Get(context.Background(), configMapName("app.condition-router", "dubbo"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, constant.KubernetesConfigManagedBy, configMap.Labels[constant.KubernetesConfigManagedByLabel])
+	assert.Equal(t, "app.condition-router", configMap.Annotations[constant.KubernetesConfigKeyAnnotation])
+	assert.Equal(t, "dubbo", configMap.Annotations[constant.KubernetesConfigGroupAnnotation])
+
+	rule, err := c.GetRule("app.condition-router")
+	require.NoError(t, err)
+	assert.Equal(t, "enabled: true", rule)
+
+	require.NoError(t, c.PublishConfig("app.condition-router", "dubbo", "enabled: false"))
+	rule, err = c.GetRule("app.condition-router", config_center.WithGroup("dubbo"))
+	require.NoError(t, err)
+	assert.Equal(t, "enabled: false", rule)
+
+	rule, err = c.GetProperties("dubbo.properties", config_center.WithGroup("other"))
+	require.NoError(t, err)
+	assert.Equal(t, "a=b", rule)
+
+	rule, err = c.GetProperties("absent")
+	require.NoError(t, err)
+	assert.Empty(t, rule)
+
+	assert.Eventually(t, func() bool {
+		keys, err := c.GetConfigKeysByGroup("dubbo")
+		return err == nil && keys.Size() == 2 && keys.Contains("app.condition-router") && keys.Contains("app.tag-router")
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, c.RemoveConfig("app.tag-router", "dubbo"))
+	require.NoError(t, c.RemoveConfig("absent", "dubbo"))
+	assert.Eventually(t, func() bool {
+		keys, err := c.GetConfigKeysByGroup("dubbo")
+		return err == nil && keys.Size() == 1 && keys.Contains("app.condition-router")
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = c.GetConfigKeysByGroup("absent")
+	assert.Error(t, err)
+}
+
+func TestListener(t *testing.T) {
+	existing := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        configMapName("existing.tag-router", "dubbo"),
+			Namespace:   "dubbo-system",
+			Labels:      map[string]string{constant.KubernetesConfigManagedByLabel: constant.KubernetesConfigManagedBy},
+			Annotations: map[string]string{constant.KubernetesConfigKeyAnnotation: "existing.tag-router", constant.KubernetesConfigGroupAnnotation: "dubbo"},
+		},
+		Data: map[string]string{constant.KubernetesConfigContentKey: "v0"},
+	}
+	c, _ := newTestConfiguration(t, existing)
+	listener := newMockListener()
+	c.AddListener("org.apache.dubbo.UserProvider.configurators", listener, config_center.WithGroup(constant.Dubbo))
+	c.AddListener("existing.tag-router", listener)
+
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", constant.Dubbo, "v1"))
+	event := listener.next(t)
+	assert.Equal(t, "org.apache.dubbo.UserProvider.configurators", event.Key)
+	assert.Equal(t, "v1", event.Value)
+	assert.Equal(t, remoting.EventTypeAdd, event.ConfigType)
+
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", constant.Dubbo, "v2"))
+	event = listener.next(t)
+	assert.Equal(t, "v2", event.Value)
+	assert.Equal(t, remoting.EventTypeUpdate, event.ConfigType)
+
+	// the config of another group is not dispatched to the listener
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", "other", "v3"))
+
+	require.NoError(t, c.RemoveConfig("existing.tag-router", constant.Dubbo))
+	event = listener.next(t)
+	assert.Equal(t, "existing.tag-router", event.Key)
+	assert.Equal(t, remoting.EventTypeDel, event.ConfigType)
+
+	c.RemoveListener("org.apache.dubbo.UserProvider.configurators", listener, config_center.WithGroup(constant.Dubbo))
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", constant.Dubbo, "v4"))
+	select {
+	case event = <-listener.events:
+		t.Fatalf("unexpected event %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestConfigMapName(t *testing.T) {
+	tests := []struct {
+		key   string
+		group string
+	}{
+		{key: "org.apache.dubbo.UserProvider::.condition-router", group: "dubbo"},
+		{key: "dubbo.properties", group: "DUBBO_GROUP"},
+		{key: "::", group: "_"},
+		{key: strings.Repeat("very.long.key.", 30), group: "dubbo"},
+	}
+	names := make(map[string]struct{})
+	for _, tt := range tests {
+		name := configMapName(tt.key, tt.group)
+		assert.Empty(t, validation.IsDNS1123Subdomain(name), name)
+		names[name] = struct{}{}
+	}
+	assert.Len(t, names, len(tests))
+	assert.NotEqual(t, configMapName("a.b", "c"), configMapName("b", "c.a"))
+}
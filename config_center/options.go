@@ -65,6 +65,24 @@ func WithApollo() Option {
 	}
 }
 
+func WithEtcdV3() Option {
+	return func(opts *Options) {
+		opts.Center.Protocol = constant.EtcdV3Key
+	}
+}
+
+func WithKubernetes() Option {
+	return func(opts *Options) {
+		opts.Center.Protocol = constant.KubernetesKey
+	}
+}
+
+func WithPolaris() Option {
+	return func(opts *Options) {
+		opts.Center.Protocol = constant.PolarisKey
+	}
+}
+
 func WithConfigCenter(cc string) Option {
 	return func(opts *Options) {
 		opts.Center.Protocol = cc
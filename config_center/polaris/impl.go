@@ -0,0 +1,269 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package polaris
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	gxset "github.com/dubbogo/gost/container/set"
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+
+	"github.com/polarismesh/polaris-go"
+	"github.com/polarismesh/polaris-go/pkg/config"
+	"github.com/polarismesh/polaris-go/pkg/model"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/parser"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsConfigCenter "dubbo.apache.org/dubbo-go/v3/metrics/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+// polarisDynamicConfiguration maps the (key, group) pair to the config file named key in the file group,
+// the config files are read and watched by the polaris sdk, and managed by the open api of polaris.
+type polarisDynamicConfiguration struct {
+	config_center.BaseDynamicConfiguration
+	url       *common.URL
+	namespace string
+	group     string
+	timeout   time.Duration
+	configAPI polaris.ConfigAPI
+	client    configFileClient
+	parser    parser.ConfigurationParser
+	done      chan struct{}
+	once      sync.Once
+
+	// listeners of the config files, a config file is watched by the sdk once it is in the map,
+	// since the sdk can't remove the change listener of a config file
+	listenerLock sync.RWMutex
+	listeners    map[string]map[config_center.ConfigurationListener]struct{}
+}
+
+func newPolarisDynamicConfiguration(url *common.URL) (*polarisDynamicConfiguration, error) {
+	addresses := strings.Split(url.Location, ",")
+	polarisConf := config.NewDefaultConfiguration(addresses)
+	polarisConf.GetConfigFile().GetConfigConnectorConfig().SetAddresses(addresses)
+	configAPI, err := polaris.NewConfigAPIByConfig(polarisConf)
+	if err != nil {
+		return nil, perrors.WithMessagef(err, "new polaris config api (address:%+v)", addresses)
+	}
+
+	openAPI := url.GetParam(constant.PolarisConfigOpenAPIKey, "")
+	if len(openAPI) == 0 {
+		host, _, err := net.SplitHostPort(addresses[0])
+		if err != nil {
+			host = addresses[0]
+		}
+		openAPI = net.JoinHostPort(host, constant.PolarisConfigDefaultOpenAPIPort)
+	}
+	if !strings.Contains(openAPI, "://") {
+		openAPI = "http://" + openAPI
+	}
+	c := newConfiguration(url, configAPI, nil)
+	c.client = newOpenAPIClient(strings.TrimRight(openAPI, "/"), url.GetParam(constant.PolarisServiceToken, ""), c.timeout)
+	logger.Infof("[Polaris ConfigCenter] New Polaris ConfigCenter with namespace %s, url = %+v", c.namespace, url)
+	return c, nil
+}
+
+func newConfiguration(url *common.URL, configAPI polaris.ConfigAPI, client configFileClient) *polarisDynamicConfiguration {
+	timeout, err := time.ParseDuration(url.GetParam(constant.ConfigTimeoutKey, config_center.DefaultConfigTimeout))
+	if err != nil {
+		logger.Warnf("[Polaris ConfigCenter] invalid timeout %s, use default %s", url.GetParam(constant.ConfigTimeoutKey, ""), config_center.DefaultConfigTimeout)
+		timeout, _ = time.ParseDuration(config_center.DefaultConfigTimeout)
+	}
+	return &polarisDynamicConfiguration{
+		url:       url,
+		namespace: url.GetParam(constant.ConfigNamespaceKey, constant.PolarisDefaultNamespace),
+		group:     url.GetParam(constant.ConfigGroupKey, config_center.DefaultGroup),
+		timeout:   timeout,
+		configAPI: configAPI,
+		client:    client,
+		done:      make(chan struct{}),
+		listeners: make(map[string]map[config_center.ConfigurationListener]struct{}),
+	}
+}
+
+// AddListener adds listener for the key in the group of options, or the default group
+func (c *polarisDynamicConfiguration) AddListener(key string, listener config_center.ConfigurationListener, opts ...config_center.Option) {
+	group := c.groupOf(opts)
+	fk := fileKey(key, group)
+	c.listenerLock.Lock()
+	defer c.listenerLock.Unlock()
+	if _, ok := c.listeners[fk]; !ok {
+		file, err := c.configAPI.GetConfigFile(c.namespace, group, key)
+		if err != nil {
+			logger.Errorf("[Polaris ConfigCenter] watch config file %s in group %s error: %v", key, group, err)
+			return
+		}
+		file.AddChangeListener(func(event model.ConfigFileChangeEvent) {
+			c.onChange(key, group, event)
+		})
+		c.listeners[fk] = make(map[config_center.ConfigurationListener]struct{})
+	}
+	c.listeners[fk][listener] = struct{}{}
+}
+
+// RemoveListener removes listener for the key in the group of options, or the default group
+func (c *polarisDynamicConfiguration) RemoveListener(key string, listener config_center.ConfigurationListener, opts ...config_center.Option) {
+	fk := fileKey(key, c.groupOf(opts))
+	c.listenerLock.Lock()
+	defer c.listenerLock.Unlock()
+	if listeners, ok := c.listeners[fk]; ok {
+		delete(listeners, listener)
+	}
+}
+
+func (c *polarisDynamicConfiguration) onChange(key, group string, event model.ConfigFileChangeEvent) {
+	var eventType remoting.EventType
+	switch event.ChangeType {
+	case model.Added:
+		eventType = remoting.EventTypeAdd
+	case model.Modified:
+		eventType = remoting.EventTypeUpdate
+	case model.Deleted:
+		eventType = remoting.EventTypeDel
+	default:
+		return
+	}
+	if !c.IsAvailable() {
+		return
+	}
+	defer metrics.Publish(metricsConfigCenter.NewIncMetricEvent(key, group, eventType, metricsConfigCenter.Polaris))
+
+	c.listenerLock.RLock()
+	listeners := make([]config_center.ConfigurationListener, 0, len(c.listeners[fileKey(key, group)]))
+	for listener := range c.listeners[fileKey(key, group)] {
+		listeners = append(listeners, listener)
+	}
+	c.listenerLock.RUnlock()
+	for _, listener := range listeners {
+		listener.Process(&config_center.ConfigChangeEvent{Key: key, Value: event.NewValue, ConfigType: eventType})
+	}
+}
+
+func (c *polarisDynamicConfiguration) GetProperties(key string, opts ...config_center.Option) (string, error) {
+	group := c.groupOf(opts)
+	file, err := c.configAPI.GetConfigFile(c.namespace, group, key)
+	if err != nil {
+		return "", perrors.WithStack(err)
+	}
+	if !file.HasContent() {
+		logger.Warnf("[Polaris ConfigCenter] query rule fail, key=%s, group=%s, config not found", key, group)
+		return "", nil
+	}
+	return file.GetContent(), nil
+}
+
+// GetInternalProperty For polaris, getConfig and getConfigs have the same meaning.
+func (c *polarisDynamicConfiguration) GetInternalProperty(key string, opts ...config_center.Option) (string, error) {
+	return c.GetProperties(key, opts...)
+}
+
+func (c *polarisDynamicConfiguration) GetRule(key string, opts ...config_center.Option) (string, error) {
+	return c.GetProperties(key, opts...)
+}
+
+// PublishConfig creates or updates the config file of the (key, group) pair, and releases it
+func (c *polarisDynamicConfiguration) PublishConfig(key string, group string, value string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	return c.client.publish(ctx, c.namespace, c.groupOrDefault(group), key, value)
+}
+
+// RemoveConfig will remove the config with the (key, group) pair
+func (c *polarisDynamicConfiguration) RemoveConfig(key string, group string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	return c.client.delete(ctx, c.namespace, c.groupOrDefault(group), key)
+}
+
+// GetConfigKeysByGroup will return all keys with the group
+func (c *polarisDynamicConfiguration) GetConfigKeysByGroup(group string) (*gxset.HashSet, error) {
+	group = c.groupOrDefault(group)
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	names, err := c.client.list(ctx, c.namespace, group)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, perrors.New("could not find keys with group: " + group)
+	}
+	set := gxset.NewSet()
+	for _, name := range names {
+		set.Add(name)
+	}
+	return set, nil
+}
+
+func (c *polarisDynamicConfiguration) Parser() parser.ConfigurationParser {
+	return c.parser
+}
+
+func (c *polarisDynamicConfiguration) SetParser(p parser.ConfigurationParser) {
+	c.parser = p
+}
+
+func (c *polarisDynamicConfiguration) GetURL() *common.URL {
+	return c.url
+}
+
+func (c *polarisDynamicConfiguration) IsAvailable() bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (c *polarisDynamicConfiguration) Destroy() {
+	c.once.Do(func() {
+		close(c.done)
+		if sdkCtx := c.configAPI.SDKContext(); sdkCtx != nil {
+			sdkCtx.Destroy()
+		}
+	})
+}
+
+func (c *polarisDynamicConfiguration) groupOf(opts []config_center.Option) string {
+	return c.groupOrDefault(config_center.NewOptions(opts...).Center.Group)
+}
+
+func (c *polarisDynamicConfiguration) groupOrDefault(group string) string {
+	if len(group) == 0 {
+		return c.group
+	}
+	return group
+}
+
+func fileKey(key, group string) string {
+	return group + "/" + key
+}
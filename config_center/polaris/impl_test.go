@@ -0,0 +1,218 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package polaris
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+import (
+	"github.com/polarismesh/polaris-go"
+	"github.com/polarismesh/polaris-go/api"
+	"github.com/polarismesh/polaris-go/pkg/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+// mockConfigFile is the config file of mockConfigAPI
+type mockConfigFile struct {
+	model.DefaultConfigFileMetadata
+	lock      sync.Mutex
+	content   string
+	exists    bool
+	callbacks []model.OnConfigFileChange
+}
+
+func (f *mockConfigFile) GetContent() string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.content
+}
+
+func (f *mockConfigFile) HasContent() bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.exists
+}
+
+func (f *mockConfigFile) AddChangeListenerWithChannel(chan model.ConfigFileChangeEvent) {}
+
+func (f *mockConfigFile) AddChangeListener(cb model.OnConfigFileChange) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.callbacks = append(f.callbacks, cb)
+}
+
+// mockConfigAPI is an in-memory polaris config api, which also manages the config files like the open api
+type mockConfigAPI struct {
+	lock  sync.Mutex
+	files map[string]*mockConfigFile
+}
+
+func newMockConfigAPI() *mockConfigAPI {
+	return &mockConfigAPI{files: make(map[string]*mockConfigFile)}
+}
+
+func (m *mockConfigAPI) SDKContext() api.SDKContext {
+	return nil
+}
+
+func (m *mockConfigAPI) GetConfigFile(namespace, fileGroup, fileName string) (polaris.ConfigFile, error) {
+	return m.file(namespace, fileGroup, fileName), nil
+}
+
+func (m *mockConfigAPI) file(namespace, group, name string) *mockConfigFile {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	k := namespace + "/" + group + "/" + name
+	if _, ok := m.files[k]; !ok {
+		m.files[k] = &mockConfigFile{DefaultConfigFileMetadata: model.DefaultConfigFileMetadata{Namespace: namespace, FileGroup: group, FileName: name}}
+	}
+	return m.files[k]
+}
+
+func (m *mockConfigAPI) change(namespace, group, name, content string, deleted bool) {
+	f := m.file(namespace, group, name)
+	f.lock.Lock()
+	event := model.ConfigFileChangeEvent{ConfigFileMetadata: f, OldValue: f.content, NewValue: content}
+	switch {
+	case deleted:
+		event.ChangeType, event.NewValue = model.Deleted, ""
+	case f.exists:
+		event.ChangeType = model.Modified
+	default:
+		event.ChangeType = model.Added
+	}
+	f.content, f.exists = event.NewValue, !deleted
+	callbacks := append([]model.OnConfigFileChange(nil), f.callbacks...)
+	f.lock.Unlock()
+	for _, cb := range callbacks {
+		cb(event)
+	}
+}
+
+func (m *mockConfigAPI) publish(_ context.Context, namespace, group, name, content string) error {
+	m.change(namespace, group, name, content, false)
+	return nil
+}
+
+func (m *mockConfigAPI) delete(_ context.Context, namespace, group, name string) error {
+	m.change(namespace, group, name, "", true)
+	return nil
+}
+
+func (m *mockConfigAPI) list(_ context.Context, namespace, group string) ([]string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var names []string
+	for _, f := range m.files {
+		if f.Namespace == namespace && f.FileGroup == group && f.exists {
+			names = append(names, f.FileName)
+		}
+	}
+	return names, nil
+}
+
+type mockListener struct {
+	events []*config_center.ConfigChangeEvent
+}
+
+func (l *mockListener) Process(event *config_center.ConfigChangeEvent) {
+	l.events = append(l.events, event)
+}
+
+func newTestConfiguration(t *testing.T) (*polarisDynamicConfiguration, *mockConfigAPI) {
+	url, err := common.NewURL("registry://127.0.0.1:8093", common.WithParamsValue(constant.ConfigNamespaceKey, "dubbo-ns"))
+	require.NoError(t, err)
+	mock := newMockConfigAPI()
+	c := newConfiguration(url, mock, mock)
+	t.Cleanup(c.Destroy)
+	return c, mock
+}
+
+func TestPublishAndGetConfig(t *testing.T) {
+	c, mock := newTestConfiguration(t)
+
+	require.NoError(t, c.PublishConfig("app.condition-router", "", "enabled: true"))
+	require.NoError(t, c.PublishConfig("app.tag-router", "dubbo", "force: true"))
+	require.NoError(t, c.PublishConfig("dubbo.properties", "other", "a=b"))
+	assert.Equal(t, "enabled: true", mock.file("dubbo-ns", "dubbo", "app.condition-router").GetContent())
+
+	rule, err := c.GetRule("app.condition-router")
+	require.NoError(t, err)
+	assert.Equal(t, "enabled: true", rule)
+
+	rule, err = c.GetProperties("dubbo.properties", config_center.WithGroup("other"))
+	require.NoError(t, err)
+	assert.Equal(t, "a=b", rule)
+
+	rule, err = c.GetInternalProperty("absent")
+	require.NoError(t, err)
+	assert.Empty(t, rule)
+
+	keys, err := c.GetConfigKeysByGroup("dubbo")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []any{"app.condition-router", "app.tag-router"}, keys.Values())
+
+	require.NoError(t, c.RemoveConfig("app.tag-router", "dubbo"))
+	keys, err = c.GetConfigKeysByGroup("")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []any{"app.condition-router"}, keys.Values())
+
+	_, err = c.GetConfigKeysByGroup("absent")
+	assert.Error(t, err)
+}
+
+func TestListener(t *testing.T) {
+	c, _ := newTestConfiguration(t)
+	listener, another := &mockListener{}, &mockListener{}
+	c.AddListener("org.apache.dubbo.UserProvider.configurators", listener, config_center.WithGroup(constant.Dubbo))
+	c.AddListener("org.apache.dubbo.UserProvider.configurators", another)
+
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", constant.Dubbo, "v1"))
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", constant.Dubbo, "v2"))
+	// the config of another group is not dispatched to the listeners
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", "other", "v3"))
+	require.NoError(t, c.RemoveConfig("org.apache.dubbo.UserProvider.configurators", constant.Dubbo))
+
+	require.Len(t, listener.events, 3)
+	assert.Equal(t, &config_center.ConfigChangeEvent{Key: "org.apache.dubbo.UserProvider.configurators", Value: "v1", ConfigType: remoting.EventTypeAdd}, listener.events[0])
+	assert.Equal(t, &config_center.ConfigChangeEvent{Key: "org.apache.dubbo.UserProvider.configurators", Value: "v2", ConfigType: remoting.EventTypeUpdate}, listener.events[1])
+	assert.Equal(t, &config_center.ConfigChangeEvent{Key: "org.apache.dubbo.UserProvider.configurators", Value: "", ConfigType: remoting.EventTypeDel}, listener.events[2])
+	assert.Equal(t, listener.events, another.events)
+
+	c.RemoveListener("org.apache.dubbo.UserProvider.configurators", listener, config_center.WithGroup(constant.Dubbo))
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", constant.Dubbo, "v4"))
+	assert.Len(t, listener.events, 3)
+	assert.Len(t, another.events, 4)
+
+	// the listener added again is notified only once
+	c.AddListener("org.apache.dubbo.UserProvider.configurators", listener)
+	require.NoError(t, c.PublishConfig("org.apache.dubbo.UserProvider.configurators", constant.Dubbo, "v5"))
+	assert.Len(t, listener.events, 4)
+}
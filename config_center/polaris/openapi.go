@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package polaris
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+const (
+	configFilesPath   = "/config/v1/configfiles"
+	releasePath       = configFilesPath + "/release"
+	searchPath        = configFilesPath + "/search"
+	tokenHeader       = "X-Polaris-Token"
+	searchLimit       = 100
+	modifier          = "dubbo-go"
+	codeSuccess       = 200000
+	codeExistedFile   = 400201
+	codeNotFoundFile  = 400202
+	defaultFileFormat = "text"
+)
+
+// configFileClient manages the config files, since the polaris sdk only reads and watches them
+type configFileClient interface {
+	// publish creates or updates the config file, and releases it
+	publish(ctx context.Context, namespace, group, name, content string) error
+	delete(ctx context.Context, namespace, group, name string) error
+	list(ctx context.Context, namespace, group string) ([]string, error)
+}
+
+// openAPIClient manages the config files by the open api of polaris
+type openAPIClient struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+func newOpenAPIClient(address, token string, timeout time.Duration) *openAPIClient {
+	return &openAPIClient{address: address, token: token, client: &http.Client{Timeout: timeout}}
+}
+
+type configFile struct {
+	Namespace string `json:"namespace"`
+	Group     string `json:"group"`
+	Name      string `json:"name"`
+	Content   string `json:"content,omitempty"`
+	Format    string `json:"format,omitempty"`
+	CreateBy  string `json:"createBy,omitempty"`
+	ModifyBy  string `json:"modifyBy,omitempty"`
+}
+
+type configFileRelease struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Group     string `json:"group"`
+	FileName  string `json:"fileName"`
+	CreateBy  string `json:"createBy,omitempty"`
+}
+
+type response struct {
+	Code        uint32       `json:"code"`
+	Info        string       `json:"info"`
+	Total       uint32       `json:"total"`
+	ConfigFiles []configFile `json:"configFiles"`
+}
+
+func (c *openAPIClient) publish(ctx context.Context, namespace, group, name, content string) error {
+	file := &configFile{Namespace: namespace, Group: group, Name: name, Content: content, Format: defaultFileFormat, CreateBy: modifier, ModifyBy: modifier}
+	resp, err := c.do(ctx, http.MethodPost, configFilesPath, nil, file)
+	if err != nil {
+		return err
+	}
+	if resp.Code == codeExistedFile {
+		if resp, err = c.do(ctx, http.MethodPut, configFilesPath, nil, file); err != nil {
+			return err
+		}
+	}
+	if resp.Code != codeSuccess {
+		return resp.error("save config file " + name)
+	}
+
+	release := &configFileRelease{Name: name + "-" + strconv.FormatInt(time.Now().UnixMilli(), 10), Namespace: namespace, Group: group, FileName: name, CreateBy: modifier}
+	if resp, err = c.do(ctx, http.MethodPost, releasePath, nil, release); err != nil {
+		return err
+	}
+	if resp.Code != codeSuccess {
+		return resp.error("release config file " + name)
+	}
+	return nil
+}
+
+func (c *openAPIClient) delete(ctx context.Context, namespace, group, name string) error {
+	query := url.Values{"namespace": {namespace}, "group": {group}, "name": {name}, "deleteBy": {modifier}}
+	resp, err := c.do(ctx, http.MethodDelete, configFilesPath, query, nil)
+	if err != nil {
+		return err
+	}
+	if resp.Code != codeSuccess && resp.Code != codeNotFoundFile {
+		return resp.error("delete config file " + name)
+	}
+	return nil
+}
+
+func (c *openAPIClient) list(ctx context.Context, namespace, group string) ([]string, error) {
+	var names []string
+	for offset := 0; ; offset += searchLimit {
+		query := url.Values{
+			"namespace": {namespace},
+			"group":     {group},
+			"offset":    {strconv.Itoa(offset)},
+			"limit":     {strconv.Itoa(searchLimit)},
+		}
+		resp, err := c.do(ctx, http.MethodGet, searchPath, query, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Code != codeSuccess {
+			return nil, resp.error("search config files of group " + group)
+		}
+		for _, file := range resp.ConfigFiles {
+			// the search matches the group fuzzily
+			if file.Group == group {
+				names = append(names, file.Name)
+			}
+		}
+		if len(resp.ConfigFiles) < searchLimit || offset+searchLimit >= int(resp.Total) {
+			return names, nil
+		}
+	}
+}
+
+func (c *openAPIClient) do(ctx context.Context, method, path string, query url.Values, body any) (*response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, perrors.WithStack(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	target := c.address + path
+	if len(query) != 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(c.token) != 0 {
+		req.Header.Set(tokenHeader, c.token)
+	}
+	httpResp, err := c.client.Do(req)
+	if err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	defer httpResp.Body.Close()
+
+	resp := &response{}
+	if err = json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, perrors.Wrapf(err, "decode response of %s %s, status %s", method, path, httpResp.Status)
+	}
+	return resp, nil
+}
+
+func (r *response) error(action string) error {
+	return perrors.Errorf("%s failed, code: %d, info: %s", action, r.Code, r.Info)
+}
@@ -0,0 +1,181 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package polaris
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockOpenAPIServer serves the config files api of polaris in memory
+type mockOpenAPIServer struct {
+	lock     sync.Mutex
+	files    map[string]configFile
+	released map[string]string
+	tokens   []string
+}
+
+func newMockOpenAPIServer(t *testing.T) (*mockOpenAPIServer, *openAPIClient) {
+	s := &mockOpenAPIServer{files: make(map[string]configFile), released: make(map[string]string)}
+	mux := http.NewServeMux()
+	mux.HandleFunc(configFilesPath, s.configFiles)
+	mux.HandleFunc(releasePath, s.release)
+	mux.HandleFunc(searchPath, s.search)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return s, newOpenAPIClient(server.URL, "secret", time.Second)
+}
+
+func (s *mockOpenAPIServer) configFiles(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tokens = append(s.tokens, r.Header.Get(tokenHeader))
+	switch r.Method {
+	case http.MethodPost, http.MethodPut:
+		var file configFile
+		if err := json.NewDecoder(r.Body).Decode(&file); err != nil {
+			writeResponse(w, &response{Code: 400000, Info: err.Error()})
+			return
+		}
+		k := file.Namespace + "/" + file.Group + "/" + file.Name
+		_, ok := s.files[k]
+		if r.Method == http.MethodPost && ok {
+			writeResponse(w, &response{Code: codeExistedFile, Info: "existed resource"})
+			return
+		}
+		if r.Method == http.MethodPut && !ok {
+			writeResponse(w, &response{Code: codeNotFoundFile, Info: "not found resource"})
+			return
+		}
+		s.files[k] = file
+	case http.MethodDelete:
+		q := r.URL.Query()
+		k := q.Get("namespace") + "/" + q.Get("group") + "/" + q.Get("name")
+		if _, ok := s.files[k]; !ok {
+			writeResponse(w, &response{Code: codeNotFoundFile, Info: "not found resource"})
+			return
+		}
+		delete(s.files, k)
+		delete(s.released, k)
+	}
+	writeResponse(w, &response{Code: codeSuccess})
+}
+
+func (s *mockOpenAPIServer) release(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var release configFileRelease
+	if err := json.NewDecoder(r.Body).Decode(&release); err != nil {
+		writeResponse(w, &response{Code: 400000, Info: err.Error()})
+		return
+	}
+	k := release.Namespace + "/" + release.Group + "/" + release.FileName
+	file, ok := s.files[k]
+	if !ok {
+		writeResponse(w, &response{Code: codeNotFoundFile, Info: "not found resource"})
+		return
+	}
+	s.released[k] = file.Content
+	writeResponse(w, &response{Code: codeSuccess})
+}
+
+func (s *mockOpenAPIServer) search(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	q := r.URL.Query()
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	var matched []configFile
+	for _, file := range s.files {
+		if file.Namespace == q.Get("namespace") && file.Group == q.Get("group") {
+			matched = append(matched, file)
+		}
+	}
+	resp := &response{Code: codeSuccess, Total: uint32(len(matched))}
+	if offset < len(matched) {
+		resp.ConfigFiles = matched[offset:min(offset+limit, len(matched))]
+	}
+	writeResponse(w, resp)
+}
+
+func writeResponse(w http.ResponseWriter, resp *response) {
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func TestOpenAPIClient(t *testing.T) {
+	server, client := newMockOpenAPIServer(t)
+	ctx := context.Background()
+
+	require.NoError(t, client.publish(ctx, "ns", "dubbo", "app.tag-router", "v1"))
+	require.NoError(t, client.publish(ctx, "ns", "dubbo", "app.tag-router", "v2"))
+	require.NoError(t, client.publish(ctx, "ns", "dubbo", "app.condition-router", "c"))
+	assert.Equal(t, "v2", server.released["ns/dubbo/app.tag-router"])
+	assert.Equal(t, "c", server.released["ns/dubbo/app.condition-router"])
+	for _, token := range server.tokens {
+		assert.Equal(t, "secret", token)
+	}
+
+	names, err := client.list(ctx, "ns", "dubbo")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"app.tag-router", "app.condition-router"}, names)
+
+	require.NoError(t, client.delete(ctx, "ns", "dubbo", "app.tag-router"))
+	require.NoError(t, client.delete(ctx, "ns", "dubbo", "absent"))
+	names, err = client.list(ctx, "ns", "dubbo")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app.condition-router"}, names)
+}
+
+func TestOpenAPIClientListPages(t *testing.T) {
+	server, client := newMockOpenAPIServer(t)
+	for i := 0; i < searchLimit+5; i++ {
+		name := "key-" + strconv.Itoa(i)
+		server.files["ns/dubbo/"+name] = configFile{Namespace: "ns", Group: "dubbo", Name: name}
+	}
+	server.files["ns/other/key"] = configFile{Namespace: "ns", Group: "other", Name: "key"}
+
+	names, err := client.list(context.Background(), "ns", "dubbo")
+	require.NoError(t, err)
+	assert.Len(t, names, searchLimit+5)
+}
+
+func TestOpenAPIClientError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, &response{Code: 401000, Info: "unauthorized"})
+	}))
+	defer server.Close()
+	client := newOpenAPIClient(server.URL, "", time.Second)
+
+	err := client.publish(context.Background(), "ns", "dubbo", "key", "value")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unauthorized")
+	assert.Error(t, client.delete(context.Background(), "ns", "dubbo", "key"))
+	_, err = client.list(context.Background(), "ns", "dubbo")
+	assert.Error(t, err)
+}
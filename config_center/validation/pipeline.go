@@ -0,0 +1,290 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsConfigCenter "dubbo.apache.org/dubbo-go/v3/metrics/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+const (
+	// DefaultHistorySize is the count of the applied versions kept for every key by default
+	DefaultHistorySize = 10
+
+	ReasonInvalid      = "invalid"
+	ReasonEmptyAddress = "empty_address"
+)
+
+// ErrEmptyAddress is returned when a rule without force routes all the current invokers away
+var ErrEmptyAddress = errors.New("rule routes the current addresses to an empty list, set force to apply it")
+
+// Revision is an applied version of the rule of a key
+type Revision struct {
+	Version    int64
+	Value      string
+	ConfigType remoting.EventType
+	Time       time.Time
+}
+
+// Rejection is a config change rejected by the pipeline
+type Rejection struct {
+	Key    string
+	Value  string
+	Reason string
+	Err    error
+}
+
+type history struct {
+	version   int64
+	revisions []Revision
+	listeners map[config_center.ConfigurationListener]struct{}
+}
+
+// Pipeline validates the config changes, and keeps the history of the applied ones
+type Pipeline struct {
+	lock        sync.Mutex
+	historySize int
+	histories   map[string]*history
+	// rollbacks are the events dispatched by Rollback, which are applied without validation
+	rollbacks map[*config_center.ConfigChangeEvent]struct{}
+	handlers  []func(*Rejection)
+}
+
+// Option configures the Pipeline
+type Option func(*Pipeline)
+
+// WithHistorySize sets the count of the applied versions kept for every key
+func WithHistorySize(size int) Option {
+	return func(p *Pipeline) {
+		if size > 0 {
+			p.historySize = size
+		}
+	}
+}
+
+// WithRejectionHandler adds the handler called once a config change is rejected
+func WithRejectionHandler(handler func(*Rejection)) Option {
+	return func(p *Pipeline) {
+		p.handlers = append(p.handlers, handler)
+	}
+}
+
+// NewPipeline creates a Pipeline
+func NewPipeline(opts ...Option) *Pipeline {
+	p := &Pipeline{
+		historySize: DefaultHistorySize,
+		histories:   make(map[string]*history),
+		rollbacks:   make(map[*config_center.ConfigChangeEvent]struct{}),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// OnReject adds the handler called once a config change is rejected
+func (p *Pipeline) OnReject(handler func(*Rejection)) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.handlers = append(p.handlers, handler)
+}
+
+// Check validates the event before the listener processes it, the listener should ignore the event if an error
+// is returned. The accepted event is recorded in the history of its key.
+func (p *Pipeline) Check(listener config_center.ConfigurationListener, event *config_center.ConfigChangeEvent) error {
+	value, _ := event.Value.(string)
+
+	p.lock.Lock()
+	h := p.history(event.Key)
+	h.listeners[listener] = struct{}{}
+	_, rollback := p.rollbacks[event]
+	p.lock.Unlock()
+	if rollback {
+		return nil
+	}
+
+	if event.ConfigType != remoting.EventTypeDel {
+		if validator := GetValidator(event.Key); validator != nil {
+			if err := validator.Validate(event.Key, value); err != nil {
+				return p.reject(event.Key, value, ReasonInvalid, err)
+			}
+		}
+		if dryRunner, ok := listener.(DryRunner); ok {
+			result, err := dryRunner.DryRun(event)
+			if err != nil {
+				return p.reject(event.Key, value, ReasonInvalid, err)
+			}
+			if result != nil && result.Total > 0 && result.Remaining == 0 && !result.Force {
+				return p.reject(event.Key, value, ReasonEmptyAddress,
+					perrors.WithMessagef(ErrEmptyAddress, "%d addresses are routed to none", result.Total))
+			}
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.record(h, value, event.ConfigType)
+	return nil
+}
+
+// RemoveListener stops dispatching the rollbacks of the key to the listener, it should be called once the listener
+// is removed from config center. The history of the key is kept.
+func (p *Pipeline) RemoveListener(key string, listener config_center.ConfigurationListener) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if h, ok := p.histories[key]; ok {
+		delete(h.listeners, listener)
+	}
+}
+
+// History returns the applied versions of the key, the latest one is the last
+func (p *Pipeline) History(key string) []Revision {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	h, ok := p.histories[key]
+	if !ok {
+		return nil
+	}
+	return append([]Revision(nil), h.revisions...)
+}
+
+// Rollback applies the version of the key in the history again to the listeners of the key without validation.
+// It only changes the rules applied in this process, the next change of the key from config center overrides it.
+func (p *Pipeline) Rollback(key string, version int64) error {
+	p.lock.Lock()
+	h, ok := p.histories[key]
+	if !ok {
+		p.lock.Unlock()
+		return perrors.Errorf("no history of key %s", key)
+	}
+	var target *Revision
+	for i := range h.revisions {
+		if h.revisions[i].Version == version {
+			target = &h.revisions[i]
+			break
+		}
+	}
+	if target == nil {
+		p.lock.Unlock()
+		return perrors.Errorf("version %d of key %s is not in the history", version, key)
+	}
+	configType := remoting.EventTypeUpdate
+	if target.ConfigType == remoting.EventTypeDel {
+		configType = remoting.EventTypeDel
+	}
+	event := &config_center.ConfigChangeEvent{Key: key, Value: target.Value, ConfigType: configType}
+	listeners := make([]config_center.ConfigurationListener, 0, len(h.listeners))
+	for listener := range h.listeners {
+		listeners = append(listeners, listener)
+	}
+	p.rollbacks[event] = struct{}{}
+	p.lock.Unlock()
+
+	logger.Infof("[Config Validation] roll back key %s to version %d", key, version)
+	for _, listener := range listeners {
+		listener.Process(event)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.rollbacks, event)
+	p.record(h, event.Value.(string), configType)
+	return nil
+}
+
+func (p *Pipeline) history(key string) *history {
+	h, ok := p.histories[key]
+	if !ok {
+		h = &history{listeners: make(map[config_center.ConfigurationListener]struct{})}
+		p.histories[key] = h
+	}
+	return h
+}
+
+// record appends the revision unless it is the same as the latest one, since a key is usually
+// listened by the routers of all the references
+func (p *Pipeline) record(h *history, value string, configType remoting.EventType) {
+	if n := len(h.revisions); n > 0 {
+		latest := h.revisions[n-1]
+		if latest.Value == value && (latest.ConfigType == remoting.EventTypeDel) == (configType == remoting.EventTypeDel) {
+			return
+		}
+	}
+	h.version++
+	h.revisions = append(h.revisions, Revision{Version: h.version, Value: value, ConfigType: configType, Time: time.Now()})
+	if len(h.revisions) > p.historySize {
+		h.revisions = append(h.revisions[:0:0], h.revisions[len(h.revisions)-p.historySize:]...)
+	}
+}
+
+func (p *Pipeline) reject(key, value, reason string, err error) error {
+	logger.Warnf("[Config Validation] reject the change of key %s, reason: %s, error: %v", key, reason, err)
+	metrics.Publish(metricsConfigCenter.NewRejectedMetricEvent(key, reason))
+
+	p.lock.Lock()
+	handlers := make([]func(*Rejection), len(p.handlers))
+	copy(handlers, p.handlers)
+	p.lock.Unlock()
+	rejection := &Rejection{Key: key, Value: value, Reason: reason, Err: err}
+	for _, handler := range handlers {
+		handler(rejection)
+	}
+	return perrors.WithMessage(err, fmt.Sprintf("change of key %s is rejected", key))
+}
+
+var defaultPipeline = NewPipeline()
+
+// Default returns the pipeline used by the routers and configurators
+func Default() *Pipeline {
+	return defaultPipeline
+}
+
+// Check validates the event by the default pipeline
+func Check(listener config_center.ConfigurationListener, event *config_center.ConfigChangeEvent) error {
+	return defaultPipeline.Check(listener, event)
+}
+
+// RemoveListener removes the listener of the key from the default pipeline
+func RemoveListener(key string, listener config_center.ConfigurationListener) {
+	defaultPipeline.RemoveListener(key, listener)
+}
+
+// History returns the applied versions of the key in the default pipeline
+func History(key string) []Revision {
+	return defaultPipeline.History(key)
+}
+
+// Rollback rolls the key back to the version by the default pipeline
+func Rollback(key string, version int64) error {
+	return defaultPipeline.Rollback(key, version)
+}
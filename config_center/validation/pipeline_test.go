@@ -0,0 +1,169 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+// mockListener applies the events checked by the pipeline, and dry-runs them with the result
+type mockListener struct {
+	pipeline *Pipeline
+	result   *DryRunResult
+	applied  []*config_center.ConfigChangeEvent
+}
+
+func (l *mockListener) Process(event *config_center.ConfigChangeEvent) {
+	if err := l.pipeline.Check(l, event); err != nil {
+		return
+	}
+	l.applied = append(l.applied, event)
+}
+
+func (l *mockListener) DryRun(*config_center.ConfigChangeEvent) (*DryRunResult, error) {
+	return l.result, nil
+}
+
+func update(key, value string) *config_center.ConfigChangeEvent {
+	return &config_center.ConfigChangeEvent{Key: key, Value: value, ConfigType: remoting.EventTypeUpdate}
+}
+
+func TestGetValidator(t *testing.T) {
+	SetValidator(".test-rule", ValidatorFunc(func(_, _ string) error { return errors.New("test") }))
+	SetValidator(".strict.test-rule", ValidatorFunc(func(_, _ string) error { return errors.New("strict") }))
+
+	assert.EqualError(t, GetValidator("app.test-rule").Validate("", ""), "test")
+	assert.EqualError(t, GetValidator("app.strict.test-rule").Validate("", ""), "strict")
+	assert.Nil(t, GetValidator("app.unknown-rule"))
+	assert.NotNil(t, GetValidator("org.apache.dubbo.UserProvider.configurators"))
+}
+
+func TestCheckSchema(t *testing.T) {
+	var rejections []*Rejection
+	p := NewPipeline(WithRejectionHandler(func(r *Rejection) { rejections = append(rejections, r) }))
+	l := &mockListener{pipeline: p}
+
+	l.Process(update("app.configurators", "configVersion: v3.0\nscope: application\nkey: app\nconfigs:\n  - side: consumer\n    parameters:\n      timeout: 6000\n"))
+	l.Process(update("app.configurators", "configs: [unclosed"))
+	require.Len(t, l.applied, 1)
+	require.Len(t, rejections, 1)
+	assert.Equal(t, "app.configurators", rejections[0].Key)
+	assert.Equal(t, ReasonInvalid, rejections[0].Reason)
+	assert.Len(t, p.History("app.configurators"), 1)
+
+	// deletions are not validated
+	l.Process(&config_center.ConfigChangeEvent{Key: "app.configurators", ConfigType: remoting.EventTypeDel})
+	assert.Len(t, l.applied, 2)
+}
+
+func TestCheckDryRun(t *testing.T) {
+	var rejections []*Rejection
+	p := NewPipeline()
+	p.OnReject(func(r *Rejection) { rejections = append(rejections, r) })
+	l := &mockListener{pipeline: p, result: &DryRunResult{Total: 2, Remaining: 0}}
+
+	l.Process(update("app.tag-router", "v1"))
+	assert.Empty(t, l.applied)
+	require.Len(t, rejections, 1)
+	assert.Equal(t, ReasonEmptyAddress, rejections[0].Reason)
+	assert.ErrorIs(t, rejections[0].Err, ErrEmptyAddress)
+
+	l.result.Force = true
+	l.Process(update("app.tag-router", "v2"))
+	l.result = &DryRunResult{Total: 2, Remaining: 1}
+	l.Process(update("app.tag-router", "v3"))
+	// no invoker is notified yet
+	l.result = &DryRunResult{}
+	l.Process(update("app.tag-router", "v4"))
+	assert.Len(t, l.applied, 3)
+	assert.Len(t, rejections, 1)
+}
+
+func TestHistoryAndRollback(t *testing.T) {
+	p := NewPipeline(WithHistorySize(3))
+	l1, l2 := &mockListener{pipeline: p}, &mockListener{pipeline: p}
+	for _, v := range []string{"v1", "v2", "v3", "v4"} {
+		l1.Process(update("app.condition-router", v))
+		// the same change processed by another listener is recorded once
+		l2.Process(update("app.condition-router", v))
+	}
+
+	revisions := p.History("app.condition-router")
+	require.Len(t, revisions, 3)
+	assert.Equal(t, []int64{2, 3, 4}, []int64{revisions[0].Version, revisions[1].Version, revisions[2].Version})
+	assert.Equal(t, "v2", revisions[0].Value)
+	assert.Nil(t, p.History("absent"))
+
+	// the rolled back version is applied without validation
+	l1.result = &DryRunResult{Total: 1, Remaining: 0}
+	require.NoError(t, p.Rollback("app.condition-router", 2))
+	for _, l := range []*mockListener{l1, l2} {
+		require.Len(t, l.applied, 5)
+		assert.Equal(t, "v2", l.applied[4].Value)
+		assert.Equal(t, remoting.EventTypeUpdate, l.applied[4].ConfigType)
+	}
+	revisions = p.History("app.condition-router")
+	require.Len(t, revisions, 3)
+	assert.Equal(t, int64(5), revisions[2].Version)
+	assert.Equal(t, "v2", revisions[2].Value)
+
+	// the rollback is validated once it is pushed again
+	l1.Process(update("app.condition-router", "v2"))
+	assert.Len(t, l1.applied, 5)
+
+	assert.Error(t, p.Rollback("app.condition-router", 1))
+	assert.Error(t, p.Rollback("absent", 1))
+}
+
+func TestRollbackDeletion(t *testing.T) {
+	p := NewPipeline()
+	l := &mockListener{pipeline: p}
+	l.Process(update("app.condition-router", "v1"))
+	l.Process(&config_center.ConfigChangeEvent{Key: "app.condition-router", ConfigType: remoting.EventTypeDel})
+	l.Process(update("app.condition-router", "v2"))
+
+	revisions := p.History("app.condition-router")
+	require.Len(t, revisions, 3)
+	require.NoError(t, p.Rollback("app.condition-router", revisions[1].Version))
+	assert.Equal(t, remoting.EventTypeDel, l.applied[len(l.applied)-1].ConfigType)
+}
+
+func TestRemoveListener(t *testing.T) {
+	p := NewPipeline()
+	removed := &mockListener{pipeline: p}
+	kept := &mockListener{pipeline: p}
+	removed.Process(update("app.condition-router", "v1"))
+	kept.Process(update("app.condition-router", "v1"))
+	kept.Process(update("app.condition-router", "v2"))
+
+	p.RemoveListener("app.condition-router", removed)
+	require.NoError(t, p.Rollback("app.condition-router", p.History("app.condition-router")[0].Version))
+	assert.Len(t, removed.applied, 1)
+	assert.Equal(t, "v1", kept.applied[len(kept.applied)-1].Value)
+}
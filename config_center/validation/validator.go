@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation
+
+import (
+	"strings"
+	"sync"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/parser"
+)
+
+// Validator validates the schema of the raw rule of the key
+type Validator interface {
+	Validate(key, rule string) error
+}
+
+// ValidatorFunc is the adapter of the function to Validator
+type ValidatorFunc func(key, rule string) error
+
+// Validate calls f(key, rule)
+func (f ValidatorFunc) Validate(key, rule string) error {
+	return f(key, rule)
+}
+
+// DryRunResult is the result of routing the current invokers by a rule without applying it
+type DryRunResult struct {
+	// Total is the count of the current invokers
+	Total int
+	// Remaining is the count of the invokers routed by the rule
+	Remaining int
+	// Force is whether the rule is applied even if no invoker remains
+	Force bool
+}
+
+// DryRunner is implemented by the listeners which route the current invokers by the rules
+type DryRunner interface {
+	DryRun(event *config_center.ConfigChangeEvent) (*DryRunResult, error)
+}
+
+var (
+	validatorsLock sync.RWMutex
+	validators     = make(map[string]Validator)
+)
+
+func init() {
+	SetValidator(constant.ConfiguratorSuffix, ValidatorFunc(func(_, rule string) error {
+		_, err := (&parser.DefaultConfigurationParser{}).ParseToUrls(rule)
+		return err
+	}))
+}
+
+// SetValidator sets the validator of the rules whose keys end with the suffix
+func SetValidator(suffix string, validator Validator) {
+	validatorsLock.Lock()
+	defer validatorsLock.Unlock()
+	validators[suffix] = validator
+}
+
+// GetValidator returns the validator of the longest suffix of the key, or nil if there is none
+func GetValidator(key string) Validator {
+	validatorsLock.RLock()
+	defer validatorsLock.RUnlock()
+	var (
+		matched   string
+		validator Validator
+	)
+	for suffix, v := range validators {
+		if len(suffix) > len(matched) && strings.HasSuffix(key, suffix) {
+			matched, validator = suffix, v
+		}
+	}
+	return validator
+}
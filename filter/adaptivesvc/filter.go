@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 )
 
 import (
@@ -32,14 +33,22 @@ import (
 )
 
 import (
+	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
 	"dubbo.apache.org/dubbo-go/v3/filter/adaptivesvc/limiter"
+	"dubbo.apache.org/dubbo-go/v3/filter/criticality"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsRpc "dubbo.apache.org/dubbo-go/v3/metrics/rpc"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 )
 
+// limiterStatusInterval is the min interval of publishing the status of a limiter whose limit does not change.
+const limiterStatusInterval = time.Second
+
 var (
 	adaptiveServiceProviderFilterOnce sync.Once
 	instance                          filter.Filter
@@ -77,9 +86,13 @@ func (f *adaptiveServiceProviderFilter) Invoke(ctx context.Context, invoker base
 	if err != nil {
 		if errors.Is(err, ErrLimiterNotFoundOnMapper) {
 			// limiter is not found on the mapper, just create
-			// a new limiter
+			// a new limiter of the type selected by the service
+			var limiterType int
+			if limiterType, err = limiter.ParseLimiterType(limiterName(invoker.GetURL())); err != nil {
+				return &result.RPCResult{Err: wrapErrAdaptiveSvcInterrupted(err)}
+			}
 			if l, err = limiterMapperSingleton.newAndSetMethodLimiter(invoker.GetURL(),
-				invocation.MethodName(), limiter.HillClimbingLimiter); err != nil {
+				invocation.MethodName(), limiterType); err != nil {
 				return &result.RPCResult{Err: wrapErrAdaptiveSvcInterrupted(err)}
 			}
 		} else {
@@ -88,8 +101,16 @@ func (f *adaptiveServiceProviderFilter) Invoke(ctx context.Context, invoker base
 		}
 	}
 
+	if shouldShed(l, invoker.GetURL(), invocation) {
+		publishLimiterStatus(l, invoker, invocation)
+		metrics.Publish(metricsRpc.NewLimitEvent(invoker, invocation))
+		return &result.RPCResult{Err: wrapErrAdaptiveSvcInterrupted(limiter.ErrReachLimitation)}
+	}
+
 	updater, err := l.Acquire()
 	if err != nil {
+		publishLimiterStatus(l, invoker, invocation)
+		metrics.Publish(metricsRpc.NewLimitEvent(invoker, invocation))
 		return &result.RPCResult{Err: wrapErrAdaptiveSvcInterrupted(err)}
 	}
 
@@ -132,7 +153,12 @@ func (f *adaptiveServiceProviderFilter) OnResponse(_ context.Context, res result
 		return &result.RPCResult{Err: ErrUnexpectedUpdaterType}
 	}
 
-	err := updater.DoUpdate()
+	var err error
+	if dropUpdater, ok := updater.(limiter.DropUpdater); ok && isDropped(res.Error()) {
+		err = dropUpdater.DoDrop()
+	} else {
+		err = updater.DoUpdate()
+	}
 	if err != nil {
 		logger.Errorf("[adasvc filter] The DoUpdate method was failed, err: %s.", err)
 		return &result.RPCResult{Err: err}
@@ -151,10 +177,66 @@ func (f *adaptiveServiceProviderFilter) OnResponse(_ context.Context, res result
 	logger.Debugf("[adasvc filter] The attachments are set, %s: %d, %s: %d.",
 		constant.AdaptiveServiceRemainingKey, l.Remaining(),
 		constant.AdaptiveServiceInflightKey, l.Inflight())
+	publishLimiterStatus(l, invoker, invocation)
 
 	return res
 }
 
+// shouldShed returns whether the invocation should be shed because the inflight requests are over the fraction of
+// the limit available to its criticality.
+func shouldShed(l limiter.Limiter, url *common.URL, invocation base.Invocation) bool {
+	threshold := criticality.Threshold(url, criticality.FromInvocation(invocation))
+	if threshold >= 1 {
+		return false
+	}
+	return float64(l.Inflight()) >= float64(limiter.LimitOf(l))*threshold
+}
+
+// limiterName returns the name of the limiter selected by the service, HillClimbing is the default one.
+func limiterName(url *common.URL) string {
+	return url.GetParam(constant.AdaptiveServiceLimiterKey, limiter.LimiterName(limiter.HillClimbingLimiter))
+}
+
+// limiterStatus is the last status of a method limiter published to the rpc metrics.
+type limiterStatus struct {
+	mu        sync.Mutex
+	limit     uint64
+	published time.Time
+}
+
+// limiterStatuses are the statuses of the method limiters, keyed like the limiter mapper.
+var limiterStatuses sync.Map
+
+// publishLimiterStatus reports the limit and the inflight requests of the limiter to the rpc metrics. It is
+// published at once when the limit changes, otherwise at most once per limiterStatusInterval.
+func publishLimiterStatus(l limiter.Limiter, invoker base.Invoker, invocation base.Invocation) {
+	limit := limiter.LimitOf(l)
+	v, _ := limiterStatuses.LoadOrStore(invoker.GetURL().Path+invocation.MethodName(), &limiterStatus{})
+	status := v.(*limiterStatus)
+	now := time.Now()
+	status.mu.Lock()
+	if limit == status.limit && now.Sub(status.published) < limiterStatusInterval {
+		status.mu.Unlock()
+		return
+	}
+	status.limit, status.published = limit, now
+	status.mu.Unlock()
+	metrics.Publish(metricsRpc.NewAdaptiveLimiterEvent(invoker, invocation, limiterName(invoker.GetURL()),
+		limit, l.Inflight()))
+}
+
+// isDropped returns whether the invocation failed by overload, which shrinks the loss based limiters.
+func isDropped(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	code := tri.CodeOf(err)
+	return code == tri.CodeDeadlineExceeded || code == tri.CodeResourceExhausted
+}
+
 func wrapErrAdaptiveSvcInterrupted(customizedErr any) error {
 	return fmt.Errorf("%w: %v", ErrAdaptiveSvcInterrupted, customizedErr)
 }
@@ -19,6 +19,7 @@ package adaptivesvc
 
 import (
 	"context"
+	"errors"
 	"testing"
 )
 
@@ -33,9 +34,12 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/filter/adaptivesvc/limiter"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/protocol/mock"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 )
 
 type mockUpdater struct {
@@ -129,3 +133,125 @@ func TestAdaptiveServiceProviderFilter_OnResponse(t *testing.T) {
 		assert.True(t, updater.called)
 	})
 }
+
+type mockDropUpdater struct {
+	mockUpdater
+	dropped bool
+}
+
+func (m *mockDropUpdater) DoDrop() error {
+	m.dropped = true
+	return nil
+}
+
+func TestAdaptiveServiceProviderFilter_SelectLimiter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filter := newAdaptiveServiceProviderFilter()
+	enabled := map[string]any{constant.AdaptiveServiceEnabledKey: constant.AdaptiveServiceIsEnabled}
+
+	t.Run("Vegas", func(t *testing.T) {
+		u, _ := common.NewURL("dubbo://127.0.0.1:20000/com.test.VegasService?" + constant.AdaptiveServiceLimiterKey + "=vegas")
+		invoker := mock.NewMockInvoker(ctrl)
+		invoker.EXPECT().GetURL().Return(u).AnyTimes()
+		invoker.EXPECT().Invoke(gomock.Any(), gomock.Any()).Return(&result.RPCResult{Rest: "ok"})
+
+		res := filter.Invoke(context.Background(), invoker, invocation.NewRPCInvocation("GetInfo", nil, enabled))
+		require.NoError(t, res.Error())
+
+		l, err := limiterMapperSingleton.getMethodLimiter(u, "GetInfo")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), l.Inflight())
+		assert.Equal(t, "vegas", limiterName(u))
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		u, _ := common.NewURL("dubbo://127.0.0.1:20000/com.test.UnknownService?" + constant.AdaptiveServiceLimiterKey + "=unknown")
+		invoker := mock.NewMockInvoker(ctrl)
+		invoker.EXPECT().GetURL().Return(u).AnyTimes()
+
+		res := filter.Invoke(context.Background(), invoker, invocation.NewRPCInvocation("GetInfo", nil, enabled))
+		assert.True(t, isErrAdaptiveSvcInterrupted(res.Error()))
+		assert.ErrorContains(t, res.Error(), limiter.ErrUnknownLimiter.Error())
+	})
+}
+
+func TestAdaptiveServiceProviderFilter_OnResponseDropped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	u, _ := common.NewURL("dubbo://127.0.0.1:20000/com.test.DropService")
+	filter := newAdaptiveServiceProviderFilter()
+	_, _ = limiterMapperSingleton.newAndSetMethodLimiter(u, "GetInfo", limiter.AIMDLimiter)
+	invoker := mock.NewMockInvoker(ctrl)
+	invoker.EXPECT().GetURL().Return(u).AnyTimes()
+
+	for _, tt := range []struct {
+		err     error
+		dropped bool
+	}{
+		{err: nil, dropped: false},
+		{err: errors.New("biz error"), dropped: false},
+		{err: context.DeadlineExceeded, dropped: true},
+		{err: tri.NewError(tri.CodeResourceExhausted, errors.New("overloaded")), dropped: true},
+	} {
+		invoc := invocation.NewRPCInvocation("GetInfo", nil, nil)
+		updater := &mockDropUpdater{}
+		invoc.SetAttribute(constant.AdaptiveServiceUpdaterKey, updater)
+		res := &result.RPCResult{Err: tt.err}
+		res.AddAttachment(constant.AdaptiveServiceEnabledKey, constant.AdaptiveServiceIsEnabled)
+
+		filter.OnResponse(context.Background(), res, invoker, invoc)
+		assert.Equal(t, tt.dropped, updater.dropped, tt.err)
+		assert.Equal(t, !tt.dropped, updater.called, tt.err)
+	}
+}
+
+type stubLimiter struct {
+	limiter.Limiter
+	limit, inflight uint64
+}
+
+func (s *stubLimiter) Limit() uint64 { return s.limit }
+
+func (s *stubLimiter) Inflight() uint64 { return s.inflight }
+
+func TestShouldShed(t *testing.T) {
+	u, _ := common.NewURL("dubbo://127.0.0.1:20000/com.test.Service")
+	l := &stubLimiter{limit: 10, inflight: 8}
+	newInvocation := func(c string) *invocation.RPCInvocation {
+		return invocation.NewRPCInvocation("GetInfo", nil, map[string]any{constant.CriticalityKey: c})
+	}
+
+	assert.True(t, shouldShed(l, u, newInvocation(constant.CriticalitySheddable)))
+	assert.False(t, shouldShed(l, u, newInvocation(constant.CriticalityDefault)))
+	assert.False(t, shouldShed(l, u, newInvocation(constant.CriticalityCritical)))
+
+	// reserve a headroom for the critical requests
+	u.SetParam(constant.CriticalityDefaultThresholdKey, "0.8")
+	assert.True(t, shouldShed(l, u, newInvocation(constant.CriticalityDefault)))
+	assert.False(t, shouldShed(l, u, newInvocation(constant.CriticalityCritical)))
+}
+
+func TestPublishLimiterStatus(t *testing.T) {
+	ch := make(chan metrics.MetricsEvent, 10)
+	metrics.Subscribe(constant.MetricsRpc, ch)
+	defer metrics.Unsubscribe(constant.MetricsRpc)
+
+	u, _ := common.NewURL("dubbo://127.0.0.1:20000/com.test.PublishService")
+	invoker := base.NewBaseInvoker(u)
+	invoc := invocation.NewRPCInvocation("GetInfo", nil, nil)
+	l := &stubLimiter{limit: 10, inflight: 1}
+
+	// the status of an unchanged limit is sampled
+	for i := 0; i < 5; i++ {
+		publishLimiterStatus(l, invoker, invoc)
+	}
+	assert.Len(t, ch, 1)
+
+	// a changed limit is published at once
+	l.limit = 20
+	publishLimiterStatus(l, invoker, invoc)
+	assert.Len(t, ch, 2)
+}
@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package limiter
+
+import (
+	"time"
+)
+
+const (
+	aimdInitialLimit = 50
+	aimdMinLimit     = 10
+	aimdBackoffRatio = 0.9
+	aimdTimeout      = 5 * time.Second
+)
+
+// aimd is the loss based Additive Increase Multiplicative Decrease algorithm, the limitation grows by one if the
+// requests are using at least half of it, and it is cut by the backoff ratio once a request is dropped or slower
+// than the timeout.
+type aimd struct {
+	minLimit     float64
+	maxLimit     float64
+	backoffRatio float64
+	timeout      time.Duration
+}
+
+// NewAIMD returns an AIMD limiter.
+func NewAIMD() Limiter {
+	return newSampleLimiter(&aimd{
+		minLimit:     aimdMinLimit,
+		maxLimit:     float64(maxLimitation),
+		backoffRatio: aimdBackoffRatio,
+		timeout:      aimdTimeout,
+	}, aimdInitialLimit)
+}
+
+func (a *aimd) estimate(limit float64, rtt time.Duration, inflight uint64, dropped bool) float64 {
+	if dropped || rtt > a.timeout {
+		limit *= a.backoffRatio
+	} else if float64(inflight)*2 >= limit {
+		limit++
+	}
+	return clamp(limit, a.minLimit, a.maxLimit)
+}
@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package limiter
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	gradient2InitialLimit = 50
+	gradient2MinLimit     = 20
+	gradient2QueueSize    = 4
+	gradient2Smoothing    = 0.2
+	gradient2Tolerance    = 1.5
+	gradient2LongWindow   = 600
+	gradient2Warmup       = 10
+)
+
+// gradient2 compares the rtt of every request with the exponential moving average of the rtts over a long window.
+// The limitation is multiplied by the gradient longRtt / rtt, which is in [0.5, 1] after the tolerance is applied,
+// plus a small queue so it is able to grow. The long rtt decays quickly once the short one is much smaller, so the
+// limiter recovers from a period of high latency.
+type gradient2 struct {
+	minLimit  float64
+	maxLimit  float64
+	queueSize float64
+	smoothing float64
+	tolerance float64
+
+	longRtt *expMovingAverage
+}
+
+// NewGradient2 returns a Gradient2 limiter.
+func NewGradient2() Limiter {
+	return newSampleLimiter(&gradient2{
+		minLimit:  gradient2MinLimit,
+		maxLimit:  float64(maxLimitation),
+		queueSize: gradient2QueueSize,
+		smoothing: gradient2Smoothing,
+		tolerance: gradient2Tolerance,
+		longRtt:   newExpMovingAverage(gradient2LongWindow, gradient2Warmup),
+	}, gradient2InitialLimit)
+}
+
+func (g *gradient2) estimate(limit float64, rtt time.Duration, inflight uint64, _ bool) float64 {
+	shortRtt := float64(rtt)
+	if shortRtt <= 0 {
+		return limit
+	}
+	longRtt := g.longRtt.add(shortRtt)
+
+	// the long rtt drifts away during a long period of high latency, recover it faster
+	if longRtt/shortRtt > 2 {
+		g.longRtt.update(func(v float64) float64 { return v * 0.95 })
+	}
+
+	// the requests are not enough to judge whether the limitation is too small
+	if float64(inflight) < limit/2 {
+		return limit
+	}
+
+	gradient := math.Max(0.5, math.Min(1.0, g.tolerance*longRtt/shortRtt))
+	newLimit := limit*gradient + g.queueSize
+	newLimit = limit*(1-g.smoothing) + newLimit*g.smoothing
+	return clamp(newLimit, g.minLimit, g.maxLimit)
+}
+
+// expMovingAverage is the exponential moving average of a window, it is the simple average during the warmup.
+type expMovingAverage struct {
+	factor float64
+	warmup int
+	count  int
+	sum    float64
+	value  float64
+}
+
+func newExpMovingAverage(window, warmup int) *expMovingAverage {
+	return &expMovingAverage{factor: 2.0 / float64(window+1), warmup: warmup}
+}
+
+func (e *expMovingAverage) add(sample float64) float64 {
+	if e.count < e.warmup {
+		e.count++
+		e.sum += sample
+		e.value = e.sum / float64(e.count)
+	} else {
+		e.value = e.value*(1-e.factor) + sample*e.factor
+	}
+	return e.value
+}
+
+func (e *expMovingAverage) update(f func(float64) float64) {
+	e.value = f(e.value)
+}
@@ -21,7 +21,10 @@ import (
 	"fmt"
 )
 
-var ErrReachLimitation = fmt.Errorf("reach limitation")
+var (
+	ErrReachLimitation = fmt.Errorf("reach limitation")
+	ErrUnknownLimiter  = fmt.Errorf("unknown limiter")
+)
 
 var (
 	Verbose = false
@@ -29,8 +32,37 @@ var (
 
 const (
 	HillClimbingLimiter = iota
+	Gradient2Limiter
+	VegasLimiter
+	AIMDLimiter
 )
 
+// limiterNames are the names to select the limiters by the adaptive-service.limiter param of a service.
+var limiterNames = map[string]int{
+	"hill-climbing": HillClimbingLimiter,
+	"gradient2":     Gradient2Limiter,
+	"vegas":         VegasLimiter,
+	"aimd":          AIMDLimiter,
+}
+
+// ParseLimiterType returns the limiter type of the name.
+func ParseLimiterType(name string) (int, error) {
+	if t, ok := limiterNames[name]; ok {
+		return t, nil
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownLimiter, name)
+}
+
+// LimiterName returns the name of the limiter type.
+func LimiterName(limiterType int) string {
+	for name, t := range limiterNames {
+		if t == limiterType {
+			return name
+		}
+	}
+	return ""
+}
+
 type Limiter interface {
 	Inflight() uint64
 	Remaining() uint64
@@ -40,8 +72,30 @@ type Limiter interface {
 	Acquire() (Updater, error)
 }
 
+// LimitReporter is implemented by the limiters reporting their current limitation of the concurrency.
+type LimitReporter interface {
+	// Limit returns the current limitation of the concurrency.
+	Limit() uint64
+}
+
+// LimitOf returns the current limitation of the concurrency of the limiter, it is the sum of the inflight
+// and the remaining requests if the limiter is not a LimitReporter.
+func LimitOf(l Limiter) uint64 {
+	if r, ok := l.(LimitReporter); ok {
+		return r.Limit()
+	}
+	return l.Inflight() + l.Remaining()
+}
+
 type Updater interface {
 	// DoUpdate is called once an invocation is finished, it tells Updater that the invocation is finished, and please
 	// update the Remaining, Inflight parameters of the Limiter.
 	DoUpdate() error
 }
+
+// DropUpdater is implemented by the updaters of the limiters taking overloads into account, DoDrop is called
+// instead of DoUpdate once an invocation is finished by overload, e.g. it is timed out.
+type DropUpdater interface {
+	Updater
+	DoDrop() error
+}
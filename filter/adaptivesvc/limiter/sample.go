@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package limiter
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+import (
+	"go.uber.org/atomic"
+)
+
+var (
+	_ Limiter     = (*sampleLimiter)(nil)
+	_ DropUpdater = (*sampleUpdater)(nil)
+)
+
+// estimator estimates the new limitation from the sample of a finished request, it is called serially.
+type estimator interface {
+	estimate(limit float64, rtt time.Duration, inflight uint64, dropped bool) float64
+}
+
+// sampleLimiter is the limiter shared by the algorithms of the netflix concurrency-limits, which update the
+// limitation on every finished request by its rtt, the inflight requests when it started and whether it is dropped.
+type sampleLimiter struct {
+	inflight   *atomic.Uint64
+	limitation *atomic.Uint64
+
+	mutex     *sync.Mutex
+	estimator estimator
+	// estimatedLimit is the limitation without rounding, it is guarded by the mutex.
+	estimatedLimit float64
+}
+
+func newSampleLimiter(e estimator, initialLimit uint64) *sampleLimiter {
+	return &sampleLimiter{
+		inflight:       new(atomic.Uint64),
+		limitation:     atomic.NewUint64(initialLimit),
+		mutex:          new(sync.Mutex),
+		estimator:      e,
+		estimatedLimit: float64(initialLimit),
+	}
+}
+
+func (l *sampleLimiter) Inflight() uint64 {
+	return l.inflight.Load()
+}
+
+func (l *sampleLimiter) Limit() uint64 {
+	return l.limitation.Load()
+}
+
+func (l *sampleLimiter) Remaining() uint64 {
+	limitation := l.limitation.Load()
+	inflight := l.Inflight()
+	if limitation < inflight {
+		return 0
+	}
+	return limitation - inflight
+}
+
+func (l *sampleLimiter) Acquire() (Updater, error) {
+	for {
+		inflight := l.inflight.Load()
+		if inflight >= l.limitation.Load() {
+			return nil, ErrReachLimitation
+		}
+		if l.inflight.CompareAndSwap(inflight, inflight+1) {
+			return &sampleUpdater{startTime: time.Now(), inflight: inflight + 1, limiter: l}, nil
+		}
+	}
+}
+
+func (l *sampleLimiter) onSample(rtt time.Duration, inflight uint64, dropped bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	oldLimit := l.estimatedLimit
+	l.estimatedLimit = l.estimator.estimate(oldLimit, rtt, inflight, dropped)
+	l.limitation.Store(uint64(math.Max(1, l.estimatedLimit)))
+	VerboseDebugf("[sampleLimiter] rtt: %s, inflight: %d, dropped: %t, the limitation is updated from %.2f to %.2f.",
+		rtt, inflight, dropped, oldLimit, l.estimatedLimit)
+}
+
+// sampleUpdater reports the sample of a request to the sampleLimiter once it is finished.
+type sampleUpdater struct {
+	startTime time.Time
+	// inflight is the number of inflight requests when the request started, including itself
+	inflight uint64
+	limiter  *sampleLimiter
+	once     sync.Once
+}
+
+func (u *sampleUpdater) DoUpdate() error {
+	u.done(false)
+	return nil
+}
+
+func (u *sampleUpdater) DoDrop() error {
+	u.done(true)
+	return nil
+}
+
+func (u *sampleUpdater) done(dropped bool) {
+	u.once.Do(func() {
+		u.limiter.inflight.Dec()
+		u.limiter.onSample(time.Since(u.startTime), u.inflight, dropped)
+	})
+}
+
+func clamp(v, lower, upper float64) float64 {
+	return math.Max(lower, math.Min(upper, v))
+}
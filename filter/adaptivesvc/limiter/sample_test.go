@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package limiter
+
+import (
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimiterType(t *testing.T) {
+	for name, typ := range map[string]int{
+		"hill-climbing": HillClimbingLimiter,
+		"gradient2":     Gradient2Limiter,
+		"vegas":         VegasLimiter,
+		"aimd":          AIMDLimiter,
+	} {
+		got, err := ParseLimiterType(name)
+		require.NoError(t, err)
+		assert.Equal(t, typ, got)
+		assert.Equal(t, name, LimiterName(typ))
+	}
+	_, err := ParseLimiterType("unknown")
+	assert.ErrorIs(t, err, ErrUnknownLimiter)
+}
+
+func TestSampleLimiter_Acquire(t *testing.T) {
+	for name, l := range map[string]Limiter{"gradient2": NewGradient2(), "vegas": NewVegas(), "aimd": NewAIMD()} {
+		t.Run(name, func(t *testing.T) {
+			limit := LimitOf(l)
+			updaters := make([]Updater, 0, limit)
+			for i := uint64(0); i < limit; i++ {
+				u, err := l.Acquire()
+				require.NoError(t, err)
+				updaters = append(updaters, u)
+			}
+			assert.Equal(t, limit, l.Inflight())
+			assert.Equal(t, uint64(0), l.Remaining())
+			_, err := l.Acquire()
+			assert.ErrorIs(t, err, ErrReachLimitation)
+
+			for _, u := range updaters {
+				require.NoError(t, u.DoUpdate())
+				// updating twice does not release the inflight twice
+				require.NoError(t, u.DoUpdate())
+			}
+			assert.Equal(t, uint64(0), l.Inflight())
+			assert.Equal(t, LimitOf(l), l.Remaining())
+		})
+	}
+}
+
+func TestAIMD(t *testing.T) {
+	a := NewAIMD().(*sampleLimiter)
+	e := a.estimator.(*aimd)
+
+	// grows only if at least half of the limitation is used
+	assert.InDelta(t, 50.0, e.estimate(50, time.Millisecond, 10, false), 0.001)
+	assert.InDelta(t, 51.0, e.estimate(50, time.Millisecond, 25, false), 0.001)
+	// shrinks on drops and timeouts
+	assert.InDelta(t, 45.0, e.estimate(50, time.Millisecond, 25, true), 0.001)
+	assert.InDelta(t, 45.0, e.estimate(50, 6*time.Second, 25, false), 0.001)
+	assert.InDelta(t, aimdMinLimit, e.estimate(aimdMinLimit, time.Millisecond, 1, true), 0.001)
+
+	u, err := a.Acquire()
+	require.NoError(t, err)
+	require.NoError(t, u.(DropUpdater).DoDrop())
+	assert.Equal(t, uint64(45), a.Limit())
+}
+
+func TestVegas(t *testing.T) {
+	v := &vegas{maxLimit: float64(maxLimitation), probeThreshold: 1 << 30}
+
+	// the first sample is the rtt without load
+	assert.InDelta(t, 50.0, v.estimate(50, 10*time.Millisecond, 50, false), 0.001)
+	assert.Equal(t, 10*time.Millisecond, v.rttNoLoad)
+
+	// no queue, grows by beta
+	assert.Greater(t, v.estimate(50, 10*time.Millisecond, 50, false), 55.0)
+	// long queue, shrinks
+	assert.Less(t, v.estimate(50, 20*time.Millisecond, 50, false), 50.0)
+	// app limited, unchanged
+	assert.InDelta(t, 50.0, v.estimate(50, 20*time.Millisecond, 10, false), 0.001)
+	// dropped, shrinks
+	assert.Less(t, v.estimate(50, 10*time.Millisecond, 10, true), 50.0)
+
+	// the rtt without load is probed again
+	v.probeThreshold = v.probeCount + 1
+	assert.InDelta(t, 50.0, v.estimate(50, 15*time.Millisecond, 50, false), 0.001)
+	assert.Equal(t, 15*time.Millisecond, v.rttNoLoad)
+}
+
+func TestGradient2(t *testing.T) {
+	g := NewGradient2().(*sampleLimiter).estimator.(*gradient2)
+
+	limit := 50.0
+	for i := 0; i < 100; i++ {
+		limit = g.estimate(limit, 10*time.Millisecond, uint64(limit), false)
+	}
+	// a stable rtt lets the limitation grow by the queue size
+	assert.Greater(t, limit, 50.0)
+
+	grown := limit
+	for i := 0; i < 20; i++ {
+		limit = g.estimate(limit, 100*time.Millisecond, uint64(limit), false)
+	}
+	// a much larger rtt than the long one shrinks the limitation
+	assert.Less(t, limit, grown)
+	assert.GreaterOrEqual(t, limit, float64(gradient2MinLimit))
+
+	// app limited, unchanged
+	assert.InDelta(t, limit, g.estimate(limit, 100*time.Millisecond, 1, false), 0.001)
+}
+
+func TestExpMovingAverage(t *testing.T) {
+	e := newExpMovingAverage(9, 2)
+	assert.InDelta(t, 10.0, e.add(10), 0.001)
+	assert.InDelta(t, 15.0, e.add(20), 0.001)
+	// factor is 2 / (9 + 1)
+	assert.InDelta(t, 15*0.8+30*0.2, e.add(30), 0.001)
+}
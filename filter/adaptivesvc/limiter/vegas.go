@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package limiter
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	vegasInitialLimit    = 50
+	vegasProbeMultiplier = 30
+)
+
+// vegas is the delay based algorithm of TCP Vegas. It tracks the rtt without load as the minimum rtt, and estimates
+// the queue size by limit * (1 - rttNoLoad / rtt). The limitation grows while the queue is short, and shrinks while
+// it is long or a request is dropped. The rtt without load is probed again every vegasProbeMultiplier * limit samples
+// in case the latency of the service drifts.
+type vegas struct {
+	maxLimit float64
+
+	rttNoLoad      time.Duration
+	probeCount     int
+	probeThreshold int
+}
+
+// NewVegas returns a Vegas limiter.
+func NewVegas() Limiter {
+	v := &vegas{maxLimit: float64(maxLimitation)}
+	v.resetProbe(vegasInitialLimit)
+	return newSampleLimiter(v, vegasInitialLimit)
+}
+
+func (v *vegas) resetProbe(limit float64) {
+	v.probeCount = 0
+	// the jitter keeps the probes of different limiters from happening together
+	v.probeThreshold = int((0.5 + rand.Float64()*0.5) * vegasProbeMultiplier * limit)
+}
+
+func (v *vegas) estimate(limit float64, rtt time.Duration, inflight uint64, dropped bool) float64 {
+	v.probeCount++
+	if v.probeCount >= v.probeThreshold {
+		VerboseDebugf("[vegas] probe the rtt without load, the current one is %s.", v.rttNoLoad)
+		v.resetProbe(limit)
+		v.rttNoLoad = rtt
+		return limit
+	}
+	if v.rttNoLoad == 0 || rtt < v.rttNoLoad {
+		v.rttNoLoad = rtt
+		return limit
+	}
+
+	log := math.Max(1, math.Log10(limit))
+	switch {
+	case dropped:
+		limit -= log
+	case float64(inflight)*2 < limit:
+		// the requests are not enough to judge whether the limitation is too small
+		return limit
+	default:
+		queueSize := math.Ceil(limit * (1 - float64(v.rttNoLoad)/float64(rtt)))
+		alpha, beta := 3*log, 6*log
+		switch {
+		case queueSize <= log:
+			limit += beta
+		case queueSize < alpha:
+			limit += log
+		case queueSize > beta:
+			limit -= log
+		}
+	}
+	return clamp(limit, 1, v.maxLimit)
+}
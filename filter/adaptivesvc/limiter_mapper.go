@@ -66,6 +66,12 @@ func (m *limiterMapper) newAndSetMethodLimiter(url *common.URL, methodName strin
 	switch limiterType {
 	case limiter.HillClimbingLimiter:
 		l = limiter.NewHillClimbing()
+	case limiter.Gradient2Limiter:
+		l = limiter.NewGradient2()
+	case limiter.VegasLimiter:
+		l = limiter.NewVegas()
+	case limiter.AIMDLimiter:
+		l = limiter.NewAIMD()
 	default:
 		return nil, ErrLimiterTypeNotFound
 	}
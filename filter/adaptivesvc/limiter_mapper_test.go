@@ -46,6 +46,15 @@ func TestLimiterMapper_newAndSetMethodLimiter(t *testing.T) {
 	l2, err := mapper.newAndSetMethodLimiter(url, methodName, limiter.HillClimbingLimiter)
 	require.NoError(t, err)
 	assert.Same(t, l, l2)
+
+	// Test creating the other limiters
+	for _, limiterType := range []int{limiter.Gradient2Limiter, limiter.VegasLimiter, limiter.AIMDLimiter} {
+		l, err = mapper.newAndSetMethodLimiter(url, limiter.LimiterName(limiterType), limiterType)
+		require.NoError(t, err)
+		assert.NotNil(t, l)
+	}
+	_, err = mapper.newAndSetMethodLimiter(url, "unknown", -1)
+	assert.ErrorIs(t, err, ErrLimiterTypeNotFound)
 }
 
 func TestLimiterMapper_getMethodLimiter(t *testing.T) {
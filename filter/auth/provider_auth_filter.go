@@ -22,14 +22,11 @@ import (
 	"sync"
 )
 
-import (
-	"github.com/dubbogo/gost/log/logger"
-)
-
 import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
+	dubbologger "dubbo.apache.org/dubbo-go/v3/logger"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
@@ -63,7 +60,7 @@ func (paf *authFilter) Invoke(ctx context.Context, invoker base.Invoker, invocat
 		return authenticator.Authenticate(invocation, url)
 	})
 	if err != nil {
-		logger.Errorf("auth the request: %v occur exception, cause: %s", invocation, err.Error())
+		dubbologger.CtxErrorf(ctx, "auth the request: %v occur exception, cause: %s", invocation, err.Error())
 		return &result.RPCResult{
 			Err: err,
 		}
@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package criticality defines the criticality of requests, the provider side limiters shed the requests of lower
+// criticality first when they are at capacity.
+//
+// The criticality is carried by the "criticality" attachment. It could be set for a call by client.WithCallCriticality,
+// for a method of a reference by the "criticality" method config, or inherited from the context, so the criticality of
+// a request is propagated to the downstream calls made with the context of its handler.
+package criticality
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+)
+
+// Criticality is the criticality of a request, a greater one is more critical.
+type Criticality int
+
+const (
+	Sheddable Criticality = iota
+	Default
+	Critical
+)
+
+const (
+	defaultSheddableThreshold = 0.8
+	defaultDefaultThreshold   = 1.0
+)
+
+type criticalityKey struct{}
+
+// String returns the name of the criticality.
+func (c Criticality) String() string {
+	switch c {
+	case Sheddable:
+		return constant.CriticalitySheddable
+	case Critical:
+		return constant.CriticalityCritical
+	default:
+		return constant.CriticalityDefault
+	}
+}
+
+// Parse parses the case-insensitive name of a criticality.
+func Parse(name string) (Criticality, bool) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case constant.CriticalitySheddable:
+		return Sheddable, true
+	case constant.CriticalityDefault:
+		return Default, true
+	case constant.CriticalityCritical:
+		return Critical, true
+	}
+	return Default, false
+}
+
+// NewContext returns a context carrying the criticality, it is used by the calls made with the context.
+func NewContext(ctx context.Context, c Criticality) context.Context {
+	return context.WithValue(ctx, criticalityKey{}, c)
+}
+
+// FromContext returns the criticality set by NewContext, or the one in the attachments of the context, which are
+// the attachments of the request handled by a provider.
+func FromContext(ctx context.Context) (Criticality, bool) {
+	if ctx == nil {
+		return Default, false
+	}
+	if c, ok := ctx.Value(criticalityKey{}).(Criticality); ok {
+		return c, true
+	}
+	switch atta := ctx.Value(constant.AttachmentKey).(type) {
+	case map[string]any:
+		return parseAttachment(atta[constant.CriticalityKey])
+	case map[string]string:
+		return Parse(atta[constant.CriticalityKey])
+	}
+	return Default, false
+}
+
+// FromInvocation returns the criticality of the invocation, it is Default if the attachment is absent or invalid.
+func FromInvocation(inv base.Invocation) Criticality {
+	if inv == nil {
+		return Default
+	}
+	raw, _ := inv.GetAttachment(constant.CriticalityKey)
+	c, _ := Parse(raw)
+	return c
+}
+
+// parseAttachment parses the attachment value, the attachments from triple headers are string slices.
+func parseAttachment(v any) (Criticality, bool) {
+	switch val := v.(type) {
+	case string:
+		return Parse(val)
+	case []string:
+		if len(val) > 0 {
+			return Parse(val[0])
+		}
+	}
+	return Default, false
+}
+
+// Threshold returns the fraction of the limits of the url available to the requests of the criticality. The limiters
+// reject the requests once the current load is over the fraction of the limits, so the requests of lower criticality
+// are shed first. The fractions of SHEDDABLE and DEFAULT requests are 0.8 and 1.0 by default, reserve a headroom for
+// the CRITICAL requests by configuring a lower fraction for DEFAULT.
+func Threshold(url *common.URL, c Criticality) float64 {
+	switch c {
+	case Sheddable:
+		return thresholdOf(url, constant.CriticalitySheddableThresholdKey, defaultSheddableThreshold)
+	case Default:
+		return thresholdOf(url, constant.CriticalityDefaultThresholdKey, defaultDefaultThreshold)
+	default:
+		return 1
+	}
+}
+
+func thresholdOf(url *common.URL, key string, d float64) float64 {
+	if url == nil {
+		return d
+	}
+	v := url.GetParam(key, "")
+	if v == "" {
+		return d
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f <= 0 || f > 1 {
+		return d
+	}
+	return f
+}
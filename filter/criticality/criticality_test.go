@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package criticality
+
+import (
+	"context"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+)
+
+func TestParse(t *testing.T) {
+	for name, expected := range map[string]Criticality{
+		"CRITICAL":    Critical,
+		"default":     Default,
+		" Sheddable ": Sheddable,
+	} {
+		c, ok := Parse(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, expected, c, name)
+	}
+	c, ok := Parse("urgent")
+	assert.False(t, ok)
+	assert.Equal(t, Default, c)
+	assert.Equal(t, constant.CriticalitySheddable, Sheddable.String())
+}
+
+func TestFromContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	c, ok := FromContext(NewContext(context.Background(), Critical))
+	assert.True(t, ok)
+	assert.Equal(t, Critical, c)
+
+	// the attachments of a triple request
+	ctx := context.WithValue(context.Background(), constant.AttachmentKey,
+		map[string]any{constant.CriticalityKey: []string{"SHEDDABLE"}})
+	c, ok = FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, Sheddable, c)
+
+	ctx = context.WithValue(context.Background(), constant.AttachmentKey,
+		map[string]string{constant.CriticalityKey: "CRITICAL"})
+	c, ok = FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, Critical, c)
+
+	// the explicit criticality overrides the inherited one
+	c, _ = FromContext(NewContext(ctx, Sheddable))
+	assert.Equal(t, Sheddable, c)
+}
+
+func TestFromInvocation(t *testing.T) {
+	assert.Equal(t, Default, FromInvocation(invocation.NewRPCInvocation("Get", nil, nil)))
+	assert.Equal(t, Sheddable, FromInvocation(invocation.NewRPCInvocation("Get", nil,
+		map[string]any{constant.CriticalityKey: []string{"SHEDDABLE"}})))
+	assert.Equal(t, Default, FromInvocation(invocation.NewRPCInvocation("Get", nil,
+		map[string]any{constant.CriticalityKey: "unknown"})))
+}
+
+func TestThreshold(t *testing.T) {
+	url, err := common.NewURL("tri://127.0.0.1:20000/com.test.Service")
+	require.NoError(t, err)
+	assert.Equal(t, 0.8, Threshold(url, Sheddable))
+	assert.Equal(t, 1.0, Threshold(url, Default))
+	assert.Equal(t, 1.0, Threshold(url, Critical))
+
+	url.SetParam(constant.CriticalitySheddableThresholdKey, "0.5")
+	url.SetParam(constant.CriticalityDefaultThresholdKey, "0.9")
+	assert.Equal(t, 0.5, Threshold(url, Sheddable))
+	assert.Equal(t, 0.9, Threshold(url, Default))
+
+	// invalid fractions fall back to the default ones
+	url.SetParam(constant.CriticalityDefaultThresholdKey, "1.5")
+	assert.Equal(t, 1.0, Threshold(url, Default))
+}
@@ -59,7 +59,11 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
+	"dubbo.apache.org/dubbo-go/v3/filter/criticality"
 	_ "dubbo.apache.org/dubbo-go/v3/filter/handler"
+	dubbologger "dubbo.apache.org/dubbo-go/v3/logger"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsRpc "dubbo.apache.org/dubbo-go/v3/metrics/rpc"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
@@ -112,7 +116,7 @@ func (f *executeLimitFilter) Invoke(ctx context.Context, invoker base.Invoker, i
 
 	limitRate, err := strconv.ParseInt(limitRateConfig, 0, 0)
 	if err != nil {
-		logger.Errorf("The configuration of execute.limit is invalid: %s", limitRateConfig)
+		dubbologger.CtxErrorf(ctx, "The configuration of execute.limit is invalid: %s", limitRateConfig)
 		return &result.RPCResult{}
 	}
 
@@ -126,8 +130,11 @@ func (f *executeLimitFilter) Invoke(ctx context.Context, invoker base.Invoker, i
 
 	concurrentCount := state.(*ExecuteState).increase()
 	defer state.(*ExecuteState).decrease()
-	if concurrentCount > limitRate {
-		logger.Errorf("The invocation was rejected due to over the execute limitation, url: %s ", ivkURL.String())
+	// the requests of lower criticality are limited to a fraction of the limitation, so they are shed first
+	threshold := criticality.Threshold(ivkURL, criticality.FromInvocation(invocation))
+	if float64(concurrentCount) > float64(limitRate)*threshold {
+		dubbologger.CtxErrorf(ctx, "The invocation was rejected due to over the execute limitation, url: %s ", ivkURL.String())
+		metrics.Publish(metricsRpc.NewLimitEvent(invoker, invocation))
 		rejectedHandlerConfig := ivkURL.GetParam(methodConfigPrefix+constant.ExecuteRejectedExecutionHandlerKey,
 			ivkURL.GetParam(constant.ExecuteRejectedExecutionHandlerKey, constant.DefaultKey))
 		rejectedExecutionHandler, err := extension.GetRejectedExecutionHandler(rejectedHandlerConfig)
@@ -32,6 +32,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
 
 func TestFilterInvokeIgnored(t *testing.T) {
@@ -83,3 +84,37 @@ func TestFilterInvoke(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.NoError(t, result.Error())
 }
+
+func TestFilterInvokeShedByCriticality(t *testing.T) {
+	methodName := "hello2"
+	invokeUrl := common.NewURLWithOptions(
+		common.WithParams(url.Values{}),
+		common.WithParamsValue(constant.InterfaceKey, methodName),
+		common.WithParamsValue(constant.ExecuteLimitKey, "2"),
+		common.WithParamsValue(constant.CriticalitySheddableThresholdKey, "0.5"),
+	)
+	limitFilter := newFilter().(*executeLimitFilter)
+	state, _ := limitFilter.executeState.LoadOrStore(invokeUrl.ServiceKey(), &ExecuteState{})
+	// a request is in progress
+	state.(*ExecuteState).increase()
+	defer state.(*ExecuteState).decrease()
+
+	var invoked []string
+	invoker := &recordInvoker{BaseInvoker: base.NewBaseInvoker(invokeUrl), invoked: &invoked}
+	for _, c := range []string{constant.CriticalitySheddable, constant.CriticalityDefault} {
+		invoc := invocation.NewRPCInvocation(methodName, []any{"OK"}, map[string]any{constant.CriticalityKey: c})
+		limitFilter.Invoke(context.Background(), invoker, invoc)
+	}
+	assert.Equal(t, []string{constant.CriticalityDefault}, invoked)
+}
+
+type recordInvoker struct {
+	*base.BaseInvoker
+	invoked *[]string
+}
+
+func (r *recordInvoker) Invoke(_ context.Context, inv base.Invocation) result.Result {
+	c, _ := inv.GetAttachment(constant.CriticalityKey)
+	*r.invoked = append(*r.invoked, c)
+	return &result.RPCResult{}
+}
@@ -35,4 +35,5 @@ import (
 	_ "dubbo.apache.org/dubbo-go/v3/filter/token"
 	_ "dubbo.apache.org/dubbo-go/v3/filter/tps"
 	_ "dubbo.apache.org/dubbo-go/v3/filter/tracing"
+	_ "dubbo.apache.org/dubbo-go/v3/filter/wasm"
 )
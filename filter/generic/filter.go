@@ -34,6 +34,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
+	dubbologger "dubbo.apache.org/dubbo-go/v3/logger"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
@@ -79,11 +80,11 @@ func (f *genericFilter) Invoke(ctx context.Context, invoker base.Invoker, inv ba
 			// use the default generalizer(MapGeneralizer)
 			typ, err := g.GetType(arg)
 			if err != nil {
-				logger.Errorf("failed to get type, %v", err)
+				dubbologger.CtxErrorf(ctx, "failed to get type, %v", err)
 			}
 			obj, err := g.Generalize(arg)
 			if err != nil {
-				logger.Errorf("generalization failed, %v", err)
+				dubbologger.CtxErrorf(ctx, "generalization failed, %v", err)
 				return invoker.Invoke(ctx, inv)
 			}
 			types = append(types, typ)
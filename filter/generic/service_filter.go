@@ -25,8 +25,6 @@ import (
 import (
 	hessian "github.com/apache/dubbo-go-hessian2"
 
-	"github.com/dubbogo/gost/log/logger"
-
 	perrors "github.com/pkg/errors"
 )
 
@@ -35,6 +33,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
+	dubbologger "dubbo.apache.org/dubbo-go/v3/logger"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
@@ -71,7 +70,7 @@ func (f *genericServiceFilter) Invoke(ctx context.Context, invoker base.Invoker,
 	types := inv.Arguments()[1]
 	args := inv.Arguments()[2].([]hessian.Object)
 
-	logger.Debugf(`received a generic invocation:
+	dubbologger.CtxDebugf(ctx, `received a generic invocation:
 		MethodName: %s,
 		Types: %s,
 		Args: %s
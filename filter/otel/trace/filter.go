@@ -69,6 +69,10 @@ func (f *otelServerFilter) OnResponse(ctx context.Context, result result.Result,
 }
 
 func (f *otelServerFilter) Invoke(ctx context.Context, invoker base.Invoker, invocation base.Invocation) result.Result {
+	if IsStream(invocation) {
+		// streams are traced by the stream interceptor of triple over their whole lifetime
+		return invoker.Invoke(ctx, invocation)
+	}
 	attachments := invocation.Attachments()
 	bags, spanCtx := Extract(ctx, attachments, f.Propagators)
 	ctx = baggage.ContextWithBaggage(ctx, bags)
@@ -115,6 +119,10 @@ func (f *otelClientFilter) OnResponse(ctx context.Context, result result.Result,
 }
 
 func (f *otelClientFilter) Invoke(ctx context.Context, invoker base.Invoker, invocation base.Invocation) result.Result {
+	if IsStream(invocation) {
+		// streams are traced by the stream interceptor of triple over their whole lifetime
+		return invoker.Invoke(ctx, invocation)
+	}
 	tracer := f.TracerProvider.Tracer(
 		constant.TraceScopeName,
 		trace.WithInstrumentationVersion(constant.Version),
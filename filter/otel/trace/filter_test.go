@@ -33,6 +33,7 @@ import (
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
@@ -569,6 +570,7 @@ func Test_otelServerFilter_Invoke(t *testing.T) {
 	invocation.EXPECT().MethodName().Return("otel").AnyTimes()
 	invocation.EXPECT().SetAttachment(gomock.Any(), gomock.Any()).Return().AnyTimes()
 	invocation.EXPECT().Attachments().Return(map[string]any{}).AnyTimes()
+	invocation.EXPECT().GetAttribute(constant.CallTypeKey).Return(constant.CallUnary, true).AnyTimes()
 
 	tests := []struct {
 		name   string
@@ -615,6 +617,7 @@ func Test_otelClientFilter_Invoke(t *testing.T) {
 	invocation.EXPECT().MethodName().Return("otel").AnyTimes()
 	invocation.EXPECT().SetAttachment(gomock.Any(), gomock.Any()).Return().AnyTimes()
 	invocation.EXPECT().Attachments().Return(map[string]any{}).AnyTimes()
+	invocation.EXPECT().GetAttribute(constant.CallTypeKey).Return(constant.CallUnary, true).AnyTimes()
 
 	tests := []struct {
 		name   string
@@ -28,4 +28,8 @@ var (
 	RPCNameMessage         = RPCNameKey.String("message")
 	RPCMessageTypeSent     = RPCMessageTypeKey.String("SENT")
 	RPCMessageTypeReceived = RPCMessageTypeKey.String("RECEIVED")
+
+	RPCMessageUncompressedSizeKey = attribute.Key("message.uncompressed_size")
+	// RPCCancelReasonKey records why a stream was canceled, from the cause of its context
+	RPCCancelReasonKey = attribute.Key("rpc.cancel.reason")
 )
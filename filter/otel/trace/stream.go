@@ -0,0 +1,250 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"google.golang.org/protobuf/proto"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+// NewStreamInterceptor returns a triple interceptor tracing the streams of service. The span of a
+// stream covers its whole lifetime rather than the opening of it like the trace filters do, with an
+// event per message sent or received and the final status of the stream. Triple streams are framed
+// as gRPC, so the trace context is propagated by the W3C headers of the request which gRPC peers
+// understand as well. Unary calls are left to the trace filters.
+func NewStreamInterceptor(service string) tri.Interceptor {
+	return &streamInterceptor{
+		service:        service,
+		Propagators:    otel.GetTextMapPropagator(),
+		TracerProvider: otel.GetTracerProvider(),
+	}
+}
+
+// IsStream returns whether the invocation opens a triple stream, which is traced by the stream
+// interceptor instead of the trace filters.
+func IsStream(invocation base.Invocation) bool {
+	callType, _ := invocation.GetAttribute(constant.CallTypeKey)
+	switch callType {
+	case constant.CallClientStream, constant.CallServerStream, constant.CallBidiStream:
+		return true
+	default:
+		return false
+	}
+}
+
+type streamInterceptor struct {
+	service        string
+	Propagators    propagation.TextMapPropagator
+	TracerProvider trace.TracerProvider
+}
+
+func (i *streamInterceptor) WrapUnary(next tri.UnaryFunc) tri.UnaryFunc {
+	return next
+}
+
+func (i *streamInterceptor) WrapUnaryHandler(next tri.UnaryHandlerFunc) tri.UnaryHandlerFunc {
+	return next
+}
+
+func (i *streamInterceptor) WrapStreamingClient(next tri.StreamingClientFunc) tri.StreamingClientFunc {
+	return func(ctx context.Context, spec tri.Spec) tri.StreamingClientConn {
+		ctx, span := i.start(ctx, spec, trace.SpanKindClient)
+		conn := next(ctx, spec)
+		i.Propagators.Inject(ctx, propagation.HeaderCarrier(conn.RequestHeader()))
+		span.SetAttributes(semconv.NetSockPeerAddr(conn.Peer().Addr))
+		return &tracedClientConn{StreamingClientConn: conn, stream: newStreamSpan(ctx, span)}
+	}
+}
+
+func (i *streamInterceptor) WrapStreamingHandler(next tri.StreamingHandlerFunc) tri.StreamingHandlerFunc {
+	return func(ctx context.Context, conn tri.StreamingHandlerConn) error {
+		ctx = i.Propagators.Extract(ctx, propagation.HeaderCarrier(conn.RequestHeader()))
+		ctx, span := i.start(ctx, conn.Spec(), trace.SpanKindServer)
+		span.SetAttributes(semconv.NetSockPeerAddr(conn.Peer().Addr))
+		stream := newStreamSpan(ctx, span)
+		err := next(ctx, &tracedHandlerConn{StreamingHandlerConn: conn, stream: stream})
+		stream.end(err)
+		return err
+	}
+}
+
+func (i *streamInterceptor) start(ctx context.Context, spec tri.Spec, kind trace.SpanKind) (context.Context, trace.Span) {
+	tracer := i.TracerProvider.Tracer(
+		constant.TraceScopeName,
+		trace.WithInstrumentationVersion(constant.Version),
+	)
+	procedure := strings.TrimPrefix(spec.Procedure, "/")
+	method := procedure[strings.LastIndexByte(procedure, '/')+1:]
+	return tracer.Start(ctx, procedure,
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(
+			semconv.RPCSystemApacheDubbo,
+			semconv.RPCService(i.service),
+			semconv.RPCMethod(method),
+		),
+	)
+}
+
+// streamSpan is the span of a stream, which ends once with the final status of the stream, or
+// when the context of the stream is done.
+type streamSpan struct {
+	ctx      context.Context
+	span     trace.Span
+	sent     atomic.Int64
+	received atomic.Int64
+	once     sync.Once
+	stop     func() bool
+}
+
+func newStreamSpan(ctx context.Context, span trace.Span) *streamSpan {
+	s := &streamSpan{ctx: ctx, span: span}
+	s.stop = context.AfterFunc(ctx, func() {
+		s.end(context.Cause(ctx))
+	})
+	return s
+}
+
+func (s *streamSpan) event(typ attribute.KeyValue, id int64, msg any) {
+	attrs := []attribute.KeyValue{typ, RPCMessageIDKey.Int64(id)}
+	if m, ok := msg.(proto.Message); ok {
+		attrs = append(attrs, RPCMessageUncompressedSizeKey.Int(proto.Size(m)))
+	}
+	s.span.AddEvent("message", trace.WithAttributes(attrs...))
+}
+
+func (s *streamSpan) sentMessage(msg any) {
+	s.event(RPCMessageTypeSent, s.sent.Add(1), msg)
+}
+
+func (s *streamSpan) receivedMessage(msg any) {
+	s.event(RPCMessageTypeReceived, s.received.Add(1), msg)
+}
+
+// end records the status of err, an error wrapping io.EOF means the stream finished successfully.
+func (s *streamSpan) end(err error) {
+	s.once.Do(func() {
+		s.stop()
+		code := tri.Code(0)
+		switch {
+		case s.ctx.Err() != nil:
+			// the stream is over because its context is done, whatever error it ends with
+			err = context.Cause(s.ctx)
+			s.span.SetAttributes(RPCCancelReasonKey.String(err.Error()))
+			code = tri.CodeCanceled
+			if errors.Is(s.ctx.Err(), context.DeadlineExceeded) {
+				code = tri.CodeDeadlineExceeded
+			}
+		case err != nil && !errors.Is(err, io.EOF):
+			code = tri.CodeOf(err)
+		}
+		s.span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int64(int64(code)))
+		if code == 0 {
+			s.span.SetStatus(codes.Ok, codes.Ok.String())
+		} else {
+			s.span.SetStatus(codes.Error, err.Error())
+		}
+		s.span.End()
+	})
+}
+
+type tracedClientConn struct {
+	tri.StreamingClientConn
+	stream *streamSpan
+
+	mu  sync.Mutex
+	err error
+}
+
+func (c *tracedClientConn) Send(msg any) error {
+	err := c.StreamingClientConn.Send(msg)
+	if err == nil {
+		c.stream.sentMessage(msg)
+	}
+	return err
+}
+
+func (c *tracedClientConn) Receive(msg any) error {
+	err := c.StreamingClientConn.Receive(msg)
+	if err != nil {
+		// the stream is over once receiving fails, at the end of the stream or with its error
+		c.setErr(err)
+		c.stream.end(err)
+		return err
+	}
+	c.stream.receivedMessage(msg)
+	return nil
+}
+
+func (c *tracedClientConn) CloseResponse() error {
+	err := c.StreamingClientConn.CloseResponse()
+	c.mu.Lock()
+	last := c.err
+	c.mu.Unlock()
+	c.stream.end(last)
+	return err
+}
+
+func (c *tracedClientConn) setErr(err error) {
+	c.mu.Lock()
+	if c.err == nil {
+		c.err = err
+	}
+	c.mu.Unlock()
+}
+
+type tracedHandlerConn struct {
+	tri.StreamingHandlerConn
+	stream *streamSpan
+}
+
+func (c *tracedHandlerConn) Send(msg any) error {
+	err := c.StreamingHandlerConn.Send(msg)
+	if err == nil {
+		c.stream.sentMessage(msg)
+	}
+	return err
+}
+
+func (c *tracedHandlerConn) Receive(msg any) error {
+	err := c.StreamingHandlerConn.Receive(msg)
+	if err == nil {
+		c.stream.receivedMessage(msg)
+	}
+	return err
+}
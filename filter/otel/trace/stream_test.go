@@ -0,0 +1,280 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/filter"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+const testService = "org.apache.dubbo.Echo"
+
+func newTestInterceptor() (tri.Interceptor, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	return &streamInterceptor{
+		service:        testService,
+		Propagators:    propagation.TraceContext{},
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	}, recorder
+}
+
+// newTestServer serves an echoing bidi stream, a server stream failing after its first message,
+// and a server stream blocking until it is canceled.
+func newTestServer(t *testing.T, interceptor tri.Interceptor) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/"+testService+"/Echo", tri.NewBidiStreamHandler(
+		"/"+testService+"/Echo",
+		func(_ context.Context, stream *tri.BidiStream) error {
+			for {
+				msg := new(wrapperspb.StringValue)
+				if err := stream.Receive(msg); err != nil {
+					if errors.Is(err, io.EOF) {
+						return nil
+					}
+					return err
+				}
+				if err := stream.Send(msg); err != nil {
+					return err
+				}
+			}
+		},
+		tri.WithInterceptors(interceptor),
+	))
+	mux.Handle("/"+testService+"/Fail", tri.NewServerStreamHandler(
+		"/"+testService+"/Fail",
+		func() any { return new(wrapperspb.StringValue) },
+		func(_ context.Context, req *tri.Request, stream *tri.ServerStream) error {
+			if err := stream.Send(req.Msg); err != nil {
+				return err
+			}
+			return tri.NewError(tri.CodeNotFound, errors.New("no more"))
+		},
+		tri.WithInterceptors(interceptor),
+	))
+	mux.Handle("/"+testService+"/Block", tri.NewServerStreamHandler(
+		"/"+testService+"/Block",
+		func() any { return new(wrapperspb.StringValue) },
+		func(ctx context.Context, _ *tri.Request, _ *tri.ServerStream) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		tri.WithInterceptors(interceptor),
+	))
+	server := httptest.NewUnstartedServer(mux)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func spanByKind(t *testing.T, spans []sdktrace.ReadOnlySpan, kind trace.SpanKind) sdktrace.ReadOnlySpan {
+	for _, span := range spans {
+		if span.SpanKind() == kind {
+			return span
+		}
+	}
+	require.Failf(t, "missing span", "no %s span in %d spans", kind, len(spans))
+	return nil
+}
+
+func attr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func messageEvents(span sdktrace.ReadOnlySpan, typ attribute.KeyValue) (sizes []int64) {
+	for _, event := range span.Events() {
+		if event.Name != RPCNameMessage.Value.AsString() {
+			continue
+		}
+		var isType bool
+		var size int64
+		for _, kv := range event.Attributes {
+			switch kv.Key {
+			case RPCMessageTypeKey:
+				isType = kv.Value == typ.Value
+			case RPCMessageUncompressedSizeKey:
+				size = kv.Value.AsInt64()
+			}
+		}
+		if isType {
+			sizes = append(sizes, size)
+		}
+	}
+	return sizes
+}
+
+func TestStreamInterceptorBidiStream(t *testing.T) {
+	for name, opts := range map[string][]tri.ClientOption{
+		"grpc": nil,
+		"json": {tri.WithProtoJSON()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			interceptor, recorder := newTestInterceptor()
+			server := newTestServer(t, interceptor)
+			cli := tri.NewClient(server.Client(), server.URL+"/"+testService,
+				append(opts, tri.WithInterceptors(interceptor))...)
+
+			stream, err := cli.CallBidiStream(context.Background(), "Echo")
+			require.NoError(t, err)
+			msgs := []*wrapperspb.StringValue{wrapperspb.String("hello"), wrapperspb.String("dubbo-go")}
+			for _, msg := range msgs {
+				require.NoError(t, stream.Send(msg))
+			}
+			require.NoError(t, stream.CloseRequest())
+			for range msgs {
+				require.NoError(t, stream.Receive(new(wrapperspb.StringValue)))
+			}
+			// the span lasts until the end of the stream
+			assert.Len(t, recorder.Ended(), 1)
+			assert.ErrorIs(t, stream.Receive(new(wrapperspb.StringValue)), io.EOF)
+			require.NoError(t, stream.CloseResponse())
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 2)
+			clientSpan, serverSpan := spanByKind(t, spans, trace.SpanKindClient), spanByKind(t, spans, trace.SpanKindServer)
+			assert.Equal(t, testService+"/Echo", clientSpan.Name())
+			assert.Equal(t, "Echo", attr(clientSpan, semconv.RPCMethodKey).AsString())
+			assert.Equal(t, testService, attr(serverSpan, semconv.RPCServiceKey).AsString())
+			// the trace context is propagated by the request headers
+			assert.Equal(t, clientSpan.SpanContext().TraceID(), serverSpan.Parent().TraceID())
+			assert.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+
+			sizes := []int64{int64(proto.Size(msgs[0])), int64(proto.Size(msgs[1]))}
+			for _, span := range spans {
+				assert.Equal(t, sizes, messageEvents(span, RPCMessageTypeSent))
+				assert.Equal(t, sizes, messageEvents(span, RPCMessageTypeReceived))
+				assert.Equal(t, codes.Ok, span.Status().Code)
+				assert.Equal(t, int64(0), attr(span, semconv.RPCGRPCStatusCodeKey).AsInt64())
+			}
+		})
+	}
+}
+
+func TestStreamInterceptorServerStreamError(t *testing.T) {
+	interceptor, recorder := newTestInterceptor()
+	server := newTestServer(t, interceptor)
+	cli := tri.NewClient(server.Client(), server.URL+"/"+testService, tri.WithInterceptors(interceptor))
+
+	stream, err := cli.CallServerStream(context.Background(), tri.NewRequest(wrapperspb.String("hello")), "Fail")
+	require.NoError(t, err)
+	assert.True(t, stream.Receive(new(wrapperspb.StringValue)))
+	assert.False(t, stream.Receive(new(wrapperspb.StringValue)))
+	assert.Equal(t, tri.CodeNotFound, tri.CodeOf(stream.Err()))
+	require.NoError(t, stream.Close())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	for _, span := range spans {
+		assert.Equal(t, codes.Error, span.Status().Code)
+		assert.Equal(t, int64(tri.CodeNotFound), attr(span, semconv.RPCGRPCStatusCodeKey).AsInt64())
+	}
+	assert.Len(t, messageEvents(spanByKind(t, spans, trace.SpanKindClient), RPCMessageTypeReceived), 1)
+}
+
+func TestStreamInterceptorCancel(t *testing.T) {
+	interceptor, recorder := newTestInterceptor()
+	server := newTestServer(t, interceptor)
+	cli := tri.NewClient(server.Client(), server.URL+"/"+testService, tri.WithInterceptors(interceptor))
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	stream, err := cli.CallServerStream(ctx, tri.NewRequest(wrapperspb.String("hello")), "Block")
+	require.NoError(t, err)
+	cancel(errors.New("caller gone"))
+	assert.False(t, stream.Receive(new(wrapperspb.StringValue)))
+	_ = stream.Close()
+
+	// the server notices the cancellation asynchronously
+	require.Eventually(t, func() bool { return len(recorder.Ended()) == 2 }, 5e9, 1e7)
+	spans := recorder.Ended()
+	clientSpan := spanByKind(t, spans, trace.SpanKindClient)
+	assert.Equal(t, "caller gone", attr(clientSpan, RPCCancelReasonKey).AsString())
+	assert.Equal(t, int64(tri.CodeCanceled), attr(clientSpan, semconv.RPCGRPCStatusCodeKey).AsInt64())
+	serverSpan := spanByKind(t, spans, trace.SpanKindServer)
+	assert.Equal(t, codes.Error, serverSpan.Status().Code)
+	assert.NotEmpty(t, attr(serverSpan, RPCCancelReasonKey).AsString())
+}
+
+func TestIsStream(t *testing.T) {
+	inv := invocation.NewRPCInvocation("Echo", nil, nil)
+	assert.False(t, IsStream(inv))
+	inv.SetAttribute(constant.CallTypeKey, constant.CallUnary)
+	assert.False(t, IsStream(inv))
+	for _, callType := range []string{constant.CallClientStream, constant.CallServerStream, constant.CallBidiStream} {
+		inv.SetAttribute(constant.CallTypeKey, callType)
+		assert.True(t, IsStream(inv))
+	}
+}
+
+func TestOtelFilterSkipsStream(t *testing.T) {
+	url, _ := common.NewURL("tri://127.0.0.1:20000/" + testService)
+	invoker := base.NewBaseInvoker(url)
+
+	for _, newFilter := range []func(trace.TracerProvider) filter.Filter{
+		func(provider trace.TracerProvider) filter.Filter {
+			return &otelServerFilter{Propagators: propagation.TraceContext{}, TracerProvider: provider}
+		},
+		func(provider trace.TracerProvider) filter.Filter {
+			return &otelClientFilter{Propagators: propagation.TraceContext{}, TracerProvider: provider}
+		},
+	} {
+		recorder := tracetest.NewSpanRecorder()
+		f := newFilter(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		inv := invocation.NewRPCInvocation("Echo", nil, nil)
+		inv.SetAttribute(constant.CallTypeKey, constant.CallBidiStream)
+		f.Invoke(context.Background(), invoker, inv)
+		assert.Empty(t, recorder.Ended())
+
+		inv.SetAttribute(constant.CallTypeKey, constant.CallUnary)
+		f.Invoke(context.Background(), invoker, inv)
+		assert.Len(t, recorder.Ended(), 1)
+	}
+}
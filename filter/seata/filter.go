@@ -24,14 +24,11 @@ import (
 	"sync"
 )
 
-import (
-	"github.com/dubbogo/gost/log/logger"
-)
-
 import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
+	dubbologger "dubbo.apache.org/dubbo-go/v3/logger"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
@@ -64,7 +61,7 @@ func newSeataFilter() filter.Filter {
 func (f *seataFilter) Invoke(ctx context.Context, invoker base.Invoker, invocation base.Invocation) result.Result {
 	xid := invocation.GetAttachmentWithDefaultValue(string(SEATA_XID), "")
 	if len(strings.TrimSpace(xid)) > 0 {
-		logger.Debugf("Method: %v,Xid: %v", invocation.MethodName(), xid)
+		dubbologger.CtxDebugf(ctx, "Method: %v,Xid: %v", invocation.MethodName(), xid)
 		return invoker.Invoke(context.WithValue(ctx, SEATA_XID, xid), invocation)
 	}
 	return invoker.Invoke(ctx, invocation)
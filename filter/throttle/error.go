@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package throttle
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrThrottled is the error matched by all the requests rejected locally by the throttle filter, it tells the
+// requests rejected by the consumer itself apart from the ones failed by the providers.
+var ErrThrottled = errors.New("request is throttled by the consumer")
+
+// ThrottledError is returned when a request is rejected locally by the client side adaptive throttling.
+type ThrottledError struct {
+	Service     string
+	Probability float64
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s: service %s is overloaded, rejection probability %.2f", ErrThrottled, e.Service, e.Probability)
+}
+
+// Is makes errors.Is(err, ErrThrottled) work for a ThrottledError.
+func (e *ThrottledError) Is(target error) bool {
+	return target == ErrThrottled
+}
+
+// IsThrottled returns whether the error is a rejection of the client side adaptive throttling.
+func IsThrottled(err error) bool {
+	return errors.Is(err, ErrThrottled)
+}
@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package throttle implements the client side adaptive throttling described in the Google SRE book. The consumer
+// tracks how many requests it sends and how many of them are accepted by the providers, once the providers are
+// overloaded, the consumer rejects requests locally with a probability growing with the rejection ratio, so the
+// overloaded providers are not flooded by the requests which would be rejected anyway.
+package throttle
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/cluster/utils"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/filter"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsRpc "dubbo.apache.org/dubbo-go/v3/metrics/rpc"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+var (
+	once     sync.Once
+	throttle *throttleFilter
+)
+
+func init() {
+	extension.SetFilter(constant.ThrottleFilterKey, newThrottleFilter)
+}
+
+// throttleFilter is a consumer filter, the throttlers are shared by all the providers of a service.
+type throttleFilter struct {
+	throttlers sync.Map // service key -> *throttler
+}
+
+func newThrottleFilter() filter.Filter {
+	if throttle == nil {
+		once.Do(func() {
+			throttle = &throttleFilter{}
+		})
+	}
+	return throttle
+}
+
+func (f *throttleFilter) getThrottler(invoker base.Invoker) *throttler {
+	url := invoker.GetURL()
+	key := url.ServiceKey()
+	if t, ok := f.throttlers.Load(key); ok {
+		return t.(*throttler)
+	}
+	t, _ := f.throttlers.LoadOrStore(key, newThrottler(url))
+	return t.(*throttler)
+}
+
+// Invoke rejects the invocation locally by the rejection probability of the service.
+func (f *throttleFilter) Invoke(ctx context.Context, invoker base.Invoker, inv base.Invocation) result.Result {
+	t := f.getThrottler(invoker)
+	allowed, p := t.allow()
+	if t.shouldReport(p) {
+		metrics.Publish(metricsRpc.NewThrottleEvent(invoker, inv, p, !allowed))
+	}
+	if !allowed {
+		return &result.RPCResult{Err: &ThrottledError{Service: invoker.GetURL().ServiceKey(), Probability: p}}
+	}
+	return invoker.Invoke(ctx, inv)
+}
+
+// OnResponse counts the invocation as accepted unless the provider is overloaded.
+func (f *throttleFilter) OnResponse(_ context.Context, res result.Result, invoker base.Invoker, _ base.Invocation) result.Result {
+	err := res.Error()
+	if IsThrottled(err) || isOverloaded(err) {
+		return res
+	}
+	f.getThrottler(invoker).accept()
+	return res
+}
+
+// isOverloaded returns whether the error means the request is rejected or dropped by an overloaded provider.
+func isOverloaded(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || utils.DoesAdaptiveServiceReachLimitation(err) {
+		return true
+	}
+	switch tri.CodeOf(err) {
+	case tri.CodeDeadlineExceeded, tri.CodeResourceExhausted, tri.CodeUnavailable:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package throttle
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+import (
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/mock"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+func TestThrottler(t *testing.T) {
+	u, err := common.NewURL("tri://127.0.0.1:20000/com.test.Service?" + constant.ThrottleKKey + "=2")
+	require.NoError(t, err)
+	th := newThrottler(u)
+	assert.Equal(t, 2.0, th.k)
+
+	// all the requests are accepted
+	for i := 0; i < 100; i++ {
+		allowed, p := th.allow()
+		assert.True(t, allowed)
+		assert.Zero(t, p)
+		th.accept()
+	}
+	// requests are not throttled until they are more than K times of the accepts
+	for i := 0; i < 100; i++ {
+		th.allow()
+	}
+	assert.Zero(t, th.probability())
+	for i := 0; i < 200; i++ {
+		th.allow()
+	}
+	// (400 - 2 * 100) / 401
+	assert.InDelta(t, 200.0/401, th.probability(), 1e-9)
+}
+
+func TestThrottlerInvalidConfig(t *testing.T) {
+	u, err := common.NewURL("tri://127.0.0.1:20000/com.test.Service?" + constant.ThrottleKKey + "=0.5&" +
+		constant.ThrottleWindowSecondsKey + "=-1")
+	require.NoError(t, err)
+	th := newThrottler(u)
+	assert.Equal(t, defaultK, th.k)
+}
+
+func TestThrottleFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	u, err := common.NewURL("tri://127.0.0.1:20000/com.test.ThrottleService?side=consumer")
+	require.NoError(t, err)
+	invoker := mock.NewMockInvoker(ctrl)
+	invoker.EXPECT().GetURL().Return(u).AnyTimes()
+	overloaded := tri.NewError(tri.CodeResourceExhausted, errors.New("overloaded"))
+	invoker.EXPECT().Invoke(gomock.Any(), gomock.Any()).Return(&result.RPCResult{Err: overloaded}).AnyTimes()
+
+	f := newThrottleFilter()
+	var throttled, failed int
+	for i := 0; i < 1000; i++ {
+		inv := invocation.NewRPCInvocation("Get", nil, nil)
+		res := f.Invoke(context.Background(), invoker, inv)
+		res = f.OnResponse(context.Background(), res, invoker, inv)
+		if IsThrottled(res.Error()) {
+			throttled++
+			var te *ThrottledError
+			require.ErrorAs(t, res.Error(), &te)
+			assert.Equal(t, u.ServiceKey(), te.Service)
+			assert.Positive(t, te.Probability)
+		} else {
+			failed++
+			assert.False(t, IsThrottled(res.Error()))
+		}
+	}
+	// nothing is accepted, so almost all the requests are rejected locally
+	assert.Greater(t, throttled, 900)
+	assert.Positive(t, failed)
+}
+
+func TestIsOverloaded(t *testing.T) {
+	assert.False(t, isOverloaded(nil))
+	assert.False(t, isOverloaded(errors.New("biz error")))
+	assert.False(t, isOverloaded(tri.NewError(tri.CodeBizError, errors.New("biz error"))))
+	assert.True(t, isOverloaded(context.DeadlineExceeded))
+	assert.True(t, isOverloaded(tri.NewError(tri.CodeUnavailable, errors.New("unavailable"))))
+	assert.True(t, isOverloaded(tri.NewError(tri.CodeDeadlineExceeded, errors.New("timeout"))))
+}
@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package throttle
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/metrics/util/aggregate"
+)
+
+const (
+	defaultK             = 2.0
+	defaultWindowSeconds = 120
+	defaultWindowPanes   = 12
+)
+
+// throttler tracks the requests and the accepts of a service over a sliding window. Once the requests are more than
+// K times of the accepts, it rejects a request by the probability max(0, (requests - K * accepts) / (requests + 1)).
+type throttler struct {
+	k        float64
+	requests *aggregate.TimeWindowCounter
+	accepts  *aggregate.TimeWindowCounter
+	// throttling is whether the last reported probability is positive
+	throttling atomic.Bool
+}
+
+func newThrottler(url *common.URL) *throttler {
+	k := defaultK
+	if v := url.GetParam(constant.ThrottleKKey, ""); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 1 {
+			k = f
+		} else {
+			logger.Warnf("[Throttle] invalid %s: %s, it should be a number not less than 1, use default %v",
+				constant.ThrottleKKey, v, defaultK)
+		}
+	}
+	windowSeconds := url.GetParamInt(constant.ThrottleWindowSecondsKey, defaultWindowSeconds)
+	if windowSeconds <= 0 {
+		windowSeconds = defaultWindowSeconds
+	}
+	windowPanes := url.GetParamInt(constant.ThrottleWindowPanesKey, defaultWindowPanes)
+	if windowPanes <= 0 {
+		windowPanes = defaultWindowPanes
+	}
+	return &throttler{
+		k:        k,
+		requests: aggregate.NewTimeWindowCounter(int(windowPanes), windowSeconds),
+		accepts:  aggregate.NewTimeWindowCounter(int(windowPanes), windowSeconds),
+	}
+}
+
+// probability returns the probability to reject a request.
+func (t *throttler) probability() float64 {
+	requests := t.requests.Count()
+	accepts := t.accepts.Count()
+	return math.Max(0, (requests-t.k*accepts)/(requests+1))
+}
+
+// allow counts a request, and returns whether it is allowed with the rejection probability before counting it.
+func (t *throttler) allow() (bool, float64) {
+	p := t.probability()
+	t.requests.Inc()
+	return p == 0 || rand.Float64() >= p, p
+}
+
+// shouldReport returns whether the probability should be reported, a zero probability is only reported once after
+// the throttling ends.
+func (t *throttler) shouldReport(p float64) bool {
+	if p > 0 {
+		t.throttling.Store(true)
+		return true
+	}
+	return t.throttling.CompareAndSwap(true, false)
+}
+
+// accept counts a request accepted by the provider.
+func (t *throttler) accept() {
+	t.accepts.Inc()
+}
@@ -47,6 +47,9 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/filter"
 	_ "dubbo.apache.org/dubbo-go/v3/filter/handler"
 	_ "dubbo.apache.org/dubbo-go/v3/filter/tps/limiter"
+	dubbologger "dubbo.apache.org/dubbo-go/v3/logger"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsRpc "dubbo.apache.org/dubbo-go/v3/metrics/rpc"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
@@ -86,7 +89,8 @@ func (t *tpsLimitFilter) Invoke(ctx context.Context, invoker base.Invoker, invoc
 		if allow {
 			return invoker.Invoke(ctx, invocation)
 		}
-		logger.Errorf("The invocation was rejected due to over the limiter limitation, url: %s ", url.String())
+		dubbologger.CtxErrorf(ctx, "The invocation was rejected due to over the limiter limitation, url: %s ", url.String())
+		metrics.Publish(metricsRpc.NewLimitEvent(invoker, invocation))
 		rejectedExecutionHandler, err := extension.GetRejectedExecutionHandler(rejectedExeHandler)
 		if err != nil {
 			logger.Warn(err)
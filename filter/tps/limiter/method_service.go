@@ -20,12 +20,15 @@ package limiter
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 )
 
 import (
 	"github.com/dubbogo/gost/log/logger"
 
+	lru "github.com/hashicorp/golang-lru"
+
 	"github.com/modern-go/concurrent"
 )
 
@@ -34,11 +37,15 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
+	"dubbo.apache.org/dubbo-go/v3/filter/criticality"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 )
 
 const (
 	name = "method-service"
+	// maxCallerStates bounds the per-caller strategies, the callers are chosen by the requests, so the least
+	// recently used ones are evicted and start over once they come back.
+	maxCallerStates = 10000
 )
 
 func init() {
@@ -57,6 +64,11 @@ func init() {
  *   tps.limiter: "method-service" or "default" # the name of MethodServiceTpsLimiter. It's the default limiter too.
  *   tps.limit.interval: 5000 # interval, the time unit is ms
  *   tps.limit.rate: 300 # the max value in the interval. <0 means that the service will not be limited.
+ *   params:
+ *     # optional, limits the requests of each caller separately. It is "application" for the consumer application,
+ *     # "attachment:<name>" for an attachment value, or "argument:<index>" for a request argument.
+ *     # Only the most recently seen callers are tracked, the others start over once they come back.
+ *     tps.limit.key: "application"
  *   methods:
  *    - name: "GetUser"
  *      tps.interval: 3000
@@ -114,6 +126,8 @@ func init() {
  */
 type MethodServiceTpsLimiter struct {
 	tpsState *concurrent.Map
+	// callerState holds the strategies of the requests limited by callers, see tps.limit.key
+	callerState *lru.Cache
 }
 
 // IsAllowable based on method-level and service-level.
@@ -137,11 +151,25 @@ func (limiter MethodServiceTpsLimiter) IsAllowable(url *common.URL, invocation b
 		limitTarget = limitTarget + "#" + invocation.MethodName()
 	}
 
+	// the requests of each caller are limited separately if the key is configured
+	caller, byCaller := callerKey(url, invocation)
+	if byCaller {
+		limitTarget = limitTarget + "@" + caller
+	}
+
 	// looking up the limiter from 'cache'
-	limitState, found := limiter.tpsState.Load(limitTarget)
+	var (
+		limitState any
+		found      bool
+	)
+	if byCaller {
+		limitState, found = limiter.callerState.Get(limitTarget)
+	} else {
+		limitState, found = limiter.tpsState.Load(limitTarget)
+	}
 	if found {
 		// the limiter has been cached, we return its result
-		return limitState.(filter.TpsLimitStrategy).IsAllowable()
+		return isAllowable(url, invocation, limitState.(filter.TpsLimitStrategy))
 	}
 
 	// we could not find the limiter, and try to create one.
@@ -173,10 +201,63 @@ func (limiter MethodServiceTpsLimiter) IsAllowable(url *common.URL, invocation b
 		return true
 	}
 
+	var strategy filter.TpsLimitStrategy
+	if keyedCreator, ok := limitStateCreator.(filter.KeyedTpsLimitStrategyCreator); ok {
+		strategy = keyedCreator.CreateByKey(url, limitTarget, int(limitRate), int(limitInterval))
+	} else {
+		strategy = limitStateCreator.Create(int(limitRate), int(limitInterval))
+	}
 	// we using loadOrStore to ensure thread-safe
-	limitState, _ = limiter.tpsState.LoadOrStore(limitTarget, limitStateCreator.Create(int(limitRate), int(limitInterval)))
+	if byCaller {
+		if previous, ok, _ := limiter.callerState.PeekOrAdd(limitTarget, strategy); ok {
+			strategy = previous.(filter.TpsLimitStrategy)
+		}
+		return isAllowable(url, invocation, strategy)
+	}
+	limitState, _ = limiter.tpsState.LoadOrStore(limitTarget, strategy)
+
+	return isAllowable(url, invocation, limitState.(filter.TpsLimitStrategy))
+}
+
+// callerKey returns the key of the caller selected by tps.limit.key, the method-level configuration overrides the
+// service-level one. It returns false if the requests are not limited by callers.
+func callerKey(url *common.URL, invocation base.Invocation) (string, bool) {
+	keyConfig := url.GetMethodParam(invocation.MethodName(), constant.TPSLimitKeyKey,
+		url.GetParam(constant.TPSLimitKeyKey, ""))
+	if keyConfig == "" {
+		return "", false
+	}
+	kind, name, _ := strings.Cut(keyConfig, ":")
+	switch kind {
+	case constant.ApplicationKey:
+		app, _ := invocation.GetAttachment(constant.RemoteApplicationKey)
+		return app, true
+	case "attachment":
+		value, _ := invocation.GetAttachment(name)
+		return value, true
+	case "argument":
+		index, err := strconv.Atoi(name)
+		if err != nil || index < 0 {
+			logger.Warnf("Found error configuration value of %s: %s, ignores it", constant.TPSLimitKeyKey, keyConfig)
+			return "", false
+		}
+		if args := invocation.Arguments(); index < len(args) {
+			return fmt.Sprint(args[index]), true
+		}
+		return "", true
+	}
+	logger.Warnf("Found error configuration value of %s: %s, ignores it", constant.TPSLimitKeyKey, keyConfig)
+	return "", false
+}
 
-	return limitState.(filter.TpsLimitStrategy).IsAllowable()
+// isAllowable limits the requests of lower criticality to a fraction of the rate if the strategy supports it, so
+// they are shed before the more critical ones.
+func isAllowable(url *common.URL, invocation base.Invocation, strategy filter.TpsLimitStrategy) bool {
+	threshold := criticality.Threshold(url, criticality.FromInvocation(invocation))
+	if fractional, ok := strategy.(filter.FractionalTpsLimitStrategy); ok && threshold < 1 {
+		return fractional.IsAllowableWithin(threshold)
+	}
+	return strategy.IsAllowable()
 }
 
 // getLimitConfig will try to fetch the configuration from url.
@@ -216,9 +297,16 @@ var (
 // GetMethodServiceTpsLimiter will return an MethodServiceTpsLimiter instance.
 func GetMethodServiceTpsLimiter() filter.TpsLimiter {
 	methodServiceTpsLimiterOnce.Do(func() {
-		methodServiceTpsLimiterInstance = &MethodServiceTpsLimiter{
-			tpsState: concurrent.NewMap(),
-		}
+		methodServiceTpsLimiterInstance = newMethodServiceTpsLimiter(maxCallerStates)
 	})
 	return methodServiceTpsLimiterInstance
 }
+
+func newMethodServiceTpsLimiter(maxCallers int) *MethodServiceTpsLimiter {
+	// the size is always positive, so it never fails
+	callerState, _ := lru.New(maxCallers)
+	return &MethodServiceTpsLimiter{
+		tpsState:    concurrent.NewMap(),
+		callerState: callerState,
+	}
+}
@@ -156,3 +156,77 @@ func (creator *mockStrategyCreator) Create(rate int, interval int) filter.TpsLim
 	assert.Equal(creator.t, creator.interval, interval)
 	return creator.strategy
 }
+
+type keyedStrategyCreator struct {
+	keys []string
+}
+
+func (creator *keyedStrategyCreator) Create(rate int, interval int) filter.TpsLimitStrategy {
+	return creator.CreateByKey(nil, constant.DefaultKey, rate, interval)
+}
+
+func (creator *keyedStrategyCreator) CreateByKey(_ *common.URL, key string, rate int, interval int) filter.TpsLimitStrategy {
+	creator.keys = append(creator.keys, key)
+	return &countStrategy{rate: rate}
+}
+
+type countStrategy struct {
+	rate, count int
+}
+
+func (s *countStrategy) IsAllowable() bool {
+	s.count++
+	return s.count <= s.rate
+}
+
+func TestMethodServiceTpsLimiterImplIsAllowableByCaller(t *testing.T) {
+	creator := &keyedStrategyCreator{}
+	extension.SetTpsLimitStrategy("keyed", creator)
+
+	invokeUrl := common.NewURLWithOptions(
+		common.WithParams(url.Values{}),
+		common.WithPath("com.test.CallerService"),
+		common.WithParamsValue(constant.InterfaceKey, "com.test.CallerService"),
+		common.WithParamsValue(constant.TPSLimitRateKey, "1"),
+		common.WithParamsValue(constant.TPSLimitIntervalKey, "60000"),
+		common.WithParamsValue(constant.TPSLimitStrategyKey, "keyed"),
+		common.WithParamsValue(constant.TPSLimitKeyKey, constant.ApplicationKey),
+		common.WithParamsValue("methods.Search."+constant.TPSLimitKeyKey, "argument:0"))
+
+	limiter := GetMethodServiceTpsLimiter()
+	newInvocation := func(method, app string, args ...any) *invocation.RPCInvocation {
+		return invocation.NewRPCInvocation(method, args, map[string]any{constant.RemoteApplicationKey: app})
+	}
+	assert.True(t, limiter.IsAllowable(invokeUrl, newInvocation("Get", "app1")))
+	assert.False(t, limiter.IsAllowable(invokeUrl, newInvocation("Get", "app1")))
+	assert.True(t, limiter.IsAllowable(invokeUrl, newInvocation("Get", "app2")))
+	assert.True(t, limiter.IsAllowable(invokeUrl, newInvocation("Search", "app1", "tenant1")))
+	assert.False(t, limiter.IsAllowable(invokeUrl, newInvocation("Search", "app2", "tenant1")))
+
+	service := invokeUrl.ServiceKey()
+	assert.Equal(t, []string{service + "@app1", service + "@app2", service + "@tenant1"}, creator.keys)
+}
+
+func TestMethodServiceTpsLimiterBoundsCallerState(t *testing.T) {
+	invokeUrl := common.NewURLWithOptions(
+		common.WithParams(url.Values{}),
+		common.WithPath("com.test.BoundedService"),
+		common.WithParamsValue(constant.InterfaceKey, "com.test.BoundedService"),
+		common.WithParamsValue(constant.TPSLimitRateKey, "1"),
+		common.WithParamsValue(constant.TPSLimitIntervalKey, "60000"),
+		common.WithParamsValue(constant.TPSLimitStrategyKey, strategy.FixedWindowKey),
+		common.WithParamsValue(constant.TPSLimitKeyKey, "argument:0"))
+
+	limiter := newMethodServiceTpsLimiter(2)
+	newInvocation := func(caller string) *invocation.RPCInvocation {
+		return invocation.NewRPCInvocation("Get", []any{caller}, nil)
+	}
+	for _, caller := range []string{"c1", "c2", "c3"} {
+		assert.True(t, limiter.IsAllowable(invokeUrl, newInvocation(caller)))
+		assert.False(t, limiter.IsAllowable(invokeUrl, newInvocation(caller)))
+	}
+	// the least recently used caller is evicted and starts over
+	assert.Equal(t, 2, limiter.callerState.Len())
+	assert.True(t, limiter.IsAllowable(invokeUrl, newInvocation("c1")))
+	assert.False(t, limiter.IsAllowable(invokeUrl, newInvocation("c3")))
+}
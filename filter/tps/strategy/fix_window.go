@@ -68,6 +68,16 @@ type FixedWindowTpsLimitStrategy struct {
 // IsAllowable determines if the requests over the TPS limit within the interval.
 // It is not thread-safe.
 func (impl *FixedWindowTpsLimitStrategy) IsAllowable() bool {
+	return impl.isAllowable(impl.rate)
+}
+
+// IsAllowableWithin determines if the requests over the fraction of the TPS limit within the interval.
+// It is not thread-safe.
+func (impl *FixedWindowTpsLimitStrategy) IsAllowableWithin(fraction float64) bool {
+	return impl.isAllowable(int32(float64(impl.rate) * fraction))
+}
+
+func (impl *FixedWindowTpsLimitStrategy) isAllowable(rate int32) bool {
 	current := time.Now().UnixNano()
 	if impl.timestamp+impl.interval < current {
 		// it's a new window
@@ -77,7 +87,7 @@ func (impl *FixedWindowTpsLimitStrategy) IsAllowable() bool {
 		impl.count = 0
 	}
 	// this operation is thread-safe, but count + 1 may be overflow
-	return atomic.AddInt32(&impl.count, 1) <= impl.rate
+	return atomic.AddInt32(&impl.count, 1) <= rate
 }
 
 type fixedWindowStrategyCreator struct{}
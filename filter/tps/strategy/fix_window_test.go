@@ -42,3 +42,14 @@ func TestFixedWindowTpsLimitStrategyImplIsAllowable(t *testing.T) {
 	assert.True(t, strategy.IsAllowable())
 	assert.False(t, strategy.IsAllowable())
 }
+
+func TestFixedWindowTpsLimitStrategyImplIsAllowableWithin(t *testing.T) {
+	creator := &fixedWindowStrategyCreator{}
+	strategy := creator.Create(10, 60000).(*FixedWindowTpsLimitStrategy)
+	for i := 0; i < 5; i++ {
+		assert.True(t, strategy.IsAllowableWithin(0.5))
+	}
+	// the requests over the fraction are rejected, while the others are still allowed
+	assert.False(t, strategy.IsAllowableWithin(0.5))
+	assert.True(t, strategy.IsAllowable())
+}
@@ -0,0 +1,202 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+/*
+Package redis provides a TPS limit strategy sharing the limitation among all the providers through Redis.
+
+The limitation is a token bucket kept in Redis, it is refilled at tps.limit.rate tokens per tps.limit.interval, and
+updated by a Lua script atomically. Redis 5.0 or later is required. The providers fall back to limit the requests
+locally when Redis is unreachable.
+
+	"UserProvider":
+	  interface : "com.ikurento.user.UserProvider"
+	  ... # other configuration
+	  tps.limiter: "method-service"
+	  tps.limit.strategy: "redis"
+	  tps.limit.interval: 1000
+	  tps.limit.rate: 300
+	  params:
+	    tps.limit.key: "application" # optional, limit each consumer application separately
+	    tps.limit.redis.address: "127.0.0.1:6379"
+	    tps.limit.redis.timeout: "100ms"
+	    tps.limit.redis.fallback-rate: 100 # optional, the local limitation when Redis is unreachable
+*/
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/filter"
+	_ "dubbo.apache.org/dubbo-go/v3/filter/tps/strategy"
+)
+
+const (
+	// Key is the name of the strategy
+	Key = "redis"
+
+	defaultAddress   = "127.0.0.1:6379"
+	defaultTimeout   = "100ms"
+	defaultKeyPrefix = "dubbo:tps:"
+	// fallbackStrategy limits the requests locally when Redis is unreachable
+	fallbackStrategy = "slidingWindow"
+	// retryInterval is how long the requests are limited locally after Redis fails
+	retryInterval = time.Second
+)
+
+// tokenBucketScript takes a token from the bucket if the tokens left are more than the part of the capacity
+// unavailable to the request, it returns 1 if the request is allowed.
+//
+// KEYS[1]: the key of the bucket
+// ARGV[1]: the capacity of the bucket, the bucket is refilled at capacity tokens per interval
+// ARGV[2]: the interval in milliseconds
+// ARGV[3]: the fraction of the capacity available to the request
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local fraction = tonumber(ARGV[3])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'timestamp')
+local tokens = tonumber(bucket[1])
+local timestamp = tonumber(bucket[2])
+if tokens == nil or timestamp == nil then
+	tokens = capacity
+	timestamp = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - timestamp) * capacity / interval)
+local allowed = 0
+if tokens - 1 >= capacity * (1 - fraction) then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'timestamp', tostring(now))
+redis.call('PEXPIRE', KEYS[1], interval * 2)
+return allowed
+`)
+
+var clients sync.Map // address/username/db -> *redis.Client
+
+func init() {
+	extension.SetTpsLimitStrategy(Key, &strategyCreator{})
+}
+
+// TpsLimitStrategy limits the requests by a token bucket in Redis, it is thread-safe.
+type TpsLimitStrategy struct {
+	client   *redis.Client
+	key      string
+	rate     int
+	interval int
+	timeout  time.Duration
+	fallback filter.TpsLimitStrategy
+	// retryAt is the time in unix nanoseconds before which the requests are limited locally
+	retryAt atomic.Int64
+}
+
+// IsAllowable takes a token from the bucket in Redis.
+func (s *TpsLimitStrategy) IsAllowable() bool {
+	return s.IsAllowableWithin(1)
+}
+
+// IsAllowableWithin takes a token from the bucket in Redis if the tokens left are more than the part of the
+// capacity unavailable to the request.
+func (s *TpsLimitStrategy) IsAllowableWithin(fraction float64) bool {
+	if time.Now().UnixNano() < s.retryAt.Load() {
+		return s.isAllowableLocally(fraction)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	allowed, err := tokenBucketScript.Run(ctx, s.client, []string{s.key}, s.rate, s.interval, fraction).Int()
+	if err != nil {
+		s.retryAt.Store(time.Now().Add(retryInterval).UnixNano())
+		logger.Warnf("[TPS Redis] failed to limit %s by Redis, limits it locally in %s: %v", s.key, retryInterval, err)
+		return s.isAllowableLocally(fraction)
+	}
+	return allowed == 1
+}
+
+func (s *TpsLimitStrategy) isAllowableLocally(fraction float64) bool {
+	if fractional, ok := s.fallback.(filter.FractionalTpsLimitStrategy); ok && fraction < 1 {
+		return fractional.IsAllowableWithin(fraction)
+	}
+	return s.fallback.IsAllowable()
+}
+
+type strategyCreator struct{}
+
+// Create returns a TpsLimitStrategy connecting the default Redis address
+func (creator *strategyCreator) Create(rate int, interval int) filter.TpsLimitStrategy {
+	return creator.CreateByKey(common.NewURLWithOptions(), constant.DefaultKey, rate, interval)
+}
+
+// CreateByKey returns a TpsLimitStrategy connecting the Redis configured by the url, the strategies of the same key
+// share the token bucket.
+func (creator *strategyCreator) CreateByKey(url *common.URL, key string, rate int, interval int) filter.TpsLimitStrategy {
+	timeout := url.GetParamDuration(constant.TPSLimitRedisTimeoutKey, defaultTimeout)
+	fallbackRate := int(url.GetParamInt(constant.TPSLimitRedisFallbackRateKey, int64(rate)))
+	fallbackCreator, err := extension.GetTpsLimitStrategyCreator(fallbackStrategy)
+	if err != nil {
+		panic(err)
+	}
+	return &TpsLimitStrategy{
+		client:   getClient(url, timeout),
+		key:      url.GetParam(constant.TPSLimitRedisKeyPrefixKey, defaultKeyPrefix) + key,
+		rate:     rate,
+		interval: interval,
+		timeout:  timeout,
+		fallback: fallbackCreator.Create(fallbackRate, interval),
+	}
+}
+
+// getClient returns the client shared by the strategies connecting the same Redis.
+func getClient(url *common.URL, timeout time.Duration) *redis.Client {
+	opts := &redis.Options{
+		Addr:         url.GetParam(constant.TPSLimitRedisAddressKey, defaultAddress),
+		Username:     url.GetParam(constant.TPSLimitRedisUsernameKey, ""),
+		Password:     url.GetParam(constant.TPSLimitRedisPasswordKey, ""),
+		DB:           int(url.GetParamInt(constant.TPSLimitRedisDBKey, 0)),
+		DialTimeout:  timeout,
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+		// fall back to the local limitation instead of retrying
+		MaxRetries: -1,
+	}
+	clientKey := fmt.Sprintf("%s/%s/%d", opts.Addr, opts.Username, opts.DB)
+	if client, ok := clients.Load(clientKey); ok {
+		return client.(*redis.Client)
+	}
+	newClient := redis.NewClient(opts)
+	client, loaded := clients.LoadOrStore(clientKey, newClient)
+	if loaded {
+		_ = newClient.Close()
+	}
+	return client.(*redis.Client)
+}
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/filter"
+)
+
+func newStrategy(t *testing.T, m *miniredis.Miniredis, key string, rate, interval int, params ...string) filter.TpsLimitStrategy {
+	url := common.NewURLWithOptions(common.WithParamsValue(constant.TPSLimitRedisAddressKey, m.Addr()))
+	for i := 0; i+1 < len(params); i += 2 {
+		url.SetParam(params[i], params[i+1])
+	}
+	strategy := (&strategyCreator{}).CreateByKey(url, key, rate, interval)
+	require.IsType(t, &TpsLimitStrategy{}, strategy)
+	return strategy
+}
+
+func TestIsAllowable(t *testing.T) {
+	m := miniredis.RunT(t)
+	now := time.Now()
+	m.SetTime(now)
+
+	strategy := newStrategy(t, m, "com.test.Service", 3, 60000)
+	// another provider shares the bucket
+	another := newStrategy(t, m, "com.test.Service", 3, 60000)
+	assert.True(t, strategy.IsAllowable())
+	assert.True(t, another.IsAllowable())
+	assert.True(t, strategy.IsAllowable())
+	assert.False(t, another.IsAllowable())
+	assert.False(t, strategy.IsAllowable())
+
+	// the buckets of different keys are independent
+	assert.True(t, newStrategy(t, m, "com.test.Service@app", 3, 60000).IsAllowable())
+
+	// the bucket is refilled by the rate
+	m.SetTime(now.Add(20 * time.Second))
+	assert.True(t, strategy.IsAllowable())
+	assert.False(t, strategy.IsAllowable())
+	assert.True(t, m.Exists(defaultKeyPrefix+"com.test.Service"))
+}
+
+func TestIsAllowableWithin(t *testing.T) {
+	m := miniredis.RunT(t)
+	m.SetTime(time.Now())
+
+	strategy := newStrategy(t, m, "com.test.Service#Get", 10, 60000).(filter.FractionalTpsLimitStrategy)
+	for i := 0; i < 5; i++ {
+		assert.True(t, strategy.IsAllowableWithin(0.5))
+	}
+	assert.False(t, strategy.IsAllowableWithin(0.5))
+	// the reserved tokens are still available to the requests of higher criticality
+	assert.True(t, strategy.IsAllowable())
+}
+
+func TestFallback(t *testing.T) {
+	m := miniredis.RunT(t)
+	strategy := newStrategy(t, m, "com.test.Service", 10, 60000,
+		constant.TPSLimitRedisFallbackRateKey, "2", constant.TPSLimitRedisTimeoutKey, "50ms",
+		constant.TPSLimitRedisKeyPrefixKey, "fallback:")
+	assert.True(t, strategy.IsAllowable())
+	assert.True(t, m.Exists("fallback:com.test.Service"))
+
+	m.Close()
+	// the requests are limited locally by the fallback rate
+	assert.True(t, strategy.IsAllowable())
+	assert.True(t, strategy.IsAllowable())
+	assert.False(t, strategy.IsAllowable())
+}
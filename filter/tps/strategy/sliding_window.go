@@ -57,12 +57,22 @@ type SlidingWindowTpsLimitStrategy struct {
 // IsAllowable determines whether the number of requests within the time window overs the threshold
 // It is thread-safe.
 func (impl *SlidingWindowTpsLimitStrategy) IsAllowable() bool {
+	return impl.isAllowable(impl.rate)
+}
+
+// IsAllowableWithin determines whether the number of requests within the time window overs the fraction of the threshold
+// It is thread-safe.
+func (impl *SlidingWindowTpsLimitStrategy) IsAllowableWithin(fraction float64) bool {
+	return impl.isAllowable(int(float64(impl.rate) * fraction))
+}
+
+func (impl *SlidingWindowTpsLimitStrategy) isAllowable(rate int) bool {
 	impl.mutex.Lock()
 	defer impl.mutex.Unlock()
 	// quick path
 	size := impl.queue.Len()
 	current := time.Now().UnixNano()
-	if size < impl.rate {
+	if size < rate {
 		impl.queue.PushBack(current)
 		return true
 	}
@@ -76,7 +86,7 @@ func (impl *SlidingWindowTpsLimitStrategy) IsAllowable() bool {
 		impl.queue.Remove(timestamp)
 		timestamp = impl.queue.Front()
 	}
-	if impl.queue.Len() < impl.rate {
+	if impl.queue.Len() < rate {
 		impl.queue.PushBack(current)
 		return true
 	}
@@ -44,3 +44,15 @@ func TestSlidingWindowTpsLimitStrategyImplIsAllowable(t *testing.T) {
 	assert.True(t, strategy.IsAllowable())
 	assert.False(t, strategy.IsAllowable())
 }
+
+func TestSlidingWindowTpsLimitStrategyImplIsAllowableWithin(t *testing.T) {
+	creator := &slidingWindowStrategyCreator{}
+	strategy := creator.Create(10, 60000).(*SlidingWindowTpsLimitStrategy)
+	for i := 0; i < 8; i++ {
+		assert.True(t, strategy.IsAllowableWithin(0.8))
+	}
+	assert.False(t, strategy.IsAllowableWithin(0.8))
+	assert.True(t, strategy.IsAllowable())
+	assert.True(t, strategy.IsAllowable())
+	assert.False(t, strategy.IsAllowable())
+}
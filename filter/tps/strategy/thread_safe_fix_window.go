@@ -59,6 +59,13 @@ func (impl *ThreadSafeFixedWindowTpsLimitStrategy) IsAllowable() bool {
 	return impl.fixedWindow.IsAllowable()
 }
 
+// IsAllowableWithin implements thread-safe then run the FixedWindowTpsLimitStrategy with the fraction of the rate
+func (impl *ThreadSafeFixedWindowTpsLimitStrategy) IsAllowableWithin(fraction float64) bool {
+	impl.mutex.Lock()
+	defer impl.mutex.Unlock()
+	return impl.fixedWindow.IsAllowableWithin(fraction)
+}
+
 type threadSafeFixedWindowStrategyCreator struct {
 	fixedWindowStrategyCreator *fixedWindowStrategyCreator
 }
@@ -17,6 +17,10 @@
 
 package filter
 
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+)
+
 // TpsLimitStrategy is the interface which defines how to do the TPS limiting in method level.
 //
 // IsAllowable will return true if this invocation is not over limitation.
@@ -24,6 +28,15 @@ type TpsLimitStrategy interface {
 	IsAllowable() bool
 }
 
+// FractionalTpsLimitStrategy is the TpsLimitStrategy able to limit the requests to a fraction of the rate, it is
+// used to shed the requests of lower criticality before the rate is reached.
+//
+// IsAllowableWithin will return true if this invocation is not over the fraction of the limitation.
+type FractionalTpsLimitStrategy interface {
+	TpsLimitStrategy
+	IsAllowableWithin(fraction float64) bool
+}
+
 // TpsLimitStrategyCreator is the interface which creates TpsLimitStrategy.
 type TpsLimitStrategyCreator interface {
 	// Create will create an instance of TpsLimitStrategy
@@ -33,3 +46,13 @@ type TpsLimitStrategyCreator interface {
 	// which means that the limiter limitation is 100 times per 1000ms (100/1000ms)
 	Create(limit int, interval int) TpsLimitStrategy
 }
+
+// KeyedTpsLimitStrategyCreator is the TpsLimitStrategyCreator which creates the TpsLimitStrategy by the url of the
+// service and the key of the limit target. It is implemented by the strategies sharing their states among the
+// providers, e.g. in a remote storage.
+//
+// CreateByKey will be used instead of Create if the creator implements this interface.
+type KeyedTpsLimitStrategyCreator interface {
+	TpsLimitStrategyCreator
+	CreateByKey(url *common.URL, key string, limit int, interval int) TpsLimitStrategy
+}
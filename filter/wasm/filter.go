@@ -0,0 +1,210 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package wasm provides a filter running the on_request and on_response hooks of a WebAssembly module.
+package wasm
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+	"weak"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/filter"
+	dubbologger "dubbo.apache.org/dubbo-go/v3/logger"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	"dubbo.apache.org/dubbo-go/v3/wasm"
+)
+
+const (
+	onRequest  = "on_request"
+	onResponse = "on_response"
+
+	// loadRetryBackoff is the time before loading the module of an invoker url again once it failed
+	loadRetryBackoff = 30 * time.Second
+)
+
+var (
+	once       sync.Once
+	wasmFilter *Filter
+
+	// modules caches the module of every invoker url, an entry is removed once its url is collected
+	modules sync.Map // weak.Pointer[common.URL] -> *moduleEntry
+)
+
+// moduleEntry is the module resolved for an invoker url
+type moduleEntry struct {
+	module  *wasm.Module
+	retryAt time.Time // the time to load the module again if it failed to load, zero if it is loaded
+}
+
+func init() {
+	extension.SetFilter(constant.WasmFilterKey, newFilter)
+}
+
+// Filter runs the hooks of the module configured by the `wasm.filter.*` params of the invoker url.
+// A module failing to run never fails the invocation, the invocation goes on as if the filter is absent.
+//
+//	"UserProvider":
+//	  interface: "com.ikurento.user.UserProvider"
+//	  filter: "wasm"
+//	  params:
+//	    wasm.filter.path: "/path/to/filter.wasm"
+//	    wasm.filter.timeout: "50ms"
+type Filter struct{}
+
+func newFilter() filter.Filter {
+	if wasmFilter == nil {
+		once.Do(func() {
+			wasmFilter = &Filter{}
+		})
+	}
+	return wasmFilter
+}
+
+// Invoke runs on_request, a non-zero return value or status rejects the invocation.
+func (f *Filter) Invoke(ctx context.Context, invoker base.Invoker, invocation base.Invocation) result.Result {
+	m := moduleOf(invoker)
+	if m == nil || !m.HasFunction(onRequest) {
+		return invoker.Invoke(ctx, invocation)
+	}
+
+	state := &wasm.CallState{
+		Method:      invocation.MethodName(),
+		Attachments: wasm.CopyAttachments(invocation.Attachments()),
+		Status:      wasm.StatusOK,
+	}
+	ret, err := m.Call(ctx, onRequest, state)
+	if err != nil {
+		dubbologger.CtxWarnf(ctx, "[Wasm Filter] %v", err)
+		return invoker.Invoke(ctx, invocation)
+	}
+	if ret != 0 || state.Status != wasm.StatusOK {
+		status := state.Status
+		if status == wasm.StatusOK {
+			status = ret
+		}
+		return &result.RPCResult{Err: perrors.Errorf("[Wasm Filter] invocation of %s is rejected by module %s with status %d",
+			invocation.MethodName(), m.Name(), status)}
+	}
+	for k, v := range changedAttachments(invocation.Attachments(), state.Attachments) {
+		invocation.SetAttachment(k, v)
+	}
+	return invoker.Invoke(ctx, invocation)
+}
+
+// OnResponse runs on_response, the guest may change the result attachments and the result status.
+// Setting a non-zero status on a successful result turns it into an error, setting StatusOK on a
+// failed result clears the error.
+func (f *Filter) OnResponse(ctx context.Context, res result.Result, invoker base.Invoker, invocation base.Invocation) result.Result {
+	m := moduleOf(invoker)
+	if m == nil || !m.HasFunction(onResponse) {
+		return res
+	}
+
+	status := wasm.StatusOK
+	if res.Error() != nil {
+		status = wasm.StatusError
+	}
+	state := &wasm.CallState{
+		Method:      invocation.MethodName(),
+		Attachments: wasm.CopyAttachments(res.Attachments()),
+		Status:      status,
+	}
+	if _, err := m.Call(ctx, onResponse, state); err != nil {
+		dubbologger.CtxWarnf(ctx, "[Wasm Filter] %v", err)
+		return res
+	}
+	for k, v := range changedAttachments(res.Attachments(), state.Attachments) {
+		res.AddAttachment(k, v)
+	}
+	if state.Status == status {
+		return res
+	}
+	if state.Status == wasm.StatusOK {
+		res.SetError(nil)
+	} else {
+		res.SetError(perrors.Errorf("[Wasm Filter] result of %s is rejected by module %s with status %d",
+			invocation.MethodName(), m.Name(), state.Status))
+	}
+	return res
+}
+
+// moduleOf resolves the module of the invoker url once, a failed one is resolved again after loadRetryBackoff.
+func moduleOf(invoker base.Invoker) *wasm.Module {
+	url := invoker.GetURL()
+	if url == nil {
+		return nil
+	}
+	key := weak.Make(url)
+	if v, ok := modules.Load(key); ok {
+		if entry := v.(*moduleEntry); entry.retryAt.IsZero() || time.Now().Before(entry.retryAt) {
+			return entry.module
+		}
+	}
+
+	entry := &moduleEntry{}
+	m, err := wasm.ModuleFromURL(url, constant.WasmFilterPrefix)
+	if err != nil {
+		logger.Warnf("[Wasm Filter] load module failed, retry in %s: %v", loadRetryBackoff, err)
+		entry.retryAt = time.Now().Add(loadRetryBackoff)
+	} else {
+		entry.module = m
+	}
+	if prev, loaded := modules.Swap(key, entry); loaded {
+		// the concurrent callers resolve the same module, which is referred once per url
+		prev.(*moduleEntry).module.Release()
+	} else {
+		runtime.AddCleanup(url, releaseModule, key)
+	}
+	return entry.module
+}
+
+// releaseModule removes the module of the collected invoker url
+func releaseModule(key weak.Pointer[common.URL]) {
+	if v, ok := modules.LoadAndDelete(key); ok {
+		v.(*moduleEntry).module.Release()
+	}
+}
+
+// changedAttachments returns the attachments set by the guest, which are always strings.
+func changedAttachments(origin, attachments map[string]any) map[string]string {
+	res := make(map[string]string)
+	for k, v := range attachments {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if old, ok := origin[k].(string); !ok || old != s {
+			res[k] = s
+		}
+	}
+	return res
+}
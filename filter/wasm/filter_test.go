@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wasm
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"weak"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+)
+
+func wasmURL(file string) *common.URL {
+	return common.NewURLWithOptions(
+		common.WithParams(url.Values{}),
+		common.WithParamsValue(constant.WasmFilterPrefix+constant.WasmPathSuffix, filepath.Join("..", "..", "wasm", "testdata", file)))
+}
+
+func TestFilterInvoke(t *testing.T) {
+	f := newFilter()
+	invoker := base.NewBaseInvoker(wasmURL("filter.wasm"))
+
+	inv := invocation.NewRPCInvocation("GetUser", []any{"OK"}, map[string]any{})
+	res := f.Invoke(context.Background(), invoker, inv)
+	require.NoError(t, res.Error())
+	value, ok := inv.GetAttachment("wasm")
+	assert.True(t, ok)
+	assert.Equal(t, "yes", value)
+
+	inv = invocation.NewRPCInvocation("GetUser", []any{"OK"}, map[string]any{"deny": []string{"true"}})
+	res = f.Invoke(context.Background(), invoker, inv)
+	require.Error(t, res.Error())
+	assert.Contains(t, res.Error().Error(), "status 403")
+}
+
+func TestFilterOnResponse(t *testing.T) {
+	f := newFilter()
+	invoker := base.NewBaseInvoker(wasmURL("filter.wasm"))
+	inv := invocation.NewRPCInvocation("GetUser", []any{"OK"}, map[string]any{})
+
+	res := f.OnResponse(context.Background(), &result.RPCResult{Err: errors.New("failed")}, invoker, inv)
+	require.NoError(t, res.Error())
+	assert.Equal(t, "yes", res.Attachment("wasm", ""))
+}
+
+func TestFilterFailOpen(t *testing.T) {
+	f := newFilter()
+
+	// no module configured
+	inv := invocation.NewRPCInvocation("GetUser", []any{"OK"}, map[string]any{})
+	res := f.Invoke(context.Background(), base.NewBaseInvoker(common.NewURLWithOptions()), inv)
+	require.NoError(t, res.Error())
+
+	// the module runs out of time
+	u := wasmURL("loop.wasm")
+	u.SetParam(constant.WasmFilterPrefix+constant.WasmTimeoutSuffix, "10ms")
+	res = f.Invoke(context.Background(), base.NewBaseInvoker(u), inv)
+	require.NoError(t, res.Error())
+}
+
+func TestModuleOfCache(t *testing.T) {
+	invoker := base.NewBaseInvoker(wasmURL("filter.wasm"))
+	m := moduleOf(invoker)
+	require.NotNil(t, m)
+	assert.Same(t, m, moduleOf(invoker))
+
+	// the failure is cached until the backoff elapses
+	absent := base.NewBaseInvoker(wasmURL("absent.wasm"))
+	assert.Nil(t, moduleOf(absent))
+	v, ok := modules.Load(weak.Make(absent.GetURL()))
+	require.True(t, ok)
+	failed := v.(*moduleEntry)
+	assert.False(t, failed.retryAt.IsZero())
+	assert.Nil(t, moduleOf(absent))
+	v, _ = modules.Load(weak.Make(absent.GetURL()))
+	assert.Same(t, failed, v)
+}
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	clusterbase "dubbo.apache.org/dubbo-go/v3/cluster/cluster/base"
+	"dubbo.apache.org/dubbo-go/v3/cluster/utils"
+	"dubbo.apache.org/dubbo-go/v3/filter/throttle"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+// statusClientClosedRequest is the status of the requests canceled by the clients, following nginx.
+const statusClientClosedRequest = 499
+
+// requestError is the error of a bad HTTP request, which is not sent to the providers
+type requestError struct {
+	status int
+	err    error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+func badRequest(err error) error {
+	return &requestError{status: http.StatusBadRequest, err: err}
+}
+
+// readBody reads the body up to maxBodySize bytes
+func readBody(r *http.Request, maxBodySize int64) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return nil, badRequest(perrors.WithMessage(err, "read body"))
+	}
+	if int64(len(data)) > maxBodySize {
+		return nil, &requestError{status: http.StatusRequestEntityTooLarge, err: perrors.Errorf("body exceeds %d bytes", maxBodySize)}
+	}
+	return data, nil
+}
+
+// triStatus maps the triple codes to the HTTP status codes, following the HTTP mapping of gRPC.
+var triStatus = map[tri.Code]int{
+	tri.CodeCanceled:           statusClientClosedRequest,
+	tri.CodeInvalidArgument:    http.StatusBadRequest,
+	tri.CodeDeadlineExceeded:   http.StatusGatewayTimeout,
+	tri.CodeNotFound:           http.StatusNotFound,
+	tri.CodeAlreadyExists:      http.StatusConflict,
+	tri.CodePermissionDenied:   http.StatusForbidden,
+	tri.CodeResourceExhausted:  http.StatusTooManyRequests,
+	tri.CodeFailedPrecondition: http.StatusBadRequest,
+	tri.CodeAborted:            http.StatusConflict,
+	tri.CodeOutOfRange:         http.StatusBadRequest,
+	tri.CodeUnimplemented:      http.StatusNotImplemented,
+	tri.CodeInternal:           http.StatusInternalServerError,
+	tri.CodeUnavailable:        http.StatusServiceUnavailable,
+	tri.CodeDataLoss:           http.StatusInternalServerError,
+	tri.CodeUnauthenticated:    http.StatusUnauthorized,
+}
+
+// httpStatus returns the HTTP status code of the error of a call. The errors which are not triple errors, such as
+// the exceptions of the dubbo providers, are bad gateway.
+func httpStatus(err error) int {
+	var reqErr *requestError
+	switch {
+	case errors.As(err, &reqErr):
+		return reqErr.status
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest
+	case throttle.IsThrottled(err), utils.DoesAdaptiveServiceReachLimitation(err),
+		errors.Is(err, clusterbase.ErrNoProviderAvailable):
+		return http.StatusServiceUnavailable
+	}
+	if status, ok := triStatus[tri.CodeOf(err)]; ok {
+		return status
+	}
+	return http.StatusBadGateway
+}
+
+// errorBody is the body of the error responses
+type errorBody struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeError writes the status of err. The errors of the bad requests are sent to the callers to fix the requests,
+// the others are logged only, as they may disclose the details of the providers, and the callers get the status text.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status := httpStatus(err)
+	message := statusText(status)
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		message = err.Error()
+	} else {
+		logger.Warnf("[Gateway] %s %s failed: %v", r.Method, r.URL.Path, err)
+	}
+	writeJSON(w, status, errorBody{Code: status, Message: message})
+}
+
+func statusText(status int) string {
+	if status == statusClientClosedRequest {
+		return "Client Closed Request"
+	}
+	return http.StatusText(status)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		logger.Warnf("[Gateway] failed to encode the response, err=%v", err)
+		status = http.StatusInternalServerError
+		data, _ = json.Marshal(errorBody{Code: status, Message: statusText(status)})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
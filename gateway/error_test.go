@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	clusterbase "dubbo.apache.org/dubbo-go/v3/cluster/cluster/base"
+	"dubbo.apache.org/dubbo-go/v3/filter/throttle"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	for err, status := range map[error]int{
+		badRequest(errors.New("bad")):                                          http.StatusBadRequest,
+		perrors.WithMessage(context.DeadlineExceeded, "call"):                  http.StatusGatewayTimeout,
+		context.Canceled:                                                       statusClientClosedRequest,
+		&throttle.ThrottledError{Service: "s"}:                                 http.StatusServiceUnavailable,
+		perrors.WithStack(clusterbase.ErrNoProviderAvailable):                  http.StatusServiceUnavailable,
+		errors.New("Failed to invoke the method m. No provider available for"): http.StatusBadGateway,
+		tri.NewError(tri.CodeUnauthenticated, errors.New("auth")):              http.StatusUnauthorized,
+		tri.NewError(tri.CodeResourceExhausted, errors.New("tps")):             http.StatusTooManyRequests,
+		tri.NewError(tri.CodeUnknown, errors.New("unknown")):                   http.StatusBadGateway,
+		errors.New("java.lang.NullPointerException"):                           http.StatusBadGateway,
+	} {
+		assert.Equal(t, status, httpStatus(err), err.Error())
+	}
+}
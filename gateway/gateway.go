@@ -0,0 +1,310 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gateway serves the dubbo and triple services over HTTP and JSON. The routes map the HTTP requests onto
+// the $invoke generic calls or the protobuf generic calls of the services, which are referred by the client, so the
+// providers are discovered from the registries and the consumer filters apply to the calls. The routes are reloaded
+// once they change in the config center.
+//
+// The routes in the config center are in yaml:
+//
+//	routes:
+//	  - method: GET
+//	    path: /users/{id}
+//	    interface: org.apache.dubbo.samples.UserProvider
+//	    rpc-method: getUser
+//	    parameter-types: [java.lang.String]
+//	    parameters: [path.id]
+//	  - method: POST
+//	    path: /greet
+//	    type: protobuf
+//	    interface: greet.GreetService
+//	    rpc-method: Greet
+//	    descriptor-set: greet.protoset
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	conf "dubbo.apache.org/dubbo-go/v3/common/config"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/filter/generic"
+	pbgeneric "dubbo.apache.org/dubbo-go/v3/protocol/triple/generic"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+// Gateway is the HTTP handler serving the routes.
+type Gateway struct {
+	cli  *client.Client
+	opts *Options
+
+	mux atomic.Pointer[http.ServeMux]
+
+	lock     sync.Mutex            // guards the reloads and services
+	services map[string]*reference // service key -> the reference of the service
+	routed   map[string]bool       // the service keys of the routes served
+
+	// dialGeneric refers to the service of the generic route, and returns the function destroying the reference
+	dialGeneric func(route *Route) (*generic.GenericService, func(), error)
+
+	server *http.Server
+}
+
+// reference is a service referred by the routes
+type reference struct {
+	svc     any // *generic.GenericService or *pbgeneric.Service
+	destroy func()
+}
+
+// New creates a gateway referring to the services with cli, and subscribes to the routes in the config center.
+func New(cli *client.Client, opts ...Option) (*Gateway, error) {
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	for _, r := range options.Routes {
+		if err := r.validate(); err != nil {
+			return nil, err
+		}
+	}
+	g := &Gateway{
+		cli:      cli,
+		opts:     options,
+		services: make(map[string]*reference),
+	}
+	g.dialGeneric = func(route *Route) (*generic.GenericService, func(), error) {
+		svc, conn, err := g.cli.DialGenericService(route.Interface, route.referenceOptions()...)
+		if err != nil {
+			return nil, nil, err
+		}
+		return svc, conn.Destroy, nil
+	}
+	if err := g.SetRoutes(options.Routes); err != nil {
+		return nil, err
+	}
+	g.subscribe()
+	return g, nil
+}
+
+// subscribe loads the routes from the config center, and listens to their changes
+func (g *Gateway) subscribe() {
+	if g.opts.DynamicConfiguration == nil {
+		g.opts.DynamicConfiguration = conf.GetEnvInstance().GetDynamicConfiguration()
+	}
+	dynamicConfiguration := g.opts.DynamicConfiguration
+	if dynamicConfiguration == nil {
+		logger.Infof("Config center does not start, the gateway serves the static routes only")
+		return
+	}
+	dynamicConfiguration.AddListener(g.opts.RoutesKey, g, config_center.WithGroup(g.opts.RoutesGroup))
+	value, err := dynamicConfiguration.GetRule(g.opts.RoutesKey, config_center.WithGroup(g.opts.RoutesGroup))
+	if err != nil {
+		logger.Warnf("Failed to query the gateway routes, key=%s, err=%v", g.opts.RoutesKey, err)
+		return
+	}
+	if strings.TrimSpace(value) != "" {
+		g.Process(&config_center.ConfigChangeEvent{Key: g.opts.RoutesKey, Value: value, ConfigType: remoting.EventTypeAdd})
+	}
+}
+
+// Process reloads the routes from the config center, the static routes are served once the routes are removed from
+// it. The routes failing to load are ignored, and the former ones are still served.
+func (g *Gateway) Process(event *config_center.ConfigChangeEvent) {
+	routes := g.opts.Routes
+	content, _ := event.Value.(string)
+	if event.ConfigType != remoting.EventTypeDel && strings.TrimSpace(content) != "" {
+		var err error
+		if routes, err = ParseRoutes(content); err != nil {
+			logger.Errorf("Failed to parse the gateway routes, key=%s, err=%v", g.opts.RoutesKey, err)
+			return
+		}
+	}
+	if err := g.SetRoutes(routes); err != nil {
+		logger.Errorf("Failed to load the gateway routes, key=%s, err=%v", g.opts.RoutesKey, err)
+		return
+	}
+	logger.Infof("[Gateway] load %d routes from %s", len(routes), g.opts.RoutesKey)
+}
+
+// SetRoutes replaces the routes served by the gateway, it serves the former routes if any of them fails to load.
+// The references of the services which are not routed any more are destroyed.
+func (g *Gateway) SetRoutes(routes []*Route) (err error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	mux := http.NewServeMux()
+	routed := make(map[string]bool, len(routes))
+	defer func() {
+		// http.ServeMux panics on the invalid or conflicting patterns
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+		if err != nil {
+			// the former routes are still served
+			g.release(g.routed)
+			return
+		}
+		g.mux.Store(mux)
+		g.routed = routed
+		g.release(routed)
+	}()
+	for _, route := range routes {
+		t, err := g.target(route)
+		if err != nil {
+			return perrors.WithMessagef(err, "route %s", route.pattern())
+		}
+		routed[route.serviceKey()] = true
+		mux.Handle(route.pattern(), g.handler(t))
+	}
+	return nil
+}
+
+// release destroys the references of the services which are not in routed
+func (g *Gateway) release(routed map[string]bool) {
+	for key, ref := range g.services {
+		if !routed[key] {
+			logger.Infof("[Gateway] release the reference of %s which is not routed", key)
+			ref.destroy()
+			delete(g.services, key)
+		}
+	}
+}
+
+// target refers to the service of the route, the routes of the same service share the reference
+func (g *Gateway) target(route *Route) (target, error) {
+	key := route.serviceKey()
+	ref, ok := g.services[key]
+	if route.Type == RouteTypeProtobuf {
+		if !ok {
+			svc, err := g.dialProtobuf(route)
+			if err != nil {
+				return nil, err
+			}
+			ref = &reference{svc: svc, destroy: svc.Destroy}
+			g.services[key] = ref
+		}
+		pbSvc := ref.svc.(*pbgeneric.Service)
+		md, err := pbSvc.Method(route.RPCMethod)
+		if err != nil {
+			return nil, err
+		}
+		if md.IsStreamingClient() || md.IsStreamingServer() {
+			return nil, perrors.Errorf("method %s is not unary", md.FullName())
+		}
+		return &protobufTarget{route: route, pathParams: pathParams(route.Path), svc: pbSvc}, nil
+	}
+	if !ok {
+		svc, destroy, err := g.dialGeneric(route)
+		if err != nil {
+			return nil, err
+		}
+		ref = &reference{svc: svc, destroy: destroy}
+		g.services[key] = ref
+	}
+	return &genericTarget{route: route, svc: ref.svc.(*generic.GenericService)}, nil
+}
+
+func (g *Gateway) dialProtobuf(route *Route) (*pbgeneric.Service, error) {
+	var source pbgeneric.DescriptorSource
+	var err error
+	if route.DescriptorSet != "" {
+		source, err = pbgeneric.LoadDescriptorSet(route.DescriptorSet)
+	} else {
+		source, err = pbgeneric.NewReflectionSource(g.cli, client.WithURL(route.URL))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pbgeneric.NewService(context.Background(), g.cli, source, route.Interface, route.referenceOptions()...)
+}
+
+func (g *Gateway) handler(t target) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the consumer tracing filter continues the trace of the HTTP request
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		req, err := newRequest(r, g.opts.MaxBodySize)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		res, err := t.invoke(ctx, req)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	})
+}
+
+// ServeHTTP serves the request with the route it matches.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.Load().ServeHTTP(w, r)
+}
+
+// Start listens on the address and serves the requests, it blocks until the gateway stops.
+func (g *Gateway) Start() error {
+	l, err := net.Listen("tcp", g.opts.Address)
+	if err != nil {
+		return err
+	}
+	return g.Serve(l)
+}
+
+// Serve serves the requests accepted by l, it blocks until the gateway stops.
+func (g *Gateway) Serve(l net.Listener) error {
+	g.lock.Lock()
+	g.server = &http.Server{Handler: g}
+	server := g.server
+	g.lock.Unlock()
+	if err := server.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Stop stops listening to the routes in the config center, and shuts the server down gracefully.
+func (g *Gateway) Stop(ctx context.Context) error {
+	if g.opts.DynamicConfiguration != nil {
+		g.opts.DynamicConfiguration.RemoveListener(g.opts.RoutesKey, g, config_center.WithGroup(g.opts.RoutesGroup))
+	}
+	g.lock.Lock()
+	server := g.server
+	g.lock.Unlock()
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
@@ -0,0 +1,334 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"google.golang.org/protobuf/proto"
+
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/filter/generic"
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+// invocation is a $invoke call received by the fake generic services
+type invocation struct {
+	service string
+	method  string
+	types   []string
+	args    []hessian.Object
+}
+
+type fakeGeneric struct {
+	mu          sync.Mutex
+	invocations []invocation
+	result      any
+	err         error
+	destroyed   []string // the interfaces whose references are destroyed
+}
+
+func (f *fakeGeneric) last() invocation {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.invocations[len(f.invocations)-1]
+}
+
+func newTestGateway(t *testing.T, fake *fakeGeneric) *Gateway {
+	cli, err := client.NewClient()
+	require.NoError(t, err)
+	g, err := New(cli)
+	require.NoError(t, err)
+	g.dialGeneric = func(route *Route) (*generic.GenericService, func(), error) {
+		svc := generic.NewGenericService(route.Interface)
+		svc.Invoke = func(_ context.Context, method string, types []string, args []hessian.Object) (any, error) {
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			fake.invocations = append(fake.invocations, invocation{service: route.Interface, method: method, types: types, args: args})
+			return fake.result, fake.err
+		}
+		return svc, func() {
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			fake.destroyed = append(fake.destroyed, route.Interface)
+		}, nil
+	}
+	return g
+}
+
+func do(g *Gateway, method, target, body string, header ...string) (int, string) {
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, target, r)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, req)
+	return w.Code, w.Body.String()
+}
+
+func TestGenericRoute(t *testing.T) {
+	fake := &fakeGeneric{result: map[any]any{"name": "dubbo", "tags": []any{map[any]any{1: "a"}}}}
+	g := newTestGateway(t, fake)
+	require.NoError(t, g.SetRoutes([]*Route{
+		{
+			Method: http.MethodGet, Path: "/users/{id}",
+			Interface: "org.apache.dubbo.UserProvider", RPCMethod: "getUser",
+			ParameterTypes: []string{"java.lang.Long", "boolean", "java.lang.String"},
+			Parameters:     []string{"path.id", "query.verbose", "header.X-Token"},
+		},
+		{
+			Method: http.MethodPost, Path: "/users",
+			Interface: "org.apache.dubbo.UserProvider", RPCMethod: "addUser",
+			ParameterTypes: []string{"org.apache.dubbo.User", "int"},
+			Parameters:     []string{"body", "body.age"},
+		},
+	}))
+
+	status, body := do(g, http.MethodGet, "/users/42?verbose=true", "", "X-Token", "secret")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"name": "dubbo", "tags": [{"1": "a"}]}`, body)
+	assert.Equal(t, invocation{
+		service: "org.apache.dubbo.UserProvider",
+		method:  "getUser",
+		types:   []string{"java.lang.Long", "boolean", "java.lang.String"},
+		args:    []hessian.Object{int64(42), true, "secret"},
+	}, fake.last())
+
+	status, _ = do(g, http.MethodPost, "/users", `{"name": "dubbo", "age": 12, "score": 1.5}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []hessian.Object{
+		map[string]any{"name": "dubbo", "age": int64(12), "score": 1.5},
+		int32(12),
+	}, fake.last().args)
+
+	status, body = do(g, http.MethodGet, "/users/abc", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "parameter path.id is not java.lang.Long")
+	status, _ = do(g, http.MethodPost, "/users", `{"name":`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = do(g, http.MethodPut, "/users", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+	status, _ = do(g, http.MethodGet, "/orders", "")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	fake.err = tri.NewError(tri.CodeUnavailable, errors.New("no provider"))
+	status, body = do(g, http.MethodGet, "/users/42", "")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	// the details of the provider errors are not sent to the callers
+	assert.JSONEq(t, `{"code": 503, "message": "Service Unavailable"}`, body)
+	fake.err = errors.New("java.lang.IllegalStateException")
+	status, _ = do(g, http.MethodGet, "/users/42", "")
+	assert.Equal(t, http.StatusBadGateway, status)
+}
+
+func TestSetRoutesConflict(t *testing.T) {
+	g := newTestGateway(t, &fakeGeneric{})
+	route := &Route{Path: "/users", Interface: "org.apache.dubbo.UserProvider", RPCMethod: "listUsers"}
+	require.NoError(t, g.SetRoutes([]*Route{route}))
+	require.Error(t, g.SetRoutes([]*Route{route, route}))
+	// the former routes are still served
+	status, _ := do(g, http.MethodGet, "/users", "")
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestSetRoutesRelease(t *testing.T) {
+	fake := &fakeGeneric{}
+	g := newTestGateway(t, fake)
+	users := &Route{Path: "/users", Interface: "org.apache.dubbo.UserProvider", RPCMethod: "listUsers"}
+	orders := &Route{Path: "/orders", Interface: "org.apache.dubbo.OrderProvider", RPCMethod: "listOrders"}
+	require.NoError(t, g.SetRoutes([]*Route{users, orders}))
+	assert.Len(t, g.services, 2)
+
+	// the services referred by the failed routes are released, the former ones are kept
+	items := &Route{Path: "/items", Interface: "org.apache.dubbo.ItemProvider", RPCMethod: "listItems"}
+	require.Error(t, g.SetRoutes([]*Route{items, users, users}))
+	assert.Equal(t, []string{"org.apache.dubbo.ItemProvider"}, fake.destroyed)
+	assert.Len(t, g.services, 2)
+
+	require.NoError(t, g.SetRoutes([]*Route{users}))
+	assert.Equal(t, []string{"org.apache.dubbo.ItemProvider", "org.apache.dubbo.OrderProvider"}, fake.destroyed)
+	assert.Len(t, g.services, 1)
+	status, _ := do(g, http.MethodGet, "/users", "")
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestReloadRoutes(t *testing.T) {
+	fake := &fakeGeneric{result: "ok"}
+	g := newTestGateway(t, fake)
+	g.opts.Routes = []*Route{{Path: "/static", Interface: "org.apache.dubbo.StaticProvider", RPCMethod: "get"}}
+
+	dynamicConfiguration, err := (&config_center.MockDynamicConfigurationFactory{Content: `
+routes:
+  - method: GET
+    path: /users/{id}
+    interface: org.apache.dubbo.UserProvider
+    rpc-method: getUser
+    parameter-types: [java.lang.String]
+    parameters: [path.id]
+`}).GetDynamicConfiguration(nil)
+	require.NoError(t, err)
+	g.opts.DynamicConfiguration = dynamicConfiguration
+	g.subscribe()
+
+	status, body := do(g, http.MethodGet, "/users/1", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `"ok"`, body)
+	assert.Equal(t, "getUser", fake.last().method)
+
+	g.Process(&config_center.ConfigChangeEvent{ConfigType: remoting.EventTypeUpdate, Value: `
+routes:
+  - path: /orders
+    interface: org.apache.dubbo.OrderProvider
+    rpc-method: listOrders
+`})
+	status, _ = do(g, http.MethodGet, "/users/1", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = do(g, http.MethodGet, "/orders", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "org.apache.dubbo.OrderProvider", fake.last().service)
+
+	// the invalid routes are ignored
+	g.Process(&config_center.ConfigChangeEvent{ConfigType: remoting.EventTypeUpdate, Value: "routes:\n  - path: orders\n"})
+	status, _ = do(g, http.MethodGet, "/orders", "")
+	assert.Equal(t, http.StatusOK, status)
+
+	g.Process(&config_center.ConfigChangeEvent{ConfigType: remoting.EventTypeDel})
+	status, _ = do(g, http.MethodGet, "/orders", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = do(g, http.MethodGet, "/static", "")
+	assert.Equal(t, http.StatusOK, status)
+}
+
+// echoDescriptorSet describes the service generic.test.EchoService with the unary method Echo
+func echoDescriptorSet() *descriptorpb.FileDescriptorSet {
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+	return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("generic/test/echo.proto"),
+		Package: proto.String("generic.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("EchoMessage"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("text", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				field("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+			},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("EchoService"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Echo"),
+				InputType:  proto.String(".generic.test.EchoMessage"),
+				OutputType: proto.String(".generic.test.EchoMessage"),
+			}},
+		}},
+	}}}
+}
+
+func TestProtobufRoute(t *testing.T) {
+	set := echoDescriptorSet()
+	data, err := proto.Marshal(set)
+	require.NoError(t, err)
+	descriptorSet := filepath.Join(t.TempDir(), "echo.protoset")
+	require.NoError(t, os.WriteFile(descriptorSet, data, 0o600))
+
+	files, err := protodesc.NewFiles(set)
+	require.NoError(t, err)
+	desc, err := files.FindDescriptorByName("generic.test.EchoMessage")
+	require.NoError(t, err)
+	msgDesc := desc.(protoreflect.MessageDescriptor)
+	mux := http.NewServeMux()
+	mux.Handle("/generic.test.EchoService/Echo", tri.NewUnaryHandler("/generic.test.EchoService/Echo",
+		func() any { return dynamicpb.NewMessage(msgDesc) },
+		func(_ context.Context, req *tri.Request) (*tri.Response, error) {
+			msg := req.Msg.(*dynamicpb.Message)
+			if msg.Get(msgDesc.Fields().ByName("text")).String() == "missing" {
+				return nil, tri.NewError(tri.CodeNotFound, errors.New("text not found"))
+			}
+			return tri.NewResponse(msg), nil
+		}))
+	server := httptest.NewServer(h2c.NewHandler(mux, &http2.Server{}))
+	defer server.Close()
+
+	g := newTestGateway(t, &fakeGeneric{})
+	require.NoError(t, g.SetRoutes([]*Route{{
+		Method: http.MethodPost, Path: "/echo/{text}",
+		Type: RouteTypeProtobuf, Interface: "generic.test.EchoService", RPCMethod: "Echo",
+		URL: "tri://" + strings.TrimPrefix(server.URL, "http://"), DescriptorSet: descriptorSet,
+	}}))
+
+	status, body := do(g, http.MethodPost, "/echo/hello?count=3", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"text": "hello", "count": 3}`, body)
+
+	status, body = do(g, http.MethodPost, "/echo/hello", `{"count": 2, "text": "overridden"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"text": "hello", "count": 2}`, body)
+
+	status, _ = do(g, http.MethodPost, "/echo/hello", `{"unknown": 1}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = do(g, http.MethodPost, "/echo/missing", "")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	err = g.SetRoutes([]*Route{{
+		Path: "/echo", Type: RouteTypeProtobuf, Interface: "generic.test.EchoService", RPCMethod: "Missing",
+		URL: "tri://" + strings.TrimPrefix(server.URL, "http://"), DescriptorSet: descriptorSet,
+	}})
+	assert.ErrorContains(t, err, "has no method Missing")
+}
@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+)
+
+type Options struct {
+	// Address is the address the gateway listens on.
+	Address string
+	// Routes is the static routes, which the routes in the config center replace.
+	Routes []*Route
+	// MaxBodySize limits the size of the request bodies.
+	MaxBodySize int64
+
+	// DynamicConfiguration is the config center of the routes, the one of the application is used if it is nil.
+	DynamicConfiguration config_center.DynamicConfiguration
+	RoutesKey            string
+	RoutesGroup          string
+}
+
+func defaultOptions() *Options {
+	return &Options{
+		Address:     constant.DefaultGatewayAddress,
+		MaxBodySize: constant.DefaultGatewayMaxBodySize,
+		RoutesKey:   constant.DefaultGatewayRoutesKey,
+		RoutesGroup: constant.DefaultGatewayRoutesGroup,
+	}
+}
+
+type Option func(*Options)
+
+// WithAddress sets the address the gateway listens on, :8080 by default.
+func WithAddress(address string) Option {
+	return func(opts *Options) {
+		opts.Address = address
+	}
+}
+
+// WithRoutes adds the static routes.
+func WithRoutes(routes ...*Route) Option {
+	return func(opts *Options) {
+		opts.Routes = append(opts.Routes, routes...)
+	}
+}
+
+// WithMaxBodySize limits the size of the request bodies, 4MB by default.
+func WithMaxBodySize(size int64) Option {
+	return func(opts *Options) {
+		opts.MaxBodySize = size
+	}
+}
+
+// WithDynamicConfiguration sets the config center of the routes.
+func WithDynamicConfiguration(dynamicConfiguration config_center.DynamicConfiguration) Option {
+	return func(opts *Options) {
+		opts.DynamicConfiguration = dynamicConfiguration
+	}
+}
+
+// WithRoutesKey sets the key and the group of the routes in the config center, dubbo.gateway.routes in the group
+// dubbo by default.
+func WithRoutesKey(key, group string) Option {
+	return func(opts *Options) {
+		opts.RoutesKey = key
+		opts.RoutesGroup = group
+	}
+}
@@ -0,0 +1,308 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+
+	"gopkg.in/yaml.v2"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+)
+
+const (
+	// RouteTypeGeneric routes to the $invoke generic call, with the arguments extracted by the parameters.
+	RouteTypeGeneric = "generic"
+	// RouteTypeProtobuf routes to the protobuf generic call of a triple service, with the request message built from
+	// the body, the query and the path parameters.
+	RouteTypeProtobuf = "protobuf"
+)
+
+// The sources of the parameters of the generic routes, a parameter is a source optionally followed by a path, such
+// as body.user.name, query.id or path.id. The path of header is a header name, and the path of body is a path of
+// the JSON body.
+const (
+	sourceBody   = "body"
+	sourceQuery  = "query"
+	sourcePath   = "path"
+	sourceHeader = "header"
+)
+
+// RoutesConfig is the routes of the gateway, which is published to the config center in yaml.
+type RoutesConfig struct {
+	Routes []*Route `yaml:"routes" json:"routes"`
+}
+
+// Route maps the HTTP requests matching its method and path onto the calls of a method of a service.
+type Route struct {
+	// Method is the HTTP method of the requests, any method matches if it is empty.
+	Method string `yaml:"method" json:"method,omitempty"`
+	// Path is the path of the requests, in the pattern of http.ServeMux, like /users/{id}.
+	Path string `yaml:"path" json:"path"`
+
+	// Type is RouteTypeGeneric by default.
+	Type      string `yaml:"type" json:"type,omitempty"`
+	Interface string `yaml:"interface" json:"interface"`
+	// RPCMethod is the invoked method of the service.
+	RPCMethod string `yaml:"rpc-method" json:"rpc-method"`
+	Group     string `yaml:"group" json:"group,omitempty"`
+	Version   string `yaml:"version" json:"version,omitempty"`
+	// Protocol is the protocol of the calls, it is tri for the protobuf routes.
+	Protocol string `yaml:"protocol" json:"protocol,omitempty"`
+	// URL is the url of the providers connected directly, the providers are discovered from the registries if empty.
+	URL     string `yaml:"url" json:"url,omitempty"`
+	Timeout string `yaml:"timeout" json:"timeout,omitempty"`
+	// Filter is the consumer filters of the calls, in addition to the ones of the consumer config.
+	Filter string `yaml:"filter" json:"filter,omitempty"`
+
+	// ParameterTypes and Parameters are the java types and the sources of the arguments of the generic routes.
+	ParameterTypes []string `yaml:"parameter-types" json:"parameter-types,omitempty"`
+	Parameters     []string `yaml:"parameters" json:"parameters,omitempty"`
+
+	// DescriptorSet is the descriptor set file of the service of the protobuf routes, the service is resolved through
+	// the server reflection of the URL if it is empty.
+	DescriptorSet string `yaml:"descriptor-set" json:"descriptor-set,omitempty"`
+}
+
+// ParseRoutes parses the routes in yaml.
+func ParseRoutes(content string) ([]*Route, error) {
+	config := &RoutesConfig{}
+	if err := yaml.UnmarshalStrict([]byte(content), config); err != nil {
+		return nil, err
+	}
+	for _, r := range config.Routes {
+		if err := r.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return config.Routes, nil
+}
+
+// pattern returns the pattern of the route in http.ServeMux
+func (r *Route) pattern() string {
+	if r.Method == "" {
+		return r.Path
+	}
+	return strings.ToUpper(r.Method) + " " + r.Path
+}
+
+func (r *Route) validate() error {
+	if r == nil {
+		return perrors.New("route is empty")
+	}
+	if !strings.HasPrefix(r.Path, "/") {
+		return perrors.Errorf("path of route %s must start with /", r.pattern())
+	}
+	if r.Interface == "" || r.RPCMethod == "" {
+		return perrors.Errorf("route %s must have interface and rpc-method", r.pattern())
+	}
+	if r.Timeout != "" {
+		if _, err := time.ParseDuration(r.Timeout); err != nil {
+			return perrors.Errorf("timeout of route %s is invalid: %v", r.pattern(), err)
+		}
+	}
+	switch r.Type {
+	case "", RouteTypeGeneric:
+		if len(r.ParameterTypes) != len(r.Parameters) {
+			return perrors.Errorf("route %s has %d parameter-types but %d parameters",
+				r.pattern(), len(r.ParameterTypes), len(r.Parameters))
+		}
+		for _, p := range r.Parameters {
+			switch source, _, _ := strings.Cut(p, "."); source {
+			case sourceBody, sourceQuery, sourcePath, sourceHeader:
+			default:
+				return perrors.Errorf("parameter %s of route %s must be from body, query, path or header", p, r.pattern())
+			}
+		}
+	case RouteTypeProtobuf:
+		if r.Protocol != "" && r.Protocol != constant.TriProtocol {
+			return perrors.Errorf("protobuf route %s must use the tri protocol", r.pattern())
+		}
+		if r.DescriptorSet == "" && r.URL == "" {
+			return perrors.Errorf("protobuf route %s must have descriptor-set or url", r.pattern())
+		}
+	default:
+		return perrors.Errorf("type %s of route %s is unknown", r.Type, r.pattern())
+	}
+	return nil
+}
+
+// request is the HTTP request of a route
+type request struct {
+	*http.Request
+	body any // the JSON body, nil if it is empty
+}
+
+func newRequest(r *http.Request, maxBodySize int64) (*request, error) {
+	req := &request{Request: r}
+	data, err := readBody(r, maxBodySize)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return req, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err = dec.Decode(&req.body); err != nil {
+		return nil, badRequest(perrors.WithMessage(err, "decode JSON body"))
+	}
+	req.body = normalizeNumbers(req.body)
+	return req, nil
+}
+
+// extract returns the value of the parameter, nil if it is absent
+func (r *request) extract(parameter string) any {
+	source, path, _ := strings.Cut(parameter, ".")
+	switch source {
+	case sourceBody:
+		v := r.body
+		if path == "" {
+			return v
+		}
+		for _, key := range strings.Split(path, ".") {
+			m, ok := v.(map[string]any)
+			if !ok {
+				return nil
+			}
+			v = m[key]
+		}
+		return v
+	case sourceQuery:
+		if !r.URL.Query().Has(path) {
+			return nil
+		}
+		return r.URL.Query().Get(path)
+	case sourcePath:
+		if v := r.PathValue(path); v != "" {
+			return v
+		}
+		return nil
+	case sourceHeader:
+		if v := r.Header.Get(path); v != "" {
+			return v
+		}
+		return nil
+	}
+	return nil
+}
+
+// normalizeNumbers converts the json.Number in v to int64 or float64
+func normalizeNumbers(v any) any {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	case map[string]any:
+		for k, e := range t {
+			t[k] = normalizeNumbers(e)
+		}
+	case []any:
+		for i, e := range t {
+			t[i] = normalizeNumbers(e)
+		}
+	}
+	return v
+}
+
+// convert converts the value extracted for a parameter to the java type
+func convert(v any, typ string) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	s, isString := v.(string)
+	switch typ {
+	case "java.lang.String", "string":
+		if isString {
+			return s, nil
+		}
+		return fmt.Sprint(v), nil
+	case "int", "java.lang.Integer", "short", "java.lang.Short", "byte", "java.lang.Byte", "long", "java.lang.Long":
+		var i int64
+		var err error
+		switch n := v.(type) {
+		case string:
+			i, err = strconv.ParseInt(s, 10, 64)
+		case int64:
+			i = n
+		case float64:
+			i = int64(n)
+			if float64(i) != n {
+				err = perrors.Errorf("%v is not an integer", n)
+			}
+		default:
+			err = perrors.Errorf("%v is not an integer", v)
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch typ {
+		case "long", "java.lang.Long":
+			return i, nil
+		case "short", "java.lang.Short":
+			return int16(i), nil
+		case "byte", "java.lang.Byte":
+			return int8(i), nil
+		default:
+			return int32(i), nil
+		}
+	case "double", "java.lang.Double", "float", "java.lang.Float":
+		var f float64
+		switch n := v.(type) {
+		case string:
+			var err error
+			if f, err = strconv.ParseFloat(s, 64); err != nil {
+				return nil, err
+			}
+		case int64:
+			f = float64(n)
+		case float64:
+			f = n
+		default:
+			return nil, perrors.Errorf("%v is not a number", v)
+		}
+		if typ == "float" || typ == "java.lang.Float" {
+			return float32(f), nil
+		}
+		return f, nil
+	case "boolean", "java.lang.Boolean":
+		if isString {
+			return strconv.ParseBool(s)
+		}
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+		return nil, perrors.Errorf("%v is not a boolean", v)
+	}
+	return v, nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRoutes(t *testing.T) {
+	routes, err := ParseRoutes(`
+routes:
+  - method: get
+    path: /users/{id}
+    interface: org.apache.dubbo.UserProvider
+    rpc-method: getUser
+    group: g1
+    version: 1.0.0
+    timeout: 3s
+    parameter-types: [java.lang.String]
+    parameters: [path.id]
+  - path: /greet
+    type: protobuf
+    interface: greet.GreetService
+    rpc-method: Greet
+    url: tri://127.0.0.1:20000
+`)
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+	assert.Equal(t, "GET /users/{id}", routes[0].pattern())
+	assert.Equal(t, []string{"path.id"}, routes[0].Parameters)
+	assert.Equal(t, "/greet", routes[1].pattern())
+	assert.Len(t, routes[1].referenceOptions(), 2)
+
+	for content, msg := range map[string]string{
+		"routes:\n  - path: users\n    interface: a\n    rpc-method: b\n":                                                       "must start with /",
+		"routes:\n  - path: /users\n    interface: a\n":                                                                         "must have interface and rpc-method",
+		"routes:\n  - path: /users\n    interface: a\n    rpc-method: b\n    timeout: 3\n":                                      "timeout",
+		"routes:\n  - path: /users\n    interface: a\n    rpc-method: b\n    parameters: [body]\n":                              "parameter-types",
+		"routes:\n  - path: /users\n    interface: a\n    rpc-method: b\n    type: rest\n":                                      "unknown",
+		"routes:\n  - path: /users\n    interface: a\n    rpc-method: b\n    type: protobuf\n":                                  "descriptor-set or url",
+		"routes:\n  - path: /users\n    interface: a\n    rpc-method: b\n    unknown: c\n":                                      "unknown",
+		"routes:\n  - path: /u\n    interface: a\n    rpc-method: b\n    parameter-types: [int]\n    parameters: [cookie.id]\n": "body, query, path or header",
+	} {
+		_, err = ParseRoutes(content)
+		assert.ErrorContains(t, err, msg, content)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	for _, c := range []struct {
+		v    any
+		typ  string
+		want any
+	}{
+		{"42", "java.lang.Integer", int32(42)},
+		{int64(42), "long", int64(42)},
+		{float64(42), "short", int16(42)},
+		{"1.5", "double", 1.5},
+		{int64(2), "float", float32(2)},
+		{"true", "java.lang.Boolean", true},
+		{int64(7), "java.lang.String", "7"},
+		{map[string]any{"a": int64(1)}, "org.apache.dubbo.User", map[string]any{"a": int64(1)}},
+		{nil, "int", nil},
+	} {
+		got, err := convert(c.v, c.typ)
+		require.NoError(t, err)
+		assert.Equal(t, c.want, got)
+	}
+
+	_, err := convert("a", "int")
+	assert.Error(t, err)
+	_, err = convert(1.5, "long")
+	assert.Error(t, err)
+	_, err = convert(int64(1), "boolean")
+	assert.Error(t, err)
+}
+
+func TestPathParams(t *testing.T) {
+	assert.Equal(t, []string{"id", "rest"}, pathParams("/users/{id}/{rest...}"))
+	assert.Empty(t, pathParams("/users/{$}"))
+}
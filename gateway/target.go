@@ -0,0 +1,181 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/filter/generic"
+	pbgeneric "dubbo.apache.org/dubbo-go/v3/protocol/triple/generic"
+)
+
+// target invokes the method of a route, the result is marshaled to the JSON response
+type target interface {
+	invoke(ctx context.Context, req *request) (any, error)
+}
+
+type genericTarget struct {
+	route *Route
+	svc   *generic.GenericService
+}
+
+func (t *genericTarget) invoke(ctx context.Context, req *request) (any, error) {
+	args := make([]hessian.Object, len(t.route.Parameters))
+	for i, p := range t.route.Parameters {
+		v, err := convert(req.extract(p), t.route.ParameterTypes[i])
+		if err != nil {
+			return nil, badRequest(perrors.WithMessagef(err, "parameter %s is not %s", p, t.route.ParameterTypes[i]))
+		}
+		args[i] = v
+	}
+	types := t.route.ParameterTypes
+	if types == nil {
+		types = []string{}
+	}
+	res, err := t.svc.Invoke(ctx, t.route.RPCMethod, types, args)
+	if err != nil {
+		return nil, err
+	}
+	return jsonValue(res), nil
+}
+
+type protobufTarget struct {
+	route      *Route
+	pathParams []string
+	svc        *pbgeneric.Service
+}
+
+// invoke builds the request message from the JSON object of the body, the query parameters and the path parameters,
+// the latter ones override the former ones of the same fields.
+func (t *protobufTarget) invoke(ctx context.Context, req *request) (any, error) {
+	msg := map[string]any{}
+	switch body := req.body.(type) {
+	case nil:
+	case map[string]any:
+		msg = body
+	default:
+		return nil, badRequest(perrors.New("body must be a JSON object"))
+	}
+	for name, values := range req.URL.Query() {
+		if len(values) == 1 {
+			msg[name] = values[0]
+		} else {
+			msg[name] = values
+		}
+	}
+	for _, name := range t.pathParams {
+		msg[name] = req.PathValue(name)
+	}
+	md, err := t.svc.Method(t.route.RPCMethod)
+	if err != nil {
+		return nil, err
+	}
+	reqMsg, err := t.svc.NewMessage(md.Input(), msg)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	resp, err := t.svc.Invoke(ctx, t.route.RPCMethod, reqMsg)
+	if err != nil {
+		return nil, err
+	}
+	data, err := t.svc.JSON(resp)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data), nil
+}
+
+// pathParams returns the names of the wildcards in the path pattern
+func pathParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name := strings.TrimSuffix(segment[1:len(segment)-1], "...")
+			if name != "$" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// serviceKey identifies the referred service of the route, the routes of a service share its reference
+func (r *Route) serviceKey() string {
+	return strings.Join([]string{r.Type, r.Protocol, r.Interface, r.Group, r.Version, r.URL, r.Timeout, r.Filter, r.DescriptorSet}, "|")
+}
+
+func (r *Route) referenceOptions() []client.ReferenceOption {
+	var opts []client.ReferenceOption
+	if r.Group != "" {
+		opts = append(opts, client.WithGroup(r.Group))
+	}
+	if r.Version != "" {
+		opts = append(opts, client.WithVersion(r.Version))
+	}
+	if r.Type == RouteTypeProtobuf {
+		opts = append(opts, client.WithProtocol(constant.TriProtocol))
+	} else if r.Protocol != "" {
+		opts = append(opts, client.WithProtocol(r.Protocol))
+	}
+	if r.URL != "" {
+		opts = append(opts, client.WithURL(r.URL))
+	}
+	if r.Filter != "" {
+		opts = append(opts, client.WithFilter(r.Filter))
+	}
+	if r.Timeout != "" {
+		timeout, _ := time.ParseDuration(r.Timeout)
+		opts = append(opts, client.WithRequestTimeout(timeout))
+	}
+	return opts
+}
+
+// jsonValue converts the maps with non-string keys decoded by hessian to the ones JSON supports
+func jsonValue(v any) any {
+	switch t := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(t))
+		for k, e := range t {
+			m[fmt.Sprint(k)] = jsonValue(e)
+		}
+		return m
+	case map[string]any:
+		for k, e := range t {
+			t[k] = jsonValue(e)
+		}
+	case []any:
+		for i, e := range t {
+			t[i] = jsonValue(e)
+		}
+	}
+	return v
+}
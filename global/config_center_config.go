@@ -23,7 +23,7 @@ package global
 // those configs are already built-in in many professional third-party configuration centers.
 // In most cases, namespace is used to isolate different tenants, while group is used to divide the key set from one tenant into groups.
 //
-// CenterConfig has currently supported Zookeeper, Nacos, Etcd, Consul, Apollo
+// CenterConfig has currently supported Zookeeper, Nacos, Etcd, Consul, Apollo, Kubernetes, Polaris
 type CenterConfig struct {
 	Protocol  string            `validate:"required" yaml:"protocol"  json:"protocol,omitempty"`
 	Address   string            `validate:"required" yaml:"address" json:"address,omitempty"`
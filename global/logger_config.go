@@ -17,6 +17,10 @@
 
 package global
 
+import (
+	"maps"
+)
+
 import (
 	"github.com/creasty/defaults"
 )
@@ -36,6 +40,10 @@ type LoggerConfig struct {
 
 	// logger file
 	File *File `yaml:"file"`
+
+	// levels of packages and their sub packages, keyed by package path, eg: dubbo.apache.org/dubbo-go/v3/registry: debug,
+	// supported by the slog driver
+	Levels map[string]string `yaml:"levels" json:"levels,omitempty" property:"levels"`
 }
 
 type File struct {
@@ -76,6 +84,7 @@ func (c *LoggerConfig) Clone() *LoggerConfig {
 		Format:   c.Format,
 		Appender: c.Appender,
 		File:     c.File.Clone(),
+		Levels:   maps.Clone(c.Levels),
 	}
 }
 
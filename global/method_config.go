@@ -32,6 +32,8 @@ type MethodConfig struct {
 	ExecuteLimitRejectedHandler string `yaml:"execute.limit.rejected.handler" json:"execute.limit.rejected.handler,omitempty" property:"execute.limit.rejected.handler"`
 	Sticky                      bool   `yaml:"sticky"   json:"sticky,omitempty" property:"sticky"`
 	RequestTimeout              string `yaml:"timeout"  json:"timeout,omitempty" property:"timeout"`
+	// Criticality is the criticality of the requests of the method, it is one of CRITICAL, DEFAULT and SHEDDABLE
+	Criticality string `yaml:"criticality" json:"criticality,omitempty" property:"criticality"`
 }
 
 // Clone a new MethodConfig
@@ -54,5 +56,6 @@ func (c *MethodConfig) Clone() *MethodConfig {
 		ExecuteLimitRejectedHandler: c.ExecuteLimitRejectedHandler,
 		Sticky:                      c.Sticky,
 		RequestTimeout:              c.RequestTimeout,
+		Criticality:                 c.Criticality,
 	}
 }
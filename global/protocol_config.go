@@ -28,6 +28,8 @@ import (
 // ProtocolConfig is protocol configuration
 type ProtocolConfig struct {
 	Name string `yaml:"name" json:"name,omitempty" property:"name"`
+	// Ip is the ip to listen on, or a unix domain socket like "unix:///var/run/dubbo/greet.sock",
+	// services on a unix domain socket are not published to registries.
 	Ip   string `yaml:"ip"  json:"ip,omitempty" property:"ip"`
 	Port string `yaml:"port" json:"port,omitempty" property:"port"`
 
@@ -36,6 +38,9 @@ type ProtocolConfig struct {
 
 	TripleConfig *TripleConfig `yaml:"triple" json:"triple,omitempty" property:"triple"`
 
+	// Transporter is the remoting implementation of the dubbo protocol, getty (default) or native.
+	Transporter string `yaml:"transporter" json:"transporter,omitempty" property:"transporter"`
+
 	// TODO: remove MaxServerSendMsgSize and MaxServerRecvMsgSize when version 4.0.0
 	//
 	// MaxServerSendMsgSize max size of server send message, 1mb=1000kb=1000000b 1mib=1024kb=1048576b.
@@ -70,6 +75,7 @@ func (c *ProtocolConfig) Clone() *ProtocolConfig {
 		Port:                 c.Port,
 		Params:               c.Params,
 		TripleConfig:         c.TripleConfig.Clone(),
+		Transporter:          c.Transporter,
 		MaxServerSendMsgSize: c.MaxServerSendMsgSize,
 		MaxServerRecvMsgSize: c.MaxServerRecvMsgSize,
 	}
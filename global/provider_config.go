@@ -38,6 +38,10 @@ type ProviderConfig struct {
 	// adaptive service
 	AdaptiveService        bool `yaml:"adaptive-service" json:"adaptive-service" property:"adaptive-service"`
 	AdaptiveServiceVerbose bool `yaml:"adaptive-service-verbose" json:"adaptive-service-verbose" property:"adaptive-service-verbose"`
+	// RegisterAfterWarmup delays the registry publication until the warm-up hooks of metrics/probe are done
+	RegisterAfterWarmup bool `yaml:"register-after-warmup" json:"register-after-warmup" property:"register-after-warmup"`
+	// WarmupTimeout is the longest time waiting for the warm-up hooks before the registry publication
+	WarmupTimeout string `default:"3m" yaml:"warmup-timeout" json:"warmup-timeout,omitempty" property:"warmup-timeout"`
 }
 
 func DefaultProviderConfig() *ProviderConfig {
@@ -83,5 +87,7 @@ func (c *ProviderConfig) Clone() *ProviderConfig {
 		ConfigType:             newConfigType,
 		AdaptiveService:        c.AdaptiveService,
 		AdaptiveServiceVerbose: c.AdaptiveServiceVerbose,
+		RegisterAfterWarmup:    c.RegisterAfterWarmup,
+		WarmupTimeout:          c.WarmupTimeout,
 	}
 }
@@ -45,6 +45,10 @@ type ReferenceConfig struct {
 	TracingKey       string            `yaml:"tracing-key" json:"tracing-key,omitempty" property:"tracing-key"`
 	MeshProviderPort int               `yaml:"mesh-provider-port" json:"mesh-provider-port,omitempty" property:"mesh-provider-port"`
 
+	// ProtocolPreference lists the protocols to consume in order of preference, the best one supported by
+	// a provider is used and the next ones are the fallbacks
+	ProtocolPreference []string `yaml:"protocol-preference"  json:"protocol-preference,omitempty" property:"protocol-preference"`
+
 	// config
 	MethodsConfig []*MethodConfig `yaml:"methods"  json:"methods,omitempty" property:"methods"`
 	// TODO: rename protocol_config to protocol when publish 4.0.0.
@@ -88,6 +92,9 @@ func (c *ReferenceConfig) GetOptions() []ReferenceOption {
 	if c.Protocol != "" {
 		refOpts = append(refOpts, WithReference_Protocol(c.Protocol))
 	}
+	if len(c.ProtocolPreference) > 0 {
+		refOpts = append(refOpts, WithReference_ProtocolPreference(c.ProtocolPreference))
+	}
 	if len(c.RegistryIDs) > 0 {
 		refOpts = append(refOpts, WithReference_RegistryIDs(c.RegistryIDs))
 	}
@@ -163,6 +170,9 @@ func (c *ReferenceConfig) Clone() *ReferenceConfig {
 	newRegistryIDs := make([]string, len(c.RegistryIDs))
 	copy(newRegistryIDs, c.RegistryIDs)
 
+	newProtocolPreference := make([]string, len(c.ProtocolPreference))
+	copy(newProtocolPreference, c.ProtocolPreference)
+
 	var newMethods []*MethodConfig
 	if c.MethodsConfig != nil {
 		newMethods = make([]*MethodConfig, 0, len(c.MethodsConfig))
@@ -182,6 +192,7 @@ func (c *ReferenceConfig) Clone() *ReferenceConfig {
 		URL:                  c.URL,
 		Filter:               c.Filter,
 		Protocol:             c.Protocol,
+		ProtocolPreference:   newProtocolPreference,
 		RegistryIDs:          newRegistryIDs,
 		Cluster:              c.Cluster,
 		Loadbalance:          c.Loadbalance,
@@ -238,6 +249,12 @@ func WithReference_Protocol(protocol string) ReferenceOption {
 	}
 }
 
+func WithReference_ProtocolPreference(protocols []string) ReferenceOption {
+	return func(cfg *ReferenceConfig) {
+		cfg.ProtocolPreference = protocols
+	}
+}
+
 func WithReference_RegistryIDs(registryIDs []string) ReferenceOption {
 	return func(cfg *ReferenceConfig) {
 		if len(registryIDs) >= 0 {
@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+)
+
+import (
+	dubbogoLogger "github.com/dubbogo/gost/log/logger"
+
+	"github.com/sirupsen/logrus"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+)
+
+type attrsKey struct{}
+
+// CtxLogger is a logger which attaches the attributes of the context to its logs itself,
+// like the slog driver.
+type CtxLogger interface {
+	Logger
+	LogContext(ctx context.Context, level slog.Level, msg string)
+}
+
+// WithAttrs returns a copy of ctx carrying attrs besides the attributes ctx already carries,
+// they are attached to the logs of the Ctx functions. An attribute replaces the one of ctx
+// with the same key.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	prev, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	merged := make([]slog.Attr, 0, len(prev)+len(attrs))
+	for _, a := range prev {
+		if !slices.ContainsFunc(attrs, func(b slog.Attr) bool { return a.Key == b.Key }) {
+			merged = append(merged, a)
+		}
+	}
+	merged = append(merged, attrs...)
+	return context.WithValue(ctx, attrsKey{}, merged)
+}
+
+// Attrs returns the attributes to log with ctx: the trace_id and span_id of the span in ctx,
+// followed by the attributes added by WithAttrs.
+func Attrs(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	added, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return added
+	}
+	attrs := make([]slog.Attr, 0, len(added)+2)
+	attrs = append(attrs,
+		slog.String(constant.LogTraceIDKey, sc.TraceID().String()),
+		slog.String(constant.LogSpanIDKey, sc.SpanID().String()))
+	return append(attrs, added...)
+}
+
+// CtxDebugf logs at debug level with the attributes of ctx.
+func CtxDebugf(ctx context.Context, template string, args ...any) {
+	logf(ctx, slog.LevelDebug, template, args)
+}
+
+// CtxInfof logs at info level with the attributes of ctx.
+func CtxInfof(ctx context.Context, template string, args ...any) {
+	logf(ctx, slog.LevelInfo, template, args)
+}
+
+// CtxWarnf logs at warn level with the attributes of ctx.
+func CtxWarnf(ctx context.Context, template string, args ...any) {
+	logf(ctx, slog.LevelWarn, template, args)
+}
+
+// CtxErrorf logs at error level with the attributes of ctx.
+func CtxErrorf(ctx context.Context, template string, args ...any) {
+	logf(ctx, slog.LevelError, template, args)
+}
+
+// logf logs by the global logger of gost, which dubbo-go logs with. Loggers other than CtxLogger
+// get the attributes as fields for zap and logrus, or appended to the message otherwise.
+func logf(ctx context.Context, level slog.Level, template string, args []any) {
+	lg := dubbogoLogger.GetLogger()
+	if dl, ok := lg.(*dubbogoLogger.DubboLogger); ok {
+		lg = dl.Logger
+	}
+	switch l := lg.(type) {
+	case CtxLogger:
+		l.LogContext(ctx, level, fmt.Sprintf(template, args...))
+	case *zap.SugaredLogger:
+		zl := ctxZapLogger(l)
+		if ce := zl.Check(zapLevel(level), fmt.Sprintf(template, args...)); ce != nil {
+			attrs := Attrs(ctx)
+			fields := make([]zap.Field, 0, len(attrs))
+			for _, a := range attrs {
+				fields = append(fields, zap.Any(a.Key, a.Value.Any()))
+			}
+			ce.Write(fields...)
+		}
+	case *logrus.Logger:
+		lv := logrusLevel(level)
+		if !l.IsLevelEnabled(lv) {
+			return
+		}
+		attrs := Attrs(ctx)
+		fields := make(logrus.Fields, len(attrs))
+		for _, a := range attrs {
+			fields[a.Key] = a.Value.Any()
+		}
+		l.WithFields(fields).Logf(lv, template, args...)
+	default:
+		var b strings.Builder
+		fmt.Fprintf(&b, template, args...)
+		for _, a := range Attrs(ctx) {
+			b.WriteByte(' ')
+			b.WriteString(a.String())
+		}
+		switch {
+		case level >= slog.LevelError:
+			lg.Error(b.String())
+		case level >= slog.LevelWarn:
+			lg.Warn(b.String())
+		case level >= slog.LevelInfo:
+			lg.Info(b.String())
+		default:
+			lg.Debug(b.String())
+		}
+	}
+}
+
+// ctxZapLoggers caches the zap loggers which report the callers of the Ctx functions
+// instead of logf.
+var ctxZapLoggers sync.Map
+
+func ctxZapLogger(s *zap.SugaredLogger) *zap.Logger {
+	if zl, ok := ctxZapLoggers.Load(s); ok {
+		return zl.(*zap.Logger)
+	}
+	zl, _ := ctxZapLoggers.LoadOrStore(s, s.Desugar().WithOptions(zap.AddCallerSkip(1)))
+	return zl.(*zap.Logger)
+}
+
+func zapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zapcore.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zapcore.WarnLevel
+	case level >= slog.LevelInfo:
+		return zapcore.InfoLevel
+	default:
+		return zapcore.DebugLevel
+	}
+}
+
+func logrusLevel(level slog.Level) logrus.Level {
+	switch {
+	case level >= slog.LevelError:
+		return logrus.ErrorLevel
+	case level >= slog.LevelWarn:
+		return logrus.WarnLevel
+	case level >= slog.LevelInfo:
+		return logrus.InfoLevel
+	default:
+		return logrus.DebugLevel
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+import (
+	dubbogoLogger "github.com/dubbogo/gost/log/logger"
+
+	"github.com/sirupsen/logrus"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func spanContext(ctx context.Context) context.Context {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled,
+	}))
+}
+
+func TestWithAttrs(t *testing.T) {
+	ctx := WithAttrs(context.Background(), slog.String("service", "a"), slog.String("method", "m1"))
+	ctx = WithAttrs(ctx, slog.String("method", "m2"), slog.String("remote_addr", "127.0.0.1:20000"))
+
+	got := fmt.Sprint(Attrs(ctx))
+	if got != "[service=a method=m2 remote_addr=127.0.0.1:20000]" {
+		t.Fatalf("unexpected attrs %s", got)
+	}
+	if attrs := Attrs(context.Background()); len(attrs) != 0 {
+		t.Fatalf("expected no attrs, got %v", attrs)
+	}
+}
+
+func TestAttrsWithSpan(t *testing.T) {
+	ctx := WithAttrs(spanContext(context.Background()), slog.String("method", "m"))
+	got := fmt.Sprint(Attrs(ctx))
+	if got != "[trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7 method=m]" {
+		t.Fatalf("unexpected attrs %s", got)
+	}
+}
+
+func withGlobalLogger(t *testing.T, lg dubbogoLogger.Logger) {
+	prev := dubbogoLogger.GetLogger()
+	dubbogoLogger.SetLogger(lg)
+	t.Cleanup(func() { dubbogoLogger.SetLogger(prev) })
+}
+
+func TestCtxLogZap(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	withGlobalLogger(t, &dubbogoLogger.DubboLogger{Logger: zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1)).Sugar()})
+
+	ctx := WithAttrs(spanContext(context.Background()), slog.String("method", "m"))
+	CtxDebugf(ctx, "hidden")
+	CtxWarnf(ctx, "hello %s", "zap")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Message != "hello zap" || e.Level != zapcore.WarnLevel {
+		t.Fatalf("unexpected entry %+v", e)
+	}
+	fields := e.ContextMap()
+	if fields["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" || fields["method"] != "m" {
+		t.Fatalf("unexpected fields %v", fields)
+	}
+	if !strings.HasSuffix(e.Caller.File, "context_test.go") {
+		t.Fatalf("expected the caller to be the test, got %s", e.Caller.File)
+	}
+}
+
+func TestCtxLogLogrus(t *testing.T) {
+	var buf bytes.Buffer
+	lg := logrus.New()
+	lg.SetOutput(&buf)
+	lg.SetFormatter(&logrus.JSONFormatter{})
+	withGlobalLogger(t, &dubbogoLogger.DubboLogger{Logger: lg})
+
+	CtxDebugf(spanContext(context.Background()), "hidden")
+	CtxErrorf(spanContext(context.Background()), "hello %s", "logrus")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one json entry, got %q: %v", buf.String(), err)
+	}
+	if entry["msg"] != "hello logrus" || entry["level"] != "error" || entry["span_id"] != "00f067aa0ba902b7" {
+		t.Fatalf("unexpected entry %v", entry)
+	}
+}
+
+type recordLogger struct {
+	simpleLogger
+	logs []string
+}
+
+func (r *recordLogger) Info(args ...any) {
+	r.logs = append(r.logs, fmt.Sprint(args...))
+}
+
+type ctxRecordLogger struct {
+	simpleLogger
+	levels []slog.Level
+	attrs  [][]slog.Attr
+}
+
+func (r *ctxRecordLogger) LogContext(ctx context.Context, level slog.Level, msg string) {
+	r.levels = append(r.levels, level)
+	r.attrs = append(r.attrs, Attrs(ctx))
+}
+
+func TestCtxLogOthers(t *testing.T) {
+	r := &recordLogger{}
+	withGlobalLogger(t, r)
+	CtxInfof(WithAttrs(context.Background(), slog.String("service", "s")), "hello %d", 1)
+	if len(r.logs) != 1 || r.logs[0] != "hello 1 service=s" {
+		t.Fatalf("unexpected logs %v", r.logs)
+	}
+
+	c := &ctxRecordLogger{}
+	withGlobalLogger(t, c)
+	CtxWarnf(WithAttrs(context.Background(), slog.String("service", "s")), "hello")
+	if len(c.levels) != 1 || c.levels[0] != slog.LevelWarn || fmt.Sprint(c.attrs[0]) != "[service=s]" {
+		t.Fatalf("unexpected logs %v %v", c.levels, c.attrs)
+	}
+}
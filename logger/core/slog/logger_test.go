@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+import (
+	dubbogoLogger "github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/logger"
+	dubboslog "dubbo.apache.org/dubbo-go/v3/logger/core/slog"
+)
+
+const testPackage = "dubbo.apache.org/dubbo-go/v3/logger/core/slog_test"
+
+func newLogger(level slog.Level) (*dubboslog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	return dubboslog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}), level), &buf
+}
+
+func entries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var res []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid entry %q: %v", line, err)
+		}
+		res = append(res, entry)
+	}
+	return res
+}
+
+func TestLogContext(t *testing.T) {
+	lg, buf := newLogger(slog.LevelInfo)
+	prev := dubbogoLogger.GetLogger()
+	dubbogoLogger.SetLogger(lg)
+	defer dubbogoLogger.SetLogger(prev)
+
+	ctx := logger.WithAttrs(context.Background(), slog.String("service", "greet.GreetService"), slog.String("method", "Greet"))
+	logger.CtxInfof(ctx, "hello %s", "slog")
+	logger.CtxDebugf(ctx, "hidden")
+	dubbogoLogger.Warnf("plain %d", 1)
+
+	got := entries(t, buf)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 entries, got %v", got)
+	}
+	if got[0]["msg"] != "hello slog" || got[0]["level"] != "INFO" || got[0]["service"] != "greet.GreetService" || got[0]["method"] != "Greet" {
+		t.Fatalf("unexpected entry %v", got[0])
+	}
+	if got[1]["msg"] != "plain 1" || got[1]["level"] != "WARN" {
+		t.Fatalf("unexpected entry %v", got[1])
+	}
+	for _, e := range got {
+		if source, _ := e["source"].(string); !strings.HasPrefix(source, "slog/logger_test.go:") {
+			t.Fatalf("expected the source to be the test, got %v", e["source"])
+		}
+	}
+}
+
+func TestPackageLevel(t *testing.T) {
+	lg, buf := newLogger(slog.LevelWarn)
+	lg.Info("hidden")
+	if !lg.SetPackageLevel(testPackage, "debug") {
+		t.Fatal("expected the package level to be set")
+	}
+	lg.Debugf("shown %d", 1)
+	if !lg.SetPackageLevel("dubbo.apache.org/dubbo-go/v3/logger/core", "error") {
+		t.Fatal("expected the package level to be set")
+	}
+	lg.Debug("shown 2")
+	if lg.SetPackageLevel(testPackage, "verbose") {
+		t.Fatal("expected an invalid level to be rejected")
+	}
+	lg.SetPackageLevel(testPackage, "")
+	lg.Warn("hidden by the level of the parent package")
+	lg.SetPackageLevel("dubbo.apache.org/dubbo-go/v3/logger/core", "")
+	lg.Warn("shown 3")
+	lg.Debug("hidden")
+	if !lg.SetLoggerLevel("debug") {
+		t.Fatal("expected the level to be set")
+	}
+	lg.Debug("shown 4")
+
+	var msgs []string
+	for _, e := range entries(t, buf) {
+		msgs = append(msgs, e["msg"].(string))
+	}
+	if strings.Join(msgs, ",") != "shown 1,shown 2,shown 3,shown 4" {
+		t.Fatalf("unexpected logs %v", msgs)
+	}
+}
@@ -0,0 +1,293 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package slog is the log/slog driver of the dubbo-go logger. It attaches the trace and invocation
+// attributes of the context to the logs of the Ctx functions, and supports per-package levels.
+package slog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+import (
+	"github.com/mattn/go-colorable"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/logger"
+	"dubbo.apache.org/dubbo-go/v3/logger/core"
+)
+
+// LevelFatal is the level of Fatal logs, which slog does not define.
+const LevelFatal = slog.LevelError + 4
+
+// loggingPackages are skipped when looking for the caller of a log.
+var loggingPackages = map[string]bool{
+	"github.com/dubbogo/gost/log/logger":            true,
+	"dubbo.apache.org/dubbo-go/v3/logger":           true,
+	"dubbo.apache.org/dubbo-go/v3/logger/core/slog": true,
+}
+
+func init() {
+	extension.SetLogger("slog", instantiate)
+}
+
+func instantiate(config *common.URL) (logger.Logger, error) {
+	level, err := ParseLevel(config.GetParam(constant.LoggerLevelKey, constant.LoggerLevel))
+	if err != nil {
+		return nil, err
+	}
+
+	var writers []io.Writer
+	for _, apt := range strings.Split(config.GetParam(constant.LoggerAppenderKey, constant.LoggerAppender), ",") {
+		switch apt {
+		case "console":
+			writers = append(writers, os.Stdout)
+		case "file":
+			writers = append(writers, colorable.NewNonColorable(core.FileConfig(config)))
+		}
+	}
+
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: replaceLevel}
+	var handler slog.Handler
+	if strings.ToLower(config.GetParam(constant.LoggerFormatKey, constant.LoggerFormat)) == "json" {
+		handler = slog.NewJSONHandler(io.MultiWriter(writers...), opts)
+	} else {
+		handler = slog.NewTextHandler(io.MultiWriter(writers...), opts)
+	}
+
+	lg := New(handler, level)
+	if levels := config.GetParam(constant.LoggerLevelsKey, ""); levels != "" {
+		for _, item := range strings.Split(levels, ",") {
+			pkg, lv, ok := strings.Cut(item, "=")
+			if !ok || !lg.SetPackageLevel(strings.TrimSpace(pkg), strings.TrimSpace(lv)) {
+				return nil, fmt.Errorf("invalid package level %q, expect package=level", item)
+			}
+		}
+	}
+	return lg, nil
+}
+
+// ParseLevel parses debug, info, warn, error and fatal case-insensitively.
+func ParseLevel(level string) (slog.Level, error) {
+	if strings.EqualFold(level, "fatal") {
+		return LevelFatal, nil
+	}
+	var lv slog.Level
+	if err := lv.UnmarshalText([]byte(level)); err != nil {
+		return 0, err
+	}
+	return lv, nil
+}
+
+func replaceLevel(_ []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.LevelKey && a.Value.Any() == LevelFatal {
+		a.Value = slog.StringValue("FATAL")
+	}
+	return a
+}
+
+// Logger logs to a slog.Handler. Its level applies to the packages without a level of their own,
+// a package level applies to the package and its sub packages unless they have one.
+type Logger struct {
+	handler slog.Handler
+	level   slog.LevelVar
+
+	mu sync.Mutex
+	// levels holds the sorted package levels, the longer one of nested packages comes first
+	levels atomic.Pointer[[]packageLevel]
+	// minPackageLevel is the lowest package level, it lets enabled skip looking for the caller
+	minPackageLevel atomic.Int64
+}
+
+type packageLevel struct {
+	pkg   string
+	level slog.Level
+}
+
+// New returns a Logger logging to handler at level.
+func New(handler slog.Handler, level slog.Level) *Logger {
+	l := &Logger{handler: handler}
+	l.level.Set(level)
+	l.minPackageLevel.Store(math.MaxInt64)
+	return l
+}
+
+// SetLoggerLevel sets the level of the packages without a level of their own.
+func (l *Logger) SetLoggerLevel(level string) bool {
+	lv, err := ParseLevel(level)
+	if err != nil {
+		return false
+	}
+	l.level.Set(lv)
+	return true
+}
+
+// SetPackageLevel sets the level of pkg and its sub packages, an empty level removes it.
+func (l *Logger) SetPackageLevel(pkg, level string) bool {
+	if pkg == "" {
+		return false
+	}
+	var lv slog.Level
+	if level != "" {
+		var err error
+		if lv, err = ParseLevel(level); err != nil {
+			return false
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var levels []packageLevel
+	if cur := l.levels.Load(); cur != nil {
+		for _, pl := range *cur {
+			if pl.pkg != pkg {
+				levels = append(levels, pl)
+			}
+		}
+	}
+	if level != "" {
+		levels = append(levels, packageLevel{pkg: pkg, level: lv})
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].pkg > levels[j].pkg })
+	var minLevel int64 = math.MaxInt64
+	for _, pl := range levels {
+		minLevel = min(minLevel, int64(pl.level))
+	}
+	l.minPackageLevel.Store(minLevel)
+	l.levels.Store(&levels)
+	return true
+}
+
+// enabled returns the caller if a log at level is enabled for it.
+func (l *Logger) enabled(ctx context.Context, level slog.Level) (runtime.Frame, bool) {
+	if level < l.level.Level() && int64(level) < l.minPackageLevel.Load() {
+		return runtime.Frame{}, false
+	}
+	frame, pkg := caller()
+	lowest := l.level.Level()
+	if levels := l.levels.Load(); levels != nil {
+		for _, pl := range *levels {
+			if pkg == pl.pkg || strings.HasPrefix(pkg, pl.pkg+"/") {
+				lowest = pl.level
+				break
+			}
+		}
+	}
+	return frame, level >= lowest && l.handler.Enabled(ctx, level)
+}
+
+// caller returns the frame and package of the first caller outside the logging packages.
+func caller() (runtime.Frame, string) {
+	var pcs [16]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(4, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if pkg := funcPackage(frame.Function); !loggingPackages[pkg] {
+			return frame, pkg
+		}
+		if !more {
+			return runtime.Frame{}, ""
+		}
+	}
+}
+
+// funcPackage returns the package of a function name like a/b.(*T).M.
+func funcPackage(fn string) string {
+	slash := strings.LastIndexByte(fn, '/')
+	if dot := strings.IndexByte(fn[slash+1:], '.'); dot >= 0 {
+		return fn[:slash+1+dot]
+	}
+	return fn
+}
+
+func (l *Logger) log(ctx context.Context, level slog.Level, msg func() string) {
+	frame, ok := l.enabled(ctx, level)
+	if !ok {
+		return
+	}
+	r := slog.NewRecord(time.Now(), level, msg(), 0)
+	if frame.File != "" {
+		// the source is added here rather than by the handler, as the program counter of a caller
+		// which inlined a logging function resolves to the logging function
+		r.AddAttrs(slog.String(slog.SourceKey, fmt.Sprintf("%s/%s:%d", filepath.Base(filepath.Dir(frame.File)), filepath.Base(frame.File), frame.Line)))
+	}
+	r.AddAttrs(logger.Attrs(ctx)...)
+	_ = l.handler.Handle(ctx, r)
+}
+
+// LogContext logs msg with the attributes of ctx.
+func (l *Logger) LogContext(ctx context.Context, level slog.Level, msg string) {
+	l.log(ctx, level, func() string { return msg })
+}
+
+func (l *Logger) Debug(args ...any) {
+	l.log(context.Background(), slog.LevelDebug, func() string { return fmt.Sprint(args...) })
+}
+
+func (l *Logger) Debugf(template string, args ...any) {
+	l.log(context.Background(), slog.LevelDebug, func() string { return fmt.Sprintf(template, args...) })
+}
+
+func (l *Logger) Info(args ...any) {
+	l.log(context.Background(), slog.LevelInfo, func() string { return fmt.Sprint(args...) })
+}
+
+func (l *Logger) Infof(template string, args ...any) {
+	l.log(context.Background(), slog.LevelInfo, func() string { return fmt.Sprintf(template, args...) })
+}
+
+func (l *Logger) Warn(args ...any) {
+	l.log(context.Background(), slog.LevelWarn, func() string { return fmt.Sprint(args...) })
+}
+
+func (l *Logger) Warnf(template string, args ...any) {
+	l.log(context.Background(), slog.LevelWarn, func() string { return fmt.Sprintf(template, args...) })
+}
+
+func (l *Logger) Error(args ...any) {
+	l.log(context.Background(), slog.LevelError, func() string { return fmt.Sprint(args...) })
+}
+
+func (l *Logger) Errorf(template string, args ...any) {
+	l.log(context.Background(), slog.LevelError, func() string { return fmt.Sprintf(template, args...) })
+}
+
+func (l *Logger) Fatal(args ...any) {
+	l.log(context.Background(), LevelFatal, func() string { return fmt.Sprint(args...) })
+	os.Exit(1)
+}
+
+func (l *Logger) Fatalf(template string, args ...any) {
+	l.log(context.Background(), LevelFatal, func() string { return fmt.Sprintf(template, args...) })
+	os.Exit(1)
+}
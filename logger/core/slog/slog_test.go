@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slog
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/url"
+	"testing"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+)
+
+func TestInstantiate(t *testing.T) {
+	u := &common.URL{}
+	u.ReplaceParams(url.Values{
+		constant.LoggerLevelKey:    []string{"warn"},
+		constant.LoggerAppenderKey: []string{"console"},
+		constant.LoggerFormatKey:   []string{"json"},
+		constant.LoggerLevelsKey:   []string{"dubbo.apache.org/dubbo-go/v3/registry=debug, dubbo.apache.org/dubbo-go/v3/cluster=error"},
+	})
+	lg, err := instantiate(u)
+	if err != nil {
+		t.Fatalf("expected slog logger, err=%v", err)
+	}
+	l := lg.(*Logger)
+	if l.level.Level() != slog.LevelWarn {
+		t.Fatalf("expected warn level, got %v", l.level.Level())
+	}
+	levels := *l.levels.Load()
+	if len(levels) != 2 || levels[0].pkg != "dubbo.apache.org/dubbo-go/v3/registry" || levels[0].level != slog.LevelDebug {
+		t.Fatalf("unexpected package levels %v", levels)
+	}
+}
+
+func TestInstantiateInvalid(t *testing.T) {
+	for _, params := range []url.Values{
+		{constant.LoggerLevelKey: []string{"verbose"}},
+		{constant.LoggerLevelsKey: []string{"dubbo.apache.org/dubbo-go/v3/registry"}},
+		{constant.LoggerLevelsKey: []string{"dubbo.apache.org/dubbo-go/v3/registry=verbose"}},
+	} {
+		u := &common.URL{}
+		u.ReplaceParams(params)
+		if _, err := instantiate(u); err == nil {
+			t.Fatalf("expected an error for %v", params)
+		}
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for level, want := range map[string]slog.Level{
+		"debug": slog.LevelDebug, "INFO": slog.LevelInfo, "warn": slog.LevelWarn, "error": slog.LevelError, "fatal": LevelFatal,
+	} {
+		got, err := ParseLevel(level)
+		if err != nil || got != want {
+			t.Fatalf("ParseLevel(%q) = %v, %v, want %v", level, got, err, want)
+		}
+	}
+}
+
+func TestFuncPackage(t *testing.T) {
+	for fn, want := range map[string]string{
+		"dubbo.apache.org/dubbo-go/v3/registry/zookeeper.(*zkRegistry).DoRegister": "dubbo.apache.org/dubbo-go/v3/registry/zookeeper",
+		"github.com/dubbogo/gost/log/logger.Infof":                                 "github.com/dubbogo/gost/log/logger",
+		"main.main.func1":                                                          "main",
+	} {
+		if got := funcPackage(fn); got != want {
+			t.Fatalf("funcPackage(%q) = %q, want %q", fn, got, want)
+		}
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	l := New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug}), slog.LevelWarn)
+	if _, ok := l.enabled(context.Background(), slog.LevelInfo); ok {
+		t.Fatal("expected info to be disabled")
+	}
+
+	// the caller of enabled outside the logging packages is the testing package
+	l.SetPackageLevel("dubbo.apache.org/dubbo-go/v3/registry", "error")
+	l.SetPackageLevel("testing", "info")
+	if l.minPackageLevel.Load() != int64(slog.LevelInfo) {
+		t.Fatalf("expected the lowest package level to be info, got %v", l.minPackageLevel.Load())
+	}
+	if _, ok := l.enabled(context.Background(), slog.LevelDebug); ok {
+		t.Fatal("expected debug to be disabled")
+	}
+	if _, ok := l.enabled(context.Background(), slog.LevelInfo); !ok {
+		t.Fatal("expected info to be enabled for the testing package")
+	}
+
+	l.SetPackageLevel("testing", "")
+	if _, ok := l.enabled(context.Background(), slog.LevelInfo); ok {
+		t.Fatal("expected info to be disabled once the package level is removed")
+	}
+}
+
+func BenchmarkDisabledDebug(b *testing.B) {
+	l := New(slog.NewTextHandler(io.Discard, nil), slog.LevelInfo)
+	l.SetPackageLevel("dubbo.apache.org/dubbo-go/v3/registry", "warn")
+	for i := 0; i < b.N; i++ {
+		l.Debug("disabled")
+	}
+}
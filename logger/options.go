@@ -51,12 +51,29 @@ func WithZap() Option {
 	}
 }
 
+// WithSlog uses the log/slog driver, which supports per-package levels.
+func WithSlog() Option {
+	return func(opts *Options) {
+		opts.Logger.Driver = "slog"
+	}
+}
+
 func WithLevel(level string) Option {
 	return func(opts *Options) {
 		opts.Logger.Level = level
 	}
 }
 
+// WithPackageLevel sets the level of pkg and its sub packages, eg: WithPackageLevel("dubbo.apache.org/dubbo-go/v3/registry", "debug").
+func WithPackageLevel(pkg, level string) Option {
+	return func(opts *Options) {
+		if opts.Logger.Levels == nil {
+			opts.Logger.Levels = make(map[string]string)
+		}
+		opts.Logger.Levels[pkg] = level
+	}
+}
+
 func WithFormat(format string) Option {
 	return func(opts *Options) {
 		opts.Logger.Format = format
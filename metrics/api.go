@@ -92,6 +92,21 @@ type RtOpts struct {
 	TimeWindowSeconds int64 // only for aggRt
 }
 
+// HistogramOpts is the options of a histogram
+type HistogramOpts struct {
+	Buckets []float64 // upper bounds of the buckets, the default buckets of the registry are used if empty
+}
+
+// BucketHistogramRegistry is implemented by the MetricRegistry supporting histograms with their own buckets
+type BucketHistogramRegistry interface {
+	BucketHistogram(*MetricId, *HistogramOpts) ObservableMetric // add a metric num to a histogram with opts
+}
+
+// RemovableRegistry is implemented by the MetricRegistry supporting to remove a metric of a label set
+type RemovableRegistry interface {
+	Remove(*MetricId) bool // remove the metric with the name and tags of the id, return false if not found
+}
+
 // multi registry，like micrometer CompositeMeterRegistry
 // type CompositeRegistry struct {
 // 	rs []MetricRegistry
@@ -276,6 +291,37 @@ func (d *DefaultRtVec) Record(labels map[string]string, v float64) {
 	d.metricRegistry.Rt(NewMetricIdByLabels(d.metricKey, labels), d.rtOpts).Observe(v)
 }
 
+// HistogramVec means a set of histograms with the same metricKey but different labels
+type HistogramVec interface {
+	Record(labels map[string]string, v float64)
+}
+
+// NewHistogramVec create a HistogramVec default implementation.
+func NewHistogramVec(metricRegistry MetricRegistry, metricKey *MetricKey, opts *HistogramOpts) HistogramVec {
+	return &DefaultHistogramVec{
+		metricRegistry: metricRegistry,
+		metricKey:      metricKey,
+		opts:           opts,
+	}
+}
+
+// DefaultHistogramVec is a default HistogramVec implementation.
+type DefaultHistogramVec struct {
+	metricRegistry MetricRegistry
+	metricKey      *MetricKey
+	opts           *HistogramOpts
+}
+
+// Record observes v with the buckets of opts if the registry supports them, or its default buckets.
+func (d *DefaultHistogramVec) Record(labels map[string]string, v float64) {
+	id := NewMetricIdByLabels(d.metricKey, labels)
+	if r, ok := d.metricRegistry.(BucketHistogramRegistry); ok {
+		r.BucketHistogram(id, d.opts).Observe(v)
+		return
+	}
+	d.metricRegistry.Histogram(id).Observe(v)
+}
+
 // labelsToString convert @labels to json format string for cache key
 func labelsToString(labels map[string]string) string {
 	labelsJson, err := json.Marshal(labels)
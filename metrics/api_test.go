@@ -275,6 +275,17 @@ func TestDefaultRtVec(t *testing.T) {
 	assert.NotNil(t, rt)
 }
 
+func TestDefaultHistogramVec(t *testing.T) {
+	registry := newMockMetricRegistry()
+	key := NewMetricKey("test_histogram", "Test histogram")
+	histogramVec := NewHistogramVec(registry, key, &HistogramOpts{Buckets: []float64{64, 1024}})
+	labels := map[string]string{"app": "dubbo", "version": "1.0.0"}
+
+	// the registry without BucketHistogram falls back to Histogram
+	histogramVec.Record(labels, 100.0)
+	assert.Equal(t, 100.0, registry.histograms[key.Name].value)
+}
+
 func TestDefaultQpsMetricVec(t *testing.T) {
 	registry := newMockMetricRegistry()
 	key := NewMetricKey("test_qps", "Test QPS")
@@ -94,6 +94,16 @@ type MethodMetricLevel struct {
 	Version string
 }
 
+// NewMethodMetric creates the level of a method of interfaceName in the group and version
+func NewMethodMetric(interfaceName, method, group, version string) *MethodMetricLevel {
+	return &MethodMetricLevel{
+		ServiceMetricLevel: NewServiceMetric(interfaceName),
+		Method:             method,
+		Group:              group,
+		Version:            version,
+	}
+}
+
 func (m MethodMetricLevel) Tags() map[string]string {
 	tags := m.ServiceMetricLevel.Tags()
 	tags[constant.TagMethod] = m.Method
@@ -28,6 +28,7 @@ const eventType = constant.MetricsConfigCenter
 
 var ch = make(chan metrics.MetricsEvent, 10)
 var info = metrics.NewMetricKey("dubbo_configcenter_total", "Config Changed Total")
+var rejected = metrics.NewMetricKey("dubbo_configcenter_rejected_total", "Config Change Rejected Total")
 
 func init() {
 	metrics.AddCollector("config_center", func(mr metrics.MetricRegistry, url *common.URL) {
@@ -46,8 +47,11 @@ func (c *configCenterCollector) start() {
 	metrics.Subscribe(eventType, ch)
 	go func() {
 		for e := range ch {
-			if event, ok := e.(*ConfigCenterMetricEvent); ok {
+			switch event := e.(type) {
+			case *ConfigCenterMetricEvent:
 				c.handleDataChange(event)
+			case *ConfigCenterRejectedMetricEvent:
+				c.handleRejected(event)
 			}
 		}
 	}()
@@ -58,10 +62,22 @@ func (c *configCenterCollector) handleDataChange(event *ConfigCenterMetricEvent)
 	c.r.Counter(id).Add(event.size)
 }
 
+func (c *configCenterCollector) handleRejected(event *ConfigCenterRejectedMetricEvent) {
+	labels := metrics.NewConfigCenterLevel(event.key, "", "", "").Tags()
+	delete(labels, constant.TagGroup)
+	delete(labels, constant.TagConfigCenter)
+	delete(labels, constant.TagChangeType)
+	labels[constant.TagReason] = event.reason
+	c.r.Counter(metrics.NewMetricIdByLabels(rejected, labels)).Inc()
+}
+
 const (
-	Nacos     = "nacos"
-	Apollo    = "apollo"
-	Zookeeper = "zookeeper"
+	Nacos      = "nacos"
+	Apollo     = "apollo"
+	Zookeeper  = "zookeeper"
+	Etcd       = "etcd"
+	Kubernetes = "kubernetes"
+	Polaris    = "polaris"
 )
 
 type ConfigCenterMetricEvent struct {
@@ -93,3 +109,17 @@ func (*ConfigCenterMetricEvent) Type() string {
 func NewIncMetricEvent(key, group string, changeType remoting.EventType, c string) *ConfigCenterMetricEvent {
 	return &ConfigCenterMetricEvent{key: key, group: group, changeType: changeType, configCenter: c, size: 1}
 }
+
+// ConfigCenterRejectedMetricEvent is published once a config change is rejected before it is applied
+type ConfigCenterRejectedMetricEvent struct {
+	key    string
+	reason string
+}
+
+func (*ConfigCenterRejectedMetricEvent) Type() string {
+	return eventType
+}
+
+func NewRejectedMetricEvent(key, reason string) *ConfigCenterRejectedMetricEvent {
+	return &ConfigCenterRejectedMetricEvent{key: key, reason: reason}
+}
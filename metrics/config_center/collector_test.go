@@ -91,3 +91,11 @@ func TestNewIncMetricEvent(t *testing.T) {
 	assert.Equal(t, Nacos, event.configCenter)
 	assert.InDelta(t, 1.0, event.size, 0.01)
 }
+
+func TestNewRejectedMetricEvent(t *testing.T) {
+	event := NewRejectedMetricEvent("test-key", "invalid")
+
+	assert.Equal(t, constant.MetricsConfigCenter, event.Type())
+	assert.Equal(t, "test-key", event.key)
+	assert.Equal(t, "invalid", event.reason)
+}
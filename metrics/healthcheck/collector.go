@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package healthcheck
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+)
+
+var (
+	healthCheckChan = make(chan metrics.MetricsEvent, 128)
+
+	ProbeTotal   = metrics.NewMetricKey("dubbo_consumer_health_check_total", "Total Health Check Probes")
+	ProbeSucceed = metrics.NewMetricKey("dubbo_consumer_health_check_succeed_total", "Succeed Health Check Probes")
+	ProbeFailed  = metrics.NewMetricKey("dubbo_consumer_health_check_failed_total", "Failed Health Check Probes")
+	ProbeRt      = metrics.NewMetricKey("dubbo_consumer_health_check_rt_milliseconds", "Health Check Probe Time")
+	Healthy      = metrics.NewMetricKey("dubbo_consumer_health_check_healthy", "Whether The Provider Is Healthy")
+)
+
+func init() {
+	metrics.AddCollector("health_check", func(m metrics.MetricRegistry, url *common.URL) {
+		if url.GetParamBool(constant.HealthCheckMetricsEnabledKey, true) {
+			hc := &healthCheckCollector{metrics.BaseCollector{R: m}}
+			go hc.start()
+		}
+	})
+}
+
+// healthCheckCollector is the collector of the consumer active health check metrics
+type healthCheckCollector struct {
+	metrics.BaseCollector
+}
+
+func (hc *healthCheckCollector) start() {
+	metrics.Subscribe(constant.MetricsHealthCheck, healthCheckChan)
+	for event := range healthCheckChan {
+		probeEvent, ok := event.(*ProbeEvent)
+		if !ok {
+			continue
+		}
+		if probeEvent.Removed {
+			hc.removeHandler(probeEvent)
+		} else {
+			hc.probeHandler(probeEvent)
+		}
+	}
+}
+
+// probeHandler handles the result of a probe
+func (hc *healthCheckCollector) probeHandler(event *ProbeEvent) {
+	level := NewProbeLevel(event.Interface, event.Address)
+	hc.StateCount(ProbeTotal, ProbeSucceed, ProbeFailed, level, event.Succ)
+	hc.R.Rt(metrics.NewMetricId(ProbeRt, level), &metrics.RtOpts{}).Observe(event.CostMs())
+	healthy := 0.0
+	if event.Healthy {
+		healthy = 1
+	}
+	hc.R.Gauge(metrics.NewMetricId(Healthy, level)).Set(healthy)
+}
+
+// removeHandler removes the health state of a provider which is not probed any more,
+// the counters are kept as they are totals
+func (hc *healthCheckCollector) removeHandler(event *ProbeEvent) {
+	if r, ok := hc.R.(metrics.RemovableRegistry); ok {
+		r.Remove(metrics.NewMetricId(Healthy, NewProbeLevel(event.Interface, event.Address)))
+	}
+}
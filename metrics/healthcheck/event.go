@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package healthcheck
+
+import (
+	"time"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+)
+
+// ProbeEvent is the result of a health check probe to a provider
+type ProbeEvent struct {
+	Interface string
+	Address   string
+	Succ      bool
+	// Healthy is the health state of the provider after the probe
+	Healthy bool
+	// Removed means the provider is not probed any more, the other fields except Interface and Address are unset
+	Removed bool
+	Start   time.Time
+	End     time.Time
+}
+
+func (*ProbeEvent) Type() string {
+	return constant.MetricsHealthCheck
+}
+
+func (e *ProbeEvent) CostMs() float64 {
+	return float64(e.End.Sub(e.Start)) / float64(time.Millisecond)
+}
+
+// NewProbeEvent for health check probe metrics
+func NewProbeEvent(interfaceName, address string, succ, healthy bool, start time.Time) metrics.MetricsEvent {
+	return &ProbeEvent{
+		Interface: interfaceName,
+		Address:   address,
+		Succ:      succ,
+		Healthy:   healthy,
+		Start:     start,
+		End:       time.Now(),
+	}
+}
+
+// NewRemovedEvent for a provider which is not probed any more, so its health state is not reported
+func NewRemovedEvent(interfaceName, address string) metrics.MetricsEvent {
+	return &ProbeEvent{
+		Interface: interfaceName,
+		Address:   address,
+		Removed:   true,
+	}
+}
+
+// ProbeLevel is the metric level of a provider of a service
+type ProbeLevel struct {
+	*metrics.ServiceMetricLevel
+	Address string
+}
+
+func NewProbeLevel(interfaceName, address string) *ProbeLevel {
+	return &ProbeLevel{ServiceMetricLevel: metrics.NewServiceMetric(interfaceName), Address: address}
+}
+
+func (l ProbeLevel) Tags() map[string]string {
+	tags := l.ServiceMetricLevel.Tags()
+	tags[constant.TagAddress] = l.Address
+	return tags
+}
@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package healthcheck
+
+import (
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+)
+
+func TestNewProbeEvent(t *testing.T) {
+	start := time.Now().Add(-5 * time.Millisecond)
+	event := NewProbeEvent("org.example.DemoService", "127.0.0.1:20000", false, true, start).(*ProbeEvent)
+
+	assert.Equal(t, constant.MetricsHealthCheck, event.Type())
+	assert.Equal(t, "org.example.DemoService", event.Interface)
+	assert.Equal(t, "127.0.0.1:20000", event.Address)
+	assert.False(t, event.Succ)
+	assert.True(t, event.Healthy)
+	assert.GreaterOrEqual(t, event.CostMs(), 5.0)
+}
+
+func TestNewRemovedEvent(t *testing.T) {
+	event := NewRemovedEvent("org.example.DemoService", "127.0.0.1:20000").(*ProbeEvent)
+
+	assert.Equal(t, constant.MetricsHealthCheck, event.Type())
+	assert.Equal(t, "org.example.DemoService", event.Interface)
+	assert.Equal(t, "127.0.0.1:20000", event.Address)
+	assert.True(t, event.Removed)
+}
+
+func TestProbeLevelTags(t *testing.T) {
+	tags := NewProbeLevel("org.example.DemoService", "127.0.0.1:20000").Tags()
+
+	assert.Equal(t, "org.example.DemoService", tags[constant.TagInterface])
+	assert.Equal(t, "127.0.0.1:20000", tags[constant.TagAddress])
+	assert.Contains(t, tags, constant.TagApplicationName)
+}
@@ -87,8 +87,11 @@ func CheckLiveness(ctx context.Context) error {
 	return runChecks(ctx, &livenessMu, livenessChecks)
 }
 
-// CheckReadiness evaluates all readiness checks.
+// CheckReadiness evaluates the warm-up hooks and all readiness checks.
 func CheckReadiness(ctx context.Context) error {
+	if err := CheckWarmup(); err != nil {
+		return err
+	}
 	return runChecks(ctx, &readinessMu, readinessChecks)
 }
 
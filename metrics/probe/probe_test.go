@@ -36,6 +36,10 @@ func resetProbeState() {
 	startupChecks = map[string]CheckFunc{}
 	startupMu.Unlock()
 
+	warmupMu.Lock()
+	warmupHooks = map[string]*warmupHook{}
+	warmupMu.Unlock()
+
 	internalStateEnabled.Store(false)
 	readyFlag.Store(false)
 	startupFlag.Store(false)
@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var errWarmingUp = errors.New("warming up")
+
+// warmupHook is a user-registered warm-up task, run at most once.
+type warmupHook struct {
+	fn      CheckFunc
+	once    sync.Once
+	started atomic.Bool
+	done    chan struct{}
+	err     error
+}
+
+func (h *warmupHook) start(ctx context.Context) {
+	h.once.Do(func() {
+		h.started.Store(true)
+		go func() {
+			defer close(h.done)
+			h.err = h.fn(ctx)
+		}()
+	})
+}
+
+func (h *warmupHook) finished() (bool, error) {
+	select {
+	case <-h.done:
+		return true, h.err
+	default:
+		return false, nil
+	}
+}
+
+var (
+	warmupMu    sync.RWMutex
+	warmupHooks = make(map[string]*warmupHook)
+)
+
+// RegisterWarmup registers a warm-up hook, such as cache preloading. The hooks
+// are run concurrently by AwaitWarmup with its context, and once started,
+// readiness fails until all of them have returned nil.
+func RegisterWarmup(name string, fn CheckFunc) {
+	if name == "" || fn == nil {
+		return
+	}
+	warmupMu.Lock()
+	defer warmupMu.Unlock()
+	warmupHooks[name] = &warmupHook{fn: fn, done: make(chan struct{})}
+}
+
+// AwaitWarmup starts the registered warm-up hooks which have not run yet and
+// blocks until all of them have finished or ctx is done.
+func AwaitWarmup(ctx context.Context) error {
+	hooks := snapshotWarmupHooks()
+	for _, h := range hooks {
+		h.start(ctx)
+	}
+	var errs []error
+	for name, h := range hooks {
+		select {
+		case <-h.done:
+			if h.err != nil {
+				errs = append(errs, fmt.Errorf("warmup %s: %w", name, h.err))
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AwaitWarmupTimeout runs AwaitWarmup, giving up once the timeout elapses.
+func AwaitWarmupTimeout(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return AwaitWarmup(ctx)
+}
+
+// CheckWarmup returns nil once every started warm-up hook has succeeded. The hooks
+// which are not started, since the registry publication is not gated on them, are ignored.
+func CheckWarmup() error {
+	for name, h := range snapshotWarmupHooks() {
+		if !h.started.Load() {
+			continue
+		}
+		ok, err := h.finished()
+		if !ok {
+			return fmt.Errorf("warmup %s: %w", name, errWarmingUp)
+		}
+		if err != nil {
+			return fmt.Errorf("warmup %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func snapshotWarmupHooks() map[string]*warmupHook {
+	warmupMu.RLock()
+	defer warmupMu.RUnlock()
+	snapshot := make(map[string]*warmupHook, len(warmupHooks))
+	for k, v := range warmupHooks {
+		snapshot[k] = v
+	}
+	return snapshot
+}
@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAwaitWarmup(t *testing.T) {
+	resetProbeState()
+
+	release := make(chan struct{})
+	RegisterWarmup("cache", func(context.Context) error {
+		<-release
+		return nil
+	})
+
+	// the hooks which are not started don't gate readiness
+	if err := CheckReadiness(context.Background()); err != nil {
+		t.Fatalf("expected readiness ok before warm-up starts, got %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- AwaitWarmup(context.Background()) }()
+	select {
+	case err := <-done:
+		t.Fatalf("expected AwaitWarmup to block, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if err := CheckReadiness(context.Background()); !errors.Is(err, errWarmingUp) {
+		t.Fatalf("expected readiness to wait for warm-up, got %v", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("expected warm-up ok, got %v", err)
+	}
+	if err := CheckReadiness(context.Background()); err != nil {
+		t.Fatalf("expected readiness ok after warm-up, got %v", err)
+	}
+}
+
+func TestAwaitWarmupRunsHookOnce(t *testing.T) {
+	resetProbeState()
+
+	calls := 0
+	RegisterWarmup("once", func(context.Context) error {
+		calls++
+		return nil
+	})
+	for i := 0; i < 3; i++ {
+		if err := AwaitWarmup(context.Background()); err != nil {
+			t.Fatalf("expected warm-up ok, got %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected hook to run once, got %d", calls)
+	}
+}
+
+func TestAwaitWarmupFailure(t *testing.T) {
+	resetProbeState()
+
+	RegisterWarmup("fail", func(context.Context) error { return errors.New("bad") })
+	if err := AwaitWarmup(context.Background()); err == nil {
+		t.Fatalf("expected warm-up error, got nil")
+	}
+	if err := CheckReadiness(context.Background()); err == nil {
+		t.Fatalf("expected readiness error after failed warm-up, got nil")
+	}
+}
+
+func TestAwaitWarmupContextDone(t *testing.T) {
+	resetProbeState()
+
+	RegisterWarmup("stuck", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err := AwaitWarmupTimeout(20 * time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
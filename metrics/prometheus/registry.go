@@ -91,11 +91,19 @@ func (p *promMetricRegistry) Gauge(m *metrics.MetricId) metrics.GaugeMetric {
 }
 
 func (p *promMetricRegistry) Histogram(m *metrics.MetricId) metrics.ObservableMetric {
+	return p.BucketHistogram(m, nil)
+}
+
+func (p *promMetricRegistry) BucketHistogram(m *metrics.MetricId, opts *metrics.HistogramOpts) metrics.ObservableMetric {
 	vec := p.getOrComputeVec(m.Name, func() prom.Collector {
-		return prom.NewHistogramVec(prom.HistogramOpts{
+		histogramOpts := prom.HistogramOpts{
 			Name: m.Name,
 			Help: m.Desc,
-		}, m.TagKeys())
+		}
+		if opts != nil && len(opts.Buckets) > 0 {
+			histogramOpts.Buckets = opts.Buckets
+		}
+		return prom.NewHistogramVec(histogramOpts, m.TagKeys())
 	}).(*prom.HistogramVec)
 	return vec.With(m.Tags)
 }
@@ -110,6 +118,18 @@ func (p *promMetricRegistry) Summary(m *metrics.MetricId) metrics.ObservableMetr
 	return vec.With(m.Tags)
 }
 
+// Remove removes the metric with the tags of m from the vec named m.Name, rt metrics are not removable
+func (p *promMetricRegistry) Remove(m *metrics.MetricId) bool {
+	v, ok := p.vecs.Load(m.Name)
+	if !ok {
+		return false
+	}
+	if vec, ok := v.(interface{ Delete(prom.Labels) bool }); ok {
+		return vec.Delete(m.Tags)
+	}
+	return false
+}
+
 func (p *promMetricRegistry) Rt(m *metrics.MetricId, opts *metrics.RtOpts) metrics.ObservableMetric {
 	key := m.Name
 	var supplier func() prom.Collector
@@ -76,6 +76,21 @@ func TestPromMetricRegistryGauge(t *testing.T) {
 
 }
 
+func TestPromMetricRegistryRemove(t *testing.T) {
+	p := NewPromMetricRegistry(prom.NewRegistry(), url)
+	assert.False(t, p.Remove(metricId))
+	p.Gauge(metricId).Set(100)
+	other := &metrics.MetricId{Name: metricId.Name, Desc: metricId.Desc, Tags: map[string]string{"app": "dubbo", "version": "2.0.0"}}
+	p.Gauge(other).Set(1)
+
+	assert.True(t, p.Remove(metricId))
+	assert.False(t, p.Remove(metricId))
+	text, err := p.Scrape()
+	require.NoError(t, err)
+	assert.NotContains(t, text, `dubbo_request{app="dubbo",version="1.0.0"}`)
+	assert.Contains(t, text, `dubbo_request{app="dubbo",version="2.0.0"} 1`)
+}
+
 func TestPromMetricRegistryHistogram(t *testing.T) {
 	p := NewPromMetricRegistry(prom.NewRegistry(), url)
 	p.Histogram(metricId).Observe(100)
@@ -87,6 +102,15 @@ func TestPromMetricRegistryHistogram(t *testing.T) {
 	assert.Contains(t, text, `dubbo_request_count{app="dubbo",version="1.0.0"} 1`)
 }
 
+func TestPromMetricRegistryHistogramBuckets(t *testing.T) {
+	p := NewPromMetricRegistry(prom.NewRegistry(), url)
+	p.BucketHistogram(metricId, &metrics.HistogramOpts{Buckets: []float64{64, 1024}}).Observe(100)
+	text, err := p.Scrape()
+	require.NoError(t, err)
+	assert.Contains(t, text, `dubbo_request_bucket{app="dubbo",version="1.0.0",le="64"} 0`)
+	assert.Contains(t, text, `dubbo_request_bucket{app="dubbo",version="1.0.0",le="1024"} 1`)
+}
+
 func TestPromMetricRegistrySummary(t *testing.T) {
 	p := NewPromMetricRegistry(prom.NewRegistry(), url)
 	p.Summary(metricId).Observe(100)
@@ -56,6 +56,8 @@ func (rc *registryCollector) start() {
 				rc.serverRegHandler(registryEvent)
 			case ServerSub:
 				rc.serverSubHandler(registryEvent)
+			case Migration:
+				rc.migrationHandler(registryEvent)
 			default:
 			}
 		}
@@ -105,3 +107,26 @@ func (rc *registryCollector) serverSubHandler(event *RegistryMetricsEvent) {
 	level := metrics.GetApplicationLevel()
 	rc.StateCount(ServiceSubscribeMetricNum, ServiceSubscribeMetricNumSucceed, ServiceSubscribeMetricNumFailed, level, event.Succ)
 }
+
+// migrationHandler handles migration metrics, the source used by the service is set to 1 and the other one to 0
+func (rc *registryCollector) migrationHandler(event *RegistryMetricsEvent) {
+	source := event.Attachment[constant.TagSource]
+	for _, s := range []string{constant.RegistryTypeInterface, constant.RegistryTypeService} {
+		labels := rc.migrationLabels(event, s)
+		value := float64(0)
+		if s == source {
+			value = 1
+		}
+		rc.R.Gauge(metrics.NewMetricIdByLabels(MigrationMetricSource, labels)).Set(value)
+	}
+	if event.Attachment["from"] != "" {
+		rc.R.Counter(metrics.NewMetricIdByLabels(MigrationMetricSwitchTotal, rc.migrationLabels(event, source))).Inc()
+	}
+}
+
+func (rc *registryCollector) migrationLabels(event *RegistryMetricsEvent, source string) map[string]string {
+	labels := metrics.GetApplicationLevel().Tags()
+	labels[constant.TagInterface] = event.Attachment[constant.TagInterface]
+	labels[constant.TagSource] = source
+	return labels
+}
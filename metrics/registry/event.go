@@ -95,3 +95,16 @@ func NewServerSubscribeEvent(succ bool) metrics.MetricsEvent {
 		Succ: succ,
 	}
 }
+
+// NewMigrationEvent for the discovery source, either constant.RegistryTypeInterface or constant.RegistryTypeService,
+// the service switches to from the previous one, which is empty when the source is selected for the first time
+func NewMigrationEvent(serviceKey, from, to string) metrics.MetricsEvent {
+	return &RegistryMetricsEvent{
+		Name: Migration,
+		Attachment: map[string]string{
+			constant.TagInterface: serviceKey,
+			"from":                from,
+			constant.TagSource:    to,
+		},
+	}
+}
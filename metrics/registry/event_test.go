@@ -109,3 +109,12 @@ func TestNewServerSubscribeEvent(t *testing.T) {
 	assert.Equal(t, ServerSub, event.Name)
 	assert.True(t, event.Succ)
 }
+
+func TestNewMigrationEvent(t *testing.T) {
+	event := NewMigrationEvent("com.example.Greeter", constant.RegistryTypeInterface, constant.RegistryTypeService).(*RegistryMetricsEvent)
+
+	assert.Equal(t, Migration, event.Name)
+	assert.Equal(t, "com.example.Greeter", event.Attachment[constant.TagInterface])
+	assert.Equal(t, constant.RegistryTypeInterface, event.Attachment["from"])
+	assert.Equal(t, constant.RegistryTypeService, event.Attachment[constant.TagSource])
+}
@@ -30,6 +30,7 @@ const (
 	Directory
 	ServerReg
 	ServerSub
+	Migration
 )
 
 const (
@@ -78,4 +79,8 @@ var (
 
 	// notify rt key
 	NotifyRt = metrics.NewMetricKey("dubbo_notify_rt_milliseconds", "Notify Time")
+
+	// migration metrics key
+	MigrationMetricSource      = metrics.NewMetricKey("dubbo_registry_migration_source", "Discovery Source Used By Service")
+	MigrationMetricSwitchTotal = metrics.NewMetricKey("dubbo_registry_migration_switch_total", "Total Discovery Source Switches")
 )
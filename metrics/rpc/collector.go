@@ -25,6 +25,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/metrics"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 )
 
 var (
@@ -62,6 +63,18 @@ func (c *rpcCollector) start() {
 				c.beforeInvokeHandler(rpcEvent)
 			case AfterInvoke:
 				c.afterInvokeHandler(rpcEvent)
+			case AdaptiveLimiter:
+				c.adaptiveLimiterHandler(rpcEvent)
+			case Throttle:
+				c.throttleHandler(rpcEvent)
+			case Limit:
+				c.limitHandler(rpcEvent)
+			case PayloadSize:
+				c.payloadSizeHandler(rpcEvent)
+			case StreamStart:
+				c.streamStartHandler(rpcEvent)
+			case StreamEnd:
+				c.streamEndHandler(rpcEvent)
 			default:
 			}
 		} else {
@@ -100,12 +113,90 @@ func (c *rpcCollector) afterInvokeHandler(event *metricsEvent) {
 			c.incRequestsFailedTotal(role, labels)
 			// Classify and increment granular error metrics
 			errType := classifyError(event.result.Error())
-			c.incRequestsFailedByType(role, labels, errType)
+			c.incRequestsFailedByType(role, labels, errType, event.invocation)
 		}
 	}
 	c.reportRTMilliseconds(role, labels, event.costTime.Milliseconds())
 }
 
+func (c *rpcCollector) adaptiveLimiterHandler(event *metricsEvent) {
+	url := event.invoker.GetURL()
+	if event.limiter == nil || getRole(url) != constant.SideProvider {
+		return
+	}
+	labels := buildLabels(url, event.invocation)
+	labels[constant.TagLimiter] = event.limiter.name
+	c.metricSet.provider.adaptiveLimit.Set(labels, float64(event.limiter.limit))
+	c.metricSet.provider.adaptiveInflight.Set(labels, float64(event.limiter.inflight))
+}
+
+func (c *rpcCollector) throttleHandler(event *metricsEvent) {
+	url := event.invoker.GetURL()
+	if event.throttle == nil || getRole(url) != constant.SideConsumer {
+		return
+	}
+	labels := buildLabels(url, event.invocation)
+	c.metricSet.consumer.throttleProbability.Set(labels, event.throttle.probability)
+	if event.throttle.rejected {
+		c.metricSet.consumer.requestsThrottledTotal.Inc(labels)
+	}
+}
+
+func (c *rpcCollector) limitHandler(event *metricsEvent) {
+	url := event.invoker.GetURL()
+	role := getRole(url)
+	if role == "" {
+		return
+	}
+	labels := buildLabels(url, event.invocation)
+	c.incRequestsLimitTotal(role, withCriticality(labels, event.invocation))
+}
+
+func (c *rpcCollector) payloadSizeHandler(event *metricsEvent) {
+	ms := c.commonMetrics(event.side)
+	if ms == nil || event.payload == nil {
+		return
+	}
+	if event.payload.request {
+		ms.requestSizeBytes.Record(event.level.Tags(), float64(event.payload.size))
+	} else {
+		ms.responseSizeBytes.Record(event.level.Tags(), float64(event.payload.size))
+	}
+}
+
+func (c *rpcCollector) streamStartHandler(event *metricsEvent) {
+	if ms := c.commonMetrics(event.side); ms != nil {
+		ms.streamsActive.Inc(event.level.Tags())
+	}
+}
+
+func (c *rpcCollector) streamEndHandler(event *metricsEvent) {
+	ms := c.commonMetrics(event.side)
+	if ms == nil || event.stream == nil {
+		return
+	}
+	labels := event.level.Tags()
+	ms.streamsActive.Dec(labels)
+	ms.streamMessagesSent.Record(labels, float64(event.stream.sent))
+	ms.streamMessagesReceived.Record(labels, float64(event.stream.received))
+	ms.streamDurationMilliseconds.Record(labels, float64(event.stream.duration.Milliseconds()))
+	reasonLabels := event.level.Tags()
+	reasonLabels[constant.TagReason] = event.stream.reason
+	ms.streamsTerminatedTotal.Inc(reasonLabels)
+}
+
+// commonMetrics returns the metrics of the side, which is either constant.SideProvider or constant.SideConsumer
+func (c *rpcCollector) commonMetrics(side string) *rpcCommonMetrics {
+	switch side {
+	case constant.SideProvider:
+		return &c.metricSet.provider.rpcCommonMetrics
+	case constant.SideConsumer:
+		return &c.metricSet.consumer.rpcCommonMetrics
+	default:
+		return nil
+	}
+}
+
 func (c *rpcCollector) recordQps(role string, labels map[string]string) {
 	switch role {
 	case constant.SideProvider:
@@ -166,7 +257,18 @@ func (c *rpcCollector) incRequestsFailedTotal(role string, labels map[string]str
 	}
 }
 
-func (c *rpcCollector) incRequestsFailedByType(role string, labels map[string]string, errType ErrorType) {
+func (c *rpcCollector) incRequestsLimitTotal(role string, labels map[string]string) {
+	switch role {
+	case constant.SideProvider:
+		c.metricSet.provider.requestsLimitTotal.Inc(labels)
+		c.metricSet.provider.requestsLimitTotalAggregate.Inc(labels)
+	case constant.SideConsumer:
+		c.metricSet.consumer.requestsLimitTotal.Inc(labels)
+		c.metricSet.consumer.requestsLimitTotalAggregate.Inc(labels)
+	}
+}
+
+func (c *rpcCollector) incRequestsFailedByType(role string, labels map[string]string, errType ErrorType, inv base.Invocation) {
 	var ms *rpcCommonMetrics
 
 	switch role {
@@ -183,8 +285,9 @@ func (c *rpcCollector) incRequestsFailedByType(role string, labels map[string]st
 		ms.requestsTimeoutTotal.Inc(labels)
 		ms.requestsTimeoutTotalAggregate.Inc(labels)
 	case ErrorTypeLimit:
-		ms.requestsLimitTotal.Inc(labels)
-		ms.requestsLimitTotalAggregate.Inc(labels)
+		limitLabels := withCriticality(labels, inv)
+		ms.requestsLimitTotal.Inc(limitLabels)
+		ms.requestsLimitTotalAggregate.Inc(limitLabels)
 	case ErrorTypeServiceUnavailable:
 		ms.requestsServiceUnavailableTotal.Inc(labels)
 		ms.requestsServiceUnavailableTotalAggregate.Inc(labels)
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rpc
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+import (
+	prom "github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	"dubbo.apache.org/dubbo-go/v3/metrics/prometheus"
+)
+
+func TestRpcCollectorPayloadAndStream(t *testing.T) {
+	url := common.NewURLWithOptions(common.WithProtocol(constant.ProtocolPrometheus))
+	registry := prometheus.NewPromMetricRegistry(prom.NewRegistry(), url)
+	c := &rpcCollector{registry: registry, metricSet: buildMetricSet(registry)}
+	level := metrics.NewMethodMetric("com.example.Greeter", "Greet", "", "")
+
+	c.payloadSizeHandler(NewRequestSizeEvent(constant.SideConsumer, level, 100).(*metricsEvent))
+	c.payloadSizeHandler(NewResponseSizeEvent(constant.SideConsumer, level, 2000).(*metricsEvent))
+	c.streamStartHandler(NewStreamStartEvent(constant.SideProvider, level).(*metricsEvent))
+	c.streamStartHandler(NewStreamStartEvent(constant.SideProvider, level).(*metricsEvent))
+	c.streamEndHandler(NewStreamEndEvent(constant.SideProvider, level, 5, 1, 20*time.Millisecond, nil).(*metricsEvent))
+	// events of an unknown side are dropped
+	c.payloadSizeHandler(NewRequestSizeEvent("unknown", level, 100).(*metricsEvent))
+
+	text, err := registry.Scrape()
+	require.NoError(t, err)
+	assert.Contains(t, text, "dubbo_consumer_request_size_bytes_sum{")
+	assert.Regexp(t, `dubbo_consumer_request_size_bytes_count\{[^}]*\} 1`, text)
+	assert.Regexp(t, `dubbo_consumer_response_size_bytes_sum\{[^}]*\} 2000`, text)
+	assert.Regexp(t, `dubbo_provider_streams_active\{[^}]*\} 1`, text)
+	assert.Regexp(t, `dubbo_provider_stream_messages_sent_sum\{[^}]*\} 5`, text)
+	assert.Regexp(t, `dubbo_provider_stream_messages_received_sum\{[^}]*\} 1`, text)
+	assert.Regexp(t, `dubbo_provider_streams_terminated_total\{[^}]*reason="ok"[^}]*\} 1`, text)
+	assert.NotContains(t, text, "dubbo_provider_request_size_bytes_count")
+
+	c.streamEndHandler(NewStreamEndEvent(constant.SideProvider, level, 0, 0, time.Millisecond, errors.New("boom")).(*metricsEvent))
+	text, err = registry.Scrape()
+	require.NoError(t, err)
+	assert.Regexp(t, `dubbo_provider_streams_active\{[^}]*\} 0`, text)
+	assert.Regexp(t, `dubbo_provider_streams_terminated_total\{[^}]*reason="unknown"[^}]*\} 1`, text)
+}
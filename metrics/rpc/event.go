@@ -18,6 +18,9 @@
 package rpc
 
 import (
+	"context"
+	"errors"
+	"io"
 	"time"
 )
 
@@ -26,6 +29,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/metrics"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 )
 
 // metricsEvent is the event defined for rpc metrics
@@ -35,6 +39,42 @@ type metricsEvent struct {
 	invocation base.Invocation
 	costTime   time.Duration
 	result     result.Result
+	limiter    *limiterStatus
+	throttle   *throttleStatus
+
+	// side and level describe the method of payload and stream events, which are reported by the
+	// protocols below the invokers
+	side    string
+	level   *metrics.MethodMetricLevel
+	payload *payloadStatus
+	stream  *streamStatus
+}
+
+// limiterStatus is the status of the adaptive service limiter of a method
+type limiterStatus struct {
+	name     string
+	limit    uint64
+	inflight uint64
+}
+
+// throttleStatus is the status of the client side adaptive throttling of a service
+type throttleStatus struct {
+	probability float64
+	rejected    bool
+}
+
+// payloadStatus is the serialized size of a request or response message
+type payloadStatus struct {
+	request bool
+	size    int
+}
+
+// streamStatus is the status of a stream when it ends
+type streamStatus struct {
+	sent     int
+	received int
+	duration time.Duration
+	reason   string
 }
 
 // Type returns the type of the event, it is used for metrics bus to dispatch the event to rpc collector
@@ -47,6 +87,12 @@ type metricsName uint8
 const (
 	BeforeInvoke metricsName = iota
 	AfterInvoke
+	AdaptiveLimiter
+	Throttle
+	Limit
+	PayloadSize
+	StreamStart
+	StreamEnd
 )
 
 func NewBeforeInvokeEvent(invoker base.Invoker, invocation base.Invocation) metrics.MetricsEvent {
@@ -57,6 +103,37 @@ func NewBeforeInvokeEvent(invoker base.Invoker, invocation base.Invocation) metr
 	}
 }
 
+// NewAdaptiveLimiterEvent reports the current limit and inflight requests of the adaptive service limiter of the invocation
+func NewAdaptiveLimiterEvent(invoker base.Invoker, invocation base.Invocation, limiterName string, limit, inflight uint64) metrics.MetricsEvent {
+	return &metricsEvent{
+		name:       AdaptiveLimiter,
+		invoker:    invoker,
+		invocation: invocation,
+		limiter:    &limiterStatus{name: limiterName, limit: limit, inflight: inflight},
+	}
+}
+
+// NewThrottleEvent reports the rejection probability of the client side adaptive throttling, and whether the
+// invocation is rejected locally
+func NewThrottleEvent(invoker base.Invoker, invocation base.Invocation, probability float64, rejected bool) metrics.MetricsEvent {
+	return &metricsEvent{
+		name:       Throttle,
+		invoker:    invoker,
+		invocation: invocation,
+		throttle:   &throttleStatus{probability: probability, rejected: rejected},
+	}
+}
+
+// NewLimitEvent reports the invocation is rejected by a provider side limiter, it is counted by the criticality of
+// the invocation
+func NewLimitEvent(invoker base.Invoker, invocation base.Invocation) metrics.MetricsEvent {
+	return &metricsEvent{
+		name:       Limit,
+		invoker:    invoker,
+		invocation: invocation,
+	}
+}
+
 func NewAfterInvokeEvent(invoker base.Invoker, invocation base.Invocation, costTime time.Duration, result result.Result) metrics.MetricsEvent {
 	return &metricsEvent{
 		name:       AfterInvoke,
@@ -66,3 +143,63 @@ func NewAfterInvokeEvent(invoker base.Invoker, invocation base.Invocation, costT
 		result:     result,
 	}
 }
+
+// NewRequestSizeEvent reports the serialized size of a request message of the method on the side, which is either
+// constant.SideConsumer or constant.SideProvider
+func NewRequestSizeEvent(side string, level *metrics.MethodMetricLevel, size int) metrics.MetricsEvent {
+	return &metricsEvent{
+		name:    PayloadSize,
+		side:    side,
+		level:   level,
+		payload: &payloadStatus{request: true, size: size},
+	}
+}
+
+// NewResponseSizeEvent reports the serialized size of a response message of the method on the side
+func NewResponseSizeEvent(side string, level *metrics.MethodMetricLevel, size int) metrics.MetricsEvent {
+	return &metricsEvent{
+		name:    PayloadSize,
+		side:    side,
+		level:   level,
+		payload: &payloadStatus{size: size},
+	}
+}
+
+// NewStreamStartEvent reports a stream of the method is opened on the side
+func NewStreamStartEvent(side string, level *metrics.MethodMetricLevel) metrics.MetricsEvent {
+	return &metricsEvent{
+		name:  StreamStart,
+		side:  side,
+		level: level,
+	}
+}
+
+// NewStreamEndEvent reports a stream of the method opened on the side ends with err after sending and receiving
+// the given numbers of messages, a nil err or one wrapping io.EOF means the stream finished successfully
+func NewStreamEndEvent(side string, level *metrics.MethodMetricLevel, sent, received int, duration time.Duration, err error) metrics.MetricsEvent {
+	return &metricsEvent{
+		name:  StreamEnd,
+		side:  side,
+		level: level,
+		stream: &streamStatus{
+			sent:     sent,
+			received: received,
+			duration: duration,
+			reason:   streamEndReason(err),
+		},
+	}
+}
+
+// streamEndReason returns the reason a stream ends with err: ok, or the name of the triple code of err
+func streamEndReason(err error) string {
+	switch {
+	case err == nil || errors.Is(err, io.EOF):
+		return "ok"
+	case errors.Is(err, context.Canceled):
+		return tri.CodeCanceled.String()
+	case errors.Is(err, context.DeadlineExceeded):
+		return tri.CodeDeadlineExceeded.String()
+	default:
+		return tri.CodeOf(err).String()
+	}
+}
@@ -18,6 +18,10 @@
 package rpc
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"testing"
 	"time"
 )
@@ -29,9 +33,11 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 )
 
 func TestMetricsEventType(t *testing.T) {
@@ -74,3 +80,94 @@ func TestNewAfterInvokeEvent(t *testing.T) {
 	assert.Equal(t, costTime, event.costTime)
 	assert.Equal(t, res, event.result)
 }
+
+func TestNewAdaptiveLimiterEvent(t *testing.T) {
+	url := base.NewBaseInvoker(&common.URL{})
+	invoc := invocation.NewRPCInvocation("TestMethod", []any{}, nil)
+
+	event := NewAdaptiveLimiterEvent(url, invoc, "vegas", 100, 42).(*metricsEvent)
+
+	assert.Equal(t, AdaptiveLimiter, event.name)
+	assert.Equal(t, url, event.invoker)
+	assert.Equal(t, invoc, event.invocation)
+	assert.Equal(t, &limiterStatus{name: "vegas", limit: 100, inflight: 42}, event.limiter)
+}
+
+func TestNewThrottleEvent(t *testing.T) {
+	url := base.NewBaseInvoker(&common.URL{})
+	invoc := invocation.NewRPCInvocation("TestMethod", []any{}, nil)
+
+	event := NewThrottleEvent(url, invoc, 0.5, true).(*metricsEvent)
+
+	assert.Equal(t, Throttle, event.name)
+	assert.Equal(t, url, event.invoker)
+	assert.Equal(t, invoc, event.invocation)
+	assert.Equal(t, &throttleStatus{probability: 0.5, rejected: true}, event.throttle)
+}
+
+func TestNewLimitEvent(t *testing.T) {
+	url := base.NewBaseInvoker(&common.URL{})
+	invoc := invocation.NewRPCInvocation("TestMethod", []any{}, map[string]any{constant.CriticalityKey: "SHEDDABLE"})
+
+	event := NewLimitEvent(url, invoc).(*metricsEvent)
+
+	assert.Equal(t, Limit, event.name)
+	assert.Equal(t, url, event.invoker)
+	assert.Equal(t, invoc, event.invocation)
+
+	labels := map[string]string{constant.TagMethod: "TestMethod"}
+	limitLabels := withCriticality(labels, invoc)
+	assert.Equal(t, constant.CriticalitySheddable, limitLabels[constant.TagCriticality])
+	assert.NotContains(t, labels, constant.TagCriticality)
+}
+
+func TestNewPayloadSizeEvent(t *testing.T) {
+	level := metrics.NewMethodMetric("com.example.Greeter", "Greet", "g", "1.0")
+
+	event := NewRequestSizeEvent(constant.SideConsumer, level, 128).(*metricsEvent)
+	assert.Equal(t, PayloadSize, event.name)
+	assert.Equal(t, constant.SideConsumer, event.side)
+	assert.Equal(t, level, event.level)
+	assert.True(t, event.payload.request)
+	assert.Equal(t, 128, event.payload.size)
+
+	event = NewResponseSizeEvent(constant.SideProvider, level, 64).(*metricsEvent)
+	assert.Equal(t, PayloadSize, event.name)
+	assert.Equal(t, constant.SideProvider, event.side)
+	assert.False(t, event.payload.request)
+	assert.Equal(t, 64, event.payload.size)
+}
+
+func TestNewStreamEvent(t *testing.T) {
+	level := metrics.NewMethodMetric("com.example.Greeter", "GreetStream", "", "")
+
+	start := NewStreamStartEvent(constant.SideProvider, level).(*metricsEvent)
+	assert.Equal(t, StreamStart, start.name)
+	assert.Equal(t, constant.SideProvider, start.side)
+	assert.Nil(t, start.stream)
+
+	end := NewStreamEndEvent(constant.SideProvider, level, 3, 2, time.Second, io.EOF).(*metricsEvent)
+	assert.Equal(t, StreamEnd, end.name)
+	assert.Equal(t, 3, end.stream.sent)
+	assert.Equal(t, 2, end.stream.received)
+	assert.Equal(t, time.Second, end.stream.duration)
+	assert.Equal(t, "ok", end.stream.reason)
+}
+
+func TestStreamEndReason(t *testing.T) {
+	tests := []struct {
+		err    error
+		reason string
+	}{
+		{nil, "ok"},
+		{io.EOF, "ok"},
+		{fmt.Errorf("recv: %w", io.EOF), "ok"},
+		{context.Canceled, tri.CodeCanceled.String()},
+		{context.DeadlineExceeded, tri.CodeDeadlineExceeded.String()},
+		{tri.NewError(tri.CodeResourceExhausted, errors.New("too many")), tri.CodeResourceExhausted.String()},
+		{errors.New("boom"), tri.CodeUnknown.String()},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.reason, streamEndReason(test.err), "%v", test.err)
+	}
+}
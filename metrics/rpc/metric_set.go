@@ -29,10 +29,18 @@ type metricSet struct {
 
 type providerMetrics struct {
 	rpcCommonMetrics
+
+	// status of the adaptive service limiters
+	adaptiveLimit    metrics.GaugeVec
+	adaptiveInflight metrics.GaugeVec
 }
 
 type consumerMetrics struct {
 	rpcCommonMetrics
+
+	// status of the client side adaptive throttling
+	throttleProbability    metrics.GaugeVec
+	requestsThrottledTotal metrics.CounterVec
 }
 
 // rpcCommonMetrics is the common metrics for both provider and consumer
@@ -60,8 +68,28 @@ type rpcCommonMetrics struct {
 	requestsBusinessFailedTotalAggregate     metrics.AggregateCounterVec
 	requestsUnknownFailedTotal               metrics.CounterVec
 	requestsUnknownFailedTotalAggregate      metrics.AggregateCounterVec
+
+	// payload sizes of all messages, including the ones of streams
+	requestSizeBytes  metrics.HistogramVec
+	responseSizeBytes metrics.HistogramVec
+
+	// streams of the streaming methods
+	streamsActive              metrics.GaugeVec
+	streamMessagesSent         metrics.HistogramVec
+	streamMessagesReceived     metrics.HistogramVec
+	streamDurationMilliseconds metrics.HistogramVec
+	streamsTerminatedTotal     metrics.CounterVec
 }
 
+var (
+	// sizeBuckets ranges from 64B to 16MB
+	sizeBuckets = &metrics.HistogramOpts{Buckets: []float64{64, 256, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20}}
+	// messageBuckets ranges from 1 to 100k messages in a stream
+	messageBuckets = &metrics.HistogramOpts{Buckets: []float64{1, 2, 5, 10, 50, 100, 500, 1000, 10000, 100000}}
+	// durationBuckets ranges from 10ms to 1h
+	durationBuckets = &metrics.HistogramOpts{Buckets: []float64{10, 100, 500, 1000, 5000, 10000, 30000, 60000, 300000, 600000, 1800000, 3600000}}
+)
+
 // buildMetricSet will call init functions to initialize the metricSet
 func buildMetricSet(registry metrics.MetricRegistry) *metricSet {
 	ms := &metricSet{
@@ -108,6 +136,17 @@ func (pm *providerMetrics) init(registry metrics.MetricRegistry) {
 	pm.requestsBusinessFailedTotalAggregate = metrics.NewAggregateCounterVec(registry, metrics.NewMetricKey("dubbo_provider_requests_business_failed_total_aggregate", "Total Failed Business Requests under the sliding window"))
 	pm.requestsUnknownFailedTotal = metrics.NewCounterVec(registry, metrics.NewMetricKey("dubbo_provider_requests_unknown_failed_total", "Total Unknown Failed Requests"))
 	pm.requestsUnknownFailedTotalAggregate = metrics.NewAggregateCounterVec(registry, metrics.NewMetricKey("dubbo_provider_requests_unknown_failed_total_aggregate", "Total Unknown Failed Requests under the sliding window"))
+
+	pm.requestSizeBytes = metrics.NewHistogramVec(registry, metrics.NewMetricKey("dubbo_provider_request_size_bytes", "The serialized size of requests received by the provider"), sizeBuckets)
+	pm.responseSizeBytes = metrics.NewHistogramVec(registry, metrics.NewMetricKey("dubbo_provider_response_size_bytes", "The serialized size of responses sent by the provider"), sizeBuckets)
+	pm.streamsActive = metrics.NewGaugeVec(registry, metrics.NewMetricKey("dubbo_provider_streams_active", "The number of streams being served by the provider"))
+	pm.streamMessagesSent = metrics.NewHistogramVec(registry, metrics.NewMetricKey("dubbo_provider_stream_messages_sent", "The number of messages sent by the provider per stream"), messageBuckets)
+	pm.streamMessagesReceived = metrics.NewHistogramVec(registry, metrics.NewMetricKey("dubbo_provider_stream_messages_received", "The number of messages received by the provider per stream"), messageBuckets)
+	pm.streamDurationMilliseconds = metrics.NewHistogramVec(registry, metrics.NewMetricKey("dubbo_provider_stream_duration_milliseconds", "The lifetime of streams served by the provider"), durationBuckets)
+	pm.streamsTerminatedTotal = metrics.NewCounterVec(registry, metrics.NewMetricKey("dubbo_provider_streams_terminated_total", "Total streams served by the provider by termination reason"))
+
+	pm.adaptiveLimit = metrics.NewGaugeVec(registry, metrics.NewMetricKey("dubbo_provider_adaptive_limit", "The concurrency limit of the adaptive service limiter"))
+	pm.adaptiveInflight = metrics.NewGaugeVec(registry, metrics.NewMetricKey("dubbo_provider_adaptive_inflight", "The inflight requests of the adaptive service limiter"))
 }
 
 func (cm *consumerMetrics) init(registry metrics.MetricRegistry) {
@@ -145,4 +184,15 @@ func (cm *consumerMetrics) init(registry metrics.MetricRegistry) {
 	cm.requestsBusinessFailedTotalAggregate = metrics.NewAggregateCounterVec(registry, metrics.NewMetricKey("dubbo_consumer_requests_business_failed_total_aggregate", "Total Failed Business Requests under the sliding window"))
 	cm.requestsUnknownFailedTotal = metrics.NewCounterVec(registry, metrics.NewMetricKey("dubbo_consumer_requests_unknown_failed_total", "Total Unknown Failed Requests"))
 	cm.requestsUnknownFailedTotalAggregate = metrics.NewAggregateCounterVec(registry, metrics.NewMetricKey("dubbo_consumer_requests_unknown_failed_total_aggregate", "Total Unknown Failed Requests under the sliding window"))
+
+	cm.requestSizeBytes = metrics.NewHistogramVec(registry, metrics.NewMetricKey("dubbo_consumer_request_size_bytes", "The serialized size of requests sent by consumers"), sizeBuckets)
+	cm.responseSizeBytes = metrics.NewHistogramVec(registry, metrics.NewMetricKey("dubbo_consumer_response_size_bytes", "The serialized size of responses received by consumers"), sizeBuckets)
+	cm.streamsActive = metrics.NewGaugeVec(registry, metrics.NewMetricKey("dubbo_consumer_streams_active", "The number of streams opened by consumers"))
+	cm.streamMessagesSent = metrics.NewHistogramVec(registry, metrics.NewMetricKey("dubbo_consumer_stream_messages_sent", "The number of messages sent by consumers per stream"), messageBuckets)
+	cm.streamMessagesReceived = metrics.NewHistogramVec(registry, metrics.NewMetricKey("dubbo_consumer_stream_messages_received", "The number of messages received by consumers per stream"), messageBuckets)
+	cm.streamDurationMilliseconds = metrics.NewHistogramVec(registry, metrics.NewMetricKey("dubbo_consumer_stream_duration_milliseconds", "The lifetime of streams opened by consumers"), durationBuckets)
+	cm.streamsTerminatedTotal = metrics.NewCounterVec(registry, metrics.NewMetricKey("dubbo_consumer_streams_terminated_total", "Total streams opened by consumers by termination reason"))
+
+	cm.throttleProbability = metrics.NewGaugeVec(registry, metrics.NewMetricKey("dubbo_consumer_throttle_probability", "The probability of the client side adaptive throttling to reject requests"))
+	cm.requestsThrottledTotal = metrics.NewCounterVec(registry, metrics.NewMetricKey("dubbo_consumer_requests_throttled_total", "Total Requests rejected by the client side adaptive throttling"))
 }
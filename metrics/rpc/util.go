@@ -29,6 +29,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/filter/criticality"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 )
 
@@ -46,6 +47,16 @@ func buildLabels(url *common.URL, invocation base.Invocation) map[string]string
 	}
 }
 
+// withCriticality returns a copy of the labels with the criticality of the invocation
+func withCriticality(labels map[string]string, invocation base.Invocation) map[string]string {
+	res := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		res[k] = v
+	}
+	res[constant.TagCriticality] = criticality.FromInvocation(invocation).String()
+	return res
+}
+
 // getRole will get the application role from the url
 func getRole(url *common.URL) (role string) {
 	if isProvider(url) {
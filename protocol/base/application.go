@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/global"
+)
+
+// ConsumerApplication returns the application of the consumer calling by the invoker url, or "" if unknown.
+// The application param of an invoker url merged from the provider url is the provider's one,
+// so the consumer application is taken from the application config attribute of the reference url,
+// which is merged into the invoker url, or from the reference url set as SubURL.
+func ConsumerApplication(url *common.URL) string {
+	if app := applicationOfAttribute(url); app != "" {
+		return app
+	}
+	if url.SubURL != nil {
+		if app := applicationOfAttribute(url.SubURL); app != "" {
+			return app
+		}
+		return url.SubURL.GetParam(constant.ApplicationKey, "")
+	}
+	return ""
+}
+
+func applicationOfAttribute(url *common.URL) string {
+	raw, ok := url.GetAttribute(constant.ApplicationKey)
+	if !ok {
+		return ""
+	}
+	switch appConf := raw.(type) {
+	case *global.ApplicationConfig:
+		if appConf != nil {
+			return appConf.Name
+		}
+	case global.ApplicationConfig:
+		return appConf.Name
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/global"
+)
+
+func TestConsumerApplication(t *testing.T) {
+	providerURL, err := common.NewURL("tri://127.0.0.1:20000/org.example.DemoService?application=provider")
+	require.NoError(t, err)
+
+	// the consumer application of the reference url is kept by MergeURL as the attribute
+	referenceURL, err := common.NewURL("consumer://127.0.0.1/org.example.DemoService?application=consumer",
+		common.WithAttribute(constant.ApplicationKey, &global.ApplicationConfig{Name: "consumer"}))
+	require.NoError(t, err)
+	merged := providerURL.MergeURL(referenceURL)
+	assert.Equal(t, "provider", merged.GetParam(constant.ApplicationKey, ""))
+	assert.Equal(t, "consumer", ConsumerApplication(merged))
+
+	// the param of the reference url set as SubURL
+	referenceURL, err = common.NewURL("consumer://127.0.0.1/org.example.DemoService?application=consumer")
+	require.NoError(t, err)
+	merged = providerURL.MergeURL(referenceURL)
+	assert.Empty(t, ConsumerApplication(merged))
+	merged.SubURL = referenceURL
+	assert.Equal(t, "consumer", ConsumerApplication(merged))
+}
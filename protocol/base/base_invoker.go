@@ -46,6 +46,8 @@ var (
 	ErrClientClosed     = perrors.New("remoting client has closed")
 	ErrNoReply          = perrors.New("request need @response")
 	ErrDestroyedInvoker = perrors.New("request Destroyed invoker")
+	// ErrHealthCheckUnsupported is returned by HealthChecker when the protocol or the provider can not be probed.
+	ErrHealthCheckUnsupported = perrors.New("health check is not supported")
 )
 
 // Invoker the service invocation interface for the consumer
@@ -63,6 +65,12 @@ type AvailabilitySetter interface {
 	SetAvailable(bool)
 }
 
+// HealthChecker is implemented by invokers that can actively probe the health of their provider.
+type HealthChecker interface {
+	// HealthCheck probes the provider once, a nil error means the provider is serving.
+	HealthCheck(ctx context.Context) error
+}
+
 // BaseInvoker provides default invoker implements Invoker
 type BaseInvoker struct {
 	url       uatomic.Pointer[common.URL]
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+// ErrDeadlineExhausted is returned when the deadline of the call, which may be inherited from an upstream caller,
+// has already passed before the request is sent. It wraps context.DeadlineExceeded.
+var ErrDeadlineExhausted = perrors.Wrap(context.DeadlineExceeded, "deadline exhausted before sending the request")
+
+// CapTimeout caps @timeout by the remaining time of the deadline of @ctx. ErrDeadlineExhausted is returned
+// if the deadline has already passed.
+func CapTimeout(ctx context.Context, timeout time.Duration) (time.Duration, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return timeout, nil
+	}
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return 0, ErrDeadlineExhausted
+	}
+	if timeout <= 0 || remaining < timeout {
+		return remaining, nil
+	}
+	return timeout, nil
+}
+
+// FormatTimeoutBudget formats the time budget of a call as the milliseconds carried by TimeoutAttachmentKey.
+// A positive budget shorter than a millisecond is rounded up, so it is not mistaken for an exhausted one.
+func FormatTimeoutBudget(timeout time.Duration) string {
+	ms := timeout.Milliseconds()
+	if timeout > 0 && ms == 0 {
+		ms = 1
+	}
+	return strconv.FormatInt(ms, 10)
+}
+
+// WithTimeoutBudget derives a context from @ctx which is done when the time budget formatted by
+// FormatTimeoutBudget runs out. @ctx is returned as it is if @budget is empty or malformed.
+func WithTimeoutBudget(ctx context.Context, budget string) (context.Context, context.CancelFunc) {
+	if budget == "" {
+		return ctx, func() {}
+	}
+	ms, err := strconv.ParseInt(budget, 10, 64)
+	if err != nil || ms < 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, time.Duration(ms)*time.Millisecond)
+}
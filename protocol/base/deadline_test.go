@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package base
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapTimeout(t *testing.T) {
+	timeout, err := CapTimeout(context.Background(), time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, timeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	timeout, err = CapTimeout(ctx, time.Second)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, timeout, 200*time.Millisecond)
+	assert.Positive(t, timeout)
+
+	timeout, err = CapTimeout(ctx, 100*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, 100*time.Millisecond, timeout)
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Millisecond))
+	defer cancelExpired()
+	_, err = CapTimeout(expired, time.Second)
+	assert.Equal(t, ErrDeadlineExhausted, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestTimeoutBudget(t *testing.T) {
+	assert.Equal(t, "1500", FormatTimeoutBudget(1500*time.Millisecond))
+	assert.Equal(t, "1", FormatTimeoutBudget(time.Microsecond))
+
+	ctx, cancel := WithTimeoutBudget(context.Background(), "")
+	defer cancel()
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+
+	ctx, cancel = WithTimeoutBudget(context.Background(), "1s")
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+
+	ctx, cancel = WithTimeoutBudget(context.Background(), "200")
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(200*time.Millisecond), deadline, 50*time.Millisecond)
+
+	ctx, cancel = WithTimeoutBudget(context.Background(), "0")
+	defer cancel()
+	<-ctx.Done()
+	assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+}
@@ -16,4 +16,8 @@
  */
 
 // Package dubbo implements dubbo rpc protocol.
+//
+// The remoting layer is selected by the `transporter` url param, `getty` by default or `native`.
+// Unix domain socket addresses are only supported by the native transporter, which they use by
+// default, an explicit `transporter: getty` for them is rejected.
 package dubbo
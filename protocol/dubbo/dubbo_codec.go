@@ -33,6 +33,8 @@ import (
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	"dubbo.apache.org/dubbo-go/v3/metrics/rpc"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/dubbo/impl"
 	invct "dubbo.apache.org/dubbo-go/v3/protocol/invocation"
@@ -104,7 +106,12 @@ func (c *DubboCodec) EncodeRequest(request *remoting.Request) (*bytes.Buffer, er
 		return nil, perrors.WithStack(err)
 	}
 
-	return pkg.Marshal()
+	buf, err := pkg.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	reportPayloadSize(constant.SideConsumer, true, invocation, buf.Len())
+	return buf, nil
 }
 
 // encode heartbeat request
@@ -157,6 +164,9 @@ func (c *DubboCodec) EncodeResponse(response *remoting.Response) (*bytes.Buffer,
 	if err != nil {
 		return nil, perrors.WithStack(err)
 	}
+	if response.Invocation != nil {
+		reportPayloadSize(constant.SideProvider, false, response.Invocation, len(pkg))
+	}
 
 	return bytes.NewBuffer(pkg), nil
 }
@@ -240,6 +250,7 @@ func (c *DubboCodec) decodeRequest(data []byte) (*remoting.Request, int, error)
 		invoc := invct.NewRPCInvocationWithOptions(invct.WithAttachments(attachments),
 			invct.WithArguments(args), invct.WithMethodName(methodName))
 		request.Data = invoc
+		reportPayloadSize(constant.SideProvider, true, invoc, hessian.HEADER_LENGTH+pkg.Header.BodyLen)
 
 	}
 	return request, hessian.HEADER_LENGTH + pkg.Header.BodyLen, nil
@@ -298,6 +309,26 @@ func (c *DubboCodec) decodeResponse(data []byte) (*remoting.Response, int, error
 		rpcResult.Attrs = pkg.Body.(*impl.ResponsePayload).Attachments
 		rpcResult.Rest = pkg.Body.(*impl.ResponsePayload).RspObj
 	}
+	// the pending response is removed after the response is decoded
+	if pending := remoting.GetPendingResponse(remoting.SequenceType(response.ID)); pending != nil && pending.Invocation != nil {
+		reportPayloadSize(constant.SideConsumer, false, pending.Invocation, hessian.HEADER_LENGTH+pkg.Header.BodyLen)
+	}
 
 	return response, hessian.HEADER_LENGTH + pkg.Header.BodyLen, nil
 }
+
+// reportPayloadSize reports the size of a request or response package of the invocation to the metrics bus
+func reportPayloadSize(side string, request bool, invocation base.Invocation, size int) {
+	service := invocation.GetAttachmentWithDefaultValue(constant.InterfaceKey, "")
+	if service == "" {
+		service = invocation.GetAttachmentWithDefaultValue(constant.PathKey, "")
+	}
+	level := metrics.NewMethodMetric(service, invocation.MethodName(),
+		invocation.GetAttachmentWithDefaultValue(constant.GroupKey, ""),
+		invocation.GetAttachmentWithDefaultValue(constant.VersionKey, ""))
+	if request {
+		metrics.Publish(rpc.NewRequestSizeEvent(side, level, size))
+	} else {
+		metrics.Publish(rpc.NewResponseSizeEvent(side, level, size))
+	}
+}
@@ -119,6 +119,10 @@ func (di *DubboInvoker) Invoke(ctx context.Context, ivc base.Invocation) result.
 			inv.SetAttachment(k, v)
 		}
 	}
+	// tell the provider which application the request comes from
+	if app := consumerApplication(di.GetURL()); len(app) > 0 {
+		inv.SetAttachment(constant.RemoteApplicationKey, app)
+	}
 
 	// put the ctx into attachment
 	di.appendCtx(ctx, inv)
@@ -136,7 +140,11 @@ func (di *DubboInvoker) Invoke(ctx context.Context, ivc base.Invocation) result.
 	}
 	// response := NewResponse(inv.Reply(), nil)
 	rest := &result.RPCResult{}
-	timeout := di.getTimeout(inv)
+	timeout, err := di.getTimeout(ctx, inv)
+	if err != nil {
+		res.SetError(err)
+		return &res
+	}
 	if async {
 		if callBack, ok := inv.CallBack().(func(response common.CallbackResponse)); ok {
 			err = client.AsyncRequest(&ivc, url, timeout, callBack, rest)
@@ -161,8 +169,8 @@ func (di *DubboInvoker) Invoke(ctx context.Context, ivc base.Invocation) result.
 	return &res
 }
 
-// get timeout including methodConfig
-func (di *DubboInvoker) getTimeout(ivc *invocation.RPCInvocation) time.Duration {
+// get timeout including methodConfig, capped by the deadline of ctx
+func (di *DubboInvoker) getTimeout(ctx context.Context, ivc *invocation.RPCInvocation) (time.Duration, error) {
 	timeout := di.timeout                                                //default timeout
 	if attachTimeout, ok := ivc.GetAttachment(constant.TimeoutKey); ok { //check invocation timeout
 		timeout, _ = time.ParseDuration(attachTimeout)
@@ -176,9 +184,15 @@ func (di *DubboInvoker) getTimeout(ivc *invocation.RPCInvocation) time.Duration
 			timeout, _ = time.ParseDuration(mTimeout)
 		}
 	}
-	// set timeout into invocation
+	// the timeout never outlives the deadline inherited from the caller
+	timeout, err := base.CapTimeout(ctx, timeout)
+	if err != nil {
+		return 0, err
+	}
+	// set timeout into invocation, and tell the provider how much time is left
 	ivc.SetAttachment(constant.TimeoutKey, strconv.Itoa(int(timeout.Milliseconds())))
-	return timeout
+	ivc.SetAttachment(constant.TimeoutAttachmentKey, base.FormatTimeoutBudget(timeout))
+	return timeout, nil
 }
 
 func (di *DubboInvoker) IsAvailable() bool {
@@ -190,6 +204,22 @@ func (di *DubboInvoker) IsAvailable() bool {
 	return false
 }
 
+// HealthCheck sends a dubbo heartbeat to the provider and waits for the response.
+func (di *DubboInvoker) HealthCheck(ctx context.Context) error {
+	client := di.getClient()
+	if client == nil {
+		return base.ErrClientClosed
+	}
+	timeout := di.timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	if timeout <= 0 {
+		return context.DeadlineExceeded
+	}
+	return client.Heartbeat(di.GetURL(), timeout)
+}
+
 // Destroy destroy dubbo client invoker.
 func (di *DubboInvoker) Destroy() {
 	di.quitOnce.Do(func() {
@@ -198,7 +228,7 @@ func (di *DubboInvoker) Destroy() {
 			activeNumber := client.DecreaseActiveNumber()
 			di.setClient(nil)
 			if activeNumber == 0 {
-				exchangeClientMap.Delete(di.GetURL().Location)
+				exchangeClientMap.Delete(exchangeClientKey(di.GetURL()))
 				client.Close()
 			}
 		}
@@ -218,3 +248,15 @@ func (di *DubboInvoker) appendCtx(ctx context.Context, ivc *invocation.RPCInvoca
 		}
 	}
 }
+
+// consumerApplication returns the application of the consumer calling by the url, the one of the
+// reference url or the application config
+func consumerApplication(url *common.URL) string {
+	if app := base.ConsumerApplication(url); app != "" {
+		return app
+	}
+	if appConf := config.GetApplicationConfig(); appConf != nil {
+		return appConf.Name
+	}
+	return ""
+}
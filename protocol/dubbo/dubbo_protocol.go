@@ -28,6 +28,8 @@ import (
 	"github.com/dubbogo/gost/log/logger"
 
 	"github.com/opentracing/opentracing-go"
+
+	perrors "github.com/pkg/errors"
 )
 
 import (
@@ -39,6 +41,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
 	"dubbo.apache.org/dubbo-go/v3/remoting/getty"
+	"dubbo.apache.org/dubbo-go/v3/remoting/native"
 )
 
 const (
@@ -79,6 +82,9 @@ func NewDubboProtocol() *DubboProtocol {
 // Export export dubbo service.
 func (dp *DubboProtocol) Export(invoker base.Invoker) base.Exporter {
 	url := invoker.GetURL()
+	if err := checkTransporter(url); err != nil {
+		panic("[DUBBO Protocol] " + err.Error())
+	}
 	serviceKey := url.ServiceKey()
 	exporter := NewDubboExporter(serviceKey, invoker, dp.ExporterMap())
 	dp.SetExporterMap(serviceKey, exporter)
@@ -90,6 +96,10 @@ func (dp *DubboProtocol) Export(invoker base.Invoker) base.Exporter {
 
 // Refer create dubbo service reference.
 func (dp *DubboProtocol) Refer(url *common.URL) base.Invoker {
+	if err := checkTransporter(url); err != nil {
+		logger.Errorf("[DUBBO Protocol] %v", err)
+		return nil
+	}
 	exchangeClient := getExchangeClient(url)
 	if exchangeClient == nil {
 		logger.Warnf("can't dial the server: %+v", url.Location)
@@ -128,7 +138,7 @@ func (dp *DubboProtocol) openServer(url *common.URL) {
 			handler := func(invocation *invocation.RPCInvocation) result.RPCResult {
 				return doHandleRequest(invocation)
 			}
-			srv := remoting.NewExchangeServer(url, getty.NewServer(url, handler))
+			srv := remoting.NewExchangeServer(url, newServer(url, handler))
 			dp.serverMap[url.Location] = srv
 			srv.Start()
 		}
@@ -157,7 +167,8 @@ func doHandleRequest(rpcInvocation *invocation.RPCInvocation) result.RPCResult {
 	invoker := exporter.(base.Exporter).GetInvoker()
 	if invoker != nil {
 		// FIXME
-		ctx := rebuildCtx(rpcInvocation)
+		ctx, cancel := rebuildCtx(rpcInvocation)
+		defer cancel()
 
 		invokeResult := invoker.Invoke(ctx, rpcInvocation)
 		if err := invokeResult.Error(); err != nil {
@@ -177,18 +188,19 @@ func doHandleRequest(rpcInvocation *invocation.RPCInvocation) result.RPCResult {
 }
 
 func getExchangeClient(url *common.URL) *remoting.ExchangeClient {
-	clientTmp, ok := exchangeClientMap.Load(url.Location)
+	key := exchangeClientKey(url)
+	clientTmp, ok := exchangeClientMap.Load(key)
 	if !ok {
 		var exchangeClientTmp *remoting.ExchangeClient
 		func() {
 			// lock for NewExchangeClient and store into map.
-			_, loaded := exchangeLock.LoadOrStore(url.Location, 0x00)
+			_, loaded := exchangeLock.LoadOrStore(key, 0x00)
 			// unlock
-			defer exchangeLock.Delete(url.Location)
+			defer exchangeLock.Delete(key)
 			if loaded {
 				// retry for 5 times.
 				for i := 0; i < 5; i++ {
-					if clientTmp, ok = exchangeClientMap.Load(url.Location); ok {
+					if clientTmp, ok = exchangeClientMap.Load(key); ok {
 						break
 					} else {
 						// if cannot get, sleep a while.
@@ -199,13 +211,10 @@ func getExchangeClient(url *common.URL) *remoting.ExchangeClient {
 			}
 
 			// todo set by config
-			exchangeClientTmp = remoting.NewExchangeClient(url, getty.NewClient(getty.Options{
-				ConnectTimeout: 3 * time.Second,
-				RequestTimeout: 3 * time.Second,
-			}), 3*time.Second, false)
+			exchangeClientTmp = remoting.NewExchangeClient(url, newClient(url), 3*time.Second, false)
 			// input store
 			if exchangeClientTmp != nil {
-				exchangeClientMap.Store(url.Location, exchangeClientTmp)
+				exchangeClientMap.Store(key, exchangeClientTmp)
 			}
 		}()
 		if exchangeClientTmp != nil {
@@ -221,10 +230,70 @@ func getExchangeClient(url *common.URL) *remoting.ExchangeClient {
 	return exchangeClient
 }
 
+// transporter returns the transporter of the url. Getty is the default one, except for unix domain sockets
+// which only the native transporter supports.
+func transporter(url *common.URL) string {
+	t := url.GetParam(constant.TransporterKey, "")
+	if t != "" && t != constant.GettyTransporter && t != constant.NativeTransporter {
+		logger.Warnf("[DUBBO Protocol] unknown transporter %s, use the default one", t)
+		t = ""
+	}
+	if t != "" {
+		return t
+	}
+	if _, ok := url.UnixSocket(); ok {
+		return constant.NativeTransporter
+	}
+	return constant.GettyTransporter
+}
+
+// checkTransporter rejects the getty transporter configured explicitly for a unix domain socket,
+// unix domain sockets need the native transporter.
+func checkTransporter(url *common.URL) error {
+	if _, ok := url.UnixSocket(); ok && url.GetParam(constant.TransporterKey, "") == constant.GettyTransporter {
+		return perrors.Errorf("the %s transporter does not support unix domain socket %s, use the %s transporter instead",
+			constant.GettyTransporter, url.Location, constant.NativeTransporter)
+	}
+	return nil
+}
+
+// exchangeClientKey is the key of the shared exchange client of the url, clients of different transporters
+// to the same address are not shared.
+func exchangeClientKey(url *common.URL) string {
+	if t := transporter(url); t != constant.GettyTransporter {
+		return t + "://" + url.Location
+	}
+	return url.Location
+}
+
+// newClient creates the remoting client of the transporter set by the url
+func newClient(url *common.URL) remoting.Client {
+	if transporter(url) == constant.NativeTransporter {
+		// todo set by config
+		return native.NewClient(native.Options{
+			ConnectTimeout: 3 * time.Second,
+			RequestTimeout: 3 * time.Second,
+		})
+	}
+	// todo set by config
+	return getty.NewClient(getty.Options{
+		ConnectTimeout: 3 * time.Second,
+		RequestTimeout: 3 * time.Second,
+	})
+}
+
+// newServer creates the remoting server of the transporter set by the url
+func newServer(url *common.URL, handler func(*invocation.RPCInvocation) result.RPCResult) remoting.Server {
+	if transporter(url) == constant.NativeTransporter {
+		return native.NewServer(url, handler)
+	}
+	return getty.NewServer(url, handler)
+}
+
 // rebuildCtx rebuild the context by attachment.
 // Once we decided to transfer more context's key-value, we should change this.
-// now we only support rebuild the tracing context
-func rebuildCtx(inv *invocation.RPCInvocation) context.Context {
+// now we only support rebuild the tracing context and the deadline propagated by the consumer
+func rebuildCtx(inv *invocation.RPCInvocation) (context.Context, context.CancelFunc) {
 	ctx := context.WithValue(context.Background(), constant.DubboCtxKey("attachment"), inv.Attachments())
 
 	// actually, if user do not use any opentracing framework, the err will not be nil.
@@ -233,5 +302,5 @@ func rebuildCtx(inv *invocation.RPCInvocation) context.Context {
 	if err == nil {
 		ctx = context.WithValue(ctx, constant.DubboCtxKey(constant.TracingRemoteSpanCtx), spanCtx)
 	}
-	return ctx
+	return base.WithTimeoutBudget(ctx, inv.GetAttachmentWithDefaultValue(constant.TimeoutAttachmentKey, ""))
 }
@@ -18,7 +18,9 @@
 package dubbo
 
 import (
+	"context"
 	"testing"
+	"time"
 )
 
 import (
@@ -30,8 +32,10 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/proxy/proxy_factory"
 	"dubbo.apache.org/dubbo-go/v3/remoting/getty"
+	"dubbo.apache.org/dubbo-go/v3/remoting/native"
 )
 
 const (
@@ -150,3 +154,62 @@ func TestDubboProtocol_Refer(t *testing.T) {
 	invokersLen = len(proto.(*DubboProtocol).Invokers())
 	assert.Equal(t, 0, invokersLen)
 }
+
+func TestTransporter(t *testing.T) {
+	url, err := common.NewURL(mockCommonUrl)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:20000", exchangeClientKey(url))
+	assert.IsType(t, &getty.Client{}, newClient(url))
+
+	url.SetParam(constant.TransporterKey, constant.NativeTransporter)
+	assert.Equal(t, "native://127.0.0.1:20000", exchangeClientKey(url))
+	assert.IsType(t, &native.Client{}, newClient(url))
+	assert.IsType(t, &native.Server{}, newServer(url, doHandleRequest))
+
+	// unix domain sockets use the native transporter by default, getty does not support them
+	url.SetParam(constant.TransporterKey, "")
+	url.SetUnixSocket("/tmp/dubbo.sock")
+	assert.Equal(t, "native://unix:/tmp/dubbo.sock", exchangeClientKey(url))
+	assert.IsType(t, &native.Client{}, newClient(url))
+	require.NoError(t, checkTransporter(url))
+
+	url.SetParam(constant.TransporterKey, constant.GettyTransporter)
+	assert.ErrorContains(t, checkTransporter(url), "does not support unix domain socket")
+	assert.Nil(t, GetProtocol().Refer(url))
+}
+
+func TestDeadlinePropagation(t *testing.T) {
+	url, err := common.NewURL(mockCommonUrl)
+	require.NoError(t, err)
+	invoker := NewDubboInvoker(url, nil)
+
+	// the configured timeout is used when the caller has no deadline
+	inv := invocation.NewRPCInvocation("GetUser", nil, nil)
+	timeout, err := invoker.getTimeout(context.Background(), inv)
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Second, timeout)
+	assert.Equal(t, "3000", inv.GetAttachmentWithDefaultValue(constant.TimeoutAttachmentKey, ""))
+
+	// the remaining time of the caller caps the configured timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	inv = invocation.NewRPCInvocation("GetUser", nil, nil)
+	timeout, err = invoker.getTimeout(ctx, inv)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, timeout, 200*time.Millisecond)
+	budget := inv.GetAttachmentWithDefaultValue(constant.TimeoutAttachmentKey, "")
+
+	// the provider derives the context of the invocation from the propagated budget
+	providerCtx, providerCancel := rebuildCtx(invocation.NewRPCInvocation("GetUser", nil,
+		map[string]any{constant.TimeoutAttachmentKey: budget}))
+	defer providerCancel()
+	deadline, ok := providerCtx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(200*time.Millisecond), deadline, 50*time.Millisecond)
+
+	// calls fail fast once the budget is exhausted
+	expired, expiredCancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Millisecond))
+	defer expiredCancel()
+	_, err = invoker.getTimeout(expired, invocation.NewRPCInvocation("GetUser", nil, nil))
+	assert.Equal(t, base.ErrDeadlineExhausted, err)
+}
@@ -43,6 +43,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 )
 
 // Request is HTTP protocol request
@@ -117,6 +118,12 @@ func (c *HTTPClient) Call(ctx context.Context, service *common.URL, req *Request
 	if reqTimeout <= 0 {
 		reqTimeout = 100 * time.Millisecond
 	}
+	// the timeout never outlives the deadline inherited from the caller, and the provider
+	// derives the context of the invocation from the remaining time in the header
+	reqTimeout, err := base.CapTimeout(ctx, reqTimeout)
+	if err != nil {
+		return err
+	}
 	httpHeader.Set("Timeout", reqTimeout.String())
 	if md, ok := ctx.Value(constant.DubboGoCtxKey).(map[string]string); ok {
 		for k := range md {
@@ -143,7 +150,7 @@ func (c *HTTPClient) Call(ctx context.Context, service *common.URL, req *Request
 		return perrors.WithStack(err)
 	}
 
-	rspBody, err := c.Do(service.Location, service.Path, httpHeader, reqBody)
+	rspBody, err := c.do(service.Location, service.Path, httpHeader, reqBody, reqTimeout)
 	if err != nil {
 		return perrors.WithStack(err)
 	}
@@ -154,6 +161,10 @@ func (c *HTTPClient) Call(ctx context.Context, service *common.URL, req *Request
 // Do is the high level of complexity and the likelihood that the fasthttp client has not been extensively used
 // in production means that you would need to expect a very large benefit to justify the adoption of fasthttp today.
 func (c *HTTPClient) Do(addr, path string, httpHeader http.Header, body []byte) ([]byte, error) {
+	return c.do(addr, path, httpHeader, body, c.options.HTTPTimeout)
+}
+
+func (c *HTTPClient) do(addr, path string, httpHeader http.Header, body []byte, timeout time.Duration) ([]byte, error) {
 	u := url.URL{Host: strings.TrimSuffix(addr, ":"), Path: path}
 	httpReq, err := http.NewRequest("POST", u.String(), bytes.NewBuffer(body))
 	if err != nil {
@@ -167,7 +178,11 @@ func (c *HTTPClient) Do(addr, path string, httpHeader http.Header, body []byte)
 		return nil, perrors.WithStack(err)
 	}
 
-	tcpConn, err := net.DialTimeout("tcp", addr, c.options.HandshakeTimeout)
+	dialTimeout := c.options.HandshakeTimeout
+	if timeout > 0 && timeout < dialTimeout {
+		dialTimeout = timeout
+	}
+	tcpConn, err := net.DialTimeout("tcp", addr, dialTimeout)
 	if err != nil {
 		return nil, perrors.WithStack(err)
 	}
@@ -180,7 +195,7 @@ func (c *HTTPClient) Do(addr, path string, httpHeader http.Header, body []byte)
 
 		return conn.SetDeadline(t)
 	}
-	if err = setNetConnTimeout(tcpConn, c.options.HTTPTimeout); err != nil {
+	if err = setNetConnTimeout(tcpConn, timeout); err != nil {
 		return nil, err
 	}
 
@@ -19,10 +19,23 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
 )
 
 import (
 	perrors "github.com/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 )
 
 // import (
@@ -214,3 +227,42 @@ func (u *UserProvider) GetUser4(id float64) (*User, error) {
 func (u *UserProvider) Reference() string {
 	return "UserProvider"
 }
+
+func TestHTTPClientCallTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	headers := make(chan http.Header, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": User{ID: "1", Name: "dubbo"}})
+	})}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	service, err := common.NewURL("jsonrpc://" + listener.Addr().String() + "/UserProvider")
+	require.NoError(t, err)
+	client := NewHTTPClient(&HTTPOptions{HandshakeTimeout: time.Second, HTTPTimeout: 3 * time.Second})
+
+	// the timeout in the header is capped by the deadline of the caller
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	rsp := &User{}
+	require.NoError(t, client.Call(ctx, service, client.NewRequest(service, "GetUser", []any{"1"}), rsp))
+	assert.Equal(t, User{ID: "1", Name: "dubbo"}, *rsp)
+	header := <-headers
+	timeout, err := time.ParseDuration(header.Get("Timeout"))
+	require.NoError(t, err)
+	assert.Greater(t, timeout, time.Duration(0))
+	assert.LessOrEqual(t, timeout, time.Second)
+
+	// the call is not sent once the deadline is exhausted
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	err = client.Call(expired, service, client.NewRequest(service, "GetUser", []any{"1"}), &User{})
+	require.ErrorIs(t, err, base.ErrDeadlineExhausted)
+	assert.Empty(t, headers)
+}
@@ -255,6 +255,12 @@ func WithIp(ip string) ServerOption {
 	return &ipOption{ip}
 }
 
+// WithUnixSocket makes the server listen on the unix domain socket of path instead of a tcp port,
+// services on it are not published to registries.
+func WithUnixSocket(path string) ServerOption {
+	return &ipOption{constant.UnixScheme + "://" + path}
+}
+
 type portOption struct {
 	Port string
 }
@@ -279,6 +285,20 @@ func WithParams(params any) ServerOption {
 	return &paramsOption{params}
 }
 
+type transporterOption struct {
+	Transporter string
+}
+
+func (o *transporterOption) applyToServer(config *ServerOptions) {
+	config.Protocol.Transporter = o.Transporter
+}
+
+// WithTransporter specifies the remoting implementation of the dubbo protocol,
+// constant.GettyTransporter (default) or constant.NativeTransporter.
+func WithTransporter(transporter string) ServerOption {
+	return &transporterOption{transporter}
+}
+
 // Deprecated：use triple.WithMaxServerSendMsgSize instead.
 func WithMaxServerSendMsgSize(size string) ServerOption {
 	panic("use triple.WithMaxServerSendMsgSize()")
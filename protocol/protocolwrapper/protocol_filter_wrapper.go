@@ -19,6 +19,7 @@ package protocolwrapper
 
 import (
 	"context"
+	"log/slog"
 	"strings"
 )
 
@@ -31,6 +32,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
+	dubbologger "dubbo.apache.org/dubbo-go/v3/logger"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
@@ -85,10 +87,18 @@ func BuildInvokerChain(invoker base.Invoker, key string) base.Invoker {
 
 	// The order of filters is from left to right, so loading from right to left
 	next := invoker
+	var head *FilterInvoker
 	for i := len(filterNames) - 1; i >= 0; i-- {
 		flt, _ := extension.GetFilter(strings.TrimSpace(filterNames[i]))
-		fi := &FilterInvoker{next: next, invoker: invoker, filter: flt}
-		next = fi
+		head = &FilterInvoker{next: next, invoker: invoker, filter: flt}
+		next = head
+	}
+	head.head = true
+	head.provider = key == constant.ServiceFilterKey
+	url := invoker.GetURL()
+	head.serviceAttr = slog.String(constant.LogServiceKey, url.ServiceKey())
+	if !head.provider && url.Location != "" {
+		head.remoteAttr = slog.String(constant.LogRemoteAddrKey, url.Location)
 	}
 	switch key {
 	case constant.ServiceFilterKey:
@@ -112,6 +122,13 @@ type FilterInvoker struct {
 	next    base.Invoker
 	invoker base.Invoker
 	filter  filter.Filter
+	// head is the first invoker of the chain, which attaches the log attributes of the invocation
+	head     bool
+	provider bool
+	// serviceAttr and remoteAttr are the log attributes of the head known when the chain is built,
+	// remoteAttr is only set for consumers
+	serviceAttr slog.Attr
+	remoteAttr  slog.Attr
 }
 
 // GetURL is used to get url from FilterInvoker
@@ -126,10 +143,37 @@ func (fi *FilterInvoker) IsAvailable() bool {
 
 // Invoke is used to call service method by invocation
 func (fi *FilterInvoker) Invoke(ctx context.Context, invocation base.Invocation) result.Result {
+	if fi.head {
+		ctx = fi.withLogAttrs(ctx, invocation)
+	}
 	result := fi.filter.Invoke(ctx, fi.next, invocation)
 	return fi.filter.OnResponse(ctx, result, fi.invoker, invocation)
 }
 
+// withLogAttrs attaches the service, method and remote address of the invocation to the logs of ctx.
+func (fi *FilterInvoker) withLogAttrs(ctx context.Context, invocation base.Invocation) context.Context {
+	attrs := [3]slog.Attr{fi.serviceAttr, slog.String(constant.LogMethodKey, invocation.MethodName())}
+	n := 2
+	if fi.provider {
+		if remote, _ := invocation.GetAttachment(constant.RemoteAddr); remote != "" {
+			attrs[n] = slog.String(constant.LogRemoteAddrKey, remote)
+			n++
+		}
+	} else if fi.remoteAttr.Key != "" {
+		attrs[n] = fi.remoteAttr
+		n++
+	}
+	return dubbologger.WithAttrs(ctx, attrs[:n]...)
+}
+
+// HealthCheck probes the provider by the wrapped invoker without going through the filters
+func (fi *FilterInvoker) HealthCheck(ctx context.Context) error {
+	if checker, ok := fi.invoker.(base.HealthChecker); ok {
+		return checker.HealthCheck(ctx)
+	}
+	return base.ErrHealthCheckUnsupported
+}
+
 // Destroy will destroy invoker
 func (fi *FilterInvoker) Destroy() {
 	fi.invoker.Destroy()
@@ -19,6 +19,7 @@ package protocolwrapper
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"testing"
 )
@@ -34,11 +35,16 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
+	dubbologger "dubbo.apache.org/dubbo-go/v3/logger"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
 
-const mockFilterKey = "mockEcho"
+const (
+	mockFilterKey    = "mockEcho"
+	mockLogFilterKey = "mockLogAttrs"
+)
 
 func TestProtocolFilterWrapperExport(t *testing.T) {
 	filtProto := extension.GetProtocol(FILTER)
@@ -64,11 +70,46 @@ func TestProtocolFilterWrapperRefer(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestFilterInvokerLogAttrs(t *testing.T) {
+	flt := &mockLogFilter{}
+	extension.SetFilter(mockLogFilterKey, func() filter.Filter { return flt })
+
+	u, err := common.NewURL("dubbo://127.0.0.1:20000/org.apache.dubbo.Greet?group=g1&version=1.0.0",
+		common.WithParamsValue(constant.ReferenceFilterKey, mockLogFilterKey+","+mockLogFilterKey))
+	assert.NoError(t, err)
+	invoker := BuildInvokerChain(base.NewBaseInvoker(u), constant.ReferenceFilterKey)
+	invoker.Invoke(context.Background(), invocation.NewRPCInvocation("Greet", nil, nil))
+	assert.Len(t, flt.attrs, 2)
+	for _, attrs := range flt.attrs {
+		assert.Equal(t, "[service=g1/org.apache.dubbo.Greet:1.0.0 method=Greet remote_addr=127.0.0.1:20000]", attrs)
+	}
+
+	flt.attrs = nil
+	u.SetParam(constant.ServiceFilterKey, mockLogFilterKey)
+	invoker = BuildInvokerChain(base.NewBaseInvoker(u), constant.ServiceFilterKey)
+	invoker.Invoke(context.Background(), invocation.NewRPCInvocation("Greet", nil, map[string]any{constant.RemoteAddr: "10.0.0.1:52000"}))
+	assert.Equal(t, []string{"[service=g1/org.apache.dubbo.Greet:1.0.0 method=Greet remote_addr=10.0.0.1:52000]"}, flt.attrs)
+}
+
 // The initialization of mockEchoFilter, for test
 func init() {
 	extension.SetFilter(mockFilterKey, newFilter)
 }
 
+// mockLogFilter records the log attributes of the invocations
+type mockLogFilter struct {
+	attrs []string
+}
+
+func (f *mockLogFilter) Invoke(ctx context.Context, invoker base.Invoker, invocation base.Invocation) result.Result {
+	f.attrs = append(f.attrs, fmt.Sprint(dubbologger.Attrs(ctx)))
+	return invoker.Invoke(ctx, invocation)
+}
+
+func (f *mockLogFilter) OnResponse(_ context.Context, result result.Result, _ base.Invoker, _ base.Invocation) result.Result {
+	return result
+}
+
 type mockEchoFilter struct{}
 
 func (ef *mockEchoFilter) Invoke(ctx context.Context, invoker base.Invoker, invocation base.Invocation) result.Result {
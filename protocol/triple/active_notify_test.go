@@ -152,3 +152,75 @@ func TestTripleHealthWatchEmitsClosingEvent(t *testing.T) {
 	assert.Equal(t, url.GetCacheInvokerMapKey(), handler.events[0].InstanceKey)
 	assert.Equal(t, serviceKey, handler.events[0].ServiceKey)
 }
+
+func TestTripleInvokerHealthCheck(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	serviceKey := common.ServiceKey(constant.HealthCheckServiceInterface, "group", "1.0.0")
+	var (
+		mux    sync.Mutex
+		status = grpc_health_v1.HealthCheckResponse_SERVING
+	)
+
+	server := tri.NewServer(addr, nil)
+	err = server.RegisterUnaryHandler(
+		"/grpc.health.v1.Health/Check",
+		func() any { return new(grpc_health_v1.HealthCheckRequest) },
+		func(ctx context.Context, req *tri.Request) (*tri.Response, error) {
+			request, ok := req.Msg.(*grpc_health_v1.HealthCheckRequest)
+			if !ok {
+				return nil, fmt.Errorf("unexpected request type %T", req.Msg)
+			}
+			if request.GetService() != serviceKey {
+				return nil, fmt.Errorf("unexpected service %s", request.GetService())
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			return tri.NewResponse(&grpc_health_v1.HealthCheckResponse{Status: status}), nil
+		},
+	)
+	require.NoError(t, err)
+
+	go func() {
+		_ = server.Run(constant.CallHTTP2, nil)
+	}()
+	defer func() {
+		_ = server.Stop()
+	}()
+
+	require.Eventually(t, func() bool {
+		conn, dialErr := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+		if dialErr != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 3*time.Second, 20*time.Millisecond)
+
+	url, err := common.NewURL(fmt.Sprintf(
+		"tri://%s/%s?interface=%s&group=group&version=1.0.0&timeout=1s",
+		addr,
+		constant.HealthCheckServiceInterface,
+		constant.HealthCheckServiceInterface,
+	))
+	require.NoError(t, err)
+
+	cm, err := newClientManager(url)
+	require.NoError(t, err)
+	invoker := &TripleInvoker{
+		BaseInvoker:   *base.NewBaseInvoker(url),
+		clientGuard:   &sync.RWMutex{},
+		clientManager: cm,
+	}
+	defer invoker.Destroy()
+
+	assert.NoError(t, invoker.HealthCheck(context.Background()))
+
+	mux.Lock()
+	status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	mux.Unlock()
+	assert.ErrorContains(t, invoker.HealthCheck(context.Background()), "NOT_SERVING")
+}
@@ -44,6 +44,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/filter/otel/trace"
 	"dubbo.apache.org/dubbo-go/v3/global"
 	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 	dubbotls "dubbo.apache.org/dubbo-go/v3/tls"
@@ -136,7 +137,14 @@ func newClientManager(url *common.URL) (*clientManager, error) {
 	version := url.GetParam(constant.VersionKey, "")
 	cliOpts = append(cliOpts, tri.WithGroup(group), tri.WithVersion(version))
 
-	// todo(DMwangnima): support opentracing
+	// trace streams over their whole lifetime, the trace filter only sees them being opened
+	if hasFilter(url, constant.ReferenceFilterKey, constant.OTELClientTraceKey) {
+		cliOpts = append(cliOpts, tri.WithInterceptors(trace.NewStreamInterceptor(url.ServiceKey())))
+	}
+	// payload sizes and streams are only known by triple, report them along with the metrics filter
+	if hasFilter(url, constant.ReferenceFilterKey, constant.MetricsFilterKey) {
+		cliOpts = append(cliOpts, metricsOption(url, constant.SideConsumer))
+	}
 
 	// handle tls
 	var (
@@ -182,6 +190,15 @@ func newClientManager(url *common.URL) (*clientManager, error) {
 	// handle http transport of triple protocol
 	var transport http.RoundTripper
 
+	// a provider on a unix domain socket is dialed by the socket path whatever the address of the request is
+	unixSocket, isUnix := url.UnixSocket()
+	dialContext := (&net.Dialer{}).DialContext
+	if isUnix {
+		dialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", unixSocket)
+		}
+	}
+
 	var callProtocol string
 	if tripleConf != nil && tripleConf.Http3 != nil && tripleConf.Http3.Enable {
 		callProtocol = constant.CallHTTP2AndHTTP3
@@ -197,6 +214,7 @@ func newClientManager(url *common.URL) (*clientManager, error) {
 	case constant.CallHTTP:
 		transport = &http.Transport{
 			TLSClientConfig: cfg,
+			DialContext:     dialContext,
 		}
 		cliOpts = append(cliOpts, tri.WithTriple())
 	case constant.CallHTTP2:
@@ -207,13 +225,22 @@ func newClientManager(url *common.URL) (*clientManager, error) {
 				ReadIdleTimeout: keepAliveInterval,
 				PingTimeout:     keepAliveTimeout,
 				DialTLSContext: func(ctx context.Context, network, addr string, tlsConfig *tls.Config) (net.Conn, error) {
-					return (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, network, addr)
+					conn, err := dialContext(ctx, network, addr)
+					if err != nil {
+						return nil, err
+					}
+					tlsConn := tls.Client(conn, tlsConfig)
+					if err = tlsConn.HandshakeContext(ctx); err != nil {
+						_ = conn.Close()
+						return nil, err
+					}
+					return tlsConn, nil
 				},
 			}
 		} else {
 			transport = &http2.Transport{
 				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
-					return (&net.Dialer{}).DialContext(ctx, network, addr)
+					return dialContext(ctx, network, addr)
 				},
 				AllowHTTP:       true,
 				ReadIdleTimeout: keepAliveInterval,
@@ -221,6 +248,9 @@ func newClientManager(url *common.URL) (*clientManager, error) {
 			}
 		}
 	case constant.CallHTTP3:
+		if isUnix {
+			return nil, fmt.Errorf("TRIPLE http3 client does not support unix domain socket %s", unixSocket)
+		}
 		if !tlsFlag {
 			return nil, fmt.Errorf("TRIPLE http3 client must have TLS config, but TLS config is nil")
 		}
@@ -238,6 +268,9 @@ func newClientManager(url *common.URL) (*clientManager, error) {
 
 		logger.Infof("Triple http3 client transport init successfully")
 	case constant.CallHTTP2AndHTTP3:
+		if isUnix {
+			return nil, fmt.Errorf("TRIPLE HTTP/2 and HTTP/3 client does not support unix domain socket %s", unixSocket)
+		}
 		if !tlsFlag {
 			return nil, fmt.Errorf("TRIPLE HTTP/2 and HTTP/3 client must have TLS config, but TLS config is nil")
 		}
@@ -256,6 +289,10 @@ func newClientManager(url *common.URL) (*clientManager, error) {
 	var baseTriURL string
 	baseTriURL = strings.TrimPrefix(url.Location, httpPrefix)
 	baseTriURL = strings.TrimPrefix(baseTriURL, httpsPrefix)
+	if isUnix {
+		// the location of a unix domain socket is not a valid authority
+		baseTriURL = "localhost"
+	}
 	if tlsFlag {
 		baseTriURL = httpsPrefix + baseTriURL
 	} else {
@@ -293,6 +330,18 @@ func (cm *clientManager) callHealthWatch(ctx context.Context, service string) (*
 	return stream, nil
 }
 
+func (cm *clientManager) callHealthCheck(ctx context.Context, service string) (*grpc_health_v1.HealthCheckResponse, error) {
+	if cm.healthClient == nil {
+		return nil, errors.New("triple health client is not initialized")
+	}
+	resp := new(grpc_health_v1.HealthCheckResponse)
+	req := tri.NewRequest(&grpc_health_v1.HealthCheckRequest{Service: service})
+	if err := cm.healthClient.CallUnary(ctx, req, "Check", tri.NewResponse(resp)); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func genKeepAliveOptions(url *common.URL, tripleConf *global.TripleConfig) ([]tri.ClientOption, time.Duration, time.Duration, error) {
 	var cliKeepAliveOpts []tri.ClientOption
 
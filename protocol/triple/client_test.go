@@ -281,6 +281,19 @@ func Test_newClientManager_NoMethods(t *testing.T) {
 	assert.NotNil(t, cm.triClient, "triClient should be created at service level")
 }
 
+func Test_newClientManager_UnixSocket(t *testing.T) {
+	url := common.NewURLWithOptions(common.WithPath("com.example.TestService"))
+	url.SetUnixSocket("/var/run/dubbo/greet.sock")
+
+	cm, err := newClientManager(url)
+	require.NoError(t, err)
+	assert.NotNil(t, cm.triClient)
+
+	url.SetAttribute(constant.TripleConfigKey, &global.TripleConfig{Http3: &global.Http3Config{Enable: true}})
+	_, err = newClientManager(url)
+	assert.Error(t, err)
+}
+
 func Test_newClientManager_WithMethods(t *testing.T) {
 	url := common.NewURLWithOptions(
 		common.WithLocation("localhost:20000"),
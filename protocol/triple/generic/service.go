@@ -0,0 +1,270 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package generic invokes triple services defined by protobuf without the generated code. The method descriptors
+// are resolved through the server reflection service or from descriptor sets, and the messages are built with
+// dynamicpb from JSON or maps, so that gateways and test tools can call any triple service.
+//
+// Example usage:
+//
+//	source, err := generic.NewReflectionSource(cli, client.WithURL("tri://127.0.0.1:20000"))
+//	if err != nil {
+//	    panic(err)
+//	}
+//	svc, err := generic.NewService(ctx, cli, source, "greet.GreetService", client.WithURL("tri://127.0.0.1:20000"))
+//	if err != nil {
+//	    panic(err)
+//	}
+//	resp, err := svc.InvokeJSON(ctx, "Greet", []byte(`{"name": "dubbo"}`))
+package generic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"google.golang.org/protobuf/proto"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+// Service invokes the methods of a protobuf service over triple.
+type Service struct {
+	desc  protoreflect.ServiceDescriptor
+	types *dynamicpb.Types
+	conn  *client.Connection
+}
+
+// NewService resolves the service with the full name from source, and refers to it with opts. The full name of the
+// service is its interface name, the same as the generated code.
+func NewService(ctx context.Context, cli *client.Client, source DescriptorSource, name string, opts ...client.ReferenceOption) (*Service, error) {
+	sd, files, err := source.FindService(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	methods := make([]string, 0, sd.Methods().Len())
+	for i := 0; i < sd.Methods().Len(); i++ {
+		methods = append(methods, string(sd.Methods().Get(i).Name()))
+	}
+	conn, err := cli.DialWithInfo(name, &client.ClientInfo{InterfaceName: name, MethodNames: methods}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		desc:  sd,
+		types: dynamicpb.NewTypes(files),
+		conn:  conn,
+	}, nil
+}
+
+// Destroy destroys the reference of the service, the calls fail afterwards.
+func (s *Service) Destroy() {
+	s.conn.Destroy()
+}
+
+// Descriptor returns the descriptor of the service.
+func (s *Service) Descriptor() protoreflect.ServiceDescriptor {
+	return s.desc
+}
+
+// Method returns the descriptor of the method with the name.
+func (s *Service) Method(name string) (protoreflect.MethodDescriptor, error) {
+	md := s.desc.Methods().ByName(protoreflect.Name(name))
+	if md == nil {
+		methods := make([]string, 0, s.desc.Methods().Len())
+		for i := 0; i < s.desc.Methods().Len(); i++ {
+			methods = append(methods, string(s.desc.Methods().Get(i).Name()))
+		}
+		return nil, perrors.Errorf("service %s has no method %s, available methods: %s",
+			s.desc.FullName(), name, strings.Join(methods, ", "))
+	}
+	return md, nil
+}
+
+// Invoke calls the unary method with the request message, see NewMessage for the accepted messages.
+func (s *Service) Invoke(ctx context.Context, method string, req any, opts ...client.CallOption) (*dynamicpb.Message, error) {
+	md, err := s.methodOf(method, false, false)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := s.NewMessage(md.Input(), req)
+	if err != nil {
+		return nil, err
+	}
+	resp := dynamicpb.NewMessage(md.Output())
+	if err = s.conn.CallUnary(ctx, []any{msg}, resp, method, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// InvokeJSON calls the unary method with the JSON request message, and returns the JSON response message.
+func (s *Service) InvokeJSON(ctx context.Context, method string, req []byte, opts ...client.CallOption) ([]byte, error) {
+	resp, err := s.Invoke(ctx, method, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.JSON(resp)
+}
+
+// InvokeMap calls the unary method with the request message in a map, and returns the response message in a map.
+func (s *Service) InvokeMap(ctx context.Context, method string, req map[string]any, opts ...client.CallOption) (map[string]any, error) {
+	resp, err := s.Invoke(ctx, method, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.Map(resp)
+}
+
+// ServerStream calls the server streaming method with the request message.
+func (s *Service) ServerStream(ctx context.Context, method string, req any, opts ...client.CallOption) (*ServerStream, error) {
+	md, err := s.methodOf(method, false, true)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := s.NewMessage(md.Input(), req)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := s.conn.CallServerStream(ctx, msg, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ServerStream{ServerStreamForClient: stream.(*tri.ServerStreamForClient), output: md.Output()}, nil
+}
+
+// ClientStream calls the client streaming method.
+func (s *Service) ClientStream(ctx context.Context, method string, opts ...client.CallOption) (*ClientStream, error) {
+	md, err := s.methodOf(method, true, false)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := s.conn.CallClientStream(ctx, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientStream{ClientStreamForClient: stream.(*tri.ClientStreamForClient), svc: s, method: md}, nil
+}
+
+// BidiStream calls the bidirectional streaming method.
+func (s *Service) BidiStream(ctx context.Context, method string, opts ...client.CallOption) (*BidiStream, error) {
+	md, err := s.methodOf(method, true, true)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := s.conn.CallBidiStream(ctx, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &BidiStream{BidiStreamForClient: stream.(*tri.BidiStreamForClient), svc: s, method: md}, nil
+}
+
+// methodOf returns the descriptor of the method, which must stream as the call does
+func (s *Service) methodOf(name string, streamingClient, streamingServer bool) (protoreflect.MethodDescriptor, error) {
+	md, err := s.Method(name)
+	if err != nil {
+		return nil, err
+	}
+	if md.IsStreamingClient() != streamingClient || md.IsStreamingServer() != streamingServer {
+		return nil, perrors.Errorf("method %s is %s, not %s", md.FullName(),
+			callType(md.IsStreamingClient(), md.IsStreamingServer()), callType(streamingClient, streamingServer))
+	}
+	return md, nil
+}
+
+func callType(streamingClient, streamingServer bool) string {
+	switch {
+	case streamingClient && streamingServer:
+		return "bidi streaming"
+	case streamingClient:
+		return "client streaming"
+	case streamingServer:
+		return "server streaming"
+	default:
+		return "unary"
+	}
+}
+
+// NewMessage builds a message of the type desc from msg, which is one of
+//   - nil, for an empty message;
+//   - []byte, json.RawMessage or string, for a message in JSON;
+//   - map[string]any, for a message in the JSON mapping of protobuf;
+//   - proto.Message of the type desc.
+func (s *Service) NewMessage(desc protoreflect.MessageDescriptor, msg any) (proto.Message, error) {
+	var data []byte
+	switch m := msg.(type) {
+	case nil:
+		return dynamicpb.NewMessage(desc), nil
+	case proto.Message:
+		if name := m.ProtoReflect().Descriptor().FullName(); name != desc.FullName() {
+			return nil, perrors.Errorf("message is %s, not %s", name, desc.FullName())
+		}
+		return m, nil
+	case []byte:
+		data = m
+	case json.RawMessage:
+		data = m
+	case string:
+		data = []byte(m)
+	case map[string]any:
+		var err error
+		if data, err = json.Marshal(m); err != nil {
+			return nil, perrors.WithMessagef(err, "encode message %s", desc.FullName())
+		}
+	default:
+		return nil, perrors.Errorf("unsupported message %T of %s", msg, desc.FullName())
+	}
+	dm := dynamicpb.NewMessage(desc)
+	if err := (protojson.UnmarshalOptions{Resolver: s.types}).Unmarshal(data, dm); err != nil {
+		return nil, perrors.WithMessagef(err, "decode message %s", desc.FullName())
+	}
+	return dm, nil
+}
+
+// JSON returns the message in JSON.
+func (s *Service) JSON(msg proto.Message) ([]byte, error) {
+	return protojson.MarshalOptions{Resolver: s.types}.Marshal(msg)
+}
+
+// Map returns the message in the JSON mapping of protobuf, in which the numbers are json.Number.
+func (s *Service) Map(msg proto.Message) (map[string]any, error) {
+	data, err := s.JSON(msg)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	m := make(map[string]any)
+	if err = dec.Decode(&m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
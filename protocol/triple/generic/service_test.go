@@ -0,0 +1,261 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"google.golang.org/protobuf/proto"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+const echoService = "generic.test.EchoService"
+
+// echoDescriptorSet describes the EchoService which has a method of each call type
+func echoDescriptorSet() *descriptorpb.FileDescriptorSet {
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+	method := func(name string, streamingClient, streamingServer bool) *descriptorpb.MethodDescriptorProto {
+		return &descriptorpb.MethodDescriptorProto{
+			Name:            proto.String(name),
+			InputType:       proto.String(".generic.test.EchoMessage"),
+			OutputType:      proto.String(".generic.test.EchoMessage"),
+			ClientStreaming: proto.Bool(streamingClient),
+			ServerStreaming: proto.Bool(streamingServer),
+		}
+	}
+	return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("generic/test/echo.proto"),
+		Package: proto.String("generic.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("EchoMessage"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("text", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				field("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+			},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("EchoService"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				method("Echo", false, false),
+				method("Repeat", false, true),
+				method("Collect", true, false),
+				method("Chat", true, true),
+			},
+		}},
+	}}}
+}
+
+// newEchoServer serves the EchoService with dynamic messages
+func newEchoServer(t *testing.T, msgDesc protoreflect.MessageDescriptor) *httptest.Server {
+	newMsg := func() *dynamicpb.Message { return dynamicpb.NewMessage(msgDesc) }
+	text := msgDesc.Fields().ByName("text")
+	count := msgDesc.Fields().ByName("count")
+
+	mux := http.NewServeMux()
+	handle := func(method string, h func(procedure string) *tri.Handler) {
+		procedure := "/" + echoService + "/" + method
+		mux.Handle(procedure, h(procedure))
+	}
+	handle("Echo", func(procedure string) *tri.Handler {
+		return tri.NewUnaryHandler(procedure, func() any { return newMsg() },
+			func(_ context.Context, req *tri.Request) (*tri.Response, error) {
+				msg := req.Msg.(*dynamicpb.Message)
+				if msg.Get(text).String() == "fail" {
+					return nil, tri.NewError(tri.CodeInvalidArgument, errors.New("echo failed"))
+				}
+				return tri.NewResponse(msg), nil
+			})
+	})
+	handle("Repeat", func(procedure string) *tri.Handler {
+		return tri.NewServerStreamHandler(procedure, func() any { return newMsg() },
+			func(_ context.Context, req *tri.Request, stream *tri.ServerStream) error {
+				msg := req.Msg.(*dynamicpb.Message)
+				for i := int32(0); i < int32(msg.Get(count).Int()); i++ {
+					if err := stream.Send(msg); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+	})
+	handle("Collect", func(procedure string) *tri.Handler {
+		return tri.NewClientStreamHandler(procedure,
+			func(_ context.Context, stream *tri.ClientStream) (*tri.Response, error) {
+				var texts []string
+				for msg := newMsg(); stream.Receive(msg); msg = newMsg() {
+					texts = append(texts, msg.Get(text).String())
+				}
+				if err := stream.Err(); err != nil {
+					return nil, err
+				}
+				resp := newMsg()
+				resp.Set(text, protoreflect.ValueOfString(strings.Join(texts, ",")))
+				resp.Set(count, protoreflect.ValueOfInt32(int32(len(texts))))
+				return tri.NewResponse(resp), nil
+			})
+	})
+	handle("Chat", func(procedure string) *tri.Handler {
+		return tri.NewBidiStreamHandler(procedure,
+			func(_ context.Context, stream *tri.BidiStream) error {
+				for {
+					msg := newMsg()
+					if err := stream.Receive(msg); errors.Is(err, io.EOF) {
+						return nil
+					} else if err != nil {
+						return err
+					}
+					if err := stream.Send(msg); err != nil {
+						return err
+					}
+				}
+			})
+	})
+	server := httptest.NewServer(h2c.NewHandler(mux, &http2.Server{}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newEchoService(t *testing.T) *Service {
+	source, err := NewDescriptorSetSource(echoDescriptorSet())
+	require.NoError(t, err)
+	sd, _, err := source.FindService(context.Background(), echoService)
+	require.NoError(t, err)
+	server := newEchoServer(t, sd.Methods().ByName("Echo").Input())
+
+	cli, err := client.NewClient()
+	require.NoError(t, err)
+	svc, err := NewService(context.Background(), cli, source, echoService,
+		client.WithURL("tri://"+strings.TrimPrefix(server.URL, "http://")))
+	require.NoError(t, err)
+	return svc
+}
+
+func TestServiceInvoke(t *testing.T) {
+	svc := newEchoService(t)
+	ctx := context.Background()
+
+	resp, err := svc.InvokeJSON(ctx, "Echo", []byte(`{"text": "hello", "count": 2}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text": "hello", "count": 2}`, string(resp))
+
+	m, err := svc.InvokeMap(ctx, "Echo", map[string]any{"text": "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"text": "hello"}, m)
+
+	msg, err := svc.Invoke(ctx, "Echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, protoreflect.FullName("generic.test.EchoMessage"), msg.Descriptor().FullName())
+	assert.Zero(t, proto.Size(msg))
+
+	_, err = svc.InvokeJSON(ctx, "Echo", []byte(`{"text": "fail"}`))
+	assert.Equal(t, tri.CodeInvalidArgument, tri.CodeOf(err))
+	_, err = svc.InvokeJSON(ctx, "Echo", []byte(`{"unknown": 1}`))
+	assert.ErrorContains(t, err, "generic.test.EchoMessage")
+	_, err = svc.Invoke(ctx, "Echo", 1)
+	assert.ErrorContains(t, err, "unsupported message int")
+	_, err = svc.Invoke(ctx, "Missing", nil)
+	assert.ErrorContains(t, err, "available methods: Echo, Repeat, Collect, Chat")
+	_, err = svc.Invoke(ctx, "Repeat", nil)
+	assert.ErrorContains(t, err, "is server streaming, not unary")
+}
+
+func TestServiceStreams(t *testing.T) {
+	svc := newEchoService(t)
+	ctx := context.Background()
+
+	t.Run("ServerStream", func(t *testing.T) {
+		stream, err := svc.ServerStream(ctx, "Repeat", `{"text": "hi", "count": 3}`)
+		require.NoError(t, err)
+		defer stream.Close()
+		received := 0
+		for {
+			msg, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			m, err := svc.Map(msg)
+			require.NoError(t, err)
+			assert.Equal(t, "hi", m["text"])
+			received++
+		}
+		assert.Equal(t, 3, received)
+	})
+
+	t.Run("ClientStream", func(t *testing.T) {
+		stream, err := svc.ClientStream(ctx, "Collect")
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(map[string]any{"text": "a"}))
+		require.NoError(t, stream.Send(`{"text": "b"}`))
+		msg, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		resp, err := svc.JSON(msg)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"text": "a,b", "count": 2}`, string(resp))
+	})
+
+	t.Run("BidiStream", func(t *testing.T) {
+		stream, err := svc.BidiStream(ctx, "Chat")
+		require.NoError(t, err)
+		defer stream.CloseResponse()
+		for _, text := range []string{"x", "y"} {
+			require.NoError(t, stream.Send(map[string]any{"text": text}))
+			msg, err := stream.Recv()
+			require.NoError(t, err)
+			m, err := svc.Map(msg)
+			require.NoError(t, err)
+			assert.Equal(t, text, m["text"])
+		}
+		require.NoError(t, stream.CloseRequest())
+		_, err = stream.Recv()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+}
@@ -0,0 +1,275 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generic
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+
+	"google.golang.org/protobuf/proto"
+
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	rpb "dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection/triple_reflection"
+)
+
+// DescriptorSource resolves the descriptors of protobuf services.
+type DescriptorSource interface {
+	// FindService returns the descriptor of the service with the full name, and the files it is resolved from which
+	// resolve the google.protobuf.Any payloads and the extensions of its messages.
+	FindService(ctx context.Context, name string) (protoreflect.ServiceDescriptor, *protoregistry.Files, error)
+}
+
+// ServiceLister is implemented by the DescriptorSource which lists the services it resolves.
+type ServiceLister interface {
+	// ListServices returns the sorted full names of the services
+	ListServices(ctx context.Context) ([]string, error)
+}
+
+type filesSource struct {
+	files *protoregistry.Files
+}
+
+// NewFilesSource returns a DescriptorSource resolving the services from files, protoregistry.GlobalFiles resolves
+// the services compiled into the binary.
+func NewFilesSource(files *protoregistry.Files) DescriptorSource {
+	return &filesSource{files: files}
+}
+
+// NewDescriptorSetSource returns a DescriptorSource resolving the services from a descriptor set, which is
+// generated by protoc with --descriptor_set_out and --include_imports.
+func NewDescriptorSetSource(set *descriptorpb.FileDescriptorSet) (DescriptorSource, error) {
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, perrors.WithMessage(err, "build file descriptors")
+	}
+	return NewFilesSource(files), nil
+}
+
+// LoadDescriptorSet returns a DescriptorSource resolving the services from the descriptor set file at path.
+func LoadDescriptorSet(path string) (DescriptorSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	set := new(descriptorpb.FileDescriptorSet)
+	if err = proto.Unmarshal(data, set); err != nil {
+		return nil, perrors.WithMessagef(err, "decode descriptor set %s", path)
+	}
+	return NewDescriptorSetSource(set)
+}
+
+func (s *filesSource) FindService(_ context.Context, name string) (protoreflect.ServiceDescriptor, *protoregistry.Files, error) {
+	sd, err := findService(s.files, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sd, s.files, nil
+}
+
+func (s *filesSource) ListServices(context.Context) ([]string, error) {
+	var services []string
+	s.files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			services = append(services, string(fd.Services().Get(i).FullName()))
+		}
+		return true
+	})
+	sort.Strings(services)
+	return services, nil
+}
+
+func findService(files *protoregistry.Files, name string) (protoreflect.ServiceDescriptor, error) {
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, perrors.WithMessagef(err, "find service %s", name)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, perrors.Errorf("%s is not a service", name)
+	}
+	return sd, nil
+}
+
+type reflectionSource struct {
+	reflection rpb.ServerReflection
+
+	lock  sync.Mutex
+	files map[string]*descriptorpb.FileDescriptorProto // file name -> file, the files received so far
+}
+
+// NewReflectionSource returns a DescriptorSource resolving the services through the server reflection service of
+// triple servers. The reflection service is not registered to the registries, so opts should set the url of the
+// server by client.WithURL.
+func NewReflectionSource(cli *client.Client, opts ...client.ReferenceOption) (DescriptorSource, error) {
+	reflection, err := rpb.NewServerReflection(cli, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewServerReflectionSource(reflection), nil
+}
+
+// NewServerReflectionSource returns a DescriptorSource resolving the services through the reflection client, which
+// lets the tools talking to triple servers with their own triple_protocol.Client reuse the resolution.
+func NewServerReflectionSource(reflection rpb.ServerReflection) DescriptorSource {
+	return &reflectionSource{
+		reflection: reflection,
+		files:      make(map[string]*descriptorpb.FileDescriptorProto),
+	}
+}
+
+func (s *reflectionSource) ListServices(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := s.reflection.ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, perrors.WithMessage(err, "open server reflection stream")
+	}
+	defer func() {
+		_ = stream.CloseRequest()
+		_ = stream.CloseResponse()
+	}()
+
+	resp, err := s.roundTrip(stream, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	services := make([]string, 0, len(resp.GetListServicesResponse().GetService()))
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		services = append(services, svc.GetName())
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+func (s *reflectionSource) FindService(ctx context.Context, name string) (protoreflect.ServiceDescriptor, *protoregistry.Files, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := s.reflection.ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, nil, perrors.WithMessage(err, "open server reflection stream")
+	}
+	defer func() {
+		_ = stream.CloseRequest()
+		_ = stream.CloseResponse()
+	}()
+
+	if err = s.request(stream, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: name},
+	}); err != nil {
+		return nil, nil, err
+	}
+	if err = s.resolveDependencies(stream); err != nil {
+		return nil, nil, err
+	}
+
+	set := &descriptorpb.FileDescriptorSet{File: make([]*descriptorpb.FileDescriptorProto, 0, len(s.files))}
+	for _, fd := range s.files {
+		set.File = append(set.File, fd)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, nil, perrors.WithMessage(err, "build file descriptors")
+	}
+	sd, err := findService(files, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sd, files, nil
+}
+
+// resolveDependencies fetches the imported files which are not received yet, the server does not send the files
+// it has sent on the stream again, and the files received on the former streams are not sent on this one.
+func (s *reflectionSource) resolveDependencies(stream rpb.ServerReflection_ServerReflectionInfoClient) error {
+	for {
+		var missing []string
+		for _, fd := range s.files {
+			for _, dep := range fd.GetDependency() {
+				if _, ok := s.files[dep]; !ok {
+					missing = append(missing, dep)
+				}
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		for _, dep := range missing {
+			if _, ok := s.files[dep]; ok {
+				continue
+			}
+			if err := s.request(stream, &rpb.ServerReflectionRequest{
+				MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+			}); err != nil {
+				return err
+			}
+			if _, ok := s.files[dep]; !ok {
+				return perrors.Errorf("server reflection did not return file %s", dep)
+			}
+		}
+	}
+}
+
+// request sends req and adds the files of the response
+func (s *reflectionSource) request(stream rpb.ServerReflection_ServerReflectionInfoClient, req *rpb.ServerReflectionRequest) error {
+	resp, err := s.roundTrip(stream, req)
+	if err != nil {
+		return err
+	}
+	for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		fd := new(descriptorpb.FileDescriptorProto)
+		if err = proto.Unmarshal(raw, fd); err != nil {
+			return perrors.WithMessage(err, "decode file descriptor")
+		}
+		s.files[fd.GetName()] = fd
+	}
+	return nil
+}
+
+// roundTrip sends req and receives its response
+func (s *reflectionSource) roundTrip(stream rpb.ServerReflection_ServerReflectionInfoClient, req *rpb.ServerReflectionRequest) (*rpb.ServerReflectionResponse, error) {
+	if err := stream.Send(req); err != nil {
+		return nil, perrors.WithMessage(err, "send server reflection request")
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, perrors.WithMessage(err, "receive server reflection response, is reflection enabled on the server")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, fmt.Errorf("server reflection: %s (code %d)", errResp.GetErrorMessage(), errResp.GetErrorCode())
+	}
+	return resp, nil
+}
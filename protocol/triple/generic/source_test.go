@@ -0,0 +1,137 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"google.golang.org/protobuf/proto"
+
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	_ "dubbo.apache.org/dubbo-go/v3/protocol/triple/health/triple_health"
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection"
+	rpb "dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection/triple_reflection"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+const healthService = "grpc.health.v1.Health"
+
+func TestReflectionSource(t *testing.T) {
+	mux := http.NewServeMux()
+	reflectionServer := reflection.NewServer()
+	mux.Handle(rpb.ServerReflectionServerReflectionInfoProcedure, tri.NewBidiStreamHandler(
+		rpb.ServerReflectionServerReflectionInfoProcedure,
+		func(ctx context.Context, stream *tri.BidiStream) error {
+			return reflectionServer.ServerReflectionInfo(ctx, &rpb.ServerReflectionServerReflectionInfoServer{BidiStream: stream})
+		},
+	))
+	server := httptest.NewServer(h2c.NewHandler(mux, &http2.Server{}))
+	defer server.Close()
+
+	cli, err := client.NewClient()
+	require.NoError(t, err)
+	source, err := NewReflectionSource(cli, client.WithURL("tri://"+strings.TrimPrefix(server.URL, "http://")))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		sd, files, err := source.FindService(context.Background(), healthService)
+		require.NoError(t, err)
+		assert.Equal(t, 2, sd.Methods().Len())
+		assert.True(t, sd.Methods().ByName("Watch").IsStreamingServer())
+		_, err = files.FindDescriptorByName("grpc.health.v1.HealthCheckResponse")
+		assert.NoError(t, err)
+	}
+
+	_, _, err = source.FindService(context.Background(), "grpc.health.v1.Missing")
+	assert.ErrorContains(t, err, "server reflection")
+}
+
+func TestReflectionSourceListServices(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(rpb.ServerReflectionServerReflectionInfoProcedure, tri.NewBidiStreamHandler(
+		rpb.ServerReflectionServerReflectionInfoProcedure,
+		func(_ context.Context, stream *tri.BidiStream) error {
+			if err := stream.Receive(new(rpb.ServerReflectionRequest)); err != nil {
+				return err
+			}
+			return stream.Send(&rpb.ServerReflectionResponse{
+				MessageResponse: &rpb.ServerReflectionResponse_ListServicesResponse{
+					ListServicesResponse: &rpb.ListServiceResponse{Service: []*rpb.ServiceResponse{
+						{Name: healthService}, {Name: "grpc.reflection.v1alpha.ServerReflection"},
+					}},
+				},
+			})
+		},
+	))
+	server := httptest.NewServer(h2c.NewHandler(mux, &http2.Server{}))
+	defer server.Close()
+
+	cli, err := client.NewClient()
+	require.NoError(t, err)
+	source, err := NewReflectionSource(cli, client.WithURL("tri://"+strings.TrimPrefix(server.URL, "http://")))
+	require.NoError(t, err)
+	services, err := source.(ServiceLister).ListServices(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{healthService, "grpc.reflection.v1alpha.ServerReflection"}, services)
+}
+
+func TestDescriptorSetSource(t *testing.T) {
+	data, err := proto.Marshal(echoDescriptorSet())
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "echo.protoset")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	source, err := LoadDescriptorSet(path)
+	require.NoError(t, err)
+	sd, _, err := source.FindService(context.Background(), echoService)
+	require.NoError(t, err)
+	assert.Equal(t, 4, sd.Methods().Len())
+
+	_, _, err = source.FindService(context.Background(), "generic.test.EchoMessage")
+	assert.ErrorContains(t, err, "is not a service")
+	_, err = LoadDescriptorSet(filepath.Join(t.TempDir(), "missing.protoset"))
+	assert.Error(t, err)
+}
+
+func TestFilesSource(t *testing.T) {
+	sd, _, err := NewFilesSource(protoregistry.GlobalFiles).FindService(context.Background(), healthService)
+	require.NoError(t, err)
+	assert.NotNil(t, sd.Methods().ByName("Check"))
+
+	services, err := NewFilesSource(protoregistry.GlobalFiles).(ServiceLister).ListServices(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, services, healthService)
+}
@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generic
+
+import (
+	"io"
+)
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+import (
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+// ServerStream receives the response messages of a server streaming call.
+type ServerStream struct {
+	*tri.ServerStreamForClient
+	output protoreflect.MessageDescriptor
+}
+
+// Recv returns the next response message, or io.EOF once the server completes the stream successfully.
+func (s *ServerStream) Recv() (*dynamicpb.Message, error) {
+	msg := dynamicpb.NewMessage(s.output)
+	if s.Receive(msg) {
+		return msg, nil
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// ClientStream sends the request messages of a client streaming call.
+type ClientStream struct {
+	*tri.ClientStreamForClient
+	svc    *Service
+	method protoreflect.MethodDescriptor
+}
+
+// Send sends the request message, see Service.NewMessage for the accepted messages. It returns io.EOF if the server
+// has closed the stream, whose error is returned by CloseAndRecv.
+func (s *ClientStream) Send(msg any) error {
+	req, err := s.svc.NewMessage(s.method.Input(), msg)
+	if err != nil {
+		return err
+	}
+	return s.ClientStreamForClient.Send(req)
+}
+
+// CloseAndRecv closes the request stream and returns the response message.
+func (s *ClientStream) CloseAndRecv() (*dynamicpb.Message, error) {
+	resp := tri.NewResponse(dynamicpb.NewMessage(s.method.Output()))
+	if err := s.CloseAndReceive(resp); err != nil {
+		return nil, err
+	}
+	return resp.Msg.(*dynamicpb.Message), nil
+}
+
+// BidiStream sends and receives the messages of a bidirectional streaming call.
+type BidiStream struct {
+	*tri.BidiStreamForClient
+	svc    *Service
+	method protoreflect.MethodDescriptor
+}
+
+// Send sends the request message, see Service.NewMessage for the accepted messages. It returns io.EOF if the server
+// has closed the stream, whose error is returned by Recv.
+func (s *BidiStream) Send(msg any) error {
+	req, err := s.svc.NewMessage(s.method.Input(), msg)
+	if err != nil {
+		return err
+	}
+	return s.BidiStreamForClient.Send(req)
+}
+
+// Recv returns the next response message, or io.EOF once the server completes the stream successfully.
+func (s *BidiStream) Recv() (*dynamicpb.Message, error) {
+	msg := dynamicpb.NewMessage(s.method.Output())
+	if err := s.Receive(msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
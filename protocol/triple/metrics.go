@@ -0,0 +1,182 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package triple
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	"dubbo.apache.org/dubbo-go/v3/metrics/rpc"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+// metricsOption reports the payload sizes of the service of url and its streams to the metrics bus, side is
+// either constant.SideConsumer or constant.SideProvider.
+func metricsOption(url *common.URL, side string) tri.Option {
+	m := &metricsReporter{
+		side:    side,
+		service: url.Interface(),
+		group:   url.Group(),
+		version: url.Version(),
+	}
+	return tri.WithOptions(tri.WithMessageSizeObserver(m.observeSize), tri.WithInterceptors(m))
+}
+
+type metricsReporter struct {
+	side    string
+	service string
+	group   string
+	version string
+}
+
+func (m *metricsReporter) level(spec tri.Spec) *metrics.MethodMetricLevel {
+	method := spec.Procedure[strings.LastIndexByte(spec.Procedure, '/')+1:]
+	return metrics.NewMethodMetric(m.service, method, m.group, m.version)
+}
+
+// observeSize reports the messages sent by consumers and received by providers as requests, and the others as
+// responses.
+func (m *metricsReporter) observeSize(spec tri.Spec, sent bool, size int) {
+	if sent == (m.side == constant.SideConsumer) {
+		metrics.Publish(rpc.NewRequestSizeEvent(m.side, m.level(spec), size))
+	} else {
+		metrics.Publish(rpc.NewResponseSizeEvent(m.side, m.level(spec), size))
+	}
+}
+
+func (m *metricsReporter) WrapUnary(next tri.UnaryFunc) tri.UnaryFunc {
+	return next
+}
+
+func (m *metricsReporter) WrapUnaryHandler(next tri.UnaryHandlerFunc) tri.UnaryHandlerFunc {
+	return next
+}
+
+func (m *metricsReporter) WrapStreamingClient(next tri.StreamingClientFunc) tri.StreamingClientFunc {
+	return func(ctx context.Context, spec tri.Spec) tri.StreamingClientConn {
+		stream := m.startStream(ctx, spec)
+		return &metricsClientConn{StreamingClientConn: next(ctx, spec), stream: stream}
+	}
+}
+
+func (m *metricsReporter) WrapStreamingHandler(next tri.StreamingHandlerFunc) tri.StreamingHandlerFunc {
+	return func(ctx context.Context, conn tri.StreamingHandlerConn) error {
+		stream := m.startStream(ctx, conn.Spec())
+		err := next(ctx, &metricsHandlerConn{StreamingHandlerConn: conn, stream: stream})
+		stream.end(err)
+		return err
+	}
+}
+
+func (m *metricsReporter) startStream(ctx context.Context, spec tri.Spec) *streamStats {
+	s := &streamStats{side: m.side, level: m.level(spec), start: time.Now()}
+	metrics.Publish(rpc.NewStreamStartEvent(s.side, s.level))
+	s.stop = context.AfterFunc(ctx, func() {
+		s.end(context.Cause(ctx))
+	})
+	return s
+}
+
+// streamStats counts the messages of a stream, which is reported once when the stream ends or its context is done.
+type streamStats struct {
+	side     string
+	level    *metrics.MethodMetricLevel
+	start    time.Time
+	sent     atomic.Int64
+	received atomic.Int64
+	once     sync.Once
+	stop     func() bool
+}
+
+func (s *streamStats) end(err error) {
+	s.once.Do(func() {
+		s.stop()
+		metrics.Publish(rpc.NewStreamEndEvent(s.side, s.level,
+			int(s.sent.Load()), int(s.received.Load()), time.Since(s.start), err))
+	})
+}
+
+type metricsClientConn struct {
+	tri.StreamingClientConn
+	stream *streamStats
+
+	mu  sync.Mutex
+	err error
+}
+
+func (c *metricsClientConn) Send(msg any) error {
+	err := c.StreamingClientConn.Send(msg)
+	if err == nil {
+		c.stream.sent.Add(1)
+	}
+	return err
+}
+
+func (c *metricsClientConn) Receive(msg any) error {
+	err := c.StreamingClientConn.Receive(msg)
+	if err != nil {
+		// the stream is over once receiving fails, at the end of the stream or with its error
+		c.mu.Lock()
+		if c.err == nil {
+			c.err = err
+		}
+		c.mu.Unlock()
+		c.stream.end(err)
+		return err
+	}
+	c.stream.received.Add(1)
+	return nil
+}
+
+func (c *metricsClientConn) CloseResponse() error {
+	err := c.StreamingClientConn.CloseResponse()
+	c.mu.Lock()
+	last := c.err
+	c.mu.Unlock()
+	c.stream.end(last)
+	return err
+}
+
+type metricsHandlerConn struct {
+	tri.StreamingHandlerConn
+	stream *streamStats
+}
+
+func (c *metricsHandlerConn) Send(msg any) error {
+	err := c.StreamingHandlerConn.Send(msg)
+	if err == nil {
+		c.stream.sent.Add(1)
+	}
+	return err
+}
+
+func (c *metricsHandlerConn) Receive(msg any) error {
+	err := c.StreamingHandlerConn.Receive(msg)
+	if err == nil {
+		c.stream.received.Add(1)
+	}
+	return err
+}
@@ -42,6 +42,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/filter/otel/trace"
 	"dubbo.apache.org/dubbo-go/v3/global"
 	"dubbo.apache.org/dubbo-go/v3/internal"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
@@ -256,7 +257,14 @@ func getHanOpts(url *common.URL, tripleConf *global.TripleConfig) (hanOpts []tri
 		hanOpts = append(hanOpts, tri.WithSendMaxBytes(maxServerSendMsgSize))
 	}
 
-	// todo:// open tracing
+	// trace streams over their whole lifetime, the trace filter only sees them being opened
+	if hasFilter(url, constant.ServiceFilterKey, constant.OTELServerTraceKey) {
+		hanOpts = append(hanOpts, tri.WithInterceptors(trace.NewStreamInterceptor(url.ServiceKey())))
+	}
+	// payload sizes and streams are only known by triple, report them along with the metrics filter
+	if hasFilter(url, constant.ServiceFilterKey, constant.MetricsFilterKey) {
+		hanOpts = append(hanOpts, metricsOption(url, constant.SideProvider))
+	}
 
 	// CORS configuration
 	if tripleConf.Cors != nil && len(tripleConf.Cors.AllowOrigins) > 0 {
@@ -402,6 +410,7 @@ func (s *Server) registerClientStreamMethodHandler(procedure string, m common.Me
 			// inject attachments
 			ctx = context.WithValue(ctx, constant.AttachmentKey, attachments)
 			invo := invocation.NewRPCInvocation(m.Name, args, attachments)
+			invo.SetAttribute(constant.CallTypeKey, constant.CallClientStream)
 			res := invoker.Invoke(ctx, invo)
 			return wrapTripleResponse(res.Result()), res.Error()
 		},
@@ -419,6 +428,7 @@ func (s *Server) registerServerStreamMethodHandler(procedure string, m common.Me
 			// inject attachments
 			ctx = context.WithValue(ctx, constant.AttachmentKey, attachments)
 			invo := invocation.NewRPCInvocation(m.Name, args, attachments)
+			invo.SetAttribute(constant.CallTypeKey, constant.CallServerStream)
 			res := invoker.Invoke(ctx, invo)
 			return res.Error()
 		},
@@ -435,6 +445,7 @@ func (s *Server) registerBidiStreamMethodHandler(procedure string, m common.Meth
 			// inject attachments
 			ctx = context.WithValue(ctx, constant.AttachmentKey, attachments)
 			invo := invocation.NewRPCInvocation(m.Name, args, attachments)
+			invo.SetAttribute(constant.CallTypeKey, constant.CallBidiStream)
 			res := invoker.Invoke(ctx, invo)
 			return res.Error()
 		},
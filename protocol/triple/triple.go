@@ -197,6 +197,16 @@ func isGenericCall(generic string) bool {
 		strings.EqualFold(generic, constant.GenericSerializationProtobufJson)
 }
 
+// hasFilter checks if the filter list of url under key contains name
+func hasFilter(url *common.URL, key, name string) bool {
+	for _, f := range strings.Split(url.GetParam(key, ""), ",") {
+		if strings.TrimSpace(f) == name {
+			return true
+		}
+	}
+	return false
+}
+
 func NewTripleProtocol() *TripleProtocol {
 	return &TripleProtocol{
 		BaseProtocol: base.NewBaseProtocol(),
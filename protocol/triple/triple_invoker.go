@@ -33,6 +33,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config"
 	gracefulshutdown "dubbo.apache.org/dubbo-go/v3/graceful_shutdown"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
@@ -219,6 +220,22 @@ func parseAttachments(ctx context.Context, url *common.URL, invocation base.Invo
 			invocation.SetAttachment(key, val)
 		}
 	}
+	// tell the provider which application the request comes from
+	if app := consumerApplication(url); len(app) > 0 {
+		invocation.SetAttachment(constant.RemoteApplicationKey, app)
+	}
+}
+
+// consumerApplication returns the application of the consumer calling by the url, the one of the
+// reference url or the application config
+func consumerApplication(url *common.URL) string {
+	if app := base.ConsumerApplication(url); app != "" {
+		return app
+	}
+	if appConf := config.GetApplicationConfig(); appConf != nil {
+		return appConf.Name
+	}
+	return ""
 }
 
 // IsAvailable get available status
@@ -269,6 +286,25 @@ func NewTripleInvoker(url *common.URL) (*TripleInvoker, error) {
 	return invoker, nil
 }
 
+// HealthCheck calls the standard grpc health Check of the service of the invoker, the provider is healthy only if it is SERVING.
+func (ti *TripleInvoker) HealthCheck(ctx context.Context) error {
+	cm := ti.getClientManager()
+	if cm == nil {
+		return base.ErrClientClosed
+	}
+	resp, err := cm.callHealthCheck(ctx, ti.GetURL().ServiceKey())
+	if err != nil {
+		if tri.CodeOf(err) == tri.CodeUnimplemented {
+			return base.ErrHealthCheckUnsupported
+		}
+		return err
+	}
+	if status := resp.GetStatus(); status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("service %s is %s", ti.GetURL().ServiceKey(), status)
+	}
+	return nil
+}
+
 func (ti *TripleInvoker) startHealthWatch(handler gracefulshutdown.ClosingEventHandler) {
 	if handler == nil || ti.GetURL() == nil || ti.GetURL().ServiceKey() == "" {
 		return
@@ -32,6 +32,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/global"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
@@ -149,6 +150,26 @@ func Test_parseAttachments(t *testing.T) {
 				assert.Equal(t, []string{"key2_1", "key2_2"}, header.Values("key2"))
 			},
 		},
+		{
+			desc: "url merged from the provider url tells the consumer application",
+			ctx: func() context.Context {
+				return context.Background()
+			},
+			url: common.NewURLWithOptions(
+				common.WithParamsValue(constant.ApplicationKey, "provider"),
+			).MergeURL(common.NewURLWithOptions(
+				common.WithParamsValue(constant.ApplicationKey, "consumer"),
+				common.WithAttribute(constant.ApplicationKey, &global.ApplicationConfig{Name: "consumer"}),
+			)),
+			invo: func() base.Invocation {
+				return invocation.NewRPCInvocationWithOptions()
+			},
+			expect: func(t *testing.T, ctx context.Context, err error) {
+				require.NoError(t, err)
+				header := http.Header(tri.ExtractFromOutgoingContext(ctx))
+				assert.Equal(t, "consumer", header.Get(constant.RemoteApplicationKey))
+			},
+		},
 		{
 			desc: "user passed-in illegal attachments",
 			ctx: func() context.Context {
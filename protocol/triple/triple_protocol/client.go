@@ -66,6 +66,8 @@ func NewClient(httpClient HTTPClient, url string, options ...ClientOption) *Clie
 			ReadMaxBytes:     config.ReadMaxBytes,
 			SendMaxBytes:     config.SendMaxBytes,
 			GetURLMaxBytes:   config.GetURLMaxBytes,
+
+			MessageSizeObserver: config.MessageSizeObserver,
 		},
 	)
 	if protocolErr != nil {
@@ -227,6 +229,7 @@ type clientConfig struct {
 	Timeout                time.Duration
 	Group                  string
 	Version                string
+	MessageSizeObserver    MessageSizeObserver
 }
 
 func newClientConfig(rawURL string, options []ClientOption) (*clientConfig, *Error) {
@@ -20,9 +20,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 )
 
+import (
+	"google.golang.org/protobuf/proto"
+)
+
 import (
 	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol/internal/assert"
@@ -184,3 +189,50 @@ func (a *assertPeerInterceptor) WrapStreamingHandler(next triple.StreamingHandle
 		return next(ctx, conn)
 	}
 }
+
+// sizeRecorder records the sizes observed by a MessageSizeObserver by direction
+type sizeRecorder struct {
+	mu       sync.Mutex
+	sent     []int
+	received []int
+}
+
+func (r *sizeRecorder) observe(spec triple.Spec, sent bool, size int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if sent {
+		r.sent = append(r.sent, size)
+	} else {
+		r.received = append(r.received, size)
+	}
+}
+
+func TestMessageSizeObserver(t *testing.T) {
+	t.Parallel()
+	var handlerSizes sizeRecorder
+	mux := http.NewServeMux()
+	mux.Handle(pingv1connect.NewPingServiceHandler(pingServer{}, triple.WithMessageSizeObserver(handlerSizes.observe)))
+	server := httptest.NewUnstartedServer(mux)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	request := &pingv1.PingRequest{Number: 42, Text: strings.Repeat(".", 256)}
+	response := &pingv1.PingResponse{Number: 42, Text: request.Text}
+	for _, opts := range [][]triple.ClientOption{nil, {triple.WithTriple()}} {
+		handlerSizes = sizeRecorder{}
+		var clientSizes sizeRecorder
+		client := pingv1connect.NewPingServiceClient(
+			server.Client(),
+			server.URL,
+			triple.WithClientOptions(opts...),
+			triple.WithMessageSizeObserver(clientSizes.observe),
+		)
+		err := client.Ping(context.Background(), triple.NewRequest(request), triple.NewResponse(&pingv1.PingResponse{}))
+		assert.Nil(t, err)
+		assert.Equal(t, clientSizes.sent, []int{proto.Size(request)})
+		assert.Equal(t, clientSizes.received, []int{proto.Size(response)})
+		assert.Equal(t, handlerSizes.received, []int{proto.Size(request)})
+		assert.Equal(t, handlerSizes.sent, []int{proto.Size(response)})
+	}
+}
@@ -60,6 +60,7 @@ type envelopeWriter struct {
 	compressionPool  *compressionPool
 	bufferPool       *bufferPool
 	sendMaxBytes     int
+	observe          func(size int)
 }
 
 // marshal and write to socket
@@ -83,6 +84,9 @@ func (w *envelopeWriter) Marshal(message any) *Error {
 			return errorf(CodeInternal, "marshal message: %w", err)
 		}
 	}
+	if w.observe != nil {
+		w.observe(len(raw))
+	}
 	// We can't avoid allocating the byte slice, so we may as well reuse it once
 	// we're done with it.
 	buffer := bytes.NewBuffer(raw)
@@ -143,6 +147,7 @@ type envelopeReader struct {
 	compressionPool *compressionPool
 	bufferPool      *bufferPool
 	readMaxBytes    int
+	observe         func(size int)
 }
 
 // Unmarshal reads entire envelope and uses codec to unmarshal
@@ -209,6 +214,9 @@ func (r *envelopeReader) Unmarshal(message any) *Error {
 			return errorf(CodeInvalidArgument, "unmarshal into %T: %w", message, err)
 		}
 	}
+	if r.observe != nil {
+		r.observe(data.Len())
+	}
 	return nil
 }
 
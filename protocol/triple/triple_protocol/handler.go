@@ -406,6 +406,7 @@ type handlerConfig struct {
 	Group                       string
 	Version                     string
 	Cors                        *CorsConfig
+	MessageSizeObserver         MessageSizeObserver
 }
 
 func newHandlerConfig(procedure string, options []HandlerOption) *handlerConfig {
@@ -467,6 +468,7 @@ func (c *handlerConfig) newProtocolHandlers(streamType StreamType) []protocolHan
 			SendMaxBytes:                c.SendMaxBytes,
 			RequireTripleProtocolHeader: c.RequireTripleProtocolHeader,
 			IdempotencyLevel:            c.IdempotencyLevel,
+			MessageSizeObserver:         c.MessageSizeObserver,
 		}))
 	}
 	return handlers
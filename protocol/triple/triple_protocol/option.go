@@ -264,6 +264,13 @@ func WithSendMaxBytes(max int) Option {
 	return &sendMaxBytesOption{Max: max}
 }
 
+// WithMessageSizeObserver configures a client or handler to report the size of
+// every message it sends or receives to observer. The size is the one of the
+// serialized message before compression.
+func WithMessageSizeObserver(observer MessageSizeObserver) Option {
+	return &messageSizeObserverOption{Observer: observer}
+}
+
 // todo(DMwangnima): consider how to expose this functionality to users
 // WithIdempotency declares the idempotency of the procedure. This can determine
 // whether a procedure call can safely be retried, and may affect which request
@@ -432,6 +439,18 @@ func (o *sendMaxBytesOption) applyToHandler(config *handlerConfig) {
 	config.SendMaxBytes = o.Max
 }
 
+type messageSizeObserverOption struct {
+	Observer MessageSizeObserver
+}
+
+func (o *messageSizeObserverOption) applyToClient(config *clientConfig) {
+	config.MessageSizeObserver = o.Observer
+}
+
+func (o *messageSizeObserverOption) applyToHandler(config *handlerConfig) {
+	config.MessageSizeObserver = o.Observer
+}
+
 type handlerOptionsOption struct {
 	options []HandlerOption
 }
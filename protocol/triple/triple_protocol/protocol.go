@@ -80,6 +80,7 @@ type protocolHandlerParams struct {
 	SendMaxBytes                int
 	RequireTripleProtocolHeader bool
 	IdempotencyLevel            IdempotencyLevel
+	MessageSizeObserver         MessageSizeObserver
 }
 
 // Handler is the server side of a protocol. HTTP handlers typically support
@@ -128,7 +129,8 @@ type protocolClientParams struct {
 	GetUseFallback   bool
 	// The gRPC family of protocols always needs access to a Protobuf codec to
 	// marshal and unmarshal errors.
-	Protobuf Codec
+	Protobuf            Codec
+	MessageSizeObserver MessageSizeObserver
 }
 
 // Client is the client side of a protocol. HTTP clients typically use a single
@@ -367,3 +369,18 @@ func canonicalizeContentTypeSlow(contentType string) string {
 	}
 	return mime.FormatMediaType(base, params)
 }
+
+// MessageSizeObserver observes the serialized size of a message sent or
+// received by the RPC described by spec.
+type MessageSizeObserver func(spec Spec, sent bool, size int)
+
+// bind returns a function observing the messages of one direction of spec, it
+// returns nil if there is no observer.
+func (o MessageSizeObserver) bind(spec Spec, sent bool) func(size int) {
+	if o == nil {
+		return nil
+	}
+	return func(size int) {
+		o(spec, sent, size)
+	}
+}
@@ -208,6 +208,7 @@ func (g *grpcHandler) NewConn(
 				compressMinBytes: g.CompressMinBytes,
 				bufferPool:       g.BufferPool,
 				sendMaxBytes:     g.SendMaxBytes,
+				observe:          g.MessageSizeObserver.bind(g.Spec, true),
 			},
 		},
 		responseWriter:  responseWriter,
@@ -222,6 +223,7 @@ func (g *grpcHandler) NewConn(
 				compressionPool: g.CompressionPools.Get(requestCompression),
 				bufferPool:      g.BufferPool,
 				readMaxBytes:    g.ReadMaxBytes,
+				observe:         g.MessageSizeObserver.bind(g.Spec, false),
 			},
 		},
 	})
@@ -299,6 +301,7 @@ func (g *grpcClient) NewConn(
 				compressMinBytes: g.CompressMinBytes,
 				bufferPool:       g.BufferPool,
 				sendMaxBytes:     g.SendMaxBytes,
+				observe:          g.MessageSizeObserver.bind(spec, true),
 			},
 		},
 		unmarshaler: grpcUnmarshaler{
@@ -307,6 +310,7 @@ func (g *grpcClient) NewConn(
 				codec:        g.Codec,
 				bufferPool:   g.BufferPool,
 				readMaxBytes: g.ReadMaxBytes,
+				observe:      g.MessageSizeObserver.bind(spec, false),
 			},
 		},
 		responseHeader:  make(http.Header),
@@ -199,6 +199,7 @@ func (h *tripleHandler) NewConn(
 			bufferPool:       h.BufferPool,
 			header:           responseWriter.Header(),
 			sendMaxBytes:     h.SendMaxBytes,
+			observe:          h.MessageSizeObserver.bind(h.Spec, true),
 		},
 		unmarshaler: tripleUnaryUnmarshaler{
 			reader:          requestBody,
@@ -207,6 +208,7 @@ func (h *tripleHandler) NewConn(
 			compressionPool: h.CompressionPools.Get(requestCompression),
 			bufferPool:      h.BufferPool,
 			readMaxBytes:    h.ReadMaxBytes,
+			observe:         h.MessageSizeObserver.bind(h.Spec, false),
 		},
 		responseTrailer: make(http.Header),
 	}
@@ -276,6 +278,7 @@ func (c *tripleClient) NewConn(
 				bufferPool:       c.BufferPool,
 				header:           duplexCall.Header(),
 				sendMaxBytes:     c.SendMaxBytes,
+				observe:          c.MessageSizeObserver.bind(spec, true),
 			},
 		},
 		unmarshaler: tripleUnaryUnmarshaler{
@@ -283,6 +286,7 @@ func (c *tripleClient) NewConn(
 			codec:        c.Codec,
 			bufferPool:   c.BufferPool,
 			readMaxBytes: c.ReadMaxBytes,
+			observe:      c.MessageSizeObserver.bind(spec, false),
 		},
 		responseHeader:  make(http.Header),
 		responseTrailer: make(http.Header),
@@ -488,6 +492,7 @@ type tripleUnaryMarshaler struct {
 	bufferPool       *bufferPool
 	header           http.Header
 	sendMaxBytes     int
+	observe          func(size int)
 }
 
 func (m *tripleUnaryMarshaler) Marshal(message any) *Error {
@@ -504,6 +509,9 @@ func (m *tripleUnaryMarshaler) Marshal(message any) *Error {
 			return errorf(CodeInternal, "marshal message: %w", err)
 		}
 	}
+	if m.observe != nil {
+		m.observe(len(data))
+	}
 	// Can't avoid allocating the slice, but we can reuse it.
 	uncompressed := bytes.NewBuffer(data)
 	defer m.bufferPool.Put(uncompressed)
@@ -551,6 +559,7 @@ type tripleUnaryUnmarshaler struct {
 	bufferPool      *bufferPool
 	alreadyRead     bool
 	readMaxBytes    int
+	observe         func(size int)
 }
 
 func (u *tripleUnaryUnmarshaler) Unmarshal(message any) *Error {
@@ -606,6 +615,9 @@ func (u *tripleUnaryUnmarshaler) UnmarshalFunc(message any, unmarshal func([]byt
 	if err := unmarshal(data.Bytes(), message); err != nil {
 		return errorf(CodeInvalidArgument, "unmarshal into %T: %w", message, err)
 	}
+	if u.observe != nil {
+		u.observe(data.Len())
+	}
 	return nil
 }
 
@@ -21,7 +21,9 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
+	"os"
 )
 
 import (
@@ -176,6 +178,9 @@ func (s *Server) RegisterCompatStreamHandler(
 }
 
 func (s *Server) Run(callProtocol string, tlsConf *tls.Config) error {
+	if _, ok := common.ParseUnixSocket(s.addr); ok && callProtocol != constant.CallHTTP2 {
+		return fmt.Errorf("unsupported protocol: %s on unix domain socket %s, only http2 is supported", callProtocol, s.addr)
+	}
 	// Support for starting HTTP/2 and HTTP/3 servers simultaneously.
 	switch callProtocol {
 	case constant.CallHTTP2:
@@ -198,6 +203,10 @@ func (s *Server) startHttp2(tlsConf *tls.Config) error {
 
 	logger.Debugf("TRIPLE HTTP/2 Server starting on %v", s.addr)
 
+	if path, ok := common.ParseUnixSocket(s.addr); ok {
+		return s.serveUnix(path, tlsConf)
+	}
+
 	var err error
 
 	if tlsConf != nil {
@@ -209,6 +218,22 @@ func (s *Server) startHttp2(tlsConf *tls.Config) error {
 	return err
 }
 
+// serveUnix serves HTTP/2 on a unix domain socket, in cleartext (h2c) unless tlsConf is set.
+// A stale socket left by a previous process is removed before listening.
+func (s *Server) serveUnix(path string, tlsConf *tls.Config) error {
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		_ = os.Remove(path)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	if tlsConf != nil {
+		return s.httpSrv.ServeTLS(listener, "", "")
+	}
+	return s.httpSrv.Serve(listener)
+}
+
 func (s *Server) startHttp3(tlsConf *tls.Config) error {
 	if tlsConf == nil {
 		return fmt.Errorf("TRIPLE HTTP/3 Server must have TLS config, but TLS config is nil")
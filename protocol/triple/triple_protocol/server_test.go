@@ -18,17 +18,27 @@
 package triple_protocol
 
 import (
+	"context"
+	"crypto/tls"
+	"net"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"golang.org/x/net/http2"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/global"
 )
 
@@ -99,3 +109,32 @@ func TestServer_RegisterMuxHandle(t *testing.T) {
 		assert.Equal(t, test.path, pattern)
 	}
 }
+
+func TestServer_RunUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tri.sock")
+	srv := NewServer("unix://"+path, nil)
+	require.NoError(t, srv.RegisterUnaryHandler("/greet.GreetService/Greet",
+		func() any { return new(wrapperspb.StringValue) },
+		func(ctx context.Context, req *Request) (*Response, error) {
+			return NewResponse(wrapperspb.String("hello " + req.Msg.(*wrapperspb.StringValue).Value)), nil
+		}))
+	go func() {
+		_ = srv.Run(constant.CallHTTP2, nil)
+	}()
+	defer srv.Stop()
+
+	httpClient := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, _, _ string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	client := NewClient(httpClient, "http://localhost/greet.GreetService")
+	resp := NewResponse(new(wrapperspb.StringValue))
+	require.Eventually(t, func() bool {
+		return client.CallUnary(context.Background(), NewRequest(wrapperspb.String("uds")), "Greet", resp) == nil
+	}, 3*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "hello uds", resp.Msg.(*wrapperspb.StringValue).Value)
+
+	assert.Error(t, NewServer("unix://"+path, nil).Run(constant.CallHTTP3, nil))
+}
@@ -29,6 +29,8 @@ import (
 )
 
 import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 )
@@ -183,3 +185,13 @@ func Test_isGenericCall(t *testing.T) {
 		})
 	}
 }
+
+func Test_hasFilter(t *testing.T) {
+	url := common.NewURLWithOptions(
+		common.WithParamsValue(constant.ServiceFilterKey, "echo, otelServerTrace,token"),
+	)
+	assert.True(t, hasFilter(url, constant.ServiceFilterKey, constant.OTELServerTraceKey))
+	assert.True(t, hasFilter(url, constant.ServiceFilterKey, "token"))
+	assert.False(t, hasFilter(url, constant.ServiceFilterKey, "otel"))
+	assert.False(t, hasFilter(url, constant.ReferenceFilterKey, constant.OTELClientTraceKey))
+}
@@ -36,6 +36,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/filter/criticality"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 )
@@ -199,6 +200,7 @@ func DefaultProxyImplementFunc(p *Proxy, v common.RPCService) {
 					inv.SetAttachment(k, value)
 				}
 			}
+			setCriticality(invCtx, p.invoke.GetURL(), inv)
 
 			result := p.invoke.Invoke(invCtx, inv)
 			err = result.Error()
@@ -282,3 +284,18 @@ func refectAndMakeObjectFunc(valueOfElem reflect.Value, makeDubboCallProxy func(
 	}
 	return nil
 }
+
+// setCriticality sets the criticality inherited from the context, or the one configured for the method, if the
+// invocation does not carry one.
+func setCriticality(ctx context.Context, url *common.URL, inv *invocation.RPCInvocation) {
+	if _, ok := inv.GetAttachment(constant.CriticalityKey); ok {
+		return
+	}
+	if c, ok := criticality.FromContext(ctx); ok {
+		inv.SetAttachment(constant.CriticalityKey, c.String())
+		return
+	}
+	if c, ok := criticality.Parse(url.GetMethodParam(inv.MethodName(), constant.CriticalityKey, "")); ok {
+		inv.SetAttachment(constant.CriticalityKey, c.String())
+	}
+}
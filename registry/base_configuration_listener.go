@@ -28,6 +28,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/config"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/validation"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
@@ -36,6 +37,10 @@ type BaseConfigurationListener struct {
 	configurators           []config_center.Configurator
 	dynamicConfiguration    config_center.DynamicConfiguration
 	defaultConfiguratorFunc func(url *common.URL) config_center.Configurator
+	// listener is the listener embedding BaseConfigurationListener, which the rollbacks are dispatched to
+	listener config_center.ConfigurationListener
+	// keys are the keys listened by InitWith
+	keys []string
 }
 
 // Configurators gets Configurator from config center
@@ -54,6 +59,8 @@ func (bcl *BaseConfigurationListener) InitWith(key string, listener config_cente
 		return
 	}
 	bcl.defaultConfiguratorFunc = f
+	bcl.listener = listener
+	bcl.keys = append(bcl.keys, key)
 	bcl.dynamicConfiguration.AddListener(key, listener)
 	if rawConfig, err := bcl.dynamicConfiguration.GetRule(key,
 		config_center.WithGroup(constant.Dubbo)); err != nil {
@@ -70,6 +77,13 @@ func (bcl *BaseConfigurationListener) InitWith(key string, listener config_cente
 // Process the notification event once there's any change happens on the config.
 func (bcl *BaseConfigurationListener) Process(event *config_center.ConfigChangeEvent) {
 	logger.Infof("Notification of overriding rule, change type is: %v , raw config content is:%v", event.ConfigType, event.Value)
+	listener := bcl.listener
+	if listener == nil {
+		listener = bcl
+	}
+	if err := validation.Check(listener, event); err != nil {
+		return
+	}
 	if event.ConfigType == remoting.EventTypeDel {
 		bcl.configurators = nil
 	} else {
@@ -79,6 +93,18 @@ func (bcl *BaseConfigurationListener) Process(event *config_center.ConfigChangeE
 	}
 }
 
+// Destroy stops listening to the keys in config center and the validation pipeline
+func (bcl *BaseConfigurationListener) Destroy() {
+	if bcl.dynamicConfiguration == nil {
+		return
+	}
+	for _, key := range bcl.keys {
+		bcl.dynamicConfiguration.RemoveListener(key, bcl.listener)
+		validation.RemoveListener(key, bcl.listener)
+	}
+	bcl.keys = nil
+}
+
 func (bcl *BaseConfigurationListener) genConfiguratorFromRawRule(rawConfig string) error {
 	urls, err := bcl.dynamicConfiguration.Parser().ParseToUrls(rawConfig)
 	if err != nil {
@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package directory
+
+import (
+	"encoding/gob"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/registry/servicediscovery/store"
+)
+
+var (
+	addressCachesLock sync.Mutex
+	addressCaches     = make(map[string]*store.CacheManager) // cache file -> cache manager
+)
+
+// addressCache persists the addresses of a subscription, so that the directory can start from them while the
+// registry is unreachable.
+type addressCache struct {
+	key   string
+	cache *store.CacheManager
+}
+
+// newAddressCache returns the address cache of the subscription of the registry url, or nil if it is not enabled by
+// the parameter registry.address-cache. The addresses of the references of an application are stored in the file
+// dubbo.registry.{application} unless registry.address-cache.file is set.
+func newAddressCache(url *common.URL) *addressCache {
+	sub := url.SubURL
+	if !AddressCacheEnabled(url) {
+		return nil
+	}
+	file := url.GetParam(constant.RegistryAddressCacheFileKey, "")
+	if file == "" {
+		file = constant.DefaultRegistryCacheFileName + sub.GetParam(constant.ApplicationKey, constant.DefaultKey)
+	}
+	cache := addressCacheManager(file)
+	if cache == nil {
+		return nil
+	}
+	key := url.Protocol + "://" + url.Location + "/" + sub.ServiceKey()
+	if namespace := url.GetParam(constant.RegistryNamespaceKey, ""); namespace != "" {
+		key = key + "?" + constant.RegistryNamespaceKey + "=" + namespace
+	}
+	return &addressCache{key: key, cache: cache}
+}
+
+func addressCacheManager(file string) *store.CacheManager {
+	addressCachesLock.Lock()
+	defer addressCachesLock.Unlock()
+
+	if cache, ok := addressCaches[file]; ok {
+		return cache
+	}
+	gob.Register([]string{})
+	cache, err := store.NewCacheManager(constant.DefaultRegistryCacheName, file, time.Minute*10, constant.DefaultRegistryCacheSize, true)
+	if err != nil {
+		logger.Warnf("Failed to create the registry address cache [%s], the err is %v", file, err)
+		return nil
+	}
+	addressCaches[file] = cache
+	return cache
+}
+
+// load returns the cached urls of the subscription
+func (c *addressCache) load() []string {
+	v, ok := c.cache.Get(c.key)
+	if !ok {
+		return nil
+	}
+	urls, _ := v.([]string)
+	return urls
+}
+
+// save caches the urls of the subscription, an empty list removes the subscription from the cache
+func (c *addressCache) save(urls []string) {
+	if len(urls) == 0 {
+		c.cache.Delete(c.key)
+		return
+	}
+	c.cache.Set(c.key, urls)
+}
+
+// AddressCacheEnabled returns whether the address cache is enabled by the reference, or the registry if the
+// reference does not set it. url is the registry url with the reference url as SubURL.
+func AddressCacheEnabled(url *common.URL) bool {
+	return paramBool(url, constant.RegistryAddressCacheKey, false)
+}
+
+// paramBool returns the bool parameter of the reference, or the registry if the reference does not set it
+func paramBool(url *common.URL, key string, def bool) bool {
+	if url.SubURL != nil && url.SubURL.GetParam(key, "") != "" {
+		return url.SubURL.GetParamBool(key, def)
+	}
+	return url.GetParamBool(key, def)
+}
@@ -34,6 +34,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/cluster/directory"
 	"dubbo.apache.org/dubbo-go/v3/cluster/directory/base"
+	"dubbo.apache.org/dubbo-go/v3/cluster/directory/healthcheck"
 	"dubbo.apache.org/dubbo-go/v3/cluster/directory/static"
 	"dubbo.apache.org/dubbo-go/v3/cluster/router/chain"
 	"dubbo.apache.org/dubbo-go/v3/common"
@@ -77,6 +78,12 @@ type RegistryDirectory struct {
 	RegisteredUrl                  *common.URL
 	closingTombstones              *sync.Map // map[string]closingTombstone
 	closingTombstoneTTL            time.Duration
+	healthChecker                  *healthcheck.Checker // nil if the active health check is not enabled
+	addressesChangedListener       func()               // guarded by invokersLock
+	addressCache                   *addressCache        // nil if the address cache is disabled
+	notifyLock                     sync.Mutex           // serializes the notifications and the loading of the address cache
+	notified                       bool                 // whether the registry has notified, guarded by notifyLock
+	fromCache                      bool                 // whether the invokers are loaded from the address cache, guarded by notifyLock
 }
 
 type closingTombstone struct {
@@ -160,6 +167,8 @@ func NewRegistryDirectory(url *common.URL, registry registry.Registry) (director
 	}
 
 	dir.consumerURL = dir.getConsumerUrl(url.SubURL)
+	dir.addressCache = newAddressCache(url)
+	dir.healthChecker = healthcheck.NewChecker(url.SubURL, dir.setNewInvokers)
 
 	if routerChain, err := chain.NewRouterChain(url); err == nil {
 		dir.SetRouterChain(routerChain)
@@ -191,22 +200,8 @@ func (dir *RegistryDirectory) Subscribe(url *common.URL) error {
 
 	}()
 
-	// Get the timeout time from the registration center configuration (default time 5s)
-	registerUrl := dir.registry.GetURL()
-
-	var timeoutStr string
-
-	if registerUrl != nil {
-		if val := registerUrl.GetParam(constant.RegistryTimeoutKey, ""); val != "" {
-			timeoutStr = val
-		}
-	}
-
-	timeout, err := time.ParseDuration(timeoutStr)
-	if err != nil {
-		logger.Warnf("Invalid timeout value %s, using default %s", timeoutStr, constant.DefaultRegTimeout)
-		timeout, _ = time.ParseDuration(constant.DefaultRegTimeout)
-	}
+	timeout := dir.registryTimeout()
+	dir.loadAddressCacheAfter(timeout)
 
 	done := make(chan struct{})
 
@@ -231,20 +226,54 @@ func (dir *RegistryDirectory) Subscribe(url *common.URL) error {
 	}
 }
 
+// registryTimeout returns the timeout of the registration center configuration (default time 5s)
+func (dir *RegistryDirectory) registryTimeout() time.Duration {
+	registerUrl := dir.registry.GetURL()
+
+	var timeoutStr string
+
+	if registerUrl != nil {
+		if val := registerUrl.GetParam(constant.RegistryTimeoutKey, ""); val != "" {
+			timeoutStr = val
+		}
+	}
+
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		logger.Warnf("Invalid timeout value %s, using default %s", timeoutStr, constant.DefaultRegTimeout)
+		timeout, _ = time.ParseDuration(constant.DefaultRegTimeout)
+	}
+	return timeout
+}
+
 // Notify monitor changes from registry,and update the cacheServices
 func (dir *RegistryDirectory) Notify(event *registry.ServiceEvent) {
 	if event == nil {
 		return
 	}
 	start := time.Now()
+	dir.notifyLock.Lock()
+	dir.notified = true
+	// the cached addresses are replaced by the ones the registry notifies one by one from now on
+	cachedInvokers := dir.uncacheLoadedInvokers()
 	dir.refreshInvokers(event)
+	dir.saveAddressCache()
+	dir.notifyLock.Unlock()
+	for _, v := range cachedInvokers {
+		v.Destroy()
+	}
 	metrics.Publish(metricsRegistry.NewNotifyEvent(start))
 }
 
 // NotifyAll notify the events that are complete Service Event List.
 // After notify the address, the callback func will be invoked.
 func (dir *RegistryDirectory) NotifyAll(events []*registry.ServiceEvent, callback func()) {
+	dir.notifyLock.Lock()
+	defer dir.notifyLock.Unlock()
+	dir.notified = true
+	dir.fromCache = false
 	dir.refreshAllInvokers(events, callback)
+	dir.saveAddressCache()
 }
 
 // refreshInvokers refreshes service's events.
@@ -255,6 +284,12 @@ func (dir *RegistryDirectory) refreshInvokers(event *registry.ServiceEvent) {
 		logger.Debug("refresh invokers with nil")
 	}
 
+	if event != nil && event.Action == remoting.EventTypeDel && dir.lastInvokerProtected(event.Key()) {
+		logger.Warnf("[Registry Directory] ignore the deletion of the last address of service %s, keep the known invoker "+
+			"since %s is enabled", dir.GetDirectoryUrl().SubURL.ServiceKey(), constant.RegistryEmptyProtectionKey)
+		return
+	}
+
 	var oldInvoker []protocolbase.Invoker
 	if event != nil {
 		oldInvoker, _ = dir.cacheInvokerByEvent(event)
@@ -274,6 +309,12 @@ func (dir *RegistryDirectory) refreshAllInvokers(events []*registry.ServiceEvent
 		oldInvokers []protocolbase.Invoker
 		addEvents   []*registry.ServiceEvent
 	)
+	if len(events) == 0 && dir.emptyProtected() {
+		logger.Warnf("[Registry Directory] ignore the empty address list of service %s, keep the known invokers "+
+			"since %s is enabled", dir.GetDirectoryUrl().SubURL.ServiceKey(), constant.RegistryEmptyProtectionKey)
+		callback()
+		return
+	}
 	dir.overrideUrl(dir.GetDirectoryUrl())
 	referenceUrl := dir.GetDirectoryUrl().SubURL
 
@@ -334,6 +375,97 @@ func (dir *RegistryDirectory) refreshAllInvokers(events []*registry.ServiceEvent
 	}
 }
 
+// emptyProtected returns whether there are invokers to keep from an empty address list, which is enabled by the
+// parameter registry.empty-protection of the reference or the registry.
+func (dir *RegistryDirectory) emptyProtected() bool {
+	if !paramBool(dir.GetDirectoryUrl(), constant.RegistryEmptyProtectionKey, false) {
+		return false
+	}
+	protected := false
+	dir.cacheInvokersMap.Range(func(_, _ any) bool {
+		protected = true
+		return false
+	})
+	return protected
+}
+
+// lastInvokerProtected returns whether the invoker of key is the last one, which the empty protection keeps from the
+// deletion of its address.
+func (dir *RegistryDirectory) lastInvokerProtected(key string) bool {
+	if !paramBool(dir.GetDirectoryUrl(), constant.RegistryEmptyProtectionKey, false) {
+		return false
+	}
+	last := false
+	dir.cacheInvokersMap.Range(func(k, _ any) bool {
+		last = k.(string) == key
+		return last
+	})
+	return last
+}
+
+// loadAddressCacheAfter starts the directory from the cached addresses if the registry has not notified any after
+// the timeout, the subscription goes on and replaces them once the registry notifies.
+func (dir *RegistryDirectory) loadAddressCacheAfter(timeout time.Duration) {
+	if dir.addressCache != nil {
+		time.AfterFunc(timeout, dir.loadAddressCache)
+	}
+}
+
+func (dir *RegistryDirectory) loadAddressCache() {
+	dir.notifyLock.Lock()
+	defer dir.notifyLock.Unlock()
+	if dir.notified || dir.fromCache || dir.IsDestroyed() {
+		return
+	}
+	var events []*registry.ServiceEvent
+	for _, u := range dir.addressCache.load() {
+		serviceURL, err := common.NewURL(u)
+		if err != nil {
+			logger.Warnf("[Registry Directory] skip the cached address %s, err is %v", u, err)
+			continue
+		}
+		events = append(events, &registry.ServiceEvent{Action: remoting.EventTypeUpdate, Service: serviceURL})
+	}
+	if len(events) == 0 {
+		return
+	}
+	logger.Warnf("[Registry Directory] the registry has not notified the addresses of service %s, start from %d cached addresses",
+		dir.GetDirectoryUrl().SubURL.ServiceKey(), len(events))
+	dir.refreshAllInvokers(events, func() {})
+	dir.fromCache = true
+}
+
+// uncacheLoadedInvokers uncaches the invokers loaded from the address cache, which the caller destroys
+func (dir *RegistryDirectory) uncacheLoadedInvokers() []protocolbase.Invoker {
+	if !dir.fromCache {
+		return nil
+	}
+	dir.fromCache = false
+	var invokers []protocolbase.Invoker
+	dir.cacheInvokersMap.Range(func(k, _ any) bool {
+		if invoker := dir.uncacheInvokerWithKey(k.(string)); invoker != nil {
+			invokers = append(invokers, invoker)
+		}
+		return true
+	})
+	return invokers
+}
+
+// saveAddressCache saves the addresses of the cached invokers to the address cache
+func (dir *RegistryDirectory) saveAddressCache() {
+	if dir.addressCache == nil || dir.fromCache {
+		return
+	}
+	var urls []string
+	dir.cacheInvokersMap.Range(func(_, v any) bool {
+		if u := v.(protocolbase.Invoker).GetURL(); u != nil {
+			urls = append(urls, u.String())
+		}
+		return true
+	})
+	dir.addressCache.save(urls)
+}
+
 // eventMatched checks if a cached invoker appears in the incoming invoker list, if no, then it is safe to remove.
 func (dir *RegistryDirectory) eventMatched(key string, events []*registry.ServiceEvent) bool {
 	for _, event := range events {
@@ -360,9 +492,32 @@ func (dir *RegistryDirectory) invokerCacheKey(event *registry.ServiceEvent) stri
 func (dir *RegistryDirectory) setNewInvokers() {
 	newInvokers := dir.toGroupInvokers()
 	dir.invokersLock.Lock()
-	defer dir.invokersLock.Unlock()
 	dir.cacheInvokers = newInvokers
 	dir.RouterChain().SetInvokers(newInvokers)
+	listener := dir.addressesChangedListener
+	dir.invokersLock.Unlock()
+	if listener != nil {
+		listener()
+	}
+}
+
+// Addresses returns the addresses of the cached invokers
+func (dir *RegistryDirectory) Addresses() []string {
+	var addresses []string
+	dir.cacheInvokersMap.Range(func(_, v any) bool {
+		if url := v.(protocolbase.Invoker).GetURL(); url != nil {
+			addresses = append(addresses, url.Location)
+		}
+		return true
+	})
+	return addresses
+}
+
+// SetAddressesChangedListener sets the listener called after the cached invokers are refreshed
+func (dir *RegistryDirectory) SetAddressesChangedListener(listener func()) {
+	dir.invokersLock.Lock()
+	defer dir.invokersLock.Unlock()
+	dir.addressesChangedListener = listener
 }
 
 // cacheInvokerByEvent caches invokers from the service event
@@ -408,13 +563,29 @@ func (dir *RegistryDirectory) convertUrl(res *registry.ServiceEvent) *common.URL
 
 func (dir *RegistryDirectory) toGroupInvokers() []protocolbase.Invoker {
 	groupInvokersMap := make(map[string][]protocolbase.Invoker)
+	unhealthyInvokersMap := make(map[string][]protocolbase.Invoker)
 
 	dir.cacheInvokersMap.Range(func(key, value any) bool {
 		invoker := value.(protocolbase.Invoker)
 		group := invoker.GetURL().GetParam(constant.GroupKey, "")
-		groupInvokersMap[group] = append(groupInvokersMap[group], invoker)
+		if dir.healthChecker.IsHealthy(key.(string)) {
+			groupInvokersMap[group] = append(groupInvokersMap[group], invoker)
+		} else {
+			unhealthyInvokersMap[group] = append(unhealthyInvokersMap[group], invoker)
+		}
 		return true
 	})
+	if len(groupInvokersMap) == 0 && len(unhealthyInvokersMap) > 0 {
+		// all the providers are unhealthy, keep them so the calls fail with the real errors instead of no provider
+		logger.Warnf("[Registry Directory] all the providers of %s are unhealthy, keep using them", dir.serviceType)
+		groupInvokersMap = unhealthyInvokersMap
+	}
+	// keep a single invoker per provider instance with the best mutual protocol
+	if preference := protocolPreference(dir.GetDirectoryUrl().SubURL); len(preference) > 1 {
+		for group, invokers := range groupInvokersMap {
+			groupInvokersMap[group] = preferProtocols(invokers, preference)
+		}
+	}
 
 	groupInvokersList := make([]protocolbase.Invoker, 0, len(groupInvokersMap))
 	if len(groupInvokersMap) == 1 {
@@ -479,6 +650,7 @@ func (dir *RegistryDirectory) uncacheInvokerWithKey(key string) protocolbase.Inv
 	protocolbase.RemoveUrlKeyUnhealthyStatus(key)
 	if cacheInvoker, ok := dir.cacheInvokersMap.Load(key); ok {
 		dir.cacheInvokersMap.Delete(key)
+		dir.healthChecker.Remove(key)
 		return cacheInvoker.(protocolbase.Invoker)
 	}
 	return nil
@@ -578,8 +750,9 @@ func (dir *RegistryDirectory) cacheInvoker(url *common.URL, event *registry.Serv
 		logger.Error("URL is nil ,pls check if service url is subscribe successfully!")
 		return nil
 	}
-	// check the url's protocol is equal to the protocol which is configured in reference config or referenceUrl is not care about protocol
-	if url.Protocol == referenceUrl.Protocol || referenceUrl.Protocol == "" {
+	// check the url's protocol is equal to the protocol which is configured in reference config, or is one of the
+	// preferred protocols of the reference, or referenceUrl is not care about protocol
+	if acceptProtocol(referenceUrl, url.Protocol) {
 		newUrl := url.MergeURL(referenceUrl)
 		dir.overrideUrl(newUrl)
 		event.Update(newUrl)
@@ -602,6 +775,7 @@ func (dir *RegistryDirectory) doCacheInvoker(newUrl *common.URL, event *registry
 		newInvoker := extension.GetProtocol(protocolwrapper.FILTER).Refer(newUrl)
 		if newInvoker != nil {
 			dir.cacheInvokersMap.Store(key, newInvoker)
+			dir.healthChecker.Add(key, newInvoker)
 		} else {
 			logger.Warnf("service will be added in cache invokers fail, result is null, invokers url is %+v", newUrl.String())
 		}
@@ -617,6 +791,7 @@ func (dir *RegistryDirectory) doCacheInvoker(newUrl *common.URL, event *registry
 		newInvoker := extension.GetProtocol(protocolwrapper.FILTER).Refer(newUrl)
 		if newInvoker != nil {
 			dir.cacheInvokersMap.Store(key, newInvoker)
+			dir.healthChecker.Add(key, newInvoker)
 			return cacheInvoker.(protocolbase.Invoker), true
 		} else {
 			logger.Warnf("service will be updated in cache invokers fail, result is null, invokers url is %+v", newUrl.String())
@@ -656,6 +831,7 @@ func (dir *RegistryDirectory) IsAvailable() bool {
 func (dir *RegistryDirectory) Destroy() {
 	// TODO:unregister & unsubscribe
 	dir.DoDestroy(func() {
+		dir.healthChecker.Stop()
 		graceful_shutdown.DefaultClosingDirectoryRegistry().Unregister(dir.closingServiceKey(), dir)
 		if dir.RegisteredUrl != nil {
 			err := dir.registry.UnRegister(dir.RegisteredUrl)
@@ -671,6 +847,13 @@ func (dir *RegistryDirectory) Destroy() {
 			}
 		}
 
+		if dir.consumerConfigurationListener != nil {
+			dir.consumerConfigurationListener.Destroy()
+		}
+		if dir.referenceConfigurationListener != nil {
+			dir.referenceConfigurationListener.Destroy()
+		}
+
 		invokers := dir.cacheInvokers
 		dir.cacheInvokers = []protocolbase.Invoker{}
 		for _, ivk := range invokers {
@@ -804,6 +987,7 @@ func NewServiceDiscoveryRegistryDirectory(url *common.URL, registry registry.Reg
 
 // Subscribe do subscribe from registry
 func (dir *ServiceDiscoveryRegistryDirectory) Subscribe(url *common.URL) error {
+	dir.loadAddressCacheAfter(dir.registryTimeout())
 	if err := dir.registry.Subscribe(url, dir); err != nil {
 		logger.Error("registry.Subscribe(url:%v, dir:%v) = error:%v", url, dir, err)
 		return err
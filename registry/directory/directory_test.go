@@ -18,7 +18,11 @@
 package directory
 
 import (
+	"context"
+	"errors"
+	"path/filepath"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -30,11 +34,13 @@ import (
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/cluster/cluster"
+	"dubbo.apache.org/dubbo-go/v3/cluster/directory/healthcheck"
 	_ "dubbo.apache.org/dubbo-go/v3/cluster/router/tag"
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/global"
+	protocolbase "dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/protocol/protocolwrapper"
 	"dubbo.apache.org/dubbo-go/v3/registry"
@@ -151,6 +157,116 @@ func Test_RefreshUrl(t *testing.T) {
 	assert.Empty(t, registryDirectory.cacheInvokers)
 }
 
+func TestRefreshUrlWithEmptyProtection(t *testing.T) {
+	registryDirectory, mockRegistry := normalRegistryDir(true)
+	registryDirectory.GetDirectoryUrl().SubURL.SetParam(constant.RegistryEmptyProtectionKey, "true")
+	providerUrl, _ := common.NewURL("dubbo://0.0.0.0:20011/org.apache.dubbo-go.mockService",
+		common.WithParamsValue(constant.ClusterKey, "mock1"),
+		common.WithParamsValue(constant.GroupKey, "group"),
+		common.WithParamsValue(constant.VersionKey, "1.0.0"))
+	providerUrl2, _ := common.NewURL("dubbo://0.0.0.0:20012/org.apache.dubbo-go.mockService",
+		common.WithParamsValue(constant.ClusterKey, "mock1"),
+		common.WithParamsValue(constant.GroupKey, "group"),
+		common.WithParamsValue(constant.VersionKey, "1.0.0"))
+	mockRegistry.MockEvents([]*registry.ServiceEvent{
+		{Action: remoting.EventTypeUpdate, Service: providerUrl},
+		{Action: remoting.EventTypeUpdate, Service: providerUrl2},
+	})
+	time.Sleep(1e9)
+	assert.Len(t, registryDirectory.cacheInvokers, 2)
+
+	// the empty address list is ignored
+	mockRegistry.MockEvents([]*registry.ServiceEvent{})
+	time.Sleep(1e9)
+	assert.Len(t, registryDirectory.cacheInvokers, 2)
+
+	// the deletions remove the addresses but the last one
+	registryDirectory.Notify(&registry.ServiceEvent{Action: remoting.EventTypeDel, Service: providerUrl})
+	assert.Len(t, registryDirectory.cacheInvokers, 1)
+	registryDirectory.Notify(&registry.ServiceEvent{Action: remoting.EventTypeDel, Service: providerUrl2})
+	assert.Len(t, registryDirectory.cacheInvokers, 1)
+	assert.Equal(t, "0.0.0.0:20012", registryDirectory.cacheInvokers[0].GetURL().Location)
+}
+
+func TestAddressCacheEnabled(t *testing.T) {
+	url, _ := common.NewURL("mock://127.0.0.1:1111")
+	url.SubURL, _ = common.NewURL("dubbo://127.0.0.1:20000/org.apache.dubbo-go.addressCacheService")
+	assert.False(t, AddressCacheEnabled(url))
+
+	// the reference enables it without the registry
+	url.SubURL.SetParam(constant.RegistryAddressCacheKey, "true")
+	assert.True(t, AddressCacheEnabled(url))
+
+	// the reference disables it over the registry
+	url.SetParam(constant.RegistryAddressCacheKey, "true")
+	url.SubURL.SetParam(constant.RegistryAddressCacheKey, "false")
+	assert.False(t, AddressCacheEnabled(url))
+}
+
+func TestAddressCache(t *testing.T) {
+	extension.SetProtocol(protocolwrapper.FILTER, protocolwrapper.NewMockProtocolFilter)
+	cacheFile := filepath.Join(t.TempDir(), "dubbo.registry")
+	newDir := func() *RegistryDirectory {
+		url, _ := common.NewURL("mock://127.0.0.1:1111",
+			common.WithParamsValue(constant.RegistryAddressCacheKey, "true"),
+			common.WithParamsValue(constant.RegistryAddressCacheFileKey, cacheFile))
+		url.SubURL, _ = common.NewURL("dubbo://127.0.0.1:20000/org.apache.dubbo-go.addressCacheService",
+			common.WithParamsValue(constant.ApplicationKey, "test-application"))
+		mockRegistry, _ := registry.NewMockRegistry(&common.URL{})
+		dir, err := NewRegistryDirectory(url, mockRegistry)
+		require.NoError(t, err)
+		return dir.(*RegistryDirectory)
+	}
+	providerUrl, _ := common.NewURL("dubbo://0.0.0.0:20011/org.apache.dubbo-go.addressCacheService")
+	providerUrl2, _ := common.NewURL("dubbo://0.0.0.0:20012/org.apache.dubbo-go.addressCacheService")
+
+	dir := newDir()
+	require.NotNil(t, dir.addressCache)
+	dir.Notify(&registry.ServiceEvent{Action: remoting.EventTypeAdd, Service: providerUrl})
+	assert.Len(t, dir.addressCache.load(), 1)
+
+	// a new directory of the same subscription starts from the cached address
+	restarted := newDir()
+	restarted.loadAddressCache()
+	assert.Len(t, restarted.cacheInvokers, 1)
+	assert.Equal(t, "0.0.0.0:20011", restarted.cacheInvokers[0].GetURL().Location)
+
+	// the first notification of the registry replaces the cached address
+	restarted.Notify(&registry.ServiceEvent{Action: remoting.EventTypeAdd, Service: providerUrl2})
+	assert.Len(t, restarted.cacheInvokers, 1)
+	assert.Equal(t, "0.0.0.0:20012", restarted.cacheInvokers[0].GetURL().Location)
+	assert.Len(t, dir.addressCache.load(), 1)
+
+	// the cache is not loaded once the registry has notified
+	restarted.loadAddressCache()
+	assert.Equal(t, "0.0.0.0:20012", restarted.cacheInvokers[0].GetURL().Location)
+}
+
+func TestAddressesChangedListener(t *testing.T) {
+	registryDirectory, mockRegistry := normalRegistryDir(true)
+	var notified atomic.Int32
+	registryDirectory.SetAddressesChangedListener(func() {
+		notified.Add(1)
+	})
+
+	providerURL1, _ := common.NewURL("dubbo://10.0.0.1:20000/org.apache.dubbo-go.mockService",
+		common.WithParamsValue(constant.ClusterKey, "mock1"),
+		common.WithParamsValue(constant.GroupKey, "group"),
+		common.WithParamsValue(constant.VersionKey, "1.0.0"))
+	providerURL2, _ := common.NewURL("dubbo://10.0.0.2:20000/org.apache.dubbo-go.mockService",
+		common.WithParamsValue(constant.ClusterKey, "mock1"),
+		common.WithParamsValue(constant.GroupKey, "group"),
+		common.WithParamsValue(constant.VersionKey, "1.0.0"))
+	mockRegistry.MockEvents([]*registry.ServiceEvent{
+		{Action: remoting.EventTypeUpdate, Service: providerURL1},
+		{Action: remoting.EventTypeUpdate, Service: providerURL2},
+	})
+	time.Sleep(1e9)
+
+	assert.ElementsMatch(t, []string{"10.0.0.1:20000", "10.0.0.2:20000"}, registryDirectory.Addresses())
+	assert.Positive(t, notified.Load())
+}
+
 func TestRemoveClosingInstanceRemovesExactInstanceKey(t *testing.T) {
 	registryDirectory, mockRegistry := normalRegistryDir(true)
 
@@ -255,6 +371,100 @@ func TestExpiredClosingTombstoneAllowsRebuild(t *testing.T) {
 	assert.Len(t, registryDirectory.cacheInvokers, 1)
 }
 
+type unhealthyInvoker struct {
+	*protocolbase.BaseInvoker
+}
+
+func (unhealthyInvoker) HealthCheck(context.Context) error {
+	return errors.New("not serving")
+}
+
+func TestHealthCheckRemovesUnhealthyInvokers(t *testing.T) {
+	registryDirectory, mockRegistry := normalRegistryDir(true)
+
+	providerURL1, _ := common.NewURL("dubbo://0.0.0.0:20000/org.apache.dubbo-go.mockService",
+		common.WithParamsValue(constant.ClusterKey, "mock1"),
+		common.WithParamsValue(constant.GroupKey, "group"),
+		common.WithParamsValue(constant.VersionKey, "1.0.0"))
+	providerURL2, _ := common.NewURL("dubbo://0.0.0.0:20001/org.apache.dubbo-go.mockService",
+		common.WithParamsValue(constant.ClusterKey, "mock1"),
+		common.WithParamsValue(constant.GroupKey, "group"),
+		common.WithParamsValue(constant.VersionKey, "1.0.0"))
+	event1 := &registry.ServiceEvent{Action: remoting.EventTypeAdd, Service: providerURL1}
+	event2 := &registry.ServiceEvent{Action: remoting.EventTypeAdd, Service: providerURL2}
+	key1 := registryDirectory.invokerCacheKey(event1)
+	key2 := registryDirectory.invokerCacheKey(event2)
+	mockRegistry.MockEvent(event1)
+	mockRegistry.MockEvent(event2)
+	time.Sleep(1e9)
+	require.Len(t, registryDirectory.cacheInvokers, 2)
+
+	checkURL := providerURL1.Clone()
+	checkURL.SetParam(constant.HealthCheckEnabledKey, "true")
+	checkURL.SetParam(constant.HealthCheckIntervalKey, "10ms")
+	checkURL.SetParam(constant.HealthCheckUnhealthyThresholdKey, "1")
+	checker := healthcheck.NewChecker(checkURL, registryDirectory.setNewInvokers)
+	defer checker.Stop()
+	registryDirectory.healthChecker = checker
+
+	// the invoker of key1 is unhealthy and taken out of the directory
+	checker.Add(key1, unhealthyInvoker{protocolbase.NewBaseInvoker(providerURL1)})
+	assert.Eventually(t, func() bool {
+		return len(registryDirectory.List(&invocation.RPCInvocation{})) == 1
+	}, 3*time.Second, 10*time.Millisecond)
+	remaining := registryDirectory.List(&invocation.RPCInvocation{})[0]
+	assert.Equal(t, key2, remaining.GetURL().GetCacheInvokerMapKey())
+
+	// all the invokers are kept when none of them is healthy
+	checker.Add(key2, unhealthyInvoker{protocolbase.NewBaseInvoker(providerURL2)})
+	assert.Eventually(t, func() bool {
+		return !checker.IsHealthy(key2)
+	}, 3*time.Second, 10*time.Millisecond)
+	assert.Len(t, registryDirectory.toGroupInvokers(), 2)
+}
+
+// unhealthyPortProtocol refers unhealthy invokers for the providers on port 20000
+type unhealthyPortProtocol struct {
+	protocolbase.BaseProtocol
+}
+
+func (p *unhealthyPortProtocol) Refer(url *common.URL) protocolbase.Invoker {
+	if url.Port == "20000" {
+		return unhealthyInvoker{protocolbase.NewBaseInvoker(url)}
+	}
+	return protocolbase.NewBaseInvoker(url)
+}
+
+func TestServiceDiscoveryDirectoryHealthCheck(t *testing.T) {
+	extension.SetProtocol(protocolwrapper.FILTER, func() protocolbase.Protocol {
+		return &unhealthyPortProtocol{BaseProtocol: protocolbase.NewBaseProtocol()}
+	})
+	defer extension.SetProtocol(protocolwrapper.FILTER, protocolwrapper.NewMockProtocolFilter)
+
+	url, _ := common.NewURL("service-discovery://127.0.0.1:1111")
+	url.SubURL, _ = common.NewURL("dubbo://127.0.0.1:20000/org.apache.dubbo-go.mockService",
+		common.WithParamsValue(constant.HealthCheckEnabledKey, "true"),
+		common.WithParamsValue(constant.HealthCheckIntervalKey, "10ms"),
+		common.WithParamsValue(constant.HealthCheckUnhealthyThresholdKey, "1"))
+	mockRegistry, _ := registry.NewMockRegistry(&common.URL{})
+	dir, err := NewServiceDiscoveryRegistryDirectory(url, mockRegistry)
+	require.NoError(t, err)
+	defer dir.Destroy()
+
+	events := make([]*registry.ServiceEvent, 0, 2)
+	for _, port := range []string{"20000", "20001"} {
+		providerURL, _ := common.NewURL("dubbo://127.0.0.1:" + port + "/org.apache.dubbo-go.mockService")
+		events = append(events, &registry.ServiceEvent{Action: remoting.EventTypeAdd, Service: providerURL})
+	}
+	dir.(*ServiceDiscoveryRegistryDirectory).NotifyAll(events, func() {})
+
+	// the providers found by application level service discovery are probed as well
+	assert.Eventually(t, func() bool {
+		invokers := dir.List(&invocation.RPCInvocation{})
+		return len(invokers) == 1 && invokers[0].GetURL().Port == "20001"
+	}, 3*time.Second, 10*time.Millisecond)
+}
+
 func normalRegistryDir(noMockEvent ...bool) (*RegistryDirectory, *registry.MockRegistry) {
 	extension.SetProtocol(protocolwrapper.FILTER, protocolwrapper.NewMockProtocolFilter)
 
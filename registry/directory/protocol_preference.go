@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package directory
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	protocolbase "dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+)
+
+// protocolPreference returns the protocols the reference consumes in order of preference,
+// it is empty if the reference only consumes a single protocol.
+func protocolPreference(referenceUrl *common.URL) []string {
+	preference := referenceUrl.GetParam(constant.ProtocolPreferenceKey, "")
+	if preference == "" {
+		return nil
+	}
+	protocols := strings.Split(preference, constant.CommaSeparator)
+	trimmed := protocols[:0]
+	for _, protocol := range protocols {
+		if protocol = strings.TrimSpace(protocol); protocol != "" {
+			trimmed = append(trimmed, protocol)
+		}
+	}
+	return trimmed
+}
+
+// acceptProtocol checks the protocol of a provider is consumed by the reference
+func acceptProtocol(referenceUrl *common.URL, protocol string) bool {
+	if preference := protocolPreference(referenceUrl); len(preference) > 0 {
+		return slices.Contains(preference, protocol)
+	}
+	return protocol == referenceUrl.Protocol || referenceUrl.Protocol == ""
+}
+
+// instanceKey identifies the provider instance behind a url, so that the urls exported by
+// the same process on several protocols are grouped together. Without the pid, the url is
+// not grouped, as the processes of an application on the same host can not be told apart.
+func instanceKey(url *common.URL) string {
+	if pid := url.GetParam(constant.PIDKey, ""); pid != "" {
+		return url.Ip + "#" + pid
+	}
+	return url.Location
+}
+
+// preferProtocols groups the invokers by provider instance and merges the invokers of an
+// instance into one invoker, which calls the best protocol according to @preference.
+func preferProtocols(invokers []protocolbase.Invoker, preference []string) []protocolbase.Invoker {
+	if len(preference) < 2 {
+		return invokers
+	}
+	rank := func(invoker protocolbase.Invoker) int {
+		return slices.Index(preference, invoker.GetURL().Protocol)
+	}
+
+	keys := make([]string, 0, len(invokers))
+	instances := make(map[string][]protocolbase.Invoker, len(invokers))
+	for _, invoker := range invokers {
+		if rank(invoker) < 0 {
+			continue
+		}
+		key := instanceKey(invoker.GetURL())
+		if _, ok := instances[key]; !ok {
+			keys = append(keys, key)
+		}
+		instances[key] = append(instances[key], invoker)
+	}
+
+	preferred := make([]protocolbase.Invoker, 0, len(keys))
+	for _, key := range keys {
+		candidates := instances[key]
+		if len(candidates) == 1 {
+			preferred = append(preferred, candidates[0])
+			continue
+		}
+		slices.SortStableFunc(candidates, func(a, b protocolbase.Invoker) int {
+			return rank(a) - rank(b)
+		})
+		preferred = append(preferred, &preferredInvoker{invokers: candidates})
+	}
+	return preferred
+}
+
+// preferredInvoker is a provider instance reachable through several protocols. It calls the
+// first available invoker in the order of preference, so that the calls fall back to the next
+// protocol while the preferred one is unavailable. The invokers are owned by the directory.
+type preferredInvoker struct {
+	invokers []protocolbase.Invoker
+}
+
+func (p *preferredInvoker) active() protocolbase.Invoker {
+	for _, invoker := range p.invokers {
+		if invoker.IsAvailable() {
+			return invoker
+		}
+	}
+	return p.invokers[0]
+}
+
+// GetURL returns the url of the protocol currently in use
+func (p *preferredInvoker) GetURL() *common.URL {
+	return p.active().GetURL()
+}
+
+// IsAvailable checks the instance is available through any of the protocols
+func (p *preferredInvoker) IsAvailable() bool {
+	for _, invoker := range p.invokers {
+		if invoker.IsAvailable() {
+			return true
+		}
+	}
+	return false
+}
+
+// Destroy does nothing, the invokers are destroyed by the directory
+func (p *preferredInvoker) Destroy() {}
+
+// Invoke calls the instance through the best available protocol
+func (p *preferredInvoker) Invoke(ctx context.Context, invocation protocolbase.Invocation) result.Result {
+	return p.active().Invoke(ctx, invocation)
+}
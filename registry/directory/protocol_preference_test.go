@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package directory
+
+import (
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	protocolbase "dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/protocolwrapper"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+func TestAcceptProtocol(t *testing.T) {
+	single, _ := common.NewURL("tri://127.0.0.1:20000/org.apache.dubbo-go.mockService")
+	assert.True(t, acceptProtocol(single, "tri"))
+	assert.False(t, acceptProtocol(single, "dubbo"))
+
+	preferred, _ := common.NewURL("tri://127.0.0.1:20000/org.apache.dubbo-go.mockService",
+		common.WithParamsValue(constant.ProtocolPreferenceKey, "tri,dubbo"))
+	assert.True(t, acceptProtocol(preferred, "tri"))
+	assert.True(t, acceptProtocol(preferred, "dubbo"))
+	assert.False(t, acceptProtocol(preferred, "jsonrpc"))
+
+	spaced, _ := common.NewURL("tri://127.0.0.1:20000/org.apache.dubbo-go.mockService",
+		common.WithParamsValue(constant.ProtocolPreferenceKey, " tri , dubbo,"))
+	assert.Equal(t, []string{"tri", "dubbo"}, protocolPreference(spaced))
+	assert.True(t, acceptProtocol(spaced, "dubbo"))
+}
+
+func TestInstanceKey(t *testing.T) {
+	withPid, _ := common.NewURL("tri://192.168.0.1:50051/mockService?pid=10&application=app")
+	assert.Equal(t, "192.168.0.1#10", instanceKey(withPid))
+
+	// the processes of an application on the same host are not grouped without the pid
+	withoutPid, _ := common.NewURL("tri://192.168.0.1:50051/mockService?application=app")
+	other, _ := common.NewURL("dubbo://192.168.0.1:20000/mockService?application=app")
+	assert.Equal(t, "192.168.0.1:50051", instanceKey(withoutPid))
+	assert.NotEqual(t, instanceKey(withoutPid), instanceKey(other))
+}
+
+func TestPreferProtocols(t *testing.T) {
+	newInvoker := func(rawURL string) *protocolbase.BaseInvoker {
+		u, err := common.NewURL(rawURL)
+		require.NoError(t, err)
+		return protocolbase.NewBaseInvoker(u)
+	}
+	dubbo1 := newInvoker("dubbo://192.168.0.1:20000/mockService?pid=10")
+	tri1 := newInvoker("tri://192.168.0.1:50051/mockService?pid=10")
+	dubbo2 := newInvoker("dubbo://192.168.0.2:20000/mockService?pid=10")
+	jsonrpc3 := newInvoker("jsonrpc://192.168.0.3:20000/mockService?pid=10")
+
+	invokers := preferProtocols([]protocolbase.Invoker{dubbo1, tri1, dubbo2, jsonrpc3}, []string{"tri", "dubbo"})
+	require.Len(t, invokers, 2)
+	assert.IsType(t, &preferredInvoker{}, invokers[0])
+	assert.Equal(t, "tri", invokers[0].GetURL().Protocol)
+	assert.Equal(t, dubbo2, invokers[1])
+
+	// fall back to dubbo while the triple invoker is unavailable
+	tri1.SetAvailable(false)
+	assert.True(t, invokers[0].IsAvailable())
+	assert.Equal(t, "dubbo", invokers[0].GetURL().Protocol)
+
+	dubbo1.SetAvailable(false)
+	assert.False(t, invokers[0].IsAvailable())
+
+	tri1.SetAvailable(true)
+	assert.Equal(t, "tri", invokers[0].GetURL().Protocol)
+
+	// a single protocol is left as it is
+	assert.Len(t, preferProtocols([]protocolbase.Invoker{dubbo1, tri1}, []string{"tri"}), 2)
+}
+
+func TestRegistryDirectoryProtocolPreference(t *testing.T) {
+	extension.SetProtocol(protocolwrapper.FILTER, protocolwrapper.NewMockProtocolFilter)
+
+	url, _ := common.NewURL("mock://127.0.0.1:1111")
+	suburl, _ := common.NewURL(
+		"tri://127.0.0.1:20000/org.apache.dubbo-go.mockService",
+		common.WithParamsValue(constant.ClusterKey, "mock"),
+		common.WithParamsValue(constant.ProtocolPreferenceKey, "tri,dubbo"),
+	)
+	url.SubURL = suburl
+	mockRegistry, _ := registry.NewMockRegistry(&common.URL{})
+	dir, err := NewRegistryDirectory(url, mockRegistry)
+	require.NoError(t, err)
+	registryDirectory := dir.(*RegistryDirectory)
+	go registryDirectory.Subscribe(suburl)
+
+	for _, provider := range []string{
+		"dubbo://192.168.0.1:20000/org.apache.dubbo-go.mockService?pid=10",
+		"tri://192.168.0.1:50051/org.apache.dubbo-go.mockService?pid=10",
+		"dubbo://192.168.0.2:20000/org.apache.dubbo-go.mockService?pid=11",
+		"jsonrpc://192.168.0.3:20000/org.apache.dubbo-go.mockService?pid=12",
+	} {
+		providerUrl, _ := common.NewURL(provider)
+		mockRegistry.(*registry.MockRegistry).MockEvent(&registry.ServiceEvent{Action: remoting.EventTypeAdd, Service: providerUrl})
+	}
+	time.Sleep(1e9)
+
+	invokers := registryDirectory.toGroupInvokers()
+	require.Len(t, invokers, 2)
+	protocols := []string{invokers[0].GetURL().Protocol, invokers[1].GetURL().Protocol}
+	assert.ElementsMatch(t, []string{"tri", "dubbo"}, protocols)
+}
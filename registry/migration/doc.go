@@ -0,0 +1,34 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package migration moves consumers from interface-level to application-level service discovery.
+//
+// A reference subscribed through both of them with the registry type all uses one of the two sources following the
+// migration rule of its application, which is delivered by the config center with the key
+// {application}.migration in the group MIGRATION, for example:
+//
+//	key: demo-consumer
+//	step: APPLICATION_FIRST
+//	threshold: 0.8
+//	interfaces:
+//	  - serviceKey: com.example.Greeter
+//	    step: FORCE_APPLICATION
+//	    force: true
+//
+// Without a rule, the step and the threshold are read from the parameters migration.step and migration.threshold of
+// the reference or the registry, which default to APPLICATION_FIRST and 1.
+package migration
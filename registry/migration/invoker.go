@@ -0,0 +1,250 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migration
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/cluster/directory"
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsRegistry "dubbo.apache.org/dubbo-go/v3/metrics/registry"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+)
+
+// Referrer is implemented by the registry protocol to refer a service through both the interface-level and the
+// application-level discovery of a registry.
+type Referrer interface {
+	// ReferMigration returns an invoker switching between the two sources following the migration rules
+	ReferMigration(interfaceURL, applicationURL *common.URL) base.Invoker
+}
+
+// Pair splits the registry urls into the pairs of the interface-level and the application-level url of a registry
+// whose registry type is all, and the other urls.
+func Pair(urls []*common.URL) (pairs [][2]*common.URL, others []*common.URL) {
+	paired := make(map[*common.URL]bool)
+	for _, u := range urls {
+		if u.Protocol != constant.ServiceRegistryProtocol || u.GetParam(constant.RegistryTypeKey, "") != constant.RegistryTypeAll {
+			continue
+		}
+		for _, v := range urls {
+			if v.Protocol == constant.RegistryProtocol && !paired[v] && sameRegistry(u, v) {
+				paired[u], paired[v] = true, true
+				pairs = append(pairs, [2]*common.URL{v, u})
+				break
+			}
+		}
+	}
+	for _, u := range urls {
+		if !paired[u] {
+			others = append(others, u)
+		}
+	}
+	return pairs, others
+}
+
+// ReferAll refers the pairs of the interface-level and the application-level url of a registry whose registry type is
+// all as migration invokers, and returns them with the last interface-level url referred and the other urls.
+func ReferAll(urls []*common.URL) ([]base.Invoker, *common.URL, []*common.URL) {
+	pairs, others := Pair(urls)
+	if len(pairs) == 0 {
+		return nil, nil, urls
+	}
+	referrer, ok := extension.GetProtocol(constant.RegistryProtocol).(Referrer)
+	if !ok {
+		return nil, nil, urls
+	}
+	var regURL *common.URL
+	invokers := make([]base.Invoker, 0, len(urls))
+	for _, pair := range pairs {
+		invokers = append(invokers, referrer.ReferMigration(pair[0], pair[1]))
+		regURL = pair[0]
+	}
+	return invokers, regURL, others
+}
+
+func sameRegistry(a, b *common.URL) bool {
+	for _, key := range []string{constant.RegistryKey, constant.RegistryNamespaceKey, constant.RegistryGroupKey, constant.RegistryTypeKey} {
+		if a.GetParam(key, "") != b.GetParam(key, "") {
+			return false
+		}
+	}
+	return a.Location == b.Location
+}
+
+// source is a discovery source of a service, named after the registry type it comes from
+type source struct {
+	name      string
+	invoker   base.Invoker
+	directory directory.Directory
+}
+
+// addresses returns the number of the distinct addresses of the source
+func (s *source) addresses() int {
+	notifier, ok := s.directory.(directory.AddressesNotifier)
+	if !ok {
+		return 0
+	}
+	set := make(map[string]struct{})
+	for _, addr := range notifier.Addresses() {
+		set[addr] = struct{}{}
+	}
+	return len(set)
+}
+
+// migrationInvoker invokes through the current discovery source of a service, which is chosen again once the
+// migration rule or the addresses of either source change.
+type migrationInvoker struct {
+	url         *common.URL
+	interfaces  *source
+	services    *source
+	defaults    setting
+	listener    *ruleListener
+	mu          sync.Mutex // serializes the choice of the source
+	setting     setting
+	current     atomic.Pointer[source]
+	destroyOnce sync.Once
+}
+
+// NewInvoker returns an invoker of the service of the reference url, which switches between the invoker referred
+// through interface-level discovery and the one through application-level discovery, each with its directory.
+func NewInvoker(url *common.URL, interfaceInvoker base.Invoker, interfaceDir directory.Directory,
+	applicationInvoker base.Invoker, applicationDir directory.Directory) base.Invoker {
+	m := &migrationInvoker{
+		url:        url,
+		interfaces: &source{name: constant.RegistryTypeInterface, invoker: interfaceInvoker, directory: interfaceDir},
+		services:   &source{name: constant.RegistryTypeService, invoker: applicationInvoker, directory: applicationDir},
+		defaults:   defaultSetting(url, interfaceDir.GetURL()),
+	}
+	m.setting = m.defaults
+	if application := url.GetParam(constant.ApplicationKey, ""); application != "" {
+		m.listener = ruleListenerOf(application)
+		m.listener.add(m)
+	}
+	for _, s := range []*source{m.interfaces, m.services} {
+		if notifier, ok := s.directory.(directory.AddressesNotifier); ok {
+			notifier.SetAddressesChangedListener(m.refresh)
+		}
+	}
+	m.refresh()
+	return m
+}
+
+// GetURL returns the url of the current source
+func (m *migrationInvoker) GetURL() *common.URL {
+	return m.current.Load().invoker.GetURL()
+}
+
+// IsAvailable returns whether the current source is available
+func (m *migrationInvoker) IsAvailable() bool {
+	return m.current.Load().invoker.IsAvailable()
+}
+
+// Invoke invokes through the current source
+func (m *migrationInvoker) Invoke(ctx context.Context, invocation base.Invocation) result.Result {
+	return m.current.Load().invoker.Invoke(ctx, invocation)
+}
+
+// Destroy destroys the invokers of both sources
+func (m *migrationInvoker) Destroy() {
+	m.destroyOnce.Do(func() {
+		if m.listener != nil {
+			m.listener.remove(m)
+		}
+		m.interfaces.invoker.Destroy()
+		m.services.invoker.Destroy()
+	})
+}
+
+// apply sets the setting from the rule, nil if the application has none
+func (m *migrationInvoker) apply(rule *Rule) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.setting = rule.settingOf(m.url, m.defaults)
+}
+
+// migrate applies the rule and chooses the source again
+func (m *migrationInvoker) migrate(rule *Rule) {
+	m.apply(rule)
+	m.refresh()
+}
+
+// refresh chooses the source following the setting and the addresses of the sources
+func (m *migrationInvoker) refresh() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev := m.current.Load()
+	next := m.choose(prev)
+	if next == prev {
+		return
+	}
+	m.current.Store(next)
+	from := ""
+	if prev != nil {
+		from = prev.name
+		logger.Infof("[Migration] service %s switches the discovery source from %s to %s, step: %s",
+			m.url.ServiceKey(), prev.name, next.name, m.setting.step)
+	}
+	metrics.Publish(metricsRegistry.NewMigrationEvent(m.url.ServiceKey(), from, next.name))
+}
+
+// choose returns the source to use. Unless the setting forces it, a forced step keeps the previous source when the
+// source of the step does not have enough addresses, so that a rule cannot empty the addresses of the service.
+func (m *migrationInvoker) choose(prev *source) *source {
+	s := m.setting
+	switch s.step {
+	case ForceInterface:
+		if s.force || prev == nil || m.interfaces.addresses() > 0 {
+			return m.interfaces
+		}
+		return prev
+	case ForceApplication:
+		if s.force || prev == nil || m.shouldMigrate(s.threshold) {
+			return m.services
+		}
+		return prev
+	default:
+		if m.shouldMigrate(s.threshold) {
+			return m.services
+		}
+		return m.interfaces
+	}
+}
+
+// shouldMigrate returns whether application-level discovery has addresses, at least threshold times as many as
+// interface-level discovery.
+func (m *migrationInvoker) shouldMigrate(threshold float64) bool {
+	services := m.services.addresses()
+	if services == 0 {
+		return false
+	}
+	interfaces := m.interfaces.addresses()
+	return interfaces == 0 || float64(services)/float64(interfaces) >= threshold
+}
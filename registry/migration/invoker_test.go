@@ -0,0 +1,246 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migration
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	conf "dubbo.apache.org/dubbo-go/v3/common/config"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+type fakeDirectory struct {
+	url       *common.URL
+	mu        sync.Mutex
+	addresses []string
+	listener  func()
+}
+
+func (d *fakeDirectory) GetURL() *common.URL                  { return d.url }
+func (d *fakeDirectory) IsAvailable() bool                    { return true }
+func (d *fakeDirectory) Destroy()                             {}
+func (d *fakeDirectory) List(base.Invocation) []base.Invoker  { return nil }
+func (d *fakeDirectory) Subscribe(*common.URL) error          { return nil }
+func (d *fakeDirectory) SetAddressesChangedListener(l func()) { d.listener = l }
+func (d *fakeDirectory) Addresses() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.addresses
+}
+
+func (d *fakeDirectory) set(addresses ...string) {
+	d.mu.Lock()
+	d.addresses = addresses
+	d.mu.Unlock()
+	if d.listener != nil {
+		d.listener()
+	}
+}
+
+type testSources struct {
+	interfaceInvoker   *base.BaseInvoker
+	applicationInvoker *base.BaseInvoker
+	interfaceDir       *fakeDirectory
+	applicationDir     *fakeDirectory
+}
+
+func newTestInvoker(t *testing.T, url *common.URL) (*migrationInvoker, *testSources) {
+	registryURL, err := common.NewURL("zookeeper://127.0.0.1:2181")
+	require.NoError(t, err)
+	s := &testSources{
+		interfaceInvoker:   base.NewBaseInvoker(common.NewURLWithOptions(common.WithProtocol("zookeeper"))),
+		applicationInvoker: base.NewBaseInvoker(common.NewURLWithOptions(common.WithProtocol(constant.ServiceRegistryProtocol))),
+		interfaceDir:       &fakeDirectory{url: registryURL},
+		applicationDir:     &fakeDirectory{url: registryURL},
+	}
+	m := NewInvoker(url, s.interfaceInvoker, s.interfaceDir, s.applicationInvoker, s.applicationDir).(*migrationInvoker)
+	return m, s
+}
+
+func serviceURL(params ...common.Option) *common.URL {
+	return common.NewURLWithOptions(append([]common.Option{
+		common.WithPath("com.example.Greeter"),
+		common.WithParamsValue(constant.InterfaceKey, "com.example.Greeter"),
+	}, params...)...)
+}
+
+func TestApplicationFirst(t *testing.T) {
+	m, s := newTestInvoker(t, serviceURL(common.WithParamsValue(constant.MigrationThresholdKey, "0.5")))
+	assert.Equal(t, constant.RegistryTypeInterface, m.current.Load().name)
+
+	s.interfaceDir.set("10.0.0.1:20000", "10.0.0.2:20000", "10.0.0.3:20000", "10.0.0.4:20000")
+	s.applicationDir.set("10.0.0.1:20000")
+	assert.Equal(t, constant.RegistryTypeInterface, m.current.Load().name)
+
+	// duplicated addresses are counted once
+	s.applicationDir.set("10.0.0.1:20000", "10.0.0.1:20000", "10.0.0.2:20000")
+	assert.Equal(t, constant.RegistryTypeService, m.current.Load().name)
+	assert.Equal(t, s.applicationInvoker.GetURL(), m.GetURL())
+	assert.True(t, m.IsAvailable())
+	m.Invoke(context.Background(), nil)
+
+	s.applicationDir.set()
+	assert.Equal(t, constant.RegistryTypeInterface, m.current.Load().name)
+	assert.Equal(t, s.interfaceInvoker.GetURL(), m.GetURL())
+
+	s.interfaceDir.set()
+	s.applicationDir.set("10.0.0.1:20000")
+	assert.Equal(t, constant.RegistryTypeService, m.current.Load().name)
+
+	m.Destroy()
+	assert.False(t, s.interfaceInvoker.IsAvailable())
+	assert.False(t, s.applicationInvoker.IsAvailable())
+}
+
+func TestForceSteps(t *testing.T) {
+	m, s := newTestInvoker(t, serviceURL(common.WithParamsValue(constant.MigrationStepKey, string(ForceApplication))))
+	assert.Equal(t, constant.RegistryTypeService, m.current.Load().name)
+
+	// forcing the interface keeps the previous source until the interface has addresses
+	m.migrate(&Rule{Step: ForceInterface})
+	assert.Equal(t, constant.RegistryTypeService, m.current.Load().name)
+	s.interfaceDir.set("10.0.0.1:20000")
+	assert.Equal(t, constant.RegistryTypeInterface, m.current.Load().name)
+
+	// forcing the application keeps the previous source until the application reaches the threshold
+	threshold := 1.0
+	m.migrate(&Rule{Step: ForceApplication, Threshold: &threshold})
+	assert.Equal(t, constant.RegistryTypeInterface, m.current.Load().name)
+	s.interfaceDir.set("10.0.0.1:20000", "10.0.0.2:20000")
+	s.applicationDir.set("10.0.0.1:20000")
+	assert.Equal(t, constant.RegistryTypeInterface, m.current.Load().name)
+	s.applicationDir.set("10.0.0.1:20000", "10.0.0.2:20000")
+	assert.Equal(t, constant.RegistryTypeService, m.current.Load().name)
+
+	// force switches regardless of the addresses
+	s.interfaceDir.set()
+	m.migrate(&Rule{Step: ForceInterface, Force: true})
+	assert.Equal(t, constant.RegistryTypeInterface, m.current.Load().name)
+}
+
+func TestRuleListener(t *testing.T) {
+	url := serviceURL(common.WithParamsValue(constant.ApplicationKey, "migration-test-consumer"))
+	m, s := newTestInvoker(t, url)
+	s.interfaceDir.set("10.0.0.1:20000")
+	s.applicationDir.set("10.0.0.2:20000")
+	assert.Equal(t, constant.RegistryTypeService, m.current.Load().name)
+
+	l := ruleListenerOf("migration-test-consumer")
+	assert.Equal(t, "migration-test-consumer"+constant.MigrationRuleSuffix, l.key)
+	l.Process(&config_center.ConfigChangeEvent{Key: l.key, Value: testRule, ConfigType: remoting.EventTypeUpdate})
+	// com.example.Greeter is forced to the application
+	assert.Equal(t, constant.RegistryTypeService, m.current.Load().name)
+
+	l.Process(&config_center.ConfigChangeEvent{Key: l.key, Value: `
+interfaces:
+  - serviceKey: com.example.Greeter
+    step: FORCE_INTERFACE
+`, ConfigType: remoting.EventTypeUpdate})
+	assert.Equal(t, constant.RegistryTypeInterface, m.current.Load().name)
+
+	// a broken rule is ignored
+	l.Process(&config_center.ConfigChangeEvent{Key: l.key, Value: "step: NOWHERE", ConfigType: remoting.EventTypeUpdate})
+	assert.Equal(t, constant.RegistryTypeInterface, m.current.Load().name)
+
+	// the step falls back to the default once the rule is deleted
+	l.Process(&config_center.ConfigChangeEvent{Key: l.key, ConfigType: remoting.EventTypeDel})
+	assert.Equal(t, constant.RegistryTypeService, m.current.Load().name)
+
+	m.Destroy()
+	assert.Empty(t, l.invokers)
+}
+
+func TestRuleListenerSubscribes(t *testing.T) {
+	factory := &config_center.MockDynamicConfigurationFactory{Content: "step: FORCE_INTERFACE"}
+	dc, err := factory.GetDynamicConfiguration(nil)
+	require.NoError(t, err)
+	conf.GetEnvInstance().SetDynamicConfiguration(dc)
+	defer conf.GetEnvInstance().SetDynamicConfiguration(nil)
+
+	url := serviceURL(common.WithParamsValue(constant.ApplicationKey, "migration-subscribed-consumer"))
+	m, s := newTestInvoker(t, url)
+	s.interfaceDir.set("10.0.0.1:20000")
+	s.applicationDir.set("10.0.0.2:20000")
+	assert.Equal(t, ForceInterface, m.setting.step)
+	assert.Equal(t, constant.RegistryTypeInterface, m.current.Load().name)
+}
+
+func TestPair(t *testing.T) {
+	newURL := func(s string) *common.URL {
+		u, err := common.NewURL(s)
+		require.NoError(t, err)
+		return u
+	}
+	sd := newURL("service-discovery-registry://127.0.0.1:2181?registry=zookeeper&registry.type=all")
+	reg := newURL("registry://127.0.0.1:2181?registry=zookeeper&registry.type=all")
+	other := newURL("registry://127.0.0.1:8848?registry=nacos&registry.type=interface")
+	sdOnly := newURL("service-discovery-registry://127.0.0.1:8848?registry=nacos&registry.type=all")
+
+	pairs, others := Pair([]*common.URL{sd, reg, other, sdOnly})
+	require.Len(t, pairs, 1)
+	assert.Equal(t, reg, pairs[0][0])
+	assert.Equal(t, sd, pairs[0][1])
+	assert.Equal(t, []*common.URL{other, sdOnly}, others)
+}
+
+type fakeReferrer struct {
+	base.BaseProtocol
+}
+
+func (p *fakeReferrer) ReferMigration(interfaceURL, _ *common.URL) base.Invoker {
+	return base.NewBaseInvoker(interfaceURL)
+}
+
+func TestReferAll(t *testing.T) {
+	newURL := func(s string) *common.URL {
+		u, err := common.NewURL(s)
+		require.NoError(t, err)
+		return u
+	}
+	sd := newURL("service-discovery-registry://127.0.0.1:2181?registry=zookeeper&registry.type=all")
+	reg := newURL("registry://127.0.0.1:2181?registry=zookeeper&registry.type=all")
+	other := newURL("registry://127.0.0.1:8848?registry=nacos&registry.type=interface")
+
+	invokers, regURL, others := ReferAll([]*common.URL{other})
+	assert.Empty(t, invokers)
+	assert.Nil(t, regURL)
+	assert.Equal(t, []*common.URL{other}, others)
+
+	extension.SetProtocol(constant.RegistryProtocol, func() base.Protocol {
+		return &fakeReferrer{BaseProtocol: base.NewBaseProtocol()}
+	})
+	invokers, regURL, others = ReferAll([]*common.URL{sd, reg, other})
+	require.Len(t, invokers, 1)
+	assert.Equal(t, reg, invokers[0].GetURL())
+	assert.Equal(t, reg, regURL)
+	assert.Equal(t, []*common.URL{other}, others)
+}
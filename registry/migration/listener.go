@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migration
+
+import (
+	"strings"
+	"sync"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	conf "dubbo.apache.org/dubbo-go/v3/common/config"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+var (
+	ruleListenersLock sync.Mutex
+	ruleListeners     = make(map[string]*ruleListener)
+)
+
+// ruleListener delivers the migration rule of an application to the migration invokers of its references
+type ruleListener struct {
+	key      string
+	mu       sync.Mutex
+	rule     *Rule
+	invokers map[*migrationInvoker]struct{}
+}
+
+// ruleListenerOf returns the listener of the application, which subscribes to the rule of the application from the
+// config center when it is created.
+func ruleListenerOf(application string) *ruleListener {
+	ruleListenersLock.Lock()
+	defer ruleListenersLock.Unlock()
+
+	if l, ok := ruleListeners[application]; ok {
+		return l
+	}
+	l := &ruleListener{
+		key:      strings.Join([]string{application, constant.MigrationRuleSuffix}, ""),
+		invokers: make(map[*migrationInvoker]struct{}),
+	}
+	ruleListeners[application] = l
+
+	dynamicConfiguration := conf.GetEnvInstance().GetDynamicConfiguration()
+	if dynamicConfiguration == nil {
+		logger.Infof("Config center does not start, the migration rule %s will not be subscribed", l.key)
+		return l
+	}
+	dynamicConfiguration.AddListener(l.key, l, config_center.WithGroup(constant.MigrationRuleGroup))
+	value, err := dynamicConfiguration.GetRule(l.key, config_center.WithGroup(constant.MigrationRuleGroup))
+	if err != nil {
+		logger.Warnf("Failed to query the migration rule, key=%s, err=%v", l.key, err)
+		return l
+	}
+	l.Process(&config_center.ConfigChangeEvent{Key: l.key, Value: value, ConfigType: remoting.EventTypeAdd})
+	return l
+}
+
+// Process updates the rule and migrates the invokers following it, a rule failing to parse is ignored
+func (l *ruleListener) Process(event *config_center.ConfigChangeEvent) {
+	var rule *Rule
+	if event.ConfigType != remoting.EventTypeDel {
+		content, _ := event.Value.(string)
+		if strings.TrimSpace(content) != "" {
+			var err error
+			if rule, err = Parse(content); err != nil {
+				logger.Errorf("Failed to parse the migration rule, key=%s, err=%v", l.key, err)
+				return
+			}
+		}
+	}
+	logger.Infof("[Migration] apply the migration rule %s: %+v", l.key, rule)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rule = rule
+	for m := range l.invokers {
+		m.migrate(rule)
+	}
+}
+
+func (l *ruleListener) add(m *migrationInvoker) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.invokers[m] = struct{}{}
+	m.apply(l.rule)
+}
+
+func (l *ruleListener) remove(m *migrationInvoker) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.invokers, m)
+}
@@ -0,0 +1,158 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migration
+
+import (
+	"strconv"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+
+	"gopkg.in/yaml.v2"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+)
+
+// Step is the stage of the migration a service is in
+type Step string
+
+const (
+	// ForceInterface uses interface-level discovery only
+	ForceInterface Step = "FORCE_INTERFACE"
+	// ApplicationFirst uses application-level discovery once its addresses reach the threshold, and falls back to
+	// interface-level discovery otherwise
+	ApplicationFirst Step = "APPLICATION_FIRST"
+	// ForceApplication uses application-level discovery only
+	ForceApplication Step = "FORCE_APPLICATION"
+)
+
+// Rule is the migration rule of an application, the interfaces override the step, threshold and force of the
+// application for the services matching their service keys.
+type Rule struct {
+	Key        string         `yaml:"key"`
+	Step       Step           `yaml:"step"`
+	Threshold  *float64       `yaml:"threshold"`
+	Force      bool           `yaml:"force"`
+	Interfaces []*ServiceRule `yaml:"interfaces"`
+}
+
+// ServiceRule is the migration rule of a service, the service key is either the interface name or the service key
+// of the reference, like group/interface:version.
+type ServiceRule struct {
+	ServiceKey string   `yaml:"serviceKey"`
+	Step       Step     `yaml:"step"`
+	Threshold  *float64 `yaml:"threshold"`
+	Force      *bool    `yaml:"force"`
+}
+
+// Parse parses the migration rule in yaml
+func Parse(content string) (*Rule, error) {
+	rule := &Rule{}
+	if err := yaml.Unmarshal([]byte(content), rule); err != nil {
+		return nil, perrors.Wrap(err, "parse migration rule")
+	}
+	if err := rule.Step.validate(); err != nil {
+		return nil, err
+	}
+	for _, r := range rule.Interfaces {
+		if r.ServiceKey == "" {
+			return nil, perrors.New("the serviceKey of the migration rule of an interface is empty")
+		}
+		if err := r.Step.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return rule, nil
+}
+
+func (s Step) validate() error {
+	switch s {
+	case "", ForceInterface, ApplicationFirst, ForceApplication:
+		return nil
+	default:
+		return perrors.Errorf("unknown migration step %q", s)
+	}
+}
+
+// setting is how a service chooses its discovery source
+type setting struct {
+	step      Step
+	threshold float64
+	force     bool
+}
+
+// defaultThreshold migrates to the application-level discovery once it has as many addresses as the interface-level
+// one, so that a partially upgraded cluster keeps using the interface-level addresses.
+const defaultThreshold = 1.0
+
+// defaultSetting reads the setting from the parameters of the reference url, then the registry url, the step is
+// ApplicationFirst and the threshold is defaultThreshold if they are absent.
+func defaultSetting(url, registryURL *common.URL) setting {
+	param := func(key string) string {
+		if v := url.GetParam(key, ""); v != "" {
+			return v
+		}
+		if registryURL != nil {
+			return registryURL.GetParam(key, "")
+		}
+		return ""
+	}
+	s := setting{step: Step(param(constant.MigrationStepKey)), threshold: defaultThreshold}
+	if s.step.validate() != nil || s.step == "" {
+		s.step = ApplicationFirst
+	}
+	if threshold, err := strconv.ParseFloat(param(constant.MigrationThresholdKey), 64); err == nil {
+		s.threshold = threshold
+	}
+	return s
+}
+
+// settingOf returns the setting of the service of url, the fields absent from the rule are taken from def
+func (r *Rule) settingOf(url *common.URL, def setting) setting {
+	if r == nil {
+		return def
+	}
+	s := def
+	if r.Step != "" {
+		s.step = r.Step
+	}
+	if r.Threshold != nil {
+		s.threshold = *r.Threshold
+	}
+	s.force = r.Force
+	for _, sr := range r.Interfaces {
+		if sr.ServiceKey != url.ServiceKey() && sr.ServiceKey != url.Interface() {
+			continue
+		}
+		if sr.Step != "" {
+			s.step = sr.Step
+		}
+		if sr.Threshold != nil {
+			s.threshold = *sr.Threshold
+		}
+		if sr.Force != nil {
+			s.force = *sr.Force
+		}
+		break
+	}
+	return s
+}
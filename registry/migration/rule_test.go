@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migration
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+)
+
+const testRule = `
+key: demo-consumer
+step: APPLICATION_FIRST
+threshold: 0.8
+interfaces:
+  - serviceKey: com.example.Greeter
+    step: FORCE_APPLICATION
+    force: true
+  - serviceKey: g1/com.example.Echo:1.0.0
+    threshold: 0.5
+`
+
+func TestParse(t *testing.T) {
+	rule, err := Parse(testRule)
+	require.NoError(t, err)
+	assert.Equal(t, "demo-consumer", rule.Key)
+	assert.Equal(t, ApplicationFirst, rule.Step)
+	assert.InDelta(t, 0.8, *rule.Threshold, 1e-9)
+	assert.False(t, rule.Force)
+	require.Len(t, rule.Interfaces, 2)
+	assert.Equal(t, ForceApplication, rule.Interfaces[0].Step)
+	assert.True(t, *rule.Interfaces[0].Force)
+	assert.Nil(t, rule.Interfaces[1].Force)
+
+	_, err = Parse("step: SOMETIMES_APPLICATION")
+	assert.Error(t, err)
+	_, err = Parse("interfaces:\n  - step: FORCE_INTERFACE")
+	assert.Error(t, err)
+	_, err = Parse("step: [")
+	assert.Error(t, err)
+}
+
+func TestSettingOf(t *testing.T) {
+	rule, err := Parse(testRule)
+	require.NoError(t, err)
+	def := setting{step: ForceInterface}
+
+	greeter := common.NewURLWithOptions(common.WithParamsValue(constant.InterfaceKey, "com.example.Greeter"),
+		common.WithParamsValue(constant.GroupKey, "g1"))
+	assert.Equal(t, setting{step: ForceApplication, threshold: 0.8, force: true}, rule.settingOf(greeter, def))
+
+	echo := common.NewURLWithOptions(common.WithParamsValue(constant.InterfaceKey, "com.example.Echo"),
+		common.WithParamsValue(constant.GroupKey, "g1"), common.WithParamsValue(constant.VersionKey, "1.0.0"))
+	assert.Equal(t, setting{step: ApplicationFirst, threshold: 0.5}, rule.settingOf(echo, def))
+
+	other := common.NewURLWithOptions(common.WithParamsValue(constant.InterfaceKey, "com.example.Other"))
+	assert.Equal(t, setting{step: ApplicationFirst, threshold: 0.8}, rule.settingOf(other, def))
+
+	var none *Rule
+	assert.Equal(t, def, none.settingOf(other, def))
+}
+
+func TestDefaultSetting(t *testing.T) {
+	url := common.NewURLWithOptions()
+	assert.Equal(t, setting{step: ApplicationFirst, threshold: defaultThreshold}, defaultSetting(url, nil))
+
+	registryURL := common.NewURLWithOptions(common.WithParamsValue(constant.MigrationStepKey, string(ForceApplication)),
+		common.WithParamsValue(constant.MigrationThresholdKey, "0.6"))
+	assert.Equal(t, setting{step: ForceApplication, threshold: 0.6}, defaultSetting(url, registryURL))
+
+	url = common.NewURLWithOptions(common.WithParamsValue(constant.MigrationStepKey, string(ForceInterface)))
+	assert.Equal(t, setting{step: ForceInterface, threshold: 0.6}, defaultSetting(url, registryURL))
+
+	url = common.NewURLWithOptions(common.WithParamsValue(constant.MigrationStepKey, "UNKNOWN"))
+	assert.Equal(t, ApplicationFirst, defaultSetting(url, nil).step)
+}
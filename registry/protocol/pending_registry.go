@@ -0,0 +1,210 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package protocol
+
+import (
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+)
+
+// pendingRegistry stands for a registry which cannot be connected when the references start, so that they can start
+// from the cached addresses. It keeps connecting to the registry in the background, and replays the registrations
+// and the subscriptions made before it connects once it does.
+type pendingRegistry struct {
+	url     *common.URL
+	connect func() (registry.Registry, error)
+
+	lock      sync.Mutex
+	reg       registry.Registry
+	pending   []pendingOp
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newPendingRegistry(url *common.URL, connect func() (registry.Registry, error)) *pendingRegistry {
+	p := &pendingRegistry{
+		url:     url,
+		connect: connect,
+		done:    make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+func (p *pendingRegistry) run() {
+	delay := time.Duration(registry.RegistryConnDelay) * time.Second
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-time.After(delay):
+		}
+
+		reg, err := p.connect()
+		if err != nil {
+			logger.Warnf("Registry %s cannot connect successfully and will retry in %v. Error: %v", p.url.Location, delay, err)
+			continue
+		}
+
+		p.lock.Lock()
+		select {
+		case <-p.done:
+			p.lock.Unlock()
+			reg.Destroy()
+			return
+		default:
+		}
+		p.reg = reg
+		pending := p.pending
+		p.pending = nil
+		p.lock.Unlock()
+
+		logger.Infof("Registry %s is connected, replay %d pending registrations and subscriptions", p.url.Location, len(pending))
+		for _, op := range pending {
+			go op.run(reg)
+		}
+		return
+	}
+}
+
+// pendingOp is a registration or a subscription made before the registry connects
+type pendingOp struct {
+	subscribe bool
+	url       *common.URL
+	listener  registry.NotifyListener
+	run       func(registry.Registry)
+}
+
+// registry returns the connected registry, or nil after queuing op to run once the registry connects
+func (p *pendingRegistry) registry(op pendingOp) registry.Registry {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.reg == nil {
+		p.pending = append(p.pending, op)
+	}
+	return p.reg
+}
+
+// dequeue returns the connected registry, or nil after removing the queued registrations or subscriptions
+// of the url and the listener, so an unregistered url or a cancelled subscription is not replayed
+func (p *pendingRegistry) dequeue(subscribe bool, url *common.URL, listener registry.NotifyListener) registry.Registry {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.reg != nil {
+		return p.reg
+	}
+	key := url.Key()
+	pending := p.pending[:0]
+	for _, op := range p.pending {
+		if op.subscribe != subscribe || op.listener != listener || op.url.Key() != key {
+			pending = append(pending, op)
+		}
+	}
+	clear(p.pending[len(pending):])
+	p.pending = pending
+	return nil
+}
+
+// connected returns the connected registry, or nil
+func (p *pendingRegistry) connected() registry.Registry {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.reg
+}
+
+func (p *pendingRegistry) GetURL() *common.URL {
+	return p.url
+}
+
+func (p *pendingRegistry) IsAvailable() bool {
+	if reg := p.connected(); reg != nil {
+		return reg.IsAvailable()
+	}
+	select {
+	case <-p.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (p *pendingRegistry) Destroy() {
+	p.closeOnce.Do(func() {
+		p.lock.Lock()
+		close(p.done)
+		reg := p.reg
+		p.lock.Unlock()
+		if reg != nil {
+			reg.Destroy()
+		}
+	})
+}
+
+func (p *pendingRegistry) Register(url *common.URL) error {
+	register := func(reg registry.Registry) {
+		if err := reg.Register(url); err != nil {
+			logger.Errorf("register %s to registry %s error: %v", url.Key(), p.url.Location, err)
+		}
+	}
+	if reg := p.registry(pendingOp{url: url, run: register}); reg != nil {
+		return reg.Register(url)
+	}
+	return nil
+}
+
+func (p *pendingRegistry) UnRegister(url *common.URL) error {
+	if reg := p.dequeue(false, url, nil); reg != nil {
+		return reg.UnRegister(url)
+	}
+	return nil
+}
+
+func (p *pendingRegistry) Subscribe(url *common.URL, listener registry.NotifyListener) error {
+	subscribe := func(reg registry.Registry) {
+		if err := reg.Subscribe(url, listener); err != nil {
+			logger.Errorf("subscribe %s from registry %s error: %v", url.Key(), p.url.Location, err)
+		}
+	}
+	if reg := p.registry(pendingOp{subscribe: true, url: url, listener: listener, run: subscribe}); reg != nil {
+		return reg.Subscribe(url, listener)
+	}
+	return nil
+}
+
+func (p *pendingRegistry) UnSubscribe(url *common.URL, listener registry.NotifyListener) error {
+	if reg := p.dequeue(true, url, listener); reg != nil {
+		return reg.UnSubscribe(url, listener)
+	}
+	return nil
+}
+
+func (p *pendingRegistry) LoadSubscribeInstances(url *common.URL, listener registry.NotifyListener) error {
+	if reg := p.connected(); reg != nil {
+		return reg.LoadSubscribeInstances(url, listener)
+	}
+	return nil
+}
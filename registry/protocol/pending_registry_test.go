@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package protocol
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+)
+
+type countingRegistry struct {
+	registry.Registry
+	registered atomic.Int32
+	subscribed atomic.Int32
+	destroyed  atomic.Bool
+}
+
+func (r *countingRegistry) Register(*common.URL) error {
+	r.registered.Add(1)
+	return nil
+}
+
+func (r *countingRegistry) Subscribe(*common.URL, registry.NotifyListener) error {
+	r.subscribed.Add(1)
+	return nil
+}
+
+func (r *countingRegistry) IsAvailable() bool {
+	return !r.destroyed.Load()
+}
+
+func (r *countingRegistry) Destroy() {
+	r.destroyed.Store(true)
+}
+
+type idListener struct {
+	id int
+}
+
+func (l *idListener) Notify(*registry.ServiceEvent) {}
+
+func (l *idListener) NotifyAll([]*registry.ServiceEvent, func()) {}
+
+func TestPendingRegistry(t *testing.T) {
+	url, _ := common.NewURL("mock://127.0.0.1:2181")
+	reg := &countingRegistry{}
+	var connects atomic.Int32
+	pending := newPendingRegistry(url, func() (registry.Registry, error) {
+		if connects.Add(1) == 1 {
+			return nil, errors.New("registry is down")
+		}
+		return reg, nil
+	})
+
+	other, _ := common.NewURL("mock://127.0.0.1:2181/org.example.OtherService")
+	kept, cancelled := &idListener{id: 1}, &idListener{id: 2}
+	assert.True(t, pending.IsAvailable())
+	require.NoError(t, pending.Register(url))
+	require.NoError(t, pending.Register(other))
+	require.NoError(t, pending.UnRegister(other))
+	require.NoError(t, pending.Subscribe(url, kept))
+	require.NoError(t, pending.Subscribe(url, cancelled))
+	require.NoError(t, pending.UnSubscribe(url, cancelled))
+	assert.Zero(t, reg.registered.Load())
+
+	// the registrations and subscriptions made before connecting are replayed, except the cancelled ones
+	require.Eventually(t, func() bool {
+		return reg.registered.Load() == 1 && reg.subscribed.Load() == 1
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.Equal(t, int32(2), connects.Load())
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), reg.registered.Load())
+	assert.Equal(t, int32(1), reg.subscribed.Load())
+
+	require.NoError(t, pending.Subscribe(url, kept))
+	assert.Equal(t, int32(2), reg.subscribed.Load())
+
+	pending.Destroy()
+	assert.True(t, reg.destroyed.Load())
+	assert.False(t, pending.IsAvailable())
+}
+
+func TestPendingRegistryDestroyBeforeConnect(t *testing.T) {
+	url, _ := common.NewURL("mock://127.0.0.1:2181")
+	var connects atomic.Int32
+	pending := newPendingRegistry(url, func() (registry.Registry, error) {
+		connects.Add(1)
+		return nil, errors.New("registry is down")
+	})
+	pending.Destroy()
+	assert.False(t, pending.IsAvailable())
+	require.NoError(t, pending.Register(url))
+	time.Sleep(time.Duration(registry.RegistryConnDelay)*time.Second + 500*time.Millisecond)
+	assert.Zero(t, connects.Load())
+}
@@ -32,6 +32,7 @@ import (
 )
 
 import (
+	"dubbo.apache.org/dubbo-go/v3/cluster/directory"
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
@@ -39,10 +40,13 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/config_center"
 	_ "dubbo.apache.org/dubbo-go/v3/config_center/configurator"
 	"dubbo.apache.org/dubbo-go/v3/global"
+	"dubbo.apache.org/dubbo-go/v3/metrics/probe"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/protocolwrapper"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 	"dubbo.apache.org/dubbo-go/v3/registry"
+	registryDirectory "dubbo.apache.org/dubbo-go/v3/registry/directory"
+	"dubbo.apache.org/dubbo-go/v3/registry/migration"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
@@ -81,11 +85,7 @@ func newRegistryProtocol() *registryProtocol {
 }
 
 func (proto *registryProtocol) getRegistry(registryUrl *common.URL) registry.Registry {
-	namespace := registryUrl.GetParam(constant.RegistryNamespaceKey, "")
-	cacheKey := registryUrl.PrimitiveURL
-	if namespace != "" {
-		cacheKey = cacheKey + "?" + constant.NacosNamespaceID + "=" + namespace
-	}
+	cacheKey := registryCacheKey(registryUrl)
 	actualReg, _ := proto.registries.LoadOrStore(cacheKey, func() any {
 		reg, err := extension.GetRegistry(registryUrl.Protocol, registryUrl)
 		if err != nil {
@@ -97,6 +97,42 @@ func (proto *registryProtocol) getRegistry(registryUrl *common.URL) registry.Reg
 	return actualReg.(registry.Registry)
 }
 
+func registryCacheKey(registryUrl *common.URL) string {
+	namespace := registryUrl.GetParam(constant.RegistryNamespaceKey, "")
+	cacheKey := registryUrl.PrimitiveURL
+	if namespace != "" {
+		cacheKey = cacheKey + "?" + constant.NacosNamespaceID + "=" + namespace
+	}
+	return cacheKey
+}
+
+// getConsumerRegistry is getRegistry for the references. When the address cache is enabled, a registry which cannot
+// be connected doesn't stop the references from starting, they start from the cached addresses while the registry
+// keeps connecting in the background.
+func (proto *registryProtocol) getConsumerRegistry(registryUrl *common.URL) registry.Registry {
+	if !registryDirectory.AddressCacheEnabled(registryUrl) {
+		return proto.getRegistry(registryUrl)
+	}
+	cacheKey := registryCacheKey(registryUrl)
+	if reg, ok := proto.registries.Load(cacheKey); ok {
+		return reg.(registry.Registry)
+	}
+	connect := func() (registry.Registry, error) {
+		return extension.GetRegistry(registryUrl.Protocol, registryUrl)
+	}
+	reg, err := connect()
+	if err != nil {
+		logger.Warnf("Registry %s cannot connect successfully, references will start from the cached addresses. Error: %s",
+			registryUrl.Location, err.Error())
+		reg = newPendingRegistry(registryUrl, connect)
+	}
+	actualReg, loaded := proto.registries.LoadOrStore(cacheKey, reg)
+	if loaded {
+		reg.Destroy()
+	}
+	return actualReg.(registry.Registry)
+}
+
 func getCacheKey(invoker base.Invoker) string {
 	url := getProviderUrl(invoker)
 	delKeys := gxset.NewSet("dynamic", "enabled")
@@ -143,13 +179,44 @@ func (proto *registryProtocol) GetRegistries() []registry.Registry {
 
 // Refer provider service from registry center
 func (proto *registryProtocol) Refer(url *common.URL) base.Invoker {
+	dic := proto.subscribe(url)
+	if dic == nil {
+		return nil
+	}
+	return join(url.SubURL, dic)
+}
+
+// ReferMigration refers provider service through both the interface-level and the application-level discovery of
+// a registry, the invoker returned switches between them following the migration rules.
+func (proto *registryProtocol) ReferMigration(interfaceURL, applicationURL *common.URL) base.Invoker {
+	interfaceDic := proto.subscribe(interfaceURL)
+	applicationDic := proto.subscribe(applicationURL)
+	if interfaceDic == nil || applicationDic == nil {
+		logger.Warnf("consumer service %v cannot subscribe through both discovery sources, will not migrate",
+			interfaceURL.SubURL.String())
+		if interfaceDic == nil {
+			return join(applicationURL.SubURL, applicationDic)
+		}
+		return join(interfaceURL.SubURL, interfaceDic)
+	}
+
+	interfaceInvoker := join(interfaceURL.SubURL, interfaceDic)
+	applicationInvoker := join(applicationURL.SubURL, applicationDic)
+	if interfaceInvoker == nil || applicationInvoker == nil {
+		return nil
+	}
+	return migration.NewInvoker(interfaceURL.SubURL, interfaceInvoker, interfaceDic, applicationInvoker, applicationDic)
+}
+
+// subscribe creates the registry directory of url and subscribes to the instance changes, nil if it fails
+func (proto *registryProtocol) subscribe(url *common.URL) directory.Directory {
 	registryUrl := url
 	serviceUrl := registryUrl.SubURL
 	if registryUrl.Protocol == constant.RegistryProtocol {
 		registryUrl.Protocol = registryUrl.GetParam(constant.RegistryKey, "")
 	}
 
-	reg := proto.getRegistry(url)
+	reg := proto.getConsumerRegistry(url)
 
 	// new registry directory for store service url from registry
 	dic, err := extension.GetDirectoryInstance(registryUrl, reg)
@@ -165,7 +232,11 @@ func (proto *registryProtocol) Refer(url *common.URL) base.Invoker {
 		logger.Errorf("consumer service %v register registry %v error, error message is %s",
 			serviceUrl.String(), registryUrl.String(), err.Error())
 	}
+	return dic
+}
 
+// join returns the cluster invoker of the directory
+func join(serviceUrl *common.URL, dic directory.Directory) base.Invoker {
 	// new cluster invoker
 	clusterKey := serviceUrl.GetParam(constant.ClusterKey, constant.DefaultCluster)
 	cluster, err := extension.GetCluster(clusterKey)
@@ -232,6 +303,17 @@ func (proto *registryProtocol) Export(originInvoker base.Invoker) base.Exporter
 		reg := proto.getRegistry(registryUrl)
 		registeredProviderUrl := getUrlToRegistry(providerUrl, registryUrl)
 
+		// the invoker is exported already, so the warm-up hooks are able to call it,
+		// but consumers won't find it before they are done
+		if providerUrl.GetParamBool(constant.RegisterAfterWarmupKey, false) {
+			timeout := providerUrl.GetParamPositiveDuration(constant.WarmupTimeoutKey, constant.DefaultWarmupTimeout)
+			if err := probe.AwaitWarmupTimeout(timeout); err != nil {
+				logger.Errorf("provider service %v warm-up error, it will not be registered, error message is %s",
+					providerUrl.Key(), err.Error())
+				return nil
+			}
+		}
+
 		err := reg.Register(registeredProviderUrl)
 		if err != nil {
 			logger.Errorf("provider service %v register registry %v error, error message is %s",
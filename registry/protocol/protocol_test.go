@@ -18,6 +18,8 @@
 package protocol
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -26,6 +28,7 @@ import (
 	gxset "github.com/dubbogo/gost/container/set"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 import (
@@ -37,10 +40,12 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/config_center"
 	"dubbo.apache.org/dubbo-go/v3/config_center/configurator"
 	"dubbo.apache.org/dubbo-go/v3/global"
+	"dubbo.apache.org/dubbo-go/v3/metrics/probe"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/protocolwrapper"
 	"dubbo.apache.org/dubbo-go/v3/registry"
 	"dubbo.apache.org/dubbo-go/v3/registry/directory"
+	"dubbo.apache.org/dubbo-go/v3/registry/migration"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
@@ -122,6 +127,30 @@ func TestOneRegRefer(t *testing.T) {
 	assert.Equal(t, 1, count)
 }
 
+func TestReferMigration(t *testing.T) {
+	regProtocol := newRegistryProtocol()
+	referNormal(t, regProtocol)
+
+	for step, source := range map[migration.Step]string{
+		migration.ApplicationFirst: "127.0.0.1:1111",
+		migration.ForceApplication: "127.0.0.1:3333",
+	} {
+		interfaceURL, _ := common.NewURL("mock://127.0.0.1:1111")
+		applicationURL, _ := common.NewURL("mock://127.0.0.1:3333")
+		suburl, _ := common.NewURL(
+			"dubbo://127.0.0.1:20000//",
+			common.WithParamsValue(constant.ClusterKey, "mock"),
+			common.WithParamsValue(constant.MigrationStepKey, string(step)),
+		)
+		interfaceURL.SubURL = suburl
+		applicationURL.SubURL = suburl
+
+		invoker := regProtocol.ReferMigration(interfaceURL, applicationURL)
+		require.NotNil(t, invoker)
+		assert.Equal(t, source, invoker.GetURL().Location, step)
+	}
+}
+
 func exporterNormal(t *testing.T, regProtocol *registryProtocol) *common.URL {
 	extension.SetProtocol("registry", GetProtocol)
 	extension.SetRegistry("mock", registry.NewMockRegistry)
@@ -184,6 +213,45 @@ func TestExporter(t *testing.T) {
 	exporterNormal(t, regProtocol)
 }
 
+func TestExporterAfterFailedWarmup(t *testing.T) {
+	regProtocol := newRegistryProtocol()
+	exporterNormal(t, regProtocol)
+
+	probe.RegisterWarmup("registry-protocol-test", func(context.Context) error {
+		return errors.New("cache not loaded")
+	})
+
+	url, _ := common.NewURL("mock://127.0.0.1:3333")
+	url.SubURL, _ = common.NewURL(
+		"jsonrpc://127.0.0.1:20001/org.apache.dubbo-go.warmupService",
+		common.WithParamsValue(constant.ClusterKey, "mock"),
+		common.WithParamsValue(constant.RegisterAfterWarmupKey, "true"),
+	)
+	assert.Nil(t, regProtocol.Export(base.NewBaseInvoker(url)))
+}
+
+func TestExporterWarmupTimeout(t *testing.T) {
+	regProtocol := newRegistryProtocol()
+	exporterNormal(t, regProtocol)
+
+	// replaces the hook of the former test
+	probe.RegisterWarmup("registry-protocol-test", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	url, _ := common.NewURL("mock://127.0.0.1:3333")
+	url.SubURL, _ = common.NewURL(
+		"jsonrpc://127.0.0.1:20001/org.apache.dubbo-go.warmupTimeoutService",
+		common.WithParamsValue(constant.ClusterKey, "mock"),
+		common.WithParamsValue(constant.RegisterAfterWarmupKey, "true"),
+		common.WithParamsValue(constant.WarmupTimeoutKey, "50ms"),
+	)
+	start := time.Now()
+	assert.Nil(t, regProtocol.Export(base.NewBaseInvoker(url)))
+	assert.Less(t, time.Since(start), constant.DefaultWarmupTimeout)
+}
+
 func TestMultiRegAndMultiProtoExporter(t *testing.T) {
 	regProtocol := newRegistryProtocol()
 	exporterNormal(t, regProtocol)
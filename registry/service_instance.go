@@ -180,6 +180,7 @@ func (d *DefaultServiceInstance) ToURLs(service *info.ServiceInfo) []*common.URL
 					common.WithPath(service.Name), common.WithInterface(service.Name),
 					common.WithMethods(service.GetMethods()), common.WithParams(service.GetParams()),
 					common.WithParams(url2.Values{constant.Tagkey: {d.Tag}}),
+					common.WithParams(d.warmupParams(service)),
 					common.WithWeight(d.GetWeight()))
 				urls = append(urls, url)
 			}
@@ -190,12 +191,26 @@ func (d *DefaultServiceInstance) ToURLs(service *info.ServiceInfo) []*common.URL
 			common.WithPath(service.Name), common.WithInterface(service.Name),
 			common.WithMethods(service.GetMethods()), common.WithParams(service.GetParams()),
 			common.WithParams(url2.Values{constant.Tagkey: {d.Tag}}),
+			common.WithParams(d.warmupParams(service)),
 			common.WithWeight(d.GetWeight()))
 		urls = append(urls, url)
 	}
 	return urls
 }
 
+// warmupParams returns the provider start time and warm-up window published in
+// instance metadata. The warm-up configured on the service itself takes precedence.
+func (d *DefaultServiceInstance) warmupParams(service *info.ServiceInfo) url2.Values {
+	params := url2.Values{}
+	if ts := d.Metadata[constant.ServiceInstanceStartTimestamp]; ts != "" {
+		params.Set(constant.RemoteTimestampKey, ts)
+	}
+	if warmup := d.Metadata[constant.ServiceInstanceWarmup]; warmup != "" && service.Params[constant.WarmupKey] == "" {
+		params.Set(constant.WarmupKey, warmup)
+	}
+	return params
+}
+
 // GetEndPoints get end points from metadata
 func (d *DefaultServiceInstance) GetEndPoints() []*Endpoint {
 	rawEndpoints := d.Metadata[constant.ServiceInstanceEndpoints]
@@ -92,6 +92,27 @@ func TestDefaultServiceInstance_ToURLsWithEndpoints(t *testing.T) {
 		assert.Len(t, urls, 1)
 		assert.Equal(t, "20880", urls[0].Port)
 	})
+
+	t.Run("warm-up from instance metadata", func(t *testing.T) {
+		instance := &DefaultServiceInstance{
+			Host: "127.0.0.1",
+			Port: 20880,
+			Metadata: map[string]string{
+				constant.ServiceInstanceStartTimestamp: "1700000000",
+				constant.ServiceInstanceWarmup:         "120",
+			},
+		}
+		urls := instance.ToURLs(serviceInfo)
+		assert.Len(t, urls, 1)
+		assert.Equal(t, "1700000000", urls[0].GetParam(constant.RemoteTimestampKey, ""))
+		assert.Equal(t, "120", urls[0].GetParam(constant.WarmupKey, ""))
+
+		// the warm-up of the service wins over the instance one
+		withWarmup := info.NewServiceInfoWithURL(serviceURL.Clone())
+		withWarmup.Params[constant.WarmupKey] = "60"
+		urls = instance.ToURLs(withWarmup)
+		assert.Equal(t, "60", urls[0].GetParam(constant.WarmupKey, ""))
+	})
 }
 
 func TestDefaultServiceInstance_GetEndPointsAndCopy(t *testing.T) {
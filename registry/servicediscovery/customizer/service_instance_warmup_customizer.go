@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package customizer
+
+import (
+	"strconv"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/cluster/loadbalance"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+)
+
+func init() {
+	extension.AddCustomizers(&warmupCustomizer{})
+}
+
+// warmupCustomizer publishes when the provider started and how long it warms up,
+// so that consumers can ramp the traffic up over that window.
+type warmupCustomizer struct{}
+
+// GetPriority will return 2 so that it will be invoked in front of user defining Customizer
+func (w *warmupCustomizer) GetPriority() int {
+	return 2
+}
+
+// Customize puts the earliest export time and the longest warm-up window of the
+// exported services into instance metadata
+func (w *warmupCustomizer) Customize(instance registry.ServiceInstance) {
+	meta := instance.GetServiceMetadata()
+	if meta == nil {
+		return
+	}
+	var (
+		start  int64
+		warmup int64 = -1
+	)
+	for _, service := range meta.Services {
+		if ts, err := strconv.ParseInt(service.Params[constant.TimestampKey], 10, 64); err == nil && ts > 0 {
+			if start == 0 || ts < start {
+				start = ts
+			}
+		}
+		if service.URL != nil && service.URL.GetParam(constant.WarmupKey, "") != "" {
+			warmup = max(warmup, loadbalance.GetWarmup(service.URL))
+		}
+	}
+	if start > 0 {
+		instance.GetMetadata()[constant.ServiceInstanceStartTimestamp] = strconv.FormatInt(start, 10)
+	}
+	if warmup >= 0 {
+		instance.GetMetadata()[constant.ServiceInstanceWarmup] = strconv.FormatInt(warmup, 10)
+	}
+}
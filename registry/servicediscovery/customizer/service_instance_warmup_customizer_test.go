@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package customizer
+
+import (
+	"net/url"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/metadata/info"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+)
+
+func TestWarmupCustomizer(t *testing.T) {
+	wc := &warmupCustomizer{}
+	assert.Equal(t, 2, wc.GetPriority())
+
+	meta := info.NewMetadataInfo("app", "")
+	meta.AddService(createWarmupServiceURL("DemoService", "200", "2m"))
+	meta.AddService(createWarmupServiceURL("GreetService", "100", "60"))
+	instance := &registry.DefaultServiceInstance{ServiceMetadata: meta}
+	wc.Customize(instance)
+
+	assert.Equal(t, "100", instance.GetMetadata()[constant.ServiceInstanceStartTimestamp])
+	assert.Equal(t, "120", instance.GetMetadata()[constant.ServiceInstanceWarmup])
+}
+
+func TestWarmupCustomizerWithoutWarmup(t *testing.T) {
+	meta := info.NewMetadataInfo("app", "")
+	meta.AddService(createWarmupServiceURL("DemoService", "200", ""))
+	instance := &registry.DefaultServiceInstance{ServiceMetadata: meta}
+	(&warmupCustomizer{}).Customize(instance)
+
+	assert.Equal(t, "200", instance.GetMetadata()[constant.ServiceInstanceStartTimestamp])
+	assert.NotContains(t, instance.GetMetadata(), constant.ServiceInstanceWarmup)
+}
+
+func createWarmupServiceURL(service, timestamp, warmup string) *common.URL {
+	params := url.Values{constant.TimestampKey: {timestamp}}
+	if warmup != "" {
+		params.Set(constant.WarmupKey, warmup)
+	}
+	return common.NewURLWithOptions(
+		common.WithProtocol("tri"),
+		common.WithPath(service),
+		common.WithInterface(service),
+		common.WithParams(params),
+	)
+}
@@ -276,6 +276,10 @@ func (s *serviceDiscoveryRegistry) SubscribeURL(url *common.URL, notify registry
 	if url.Protocol != "" {
 		protocol = url.Protocol
 	}
+	// listen to all the preferred protocols, the directory picks one of them per instance
+	if preference := url.GetParam(constant.ProtocolPreferenceKey, ""); preference != "" {
+		protocol = preference
+	}
 	protocolServiceKey := url.ServiceKey() + ":" + protocol
 	listener := s.serviceListeners[serviceNamesKey]
 	if listener == nil {
@@ -20,6 +20,7 @@ package servicediscovery
 import (
 	"encoding/gob"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 )
@@ -169,7 +170,7 @@ func (lstn *ServiceInstancesChangedListenerImpl) OnEvent(e observer.Event) error
 
 	lstn.serviceUrls = newServiceURLs
 	for key, notifyListener := range lstn.listeners {
-		urls := lstn.serviceUrls[key]
+		urls := lstn.getServiceURLs(key)
 		events := make([]*registry.ServiceEvent, 0, len(urls))
 		for _, url := range urls {
 			events = append(events, &registry.ServiceEvent{
@@ -186,7 +187,7 @@ func (lstn *ServiceInstancesChangedListenerImpl) OnEvent(e observer.Event) error
 // AddListenerAndNotify add notify listener and notify to listen service event
 func (lstn *ServiceInstancesChangedListenerImpl) AddListenerAndNotify(serviceKey string, notify registry.NotifyListener) {
 	lstn.listeners[serviceKey] = notify
-	urls := lstn.serviceUrls[serviceKey]
+	urls := lstn.getServiceURLs(serviceKey)
 	for _, url := range urls {
 		notify.Notify(&registry.ServiceEvent{
 			Action:  remoting.EventTypeAdd,
@@ -195,6 +196,21 @@ func (lstn *ServiceInstancesChangedListenerImpl) AddListenerAndNotify(serviceKey
 	}
 }
 
+// getServiceURLs returns the urls of the protocol service key. A reference with a protocol
+// preference listens to all its protocols at once, e.g. group/interface:version:tri,dubbo.
+func (lstn *ServiceInstancesChangedListenerImpl) getServiceURLs(protocolServiceKey string) []*common.URL {
+	idx := strings.LastIndex(protocolServiceKey, ":")
+	if idx < 0 || !strings.Contains(protocolServiceKey[idx+1:], constant.CommaSeparator) {
+		return lstn.serviceUrls[protocolServiceKey]
+	}
+	serviceKey := protocolServiceKey[:idx]
+	var urls []*common.URL
+	for _, protocol := range strings.Split(protocolServiceKey[idx+1:], constant.CommaSeparator) {
+		urls = append(urls, lstn.serviceUrls[common.MatchKey(serviceKey, protocol)]...)
+	}
+	return urls
+}
+
 // RemoveListener remove notify listener
 func (lstn *ServiceInstancesChangedListenerImpl) RemoveListener(serviceKey string) {
 	delete(lstn.listeners, serviceKey)
@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package servicediscovery
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+)
+
+func TestGetServiceURLsWithProtocolPreference(t *testing.T) {
+	triURL, _ := common.NewURL("tri://127.0.0.1:50051/DemoService")
+	dubboURL, _ := common.NewURL("dubbo://127.0.0.1:20000/DemoService")
+	lstn := &ServiceInstancesChangedListenerImpl{
+		serviceUrls: map[string][]*common.URL{
+			"group/DemoService:1.0.0:tri":   {triURL},
+			"group/DemoService:1.0.0:dubbo": {dubboURL},
+		},
+	}
+
+	assert.Equal(t, []*common.URL{triURL}, lstn.getServiceURLs("group/DemoService:1.0.0:tri"))
+	assert.Equal(t, []*common.URL{triURL, dubboURL}, lstn.getServiceURLs("group/DemoService:1.0.0:tri,dubbo"))
+	assert.Equal(t, []*common.URL{dubboURL}, lstn.getServiceURLs("group/DemoService:1.0.0:jsonrpc,dubbo"))
+	assert.Empty(t, lstn.getServiceURLs("group/DemoService:1.0.0:jsonrpc"))
+}
@@ -31,6 +31,7 @@ import (
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 )
 
 var (
@@ -78,6 +79,8 @@ type Response struct {
 	Event    bool
 	Error    error
 	Result   any
+	// Invocation is the invocation answered by the response on the provider side, it is not encoded
+	Invocation base.Invocation
 }
 
 // NewResponse create to a new Response.
@@ -138,7 +141,9 @@ type PendingResponse struct {
 	Callback  common.AsyncCallback
 	response  *Response
 	Reply     any
-	Done      chan struct{}
+	// Invocation is the invocation of the request waiting for the response
+	Invocation base.Invocation
+	Done       chan struct{}
 }
 
 // NewPendingResponse aims to create PendingResponse.
@@ -184,6 +189,11 @@ func removePendingResponse(seq SequenceType) *PendingResponse {
 	return nil
 }
 
+// RemovePendingResponse removes the response of a request which can not be sent
+func RemovePendingResponse(seq SequenceType) *PendingResponse {
+	return removePendingResponse(seq)
+}
+
 // GetPendingResponse gets the response
 func GetPendingResponse(seq SequenceType) *PendingResponse {
 	if presp, ok := pendingResponses.Load(seq); ok {
@@ -127,6 +127,7 @@ func (client *ExchangeClient) Request(invocation *base.Invocation, url *common.U
 	rsp := NewPendingResponse(request.ID)
 	rsp.response = NewResponse(request.ID, "2.0.2")
 	rsp.Reply = (*invocation).Reply()
+	rsp.Invocation = *invocation
 	AddPendingResponse(rsp)
 
 	err := client.client.Request(request, timeout, rsp)
@@ -161,6 +162,7 @@ func (client *ExchangeClient) AsyncRequest(invocation *base.Invocation, url *com
 	rsp.response = NewResponse(request.ID, "2.0.2")
 	rsp.Callback = callback
 	rsp.Reply = (*invocation).Reply()
+	rsp.Invocation = *invocation
 	AddPendingResponse(rsp)
 
 	err := client.client.Request(request, timeout, rsp)
@@ -192,6 +194,26 @@ func (client *ExchangeClient) Send(invocation *base.Invocation, url *common.URL,
 	return nil
 }
 
+// Heartbeat sends a two way heartbeat event and waits for the response, it probes whether the server is serving.
+func (client *ExchangeClient) Heartbeat(url *common.URL, timeout time.Duration) error {
+	if er := client.doInit(url); er != nil {
+		return er
+	}
+	request := NewRequest("2.0.2")
+	request.Event = true
+	request.TwoWay = true
+
+	rsp := NewPendingResponse(request.ID)
+	rsp.response = NewResponse(request.ID, "2.0.2")
+	AddPendingResponse(rsp)
+
+	if err := client.client.Request(request, timeout, rsp); err != nil {
+		removePendingResponse(SequenceType(request.ID))
+		return err
+	}
+	return rsp.response.Error
+}
+
 // Close close the client.
 func (client *ExchangeClient) Close() {
 	client.client.Close()
@@ -277,6 +277,7 @@ func (h *RpcServerHandler) OnMessage(session getty.Session, pkg any) {
 		return
 	}
 	resp.Result = result
+	resp.Invocation = invoc
 
 	reply(session, resp)
 }
@@ -0,0 +1,416 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package native implements the remoting client and server of the dubbo protocol on the standard net package.
+// It is an alternative of remoting/getty, selected by the `transporter: native` url param,
+// and the only one of them supporting unix domain sockets.
+package native
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/protocol/dubbo/impl"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+var (
+	errClientClosed      = perrors.New("client closed")
+	errNoConnection      = perrors.New("no available connection")
+	errClientReadTimeout = perrors.New("read response timeout")
+)
+
+// Options : param config
+type Options struct {
+	ConnectTimeout time.Duration
+	RequestTimeout time.Duration
+}
+
+// Client multiplexes requests over a few connections to one server.
+// A broken connection fails its in-flight requests at once and is
+// reconnected in the background with exponential backoff.
+type Client struct {
+	opts    Options
+	cfg     *Config
+	codec   remoting.Codec
+	network string
+	addr    string
+
+	mux    sync.RWMutex
+	conns  []*conn
+	closed bool
+	// connecting is the in-flight Connect, concurrent callers wait for it
+	connecting *connectCall
+
+	next      atomic.Uint32
+	heartbeat *time.Ticker
+	done      chan struct{}
+}
+
+// NewClient create client
+func NewClient(opt Options) *Client {
+	if opt.ConnectTimeout == 0 {
+		opt.ConnectTimeout = 3 * time.Second
+	}
+	if opt.RequestTimeout == 0 {
+		opt.RequestTimeout = 3 * time.Second
+	}
+	return &Client{
+		opts: opt,
+		done: make(chan struct{}),
+	}
+}
+
+func (c *Client) SetExchangeClient(client *remoting.ExchangeClient) {
+}
+
+type connectCall struct {
+	done chan struct{}
+	err  error
+}
+
+// Connect dials all connections, it succeeds if any connection is established.
+// Concurrent callers share the result of the in-flight dial, a failed Connect can be retried.
+func (c *Client) Connect(url *common.URL) error {
+	c.mux.Lock()
+	if c.closed {
+		c.mux.Unlock()
+		return errClientClosed
+	}
+	if call := c.connecting; call != nil {
+		c.mux.Unlock()
+		<-call.done
+		return call.err
+	}
+	if c.conns != nil {
+		c.mux.Unlock()
+		return nil
+	}
+	call := &connectCall{done: make(chan struct{})}
+	c.connecting = call
+	c.cfg = ConfigFromURL(url)
+	c.codec = remoting.GetCodec(url.Protocol)
+	c.network, c.addr = dialTarget(url)
+	c.conns = make([]*conn, c.cfg.ConnectionNum)
+	c.mux.Unlock()
+
+	call.err = c.connect()
+	c.mux.Lock()
+	c.connecting = nil
+	c.mux.Unlock()
+	close(call.done)
+	return call.err
+}
+
+func (c *Client) connect() error {
+	var (
+		lastErr error
+		failed  []int
+	)
+	for i := range c.conns {
+		if err := c.dial(i); err != nil {
+			lastErr = err
+			failed = append(failed, i)
+		}
+	}
+	if len(failed) == len(c.conns) {
+		logger.Errorf("[Native] try to connect server %v failed for : %v", c.addr, lastErr)
+		c.mux.Lock()
+		c.conns = nil
+		c.mux.Unlock()
+		return perrors.WithStack(lastErr)
+	}
+	for _, i := range failed {
+		go c.reconnect(i)
+	}
+
+	heartbeat := time.NewTicker(c.cfg.HeartbeatPeriod)
+	c.mux.Lock()
+	c.heartbeat = heartbeat
+	c.mux.Unlock()
+	go c.heartbeatLoop(heartbeat)
+	return nil
+}
+
+// Close close network connection
+func (c *Client) Close() {
+	c.mux.Lock()
+	if c.closed {
+		c.mux.Unlock()
+		return
+	}
+	c.closed = true
+	conns := c.conns
+	c.conns = nil
+	heartbeat := c.heartbeat
+	close(c.done)
+	c.mux.Unlock()
+
+	if heartbeat != nil {
+		heartbeat.Stop()
+	}
+	for _, cn := range conns {
+		if cn != nil {
+			cn.close(errClientClosed)
+		}
+	}
+}
+
+// Request send request
+func (c *Client) Request(request *remoting.Request, timeout time.Duration, response *remoting.PendingResponse) error {
+	if timeout <= 0 {
+		timeout = c.opts.RequestTimeout
+	}
+	cn, err := c.selectConn()
+	if err != nil {
+		return err
+	}
+	buf, err := c.codec.EncodeRequest(request)
+	if err != nil {
+		return perrors.WithStack(err)
+	}
+	if buf.Len()-impl.HEADER_LENGTH > c.cfg.MaxMsgLen {
+		return perrors.Errorf("Data length %d too large, max payload %d", buf.Len()-impl.HEADER_LENGTH, c.cfg.MaxMsgLen)
+	}
+
+	twoWay := request.TwoWay && response != nil
+	if twoWay {
+		cn.addPending(request.ID)
+	}
+	if err = cn.write(buf.Bytes(), timeout); err != nil {
+		cn.removePending(request.ID)
+		return perrors.WithStack(err)
+	}
+	if !twoWay || response.Callback != nil {
+		return nil
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		cn.removePending(request.ID)
+		return perrors.WithStack(errClientReadTimeout)
+	case <-response.Done:
+		return perrors.WithStack(response.Err)
+	}
+}
+
+// IsAvailable returns true if any connection is established.
+func (c *Client) IsAvailable() bool {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	if c.closed {
+		return false
+	}
+	for _, cn := range c.conns {
+		if cn != nil && !cn.closed() {
+			return true
+		}
+	}
+	return false
+}
+
+// selectConn picks the connections round robin, skipping the broken ones.
+func (c *Client) selectConn() (*conn, error) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	if c.closed {
+		return nil, errClientClosed
+	}
+	n := len(c.conns)
+	start := int(c.next.Add(1))
+	for i := 0; i < n; i++ {
+		cn := c.conns[(start+i)%n]
+		if cn != nil && !cn.closed() {
+			return cn, nil
+		}
+	}
+	return nil, errNoConnection
+}
+
+func (c *Client) dial(slot int) error {
+	raw, err := net.DialTimeout(c.network, c.addr, c.opts.ConnectTimeout)
+	if err != nil {
+		return err
+	}
+	if tcpConn, ok := raw.(*net.TCPConn); ok {
+		_ = tcpConn.SetNoDelay(true)
+		_ = tcpConn.SetKeepAlive(true)
+	}
+	cn := newConn(raw, c.codec, c.cfg, c.onMessage, func(cn *conn, err error) {
+		c.onClose(slot, cn, err)
+	})
+
+	c.mux.Lock()
+	if c.closed {
+		c.mux.Unlock()
+		_ = raw.Close()
+		return errClientClosed
+	}
+	c.conns[slot] = cn
+	c.mux.Unlock()
+	cn.start()
+	return nil
+}
+
+// dialTarget returns the network and the address of the server of the url, which is a unix domain socket or a tcp address.
+func dialTarget(url *common.URL) (string, string) {
+	if path, ok := url.UnixSocket(); ok {
+		return "unix", path
+	}
+	return "tcp", url.Location
+}
+
+func (c *Client) onMessage(cn *conn, res *remoting.DecodeResult) {
+	if res.IsRequest {
+		// heartbeat request from server
+		req := res.Result.(*remoting.Request)
+		if !req.Event {
+			logger.Errorf("[Native] unexpected request from server: %#v", req)
+			return
+		}
+		resp := remoting.NewResponse(req.ID, req.Version)
+		resp.Status = hessian.Response_OK
+		resp.Event = req.Event
+		resp.SerialID = req.SerialID
+		resp.Version = "2.0.2"
+		reply(cn, c.codec, resp, c.cfg.WriteTimeout)
+		return
+	}
+	resp := res.Result.(*remoting.Response)
+	cn.removePending(resp.ID)
+	if resp.Event && resp.Error != nil {
+		logger.Errorf("[Native] heartbeat response from %s encounters an error: %v", cn.remoteAddr(), resp.Error)
+	}
+	resp.Handle()
+}
+
+func (c *Client) onClose(slot int, cn *conn, err error) {
+	cn.failPending(perrors.WithMessagef(errConnClosed, "%v", err))
+
+	c.mux.Lock()
+	closed := c.closed
+	if !closed && c.conns[slot] == cn {
+		c.conns[slot] = nil
+	}
+	c.mux.Unlock()
+	if closed {
+		return
+	}
+	logger.Infof("[Native] connection %s -> %s is closed: %v, reconnecting", cn.localAddr(), cn.remoteAddr(), err)
+	go c.reconnect(slot)
+}
+
+func (c *Client) reconnect(slot int) {
+	backoff := c.cfg.ReconnectBackoff
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-time.After(backoff):
+		}
+		err := c.dial(slot)
+		if err == nil || perrors.Is(err, errClientClosed) {
+			return
+		}
+		logger.Debugf("[Native] reconnect %s failed: %v", c.addr, err)
+		backoff *= 2
+		if backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+}
+
+// heartbeatLoop sends heartbeats on idle connections, and closes the connections without any package
+// read in three heartbeat periods, so the broken ones are reconnected.
+func (c *Client) heartbeatLoop(heartbeat *time.Ticker) {
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-heartbeat.C:
+		}
+		c.mux.RLock()
+		conns := make([]*conn, 0, len(c.conns))
+		for _, cn := range c.conns {
+			if cn != nil {
+				conns = append(conns, cn)
+			}
+		}
+		c.mux.RUnlock()
+
+		for _, cn := range conns {
+			idle := cn.idle()
+			if idle > 3*c.cfg.HeartbeatPeriod {
+				cn.close(perrors.Errorf("no package read in %s", idle))
+				continue
+			}
+			if idle < c.cfg.HeartbeatPeriod {
+				continue
+			}
+			req := remoting.NewRequest("2.0.2")
+			req.TwoWay = true
+			req.Event = true
+			buf, err := c.codec.EncodeRequest(req)
+			if err != nil {
+				logger.Warnf("[Native] encode heartbeat failed: %v", err)
+				continue
+			}
+			// the heartbeat unanswered in a period times out, and the pending ones are dropped once the connection is closed
+			if last := cn.lastHeartbeat.Swap(req.ID); last != 0 {
+				cn.removePending(last)
+				remoting.RemovePendingResponse(remoting.SequenceType(last))
+			}
+			remoting.AddPendingResponse(remoting.NewPendingResponse(req.ID))
+			cn.addPending(req.ID)
+			if err = cn.write(buf.Bytes(), c.cfg.WriteTimeout); err != nil {
+				cn.removePending(req.ID)
+				remoting.RemovePendingResponse(remoting.SequenceType(req.ID))
+				logger.Warnf("[Native] send heartbeat to %s failed: %v", cn.remoteAddr(), err)
+			}
+		}
+	}
+}
+
+func reply(cn *conn, codec remoting.Codec, resp *remoting.Response, timeout time.Duration) {
+	buf, err := codec.EncodeResponse(resp)
+	if err != nil {
+		logger.Errorf("[Native] encode response %s failed: %v", resp, err)
+		return
+	}
+	if err = cn.write(buf.Bytes(), timeout); err != nil {
+		logger.Errorf("[Native] write response to %s failed: %v", cn.remoteAddr(), err)
+		if perrors.Is(err, errWriteQueueFull) {
+			// the peer reads too slowly, drop the connection instead of piling up responses
+			cn.close(err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package native
+
+import (
+	"time"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+)
+
+const (
+	// url params of the native transport, they can be set by the `params` of the protocol, service and reference config
+	connectionNumKey    = "native.connection-number"
+	writeQueueSizeKey   = "native.write-queue-size"
+	maxBatchKey         = "native.max-batch"
+	maxMsgLenKey        = "native.max-msg-len"
+	writeTimeoutKey     = "native.write-timeout"
+	heartbeatPeriodKey  = "native.heartbeat-period"
+	idleTimeoutKey      = "native.idle-timeout"
+	reconnectBackoffKey = "native.reconnect-backoff"
+	poolSizeKey         = "native.pool-size"
+	queueLenKey         = "native.queue-len"
+
+	defaultConnectionNum    = 2
+	defaultWriteQueueSize   = 1024
+	defaultMaxBatch         = 64
+	defaultMaxMsgLen        = 8 * 1024 * 1024
+	defaultWriteTimeout     = 5 * time.Second
+	defaultHeartbeatPeriod  = 15 * time.Second
+	defaultIdleTimeout      = 3 * time.Minute
+	defaultReconnectBackoff = 100 * time.Millisecond
+	defaultPoolSize         = 200
+	defaultQueueLen         = 1024
+	maxReconnectBackoff     = 10 * time.Second
+	readBufferSize          = 64 * 1024
+)
+
+// Config of the native transport.
+type Config struct {
+	// ConnectionNum is the number of connections a client multiplexes requests over.
+	ConnectionNum int
+	// WriteQueueSize bounds the packages waiting to be written on a connection, a full queue blocks writers.
+	WriteQueueSize int
+	// MaxBatch is the max number of packages written by a single vectored write.
+	MaxBatch int
+	// MaxMsgLen is the max body length of a package.
+	MaxMsgLen int
+	// WriteTimeout closes a connection whose peer does not read a batch in time.
+	WriteTimeout time.Duration
+	// HeartbeatPeriod is the period of client heartbeats on idle connections.
+	HeartbeatPeriod time.Duration
+	// IdleTimeout closes a server connection without any package in time.
+	IdleTimeout time.Duration
+	// ReconnectBackoff is the initial backoff of reconnecting a broken client connection.
+	ReconnectBackoff time.Duration
+	// PoolSize is the number of server workers handling the requests.
+	PoolSize int
+	// QueueLen bounds the requests waiting for a server worker, a full queue stops reading the connections.
+	QueueLen int
+}
+
+// ConfigFromURL returns the config set by the url params, unset fields keep the defaults.
+func ConfigFromURL(url *common.URL) *Config {
+	return &Config{
+		ConnectionNum:    url.GetParamPositiveInt(connectionNumKey, defaultConnectionNum),
+		WriteQueueSize:   url.GetParamPositiveInt(writeQueueSizeKey, defaultWriteQueueSize),
+		MaxBatch:         url.GetParamPositiveInt(maxBatchKey, defaultMaxBatch),
+		MaxMsgLen:        url.GetParamPositiveInt(maxMsgLenKey, defaultMaxMsgLen),
+		WriteTimeout:     url.GetParamPositiveDuration(writeTimeoutKey, defaultWriteTimeout),
+		HeartbeatPeriod:  url.GetParamPositiveDuration(heartbeatPeriodKey, defaultHeartbeatPeriod),
+		IdleTimeout:      url.GetParamPositiveDuration(idleTimeoutKey, defaultIdleTimeout),
+		ReconnectBackoff: url.GetParamPositiveDuration(reconnectBackoffKey, defaultReconnectBackoff),
+		PoolSize:         url.GetParamPositiveInt(poolSizeKey, defaultPoolSize),
+		QueueLen:         url.GetParamPositiveInt(queueLenKey, defaultQueueLen),
+	}
+}
@@ -0,0 +1,264 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package native
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/protocol/dubbo/impl"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+var (
+	errConnClosed     = perrors.New("connection closed")
+	errWriteQueueFull = perrors.New("write queue is full, the peer reads too slowly")
+	errBadMagic       = perrors.New("bad magic number of dubbo package")
+	errMsgTooLarge    = perrors.New("package is too large")
+)
+
+// conn is a connection carrying dubbo packages. Writers enqueue encoded
+// packages into a bounded queue, a single goroutine drains the queue and
+// writes as many packages as are ready by one vectored write. A single
+// goroutine reads and decodes packages and hands them to onMessage.
+type conn struct {
+	raw   net.Conn
+	codec remoting.Codec
+	cfg   *Config
+
+	writeQ chan []byte
+	done   chan struct{}
+
+	closeOnce sync.Once
+	closeErr  error
+
+	onMessage func(*conn, *remoting.DecodeResult)
+	onClose   func(*conn, error)
+
+	// lastRead is the unix nano time of the last package read.
+	lastRead atomic.Int64
+	// lastHeartbeat is the id of the last heartbeat sent, client side only.
+	lastHeartbeat atomic.Int64
+
+	// pending are the ids of the requests waiting for responses on this connection, client side only.
+	pendingLock sync.Mutex
+	pending     map[int64]struct{}
+}
+
+func newConn(raw net.Conn, codec remoting.Codec, cfg *Config,
+	onMessage func(*conn, *remoting.DecodeResult), onClose func(*conn, error)) *conn {
+	c := &conn{
+		raw:       raw,
+		codec:     codec,
+		cfg:       cfg,
+		writeQ:    make(chan []byte, cfg.WriteQueueSize),
+		done:      make(chan struct{}),
+		onMessage: onMessage,
+		onClose:   onClose,
+		pending:   make(map[int64]struct{}),
+	}
+	c.lastRead.Store(time.Now().UnixNano())
+	return c
+}
+
+func (c *conn) start() {
+	go c.readLoop()
+	go c.writeLoop()
+}
+
+func (c *conn) localAddr() string {
+	return c.raw.LocalAddr().String()
+}
+
+func (c *conn) remoteAddr() string {
+	return c.raw.RemoteAddr().String()
+}
+
+func (c *conn) idle() time.Duration {
+	return time.Since(time.Unix(0, c.lastRead.Load()))
+}
+
+func (c *conn) closed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// write enqueues an encoded package, it blocks at most timeout while the queue is full.
+func (c *conn) write(data []byte, timeout time.Duration) error {
+	select {
+	case <-c.done:
+		return errConnClosed
+	default:
+	}
+	select {
+	case c.writeQ <- data:
+		return nil
+	default:
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case c.writeQ <- data:
+		return nil
+	case <-c.done:
+		return errConnClosed
+	case <-timer.C:
+		return errWriteQueueFull
+	}
+}
+
+func (c *conn) writeLoop() {
+	batch := make(net.Buffers, 0, c.cfg.MaxBatch)
+	for {
+		batch = batch[:0]
+		select {
+		case data := <-c.writeQ:
+			batch = append(batch, data)
+		case <-c.done:
+			return
+		}
+	drain:
+		for len(batch) < c.cfg.MaxBatch {
+			select {
+			case data := <-c.writeQ:
+				batch = append(batch, data)
+			default:
+				break drain
+			}
+		}
+
+		// a peer that does not read in time fails the write, and the connection is closed
+		_ = c.raw.SetWriteDeadline(time.Now().Add(c.cfg.WriteTimeout))
+		// WriteTo consumes the buffers it is called on, so write a copy of the slice header
+		bufs := batch
+		if _, err := bufs.WriteTo(c.raw); err != nil {
+			c.close(perrors.WithMessage(err, "write"))
+			return
+		}
+	}
+}
+
+func (c *conn) readLoop() {
+	r := bufio.NewReaderSize(c.raw, readBufferSize)
+	header := make([]byte, impl.HEADER_LENGTH)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			c.close(err)
+			return
+		}
+		if header[0] != impl.MAGIC_HIGH || header[1] != impl.MAGIC_LOW {
+			c.close(errBadMagic)
+			return
+		}
+		bodyLen := int(binary.BigEndian.Uint32(header[12:]))
+		if bodyLen > c.cfg.MaxMsgLen {
+			c.close(perrors.WithMessagef(errMsgTooLarge, "body length %d, max %d", bodyLen, c.cfg.MaxMsgLen))
+			return
+		}
+		pkg := make([]byte, impl.HEADER_LENGTH+bodyLen)
+		copy(pkg, header)
+		if _, err := io.ReadFull(r, pkg[impl.HEADER_LENGTH:]); err != nil {
+			c.close(err)
+			return
+		}
+		c.lastRead.Store(time.Now().UnixNano())
+
+		res, err := c.decode(pkg)
+		if err != nil {
+			logger.Warnf("[Native] decode package from %s failed: %v", c.remoteAddr(), err)
+			continue
+		}
+		if res == nil || res.Result == nil {
+			continue
+		}
+		c.onMessage(c, res)
+	}
+}
+
+// decode decodes a whole package, a package that can not be decoded is dropped without breaking the connection.
+func (c *conn) decode(pkg []byte) (res *remoting.DecodeResult, err error) {
+	defer func() {
+		// the codec panics on a response whose request is gone, e.g. timed out
+		if e := recover(); e != nil {
+			res, err = nil, perrors.Errorf("decode panic: %v", e)
+		}
+	}()
+	res, _, err = c.codec.Decode(pkg)
+	return res, err
+}
+
+func (c *conn) close(err error) {
+	c.closeOnce.Do(func() {
+		c.closeErr = err
+		close(c.done)
+		_ = c.raw.Close()
+		if c.onClose != nil {
+			c.onClose(c, err)
+		}
+	})
+}
+
+func (c *conn) addPending(id int64) {
+	c.pendingLock.Lock()
+	c.pending[id] = struct{}{}
+	c.pendingLock.Unlock()
+}
+
+func (c *conn) removePending(id int64) {
+	c.pendingLock.Lock()
+	delete(c.pending, id)
+	c.pendingLock.Unlock()
+}
+
+// failPending completes all requests waiting on the connection with err, so callers do not wait for the timeout.
+func (c *conn) failPending(err error) {
+	c.pendingLock.Lock()
+	ids := make([]int64, 0, len(c.pending))
+	for id := range c.pending {
+		ids = append(ids, id)
+	}
+	c.pending = make(map[int64]struct{})
+	c.pendingLock.Unlock()
+
+	for _, id := range ids {
+		if remoting.GetPendingResponse(remoting.SequenceType(id)) == nil {
+			continue
+		}
+		resp := remoting.NewResponse(id, "2.0.2")
+		resp.Error = err
+		resp.Handle()
+	}
+}
@@ -0,0 +1,352 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package native_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	_ "dubbo.apache.org/dubbo-go/v3/protocol/dubbo"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+	"dubbo.apache.org/dubbo-go/v3/remoting/getty"
+	"dubbo.apache.org/dubbo-go/v3/remoting/native"
+)
+
+func freePort(t testing.TB) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func newURL(t testing.TB, port int, params string) *common.URL {
+	u, err := common.NewURL(fmt.Sprintf("dubbo://127.0.0.1:%d/com.ikurento.user.UserProvider?"+
+		"interface=com.ikurento.user.UserProvider&%s", port, params))
+	require.NoError(t, err)
+	return u
+}
+
+// echo returns the first argument, it sleeps the second argument if present.
+func echo(inv *invocation.RPCInvocation) result.RPCResult {
+	args := inv.Arguments()
+	if len(args) > 1 {
+		if d, ok := args[1].(string); ok {
+			sleep, _ := time.ParseDuration(d)
+			time.Sleep(sleep)
+		}
+	}
+	if inv.GetAttachmentWithDefaultValue(constant.RemoteAddr, "") == "" {
+		return result.RPCResult{Err: errors.New("remote address is not attached")}
+	}
+	return result.RPCResult{Rest: args[0]}
+}
+
+func request(client *remoting.ExchangeClient, url *common.URL, args ...any) result.RPCResult {
+	var inv base.Invocation = invocation.NewRPCInvocationWithOptions(
+		invocation.WithMethodName("Echo"),
+		invocation.WithArguments(args),
+		invocation.WithReply(new(string)),
+	)
+	res := result.RPCResult{}
+	_ = client.Request(&inv, url, 3*time.Second, &res)
+	return res
+}
+
+func startNative(t testing.TB, url *common.URL, handler func(*invocation.RPCInvocation) result.RPCResult) *native.Server {
+	srv := native.NewServer(url, handler)
+	srv.Start()
+	return srv
+}
+
+func TestRequest(t *testing.T) {
+	url := newURL(t, freePort(t), "native.connection-number=3")
+	srv := startNative(t, url, echo)
+	defer srv.Stop()
+
+	client := remoting.NewExchangeClient(url, native.NewClient(native.Options{}), 3*time.Second, false)
+	require.NotNil(t, client)
+	defer client.Close()
+	assert.True(t, client.IsAvailable())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			msg := fmt.Sprintf("hello %d", i)
+			res := request(client, url, msg)
+			assert.NoError(t, res.Error())
+			assert.Equal(t, msg, *res.Result().(*string))
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestSend(t *testing.T) {
+	url := newURL(t, freePort(t), "")
+	received := make(chan any, 1)
+	srv := startNative(t, url, func(inv *invocation.RPCInvocation) result.RPCResult {
+		received <- inv.Arguments()[0]
+		return result.RPCResult{}
+	})
+	defer srv.Stop()
+
+	client := remoting.NewExchangeClient(url, native.NewClient(native.Options{}), 3*time.Second, false)
+	require.NotNil(t, client)
+	defer client.Close()
+
+	var inv base.Invocation = invocation.NewRPCInvocationWithOptions(
+		invocation.WithMethodName("Echo"),
+		invocation.WithArguments([]any{"oneway"}),
+	)
+	require.NoError(t, client.Send(&inv, url, time.Second))
+	select {
+	case v := <-received:
+		assert.Equal(t, "oneway", v)
+	case <-time.After(3 * time.Second):
+		t.Fatal("the one way request is not received")
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	url := newURL(t, freePort(t), "")
+	srv := startNative(t, url, echo)
+
+	client := remoting.NewExchangeClient(url, native.NewClient(native.Options{}), 3*time.Second, false)
+	require.NotNil(t, client)
+	defer client.Close()
+	assert.NoError(t, client.Heartbeat(url, time.Second))
+
+	srv.Stop()
+	assert.Error(t, client.Heartbeat(url, time.Second))
+}
+
+func TestHeartbeatDeadPeer(t *testing.T) {
+	// the peer reads the heartbeats but never answers them
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			raw, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() { _, _ = io.Copy(io.Discard, raw) }()
+		}
+	}()
+	port := listener.Addr().(*net.TCPAddr).Port
+	url := newURL(t, port, "native.connection-number=1&native.heartbeat-period=10ms&native.reconnect-backoff=10ms")
+
+	first := remoting.NewRequest("2.0.2").ID
+	client := native.NewClient(native.Options{})
+	require.NoError(t, client.Connect(url))
+	time.Sleep(200 * time.Millisecond)
+	client.Close()
+	last := remoting.NewRequest("2.0.2").ID
+
+	// the unanswered heartbeats are not left pending
+	for id := first; id <= last; id++ {
+		assert.Nil(t, remoting.GetPendingResponse(remoting.SequenceType(id)), "heartbeat %d is pending", id)
+	}
+}
+
+func TestServerPool(t *testing.T) {
+	url := newURL(t, freePort(t), "native.pool-size=2&native.queue-len=1")
+	srv := startNative(t, url, echo)
+	defer srv.Stop()
+
+	client := remoting.NewExchangeClient(url, native.NewClient(native.Options{}), 3*time.Second, false)
+	require.NotNil(t, client)
+	defer client.Close()
+
+	// the requests beyond the workers and the queue wait to be read
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			msg := fmt.Sprintf("hello %d", i)
+			res := request(client, url, msg, "10ms")
+			assert.NoError(t, res.Error())
+			assert.Equal(t, msg, *res.Result().(*string))
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestConcurrentConnect(t *testing.T) {
+	url := newURL(t, freePort(t), "native.connection-number=3")
+	srv := startNative(t, url, echo)
+	defer srv.Stop()
+
+	client := native.NewClient(native.Options{})
+	defer client.Close()
+
+	// every caller returns after the connections are established, not only the one dialing them
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.Connect(url))
+			assert.True(t, client.IsAvailable())
+		}()
+	}
+	wg.Wait()
+}
+
+func TestMaxMsgLen(t *testing.T) {
+	url := newURL(t, freePort(t), "native.max-msg-len=1024")
+	srv := startNative(t, url, echo)
+	defer srv.Stop()
+
+	client := remoting.NewExchangeClient(url, native.NewClient(native.Options{}), 3*time.Second, false)
+	require.NotNil(t, client)
+	defer client.Close()
+
+	res := request(client, url, strings.Repeat("a", 2048))
+	assert.ErrorContains(t, res.Error(), "too large")
+
+	res = request(client, url, "small")
+	assert.NoError(t, res.Error())
+}
+
+func TestReconnect(t *testing.T) {
+	url := newURL(t, freePort(t), "native.connection-number=1&native.reconnect-backoff=10ms")
+	srv := startNative(t, url, echo)
+
+	client := remoting.NewExchangeClient(url, native.NewClient(native.Options{}), 3*time.Second, false)
+	require.NotNil(t, client)
+	defer client.Close()
+
+	// the in-flight request fails as soon as the connection is broken instead of waiting for the timeout
+	done := make(chan result.RPCResult)
+	go func() {
+		done <- request(client, url, "slow", "2s")
+	}()
+	time.Sleep(100 * time.Millisecond)
+	start := time.Now()
+	srv.Stop()
+	res := <-done
+	assert.Error(t, res.Error())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Eventually(t, func() bool {
+		return !client.IsAvailable()
+	}, time.Second, 10*time.Millisecond)
+
+	srv = startNative(t, url, echo)
+	defer srv.Stop()
+	assert.Eventually(t, client.IsAvailable, 3*time.Second, 10*time.Millisecond)
+	res = request(client, url, "again")
+	assert.NoError(t, res.Error())
+	assert.Equal(t, "again", *res.Result().(*string))
+}
+
+func TestUnixSocket(t *testing.T) {
+	url := newURL(t, 0, "")
+	url.SetUnixSocket(filepath.Join(t.TempDir(), "dubbo.sock"))
+	srv := startNative(t, url, func(inv *invocation.RPCInvocation) result.RPCResult {
+		return result.RPCResult{Rest: inv.Arguments()[0]}
+	})
+
+	client := remoting.NewExchangeClient(url, native.NewClient(native.Options{}), 3*time.Second, false)
+	require.NotNil(t, client)
+	defer client.Close()
+	res := request(client, url, "uds")
+	assert.NoError(t, res.Error())
+	assert.Equal(t, "uds", *res.Result().(*string))
+	srv.Stop()
+
+	// a stale socket left by a crashed process is replaced
+	path, _ := url.UnixSocket()
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	srv = startNative(t, url, echo)
+	srv.Stop()
+}
+
+func TestServerIdleTimeout(t *testing.T) {
+	url := newURL(t, freePort(t), "native.idle-timeout=200ms")
+	srv := startNative(t, url, echo)
+	defer srv.Stop()
+
+	raw, err := net.Dial("tcp", url.Location)
+	require.NoError(t, err)
+	defer raw.Close()
+	_ = raw.SetReadDeadline(time.Now().Add(3 * time.Second))
+	_, err = raw.Read(make([]byte, 1))
+	assert.Error(t, err)
+	if ne, ok := err.(net.Error); ok {
+		assert.False(t, ne.Timeout(), "the idle connection is not closed by the server")
+	}
+}
+
+func benchmarkTransport(b *testing.B, client remoting.Client, url *common.URL) {
+	exchangeClient := remoting.NewExchangeClient(url, client, 3*time.Second, false)
+	require.NotNil(b, exchangeClient)
+	defer exchangeClient.Close()
+	payload := strings.Repeat("a", 256)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if res := request(exchangeClient, url, payload); res.Error() != nil {
+				b.Error(res.Error())
+			}
+		}
+	})
+}
+
+func BenchmarkNative(b *testing.B) {
+	url := newURL(b, freePort(b), "")
+	srv := startNative(b, url, echo)
+	defer srv.Stop()
+	benchmarkTransport(b, native.NewClient(native.Options{}), url)
+}
+
+func BenchmarkGetty(b *testing.B) {
+	url := newURL(b, freePort(b), "")
+	srv := getty.NewServer(url, echo)
+	srv.Start()
+	defer srv.Stop()
+	time.Sleep(100 * time.Millisecond)
+	benchmarkTransport(b, getty.NewClient(getty.Options{ConnectTimeout: 3 * time.Second}), url)
+}
@@ -0,0 +1,274 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package native
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+// Server serves the dubbo protocol on plain tcp connections or a unix domain socket, TLS is not supported by the native transport.
+type Server struct {
+	cfg            *Config
+	network        string
+	addr           string
+	codec          remoting.Codec
+	requestHandler func(*invocation.RPCInvocation) result.RPCResult
+
+	mux      sync.Mutex
+	listener net.Listener
+	conns    map[*conn]struct{}
+	done     chan struct{}
+	wg       sync.WaitGroup
+
+	// tasks are the requests waiting for the workers
+	tasks chan func()
+}
+
+// NewServer create a new Server
+func NewServer(url *common.URL, handlers func(*invocation.RPCInvocation) result.RPCResult) *Server {
+	network, addr := dialTarget(url)
+	cfg := ConfigFromURL(url)
+	return &Server{
+		cfg:            cfg,
+		network:        network,
+		addr:           addr,
+		codec:          remoting.GetCodec(url.Protocol),
+		requestHandler: handlers,
+		conns:          make(map[*conn]struct{}),
+		done:           make(chan struct{}),
+		tasks:          make(chan func(), cfg.QueueLen),
+	}
+}
+
+// Start listens on the address of the url, it panics if the address can not be listened like the getty server.
+// A stale unix domain socket left by a previous process is removed before listening.
+func (s *Server) Start() {
+	if s.network == "unix" {
+		if fi, err := os.Stat(s.addr); err == nil && fi.Mode()&os.ModeSocket != 0 {
+			_ = os.Remove(s.addr)
+		}
+	}
+	listener, err := net.Listen(s.network, s.addr)
+	if err != nil {
+		panic(fmt.Sprintf("[Native] listen %s failed: %v", s.addr, err))
+	}
+	s.mux.Lock()
+	s.listener = listener
+	s.mux.Unlock()
+
+	s.wg.Add(2)
+	go s.acceptLoop(listener)
+	go s.idleLoop()
+	// the workers are not waited by Stop, so that a slow handler does not delay it
+	for i := 0; i < s.cfg.PoolSize; i++ {
+		go s.worker()
+	}
+	logger.Debugf("[Native] server bind addr{%s} ok!", listener.Addr())
+}
+
+// Addr returns the listened address, it is useful when the server listens on port 0.
+func (s *Server) Addr() string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.listener == nil {
+		return s.addr
+	}
+	return s.listener.Addr().String()
+}
+
+// Stop dubbo server
+func (s *Server) Stop() {
+	s.mux.Lock()
+	select {
+	case <-s.done:
+		s.mux.Unlock()
+		return
+	default:
+	}
+	close(s.done)
+	if s.listener != nil {
+		_ = s.listener.Close()
+	}
+	conns := make([]*conn, 0, len(s.conns))
+	for cn := range s.conns {
+		conns = append(conns, cn)
+	}
+	s.mux.Unlock()
+
+	for _, cn := range conns {
+		cn.close(perrors.New("server stopped"))
+	}
+	s.wg.Wait()
+}
+
+func (s *Server) acceptLoop(listener net.Listener) {
+	defer s.wg.Done()
+	var delay time.Duration
+	for {
+		raw, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				// back off on temporary errors like running out of file descriptors
+				if delay == 0 {
+					delay = 5 * time.Millisecond
+				} else if delay *= 2; delay > time.Second {
+					delay = time.Second
+				}
+				logger.Warnf("[Native] accept error: %v, retrying in %s", err, delay)
+				time.Sleep(delay)
+				continue
+			}
+			logger.Errorf("[Native] server %s stops accepting: %v", s.addr, err)
+			return
+		}
+		delay = 0
+		if tcpConn, ok := raw.(*net.TCPConn); ok {
+			_ = tcpConn.SetNoDelay(true)
+			_ = tcpConn.SetKeepAlive(true)
+		}
+
+		cn := newConn(raw, s.codec, s.cfg, s.onMessage, s.onClose)
+		s.mux.Lock()
+		select {
+		case <-s.done:
+			s.mux.Unlock()
+			_ = raw.Close()
+			return
+		default:
+		}
+		s.conns[cn] = struct{}{}
+		s.mux.Unlock()
+		logger.Debugf("[Native] server accepts new connection: %s", cn.remoteAddr())
+		cn.start()
+	}
+}
+
+// idleLoop closes the connections without any package read in IdleTimeout.
+func (s *Server) idleLoop() {
+	defer s.wg.Done()
+	period := s.cfg.IdleTimeout / 4
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+		s.mux.Lock()
+		var idle []*conn
+		for cn := range s.conns {
+			if cn.idle() > s.cfg.IdleTimeout {
+				idle = append(idle, cn)
+			}
+		}
+		s.mux.Unlock()
+		for _, cn := range idle {
+			logger.Warnf("[Native] connection from %s is idle over %s, closing it", cn.remoteAddr(), s.cfg.IdleTimeout)
+			cn.close(perrors.Errorf("idle over %s", s.cfg.IdleTimeout))
+		}
+	}
+}
+
+func (s *Server) onClose(cn *conn, err error) {
+	s.mux.Lock()
+	delete(s.conns, cn)
+	s.mux.Unlock()
+	logger.Debugf("[Native] connection from %s is closed: %v", cn.remoteAddr(), err)
+}
+
+func (s *Server) onMessage(cn *conn, res *remoting.DecodeResult) {
+	if !res.IsRequest {
+		logger.Warnf("[Native] unexpected response from client %s: %v", cn.remoteAddr(), res.Result)
+		return
+	}
+	req := res.Result.(*remoting.Request)
+
+	resp := remoting.NewResponse(req.ID, req.Version)
+	resp.Status = hessian.Response_OK
+	resp.Event = req.Event
+	resp.SerialID = req.SerialID
+	resp.Version = "2.0.2"
+
+	// heartbeat
+	if req.Event {
+		logger.Debugf("[Native] get rpc heartbeat request{%#v}", resp)
+		reply(cn, s.codec, resp, s.cfg.WriteTimeout)
+		return
+	}
+
+	invoc, ok := req.Data.(*invocation.RPCInvocation)
+	if !ok {
+		logger.Errorf("[Native] unexpected request data from %s: %#v", cn.remoteAddr(), req.Data)
+		return
+	}
+	attachments := invoc.Attachments()
+	attachments[constant.LocalAddr] = cn.localAddr()
+	attachments[constant.RemoteAddr] = cn.remoteAddr()
+
+	// the read loop goes on decoding while the request is handled, until the queue is full
+	task := func() {
+		res := s.requestHandler(invoc)
+		if !req.TwoWay {
+			return
+		}
+		resp.Result = res
+		reply(cn, s.codec, resp, s.cfg.WriteTimeout)
+	}
+	select {
+	case s.tasks <- task:
+	case <-s.done:
+	}
+}
+
+// worker handles the queued requests until the server stops.
+func (s *Server) worker() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case task := <-s.tasks:
+			task()
+		}
+	}
+}
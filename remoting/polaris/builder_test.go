@@ -19,10 +19,13 @@ package polaris
 
 import (
 	"net/url"
+	"os"
 	"testing"
 )
 
 import (
+	"github.com/polarismesh/polaris-go/api"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,6 +34,20 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 )
 
+func TestMain(m *testing.M) {
+	// keep the sdk logs out of the source tree
+	logDir, err := os.MkdirTemp("", "polaris-log")
+	if err != nil {
+		panic(err)
+	}
+	if err = api.SetLoggersDir(logDir); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	_ = os.RemoveAll(logDir)
+	os.Exit(code)
+}
+
 func TestGetPolarisConfigByUrl(t *testing.T) {
 	regurl := getRegUrl()
 	err := InitSDKContext(regurl)
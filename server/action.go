@@ -110,7 +110,7 @@ func (svcOpts *ServiceOptions) IsExport() bool {
 func getRandomPort(protocolConfigs []*global.ProtocolConfig) *list.List {
 	ports := list.New()
 	for _, proto := range protocolConfigs {
-		if len(proto.Port) > 0 {
+		if _, isUnix := common.ParseUnixSocket(proto.Ip); len(proto.Port) > 0 || isUnix {
 			continue
 		}
 
@@ -172,8 +172,9 @@ func (svcOpts *ServiceOptions) Export() error {
 			return formatErr
 		}
 
+		unixSocket, isUnix := common.ParseUnixSocket(protocolConf.Ip)
 		port := protocolConf.Port
-		if len(protocolConf.Port) == 0 {
+		if len(protocolConf.Port) == 0 && !isUnix {
 			port = nextPort.Value.(string)
 			nextPort = nextPort.Next()
 		}
@@ -206,6 +207,7 @@ func (svcOpts *ServiceOptions) Export() error {
 			// use TripleConfig to transport arguments
 			common.WithParamsValue(constant.MaxServerSendMsgSize, protocolConf.MaxServerSendMsgSize),
 			common.WithParamsValue(constant.MaxServerRecvMsgSize, protocolConf.MaxServerRecvMsgSize),
+			common.WithParamsValue(constant.TransporterKey, protocolConf.Transporter),
 
 			// TODO: remove IDL value when version 4.0.0
 			common.WithParamsValue(constant.IDLMode, isIDL),
@@ -223,6 +225,10 @@ func (svcOpts *ServiceOptions) Export() error {
 			common.WithAttribute(constant.ProtocolConfigKey, svcOpts.Protocols),
 		)
 
+		if isUnix {
+			ivkURL.SetUnixSocket(unixSocket)
+		}
+
 		if info != nil {
 			ivkURL.SetAttribute(constant.ServiceInfoKey, info)
 		}
@@ -242,7 +248,11 @@ func (svcOpts *ServiceOptions) Export() error {
 			return nil
 		}
 
-		if len(regUrls) > 0 {
+		if len(regUrls) > 0 && isUnix {
+			// a unix domain socket is reachable by local consumers only, so it is never published to registries
+			logger.Infof("The service %v on unix domain socket %s is exported locally without registries", svcConf.Interface, unixSocket)
+		}
+		if len(regUrls) > 0 && !isUnix {
 			svcOpts.cacheMutex.Lock()
 			if svcOpts.cacheProtocol == nil {
 				logger.Debugf(fmt.Sprintf("First load the registry protocol, url is {%v}!", ivkURL))
@@ -349,6 +359,10 @@ func (svcOpts *ServiceOptions) getUrlMap() url.Values {
 	urlMap.Set(constant.ClusterKey, svcConf.Cluster)
 	urlMap.Set(constant.LoadbalanceKey, svcConf.Loadbalance)
 	urlMap.Set(constant.WarmupKey, svcConf.Warmup)
+	if svcOpts.Provider != nil && svcOpts.Provider.RegisterAfterWarmup {
+		urlMap.Set(constant.RegisterAfterWarmupKey, "true")
+		urlMap.Set(constant.WarmupTimeoutKey, svcOpts.Provider.WarmupTimeout)
+	}
 	urlMap.Set(constant.RetriesKey, svcConf.Retries)
 	if svcConf.Group != "" {
 		urlMap.Set(constant.GroupKey, svcConf.Group)
@@ -405,6 +405,22 @@ func WithServerAdaptiveServiceVerbose() ServerOption {
 	}
 }
 
+// WithServerRegisterAfterWarmup delays the registry publication until the warm-up
+// hooks registered by probe.RegisterWarmup are done.
+func WithServerRegisterAfterWarmup() ServerOption {
+	return func(opts *ServerOptions) {
+		opts.Provider.RegisterAfterWarmup = true
+	}
+}
+
+// WithServerWarmupTimeout sets the longest time waiting for the warm-up hooks
+// before the registry publication, which is 3m by default.
+func WithServerWarmupTimeout(timeout time.Duration) ServerOption {
+	return func(opts *ServerOptions) {
+		opts.Provider.WarmupTimeout = timeout.String()
+	}
+}
+
 // WithServerTLSOption applies TLS options to the server configuration.
 // It iterates over the provided tls.
 // TLSOption and applies them to the ServerOptions.TLS field.
@@ -24,6 +24,7 @@ import (
 	"sort"
 	"strconv"
 	"sync"
+	"time"
 )
 
 import (
@@ -321,6 +322,15 @@ func (s *Server) Serve() error {
 	if err := s.exportInternalServices(); err != nil {
 		return err
 	}
+	if s.cfg.Provider != nil && s.cfg.Provider.RegisterAfterWarmup {
+		timeout, err := time.ParseDuration(s.cfg.Provider.WarmupTimeout)
+		if err != nil || timeout <= 0 {
+			timeout = constant.DefaultWarmupTimeout
+		}
+		if err := probe.AwaitWarmupTimeout(timeout); err != nil {
+			return errors.Wrap(err, "failed to warm up before registering service instance")
+		}
+	}
 	if err := exposed_tmp.RegisterServiceInstance(); err != nil {
 		return err
 	}
@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/spf13/cobra"
+)
+
+import (
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/bench"
+)
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Load test a dubbo or triple service",
+	Long: `Calls a method of a dubbo or triple service at a fixed QPS or concurrency for a duration, and reports
+the latency histogram and percentiles, the throughput and the errors by code. The calls are generic calls
+made through the dubbo-go client, so the filters, cluster and load balance of the client are exercised.
+The providers are given by --url, or discovered from the registry given by --r and --h.
+
+The arguments are rendered from a JSON template given by --d, '@file' reads it from a file. The template
+is a JSON array of arguments, or an object like {"types": ["java.lang.String"], "args": ["user-{{.Seq}}"]}.
+The functions randInt, randString, pick, uuid and now are available in the template.
+
+Protobuf triple services are called with the request message in JSON rendered from the template, e.g.
+{"name": "user-{{.Seq}}"}, when their descriptors are given by --protoset, a descriptor set generated by
+protoc --descriptor_set_out --include_imports, or resolved through the server reflection with --reflection.`,
+	Run: benchmark,
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+	benchCmd.Flags().String("url", "", "Provider urls, separated by ';' (e.g., tri://127.0.0.1:20000)")
+	benchCmd.Flags().String("r", "", "Registry type (e.g., zookeeper, nacos, etcdv3, polaris)")
+	benchCmd.Flags().String("h", "", "Host address of registry (e.g., 127.0.0.1:2181)")
+	benchCmd.Flags().String("ns", "", "Namespace of registry")
+	benchCmd.Flags().String("rt", "", "Discovery type of registry, interface, service or all (default all)")
+	benchCmd.Flags().String("proto", "", "Protocol of the providers, dubbo or tri")
+	benchCmd.Flags().String("i", "", "Target service interface")
+	benchCmd.Flags().String("g", "", "Target service group")
+	benchCmd.Flags().String("v", "", "Target service version")
+	benchCmd.Flags().String("method", "", "Target method")
+	benchCmd.Flags().String("d", "", "JSON template of the arguments, '@file' reads it from a file")
+	benchCmd.Flags().String("protoset", "", "Descriptor set of the protobuf service, the template renders its request message")
+	benchCmd.Flags().Bool("reflection", false, "Resolve the protobuf service through the server reflection of --url")
+	benchCmd.Flags().Float64("qps", 0, "Total calls per second, 0 calls as fast as the workers can")
+	benchCmd.Flags().Int("c", 10, "Number of concurrent workers")
+	benchCmd.Flags().Duration("duration", 10*time.Second, "How long to run, 0 runs until --n calls are made")
+	benchCmd.Flags().Uint64("n", 0, "Stop after this many calls, 0 means no limit")
+	benchCmd.Flags().Int("timeout", 3000, "Request timeout (ms)")
+	benchCmd.Flags().String("lb", "", "Load balance of the reference (e.g., random, roundrobin, p2c)")
+	benchCmd.Flags().String("cluster", "", "Cluster of the reference (e.g., failover, failfast)")
+	benchCmd.Flags().Int("retries", -1, "Retries of the cluster, negative keeps the default")
+	benchCmd.Flags().String("format", "text", "Report format, text, json or csv (one row per call)")
+	benchCmd.Flags().String("o", "", "Write the report to this file instead of stdout")
+	benchCmd.Flags().String("log-level", "warn", "Log level of the dubbo-go client")
+}
+
+func benchmark(cmd *cobra.Command, _ []string) {
+	flags := cmd.Flags()
+	str := func(name string) string {
+		v, _ := flags.GetString(name)
+		return v
+	}
+	logger.SetLoggerLevel(str("log-level"))
+
+	format := str("format")
+	if format != "text" && format != "json" && format != "csv" {
+		log.Fatalf("unknown report format %q, expect text, json or csv", format)
+	}
+	tmpl := str("d")
+	if strings.HasPrefix(tmpl, "@") {
+		content, err := os.ReadFile(tmpl[1:])
+		if err != nil {
+			log.Fatalln(err)
+		}
+		tmpl = string(content)
+	}
+
+	timeoutMs, _ := flags.GetInt("timeout")
+	retries, _ := flags.GetInt("retries")
+	reflection, _ := flags.GetBool("reflection")
+	target := &bench.Target{
+		URL:           str("url"),
+		Registry:      str("r"),
+		Address:       str("h"),
+		Namespace:     str("ns"),
+		RegistryType:  str("rt"),
+		Protocol:      str("proto"),
+		Interface:     str("i"),
+		Group:         str("g"),
+		Version:       str("v"),
+		Method:        str("method"),
+		Timeout:       time.Duration(timeoutMs) * time.Millisecond,
+		LoadBalance:   str("lb"),
+		Cluster:       str("cluster"),
+		Retries:       retries,
+		DescriptorSet: str("protoset"),
+		Reflection:    reflection,
+	}
+	var invoke bench.Invoker
+	if target.DescriptorSet != "" || target.Reflection {
+		payload, err := bench.NewMessagePayload(tmpl)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		invoke, err = bench.NewProtobufInvoker(target, payload)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	} else {
+		payload, err := bench.NewPayload(tmpl)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if _, _, err = payload.Render(0); err != nil {
+			log.Fatalln(err)
+		}
+		invoke, err = bench.NewInvoker(target, payload)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	opts := bench.Options{}
+	opts.QPS, _ = flags.GetFloat64("qps")
+	opts.Concurrency, _ = flags.GetInt("c")
+	opts.Duration, _ = flags.GetDuration("duration")
+	opts.Requests, _ = flags.GetUint64("n")
+
+	// an interrupt stops the benchmark early and still reports the completed calls
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	report, err := bench.Run(ctx, opts, invoke)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	var out io.Writer = os.Stdout
+	if path := str("o"); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		defer f.Close()
+		out = f
+	}
+	switch format {
+	case "json":
+		err = report.WriteJSON(out)
+	case "csv":
+		err = report.WriteCSV(out)
+	default:
+		err = report.WriteText(out)
+	}
+	if err != nil {
+		log.Fatalln(fmt.Errorf("write report: %w", err))
+	}
+}
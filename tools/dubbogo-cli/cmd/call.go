@@ -58,7 +58,7 @@ func init() {
 
 	callCmd.Flags().StringVarP(&host, "h", "", "localhost", "target server host")
 	callCmd.Flags().IntVarP(&port, "p", "", 8080, "target server port")
-	callCmd.Flags().StringVarP(&protocolName, "proto", "", "dubbo", "transfer protocol")
+	callCmd.Flags().StringVarP(&protocolName, "proto", "", "dubbo", "transfer protocol, dubbo or tri")
 	callCmd.Flags().StringVarP(&InterfaceID, "i", "", "com", "target service registered interface")
 	callCmd.Flags().StringVarP(&version, "v", "", "", "target service version")
 	callCmd.Flags().StringVarP(&group, "g", "", "", "target service group")
@@ -68,7 +68,11 @@ func init() {
 	callCmd.Flags().IntVarP(&timeout, "timeout", "", 3000, "request timeout (ms)")
 }
 
-func call(_ *cobra.Command, _ []string) {
+func call(cmd *cobra.Command, _ []string) {
+	if protocolName == tripleProtocol {
+		callTriple(cmd)
+		return
+	}
 	checkParam()
 	reqPkg := json_register.RegisterStructFromFile(sendObjFilePath)
 	recvPkg := json_register.RegisterStructFromFile(recvObjFilePath)
@@ -0,0 +1,142 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/generic"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/triple"
+)
+
+const tripleProtocol = "tri"
+
+var (
+	data           string
+	headers        []string
+	list           bool
+	describe       bool
+	tripleTLS      bool
+	tripleInsecure bool
+)
+
+func init() {
+	callCmd.Flags().StringVarP(&data, "d", "", "", "tri: JSON request body, '@' reads the request messages from stdin")
+	callCmd.Flags().StringArrayVarP(&headers, "header", "", nil, "tri: request header in 'name: value' format, may be repeated")
+	callCmd.Flags().BoolVarP(&list, "list", "", false, "tri: list the services of the server, or the methods of the service given by -i")
+	callCmd.Flags().BoolVarP(&describe, "describe", "", false, "tri: describe the service given by -i, or its method given by -method, and the message schemas")
+	callCmd.Flags().BoolVarP(&tripleTLS, "tls", "", false, "tri: connect over TLS")
+	callCmd.Flags().BoolVarP(&tripleInsecure, "insecure", "", false, "tri: skip verification of the server certificate")
+}
+
+// callTriple calls a triple service, the services, methods and message
+// schemas are discovered through the server reflection service.
+func callTriple(cmd *cobra.Command) {
+	if err := runTriple(cmd, os.Stdin, os.Stdout); err != nil {
+		triple.PrintError(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func runTriple(cmd *cobra.Command, stdin io.Reader, stdout io.Writer) error {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	scheme := "http"
+	var tlsConfig *tls.Config
+	if tripleTLS {
+		scheme = "https"
+		tlsConfig = &tls.Config{InsecureSkipVerify: tripleInsecure}
+	}
+	httpClient := triple.NewHTTPClient(tlsConfig)
+	baseURL := scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port))
+
+	source := triple.NewReflectionSource(httpClient, baseURL)
+
+	if list && !cmd.Flags().Changed("i") {
+		services, err := source.(generic.ServiceLister).ListServices(ctx)
+		if err != nil {
+			return err
+		}
+		for _, svc := range services {
+			_, _ = fmt.Fprintln(stdout, svc)
+		}
+		return nil
+	}
+
+	cli, err := triple.NewClient(ctx, source, httpClient, baseURL, InterfaceID, group, version)
+	if err != nil {
+		return err
+	}
+	switch {
+	case list:
+		methods := cli.Service().Methods()
+		for i := 0; i < methods.Len(); i++ {
+			_, _ = fmt.Fprintln(stdout, methods.Get(i).FullName())
+		}
+		return nil
+	case describe:
+		return cli.Describe(stdout, method)
+	case method == "":
+		return perrors.New("-method value not found")
+	}
+
+	header, err := parseHeaders(headers)
+	if err != nil {
+		return err
+	}
+	in := stdin
+	if data != "@" {
+		in = strings.NewReader(data)
+	}
+	return cli.Invoke(tri.NewOutgoingContext(ctx, header), method, in, stdout)
+}
+
+func parseHeaders(values []string) (http.Header, error) {
+	header := make(http.Header, len(values))
+	for _, v := range values {
+		name, value, ok := strings.Cut(v, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, perrors.Errorf("invalid header %q, want 'name: value'", v)
+		}
+		header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return header, nil
+}
@@ -18,10 +18,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 import (
@@ -31,95 +34,138 @@ import (
 )
 
 import (
-	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/metadata"
-	_ "dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/metadata/zookeeper"
+	_ "dubbo.apache.org/dubbo-go/v3/metadata/report/etcd"
+	_ "dubbo.apache.org/dubbo-go/v3/metadata/report/nacos"
+	_ "dubbo.apache.org/dubbo-go/v3/metadata/report/zookeeper"
+	_ "dubbo.apache.org/dubbo-go/v3/protocol/dubbo"
+	_ "dubbo.apache.org/dubbo-go/v3/protocol/triple"
+	_ "dubbo.apache.org/dubbo-go/v3/registry/etcdv3"
+	_ "dubbo.apache.org/dubbo-go/v3/registry/nacos"
+	_ "dubbo.apache.org/dubbo-go/v3/registry/polaris"
+	_ "dubbo.apache.org/dubbo-go/v3/registry/zookeeper"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/discovery"
 )
 
 // showCmd represents the show command
 var showCmd = &cobra.Command{
 	Use:   "show",
-	Short: "Show interfaces and methods from registry or metadata center",
-	Long:  `Displays available interfaces and their methods from the specified registry or metadata center.`,
-	Run:   show,
+	Short: "Show providers, their metadata and methods from registry",
+	Long: `Displays the provider instances registered in the specified registry, with the metadata revision,
+exported protocols and ports, and the services and methods of each instance. Application-level
+discovery is used by default, interface-level discovery is used when an interface is given by --i.`,
+	Run: show,
 }
 
 func init() {
 	rootCmd.AddCommand(showCmd)
-	showCmd.Flags().String("r", "", "Registry type (e.g., zookeeper)")
-	showCmd.Flags().String("mc", "", "Metadata center type (e.g., zookeeper)")
-	showCmd.Flags().String("h", "", "Host address of registry or metadata center (e.g., 127.0.0.1:2181)")
+	showCmd.Flags().String("r", "", "Registry type (e.g., zookeeper, nacos, etcdv3, polaris)")
+	showCmd.Flags().String("h", "", "Host address of registry (e.g., 127.0.0.1:2181)")
+	showCmd.Flags().String("ns", "", "Namespace of registry")
+	showCmd.Flags().String("rg", "", "Group of registry")
+	showCmd.Flags().String("username", "", "Username of registry and metadata center")
+	showCmd.Flags().String("password", "", "Password of registry and metadata center")
+	showCmd.Flags().String("mc", "", "Metadata center type, defaults to the registry type (e.g., zookeeper, nacos, etcdv3)")
+	showCmd.Flags().String("mh", "", "Host address of metadata center, defaults to the registry address")
+	showCmd.Flags().StringSlice("app", nil, "Only show these applications")
+	showCmd.Flags().String("i", "", "Show the providers of this interface by interface-level discovery")
+	showCmd.Flags().String("g", "", "Group of the interface")
+	showCmd.Flags().String("v", "", "Version of the interface")
+	showCmd.Flags().Int("timeout", 5000, "Registry and metadata center timeout (ms)")
+	showCmd.Flags().Int("wait", 3000, "How long interface-level discovery waits for providers (ms)")
+	showCmd.Flags().Bool("json", false, "Print the providers as JSON")
 }
 
 func show(cmd *cobra.Command, _ []string) {
-	var (
-		methodsMap map[string][]string
-		err        error
-	)
-
-	registry, _ := cmd.Flags().GetString("r")
-	metadataCenter, _ := cmd.Flags().GetString("mc")
-	host, _ := cmd.Flags().GetString("h")
+	flags := cmd.Flags()
+	opts := &discovery.Options{}
+	opts.Registry, _ = flags.GetString("r")
+	opts.Address, _ = flags.GetString("h")
+	opts.Namespace, _ = flags.GetString("ns")
+	opts.Group, _ = flags.GetString("rg")
+	opts.Username, _ = flags.GetString("username")
+	opts.Password, _ = flags.GetString("password")
+	opts.MetadataCenter, _ = flags.GetString("mc")
+	opts.MetadataAddress, _ = flags.GetString("mh")
+	opts.Applications, _ = flags.GetStringSlice("app")
+	opts.Interface, _ = flags.GetString("i")
+	opts.ServiceGroup, _ = flags.GetString("g")
+	opts.ServiceVersion, _ = flags.GetString("v")
+	timeoutMs, _ := flags.GetInt("timeout")
+	opts.Timeout = time.Duration(timeoutMs) * time.Millisecond
+	waitMs, _ := flags.GetInt("wait")
+	opts.Wait = time.Duration(waitMs) * time.Millisecond
+	asJSON, _ := flags.GetBool("json")
 
 	// Validate inputs
-	if registry == "" && metadataCenter == "" {
-		log.Println("Error: At least one of --r (registry) or --mc (metadata center) must be specified")
-		return
+	if opts.Registry == "" {
+		log.Fatalln("Error: Registry (--r) is required")
 	}
-	if host == "" {
-		log.Println("Error: Host (--h) is required")
-		return
+	if opts.Address == "" {
+		log.Fatalln("Error: Host (--h) is required")
 	}
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Interface", "Method"})
+	instances, err := discovery.List(opts)
+	if err != nil {
+		log.Fatalf("Failed to fetch providers from %s: %v", opts.Registry, err)
+	}
 
-	if registry != "" {
-		registryFactory, ok := metadata.GetFactory(registry)
-		if !ok {
-			log.Printf("Error: Registry type '%s' is not supported", registry)
-			return
+	if asJSON {
+		if instances == nil {
+			instances = []*discovery.Instance{}
 		}
-		// Pass the raw host address instead of constructing a URL
-		hostArg := []string{host}
-		methodsMap, err = registryFactory("dubbogo-cli", hostArg).ShowRegistryCenterChildren()
+		b, err := json.MarshalIndent(instances, "", "  ")
 		if err != nil {
-			log.Printf("Failed to fetch registry data from %s: %v", registry, err)
-			fmt.Printf("======================\n")
-			fmt.Printf("Registry (%s): No data available\n", registry)
-			fmt.Printf("======================\n")
-			return
-		}
-		fmt.Printf("======================\n")
-		fmt.Printf("Registry (%s):\n", registry)
-		if len(methodsMap) == 0 {
-			fmt.Println("No interfaces found")
-		} else {
-			for k, v := range methodsMap {
-				table.Append([]string{k, strings.Join(v, ", ")})
-			}
-			table.Render()
+			log.Fatalln(err)
 		}
-		fmt.Printf("======================\n")
+		fmt.Println(string(b))
+		return
 	}
 
-	if metadataCenter != "" {
-		metadataCenterFactory, ok := metadata.GetFactory(metadataCenter)
-		if !ok {
-			log.Printf("Error: Metadata center type '%s' is not supported", metadataCenter)
-			return
+	fmt.Printf("======================\n")
+	fmt.Printf("Registry (%s):\n", opts.Registry)
+	if len(instances) == 0 {
+		fmt.Println("No providers found")
+		fmt.Printf("======================\n")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"App", "Instance", "Revision", "Endpoints", "Interface", "Methods"})
+	var failed []*discovery.Instance
+	for _, ins := range instances {
+		if ins.Error != "" {
+			failed = append(failed, ins)
 		}
-		hostArg := []string{host}
-		methodsMap, err = metadataCenterFactory("dubbogo-cli", hostArg).ShowMetadataCenterChildren()
-		if err != nil {
-			log.Printf("Error fetching metadata center data: %v", err)
-			return
+		endpoints := make([]string, 0, len(ins.Endpoints))
+		for _, ep := range ins.Endpoints {
+			endpoints = append(endpoints, strings.TrimPrefix(ep.Protocol+":"+strconv.Itoa(ep.Port), ":"))
 		}
-		fmt.Printf("======================\n")
-		fmt.Printf("MetadataCenter (%s):\n", metadataCenter)
-		for k, v := range methodsMap {
-			table.Append([]string{k, strings.Join(v, ", ")})
+		row := []string{ins.App, ins.Address, ins.Revision, strings.Join(endpoints, ", ")}
+		if len(ins.Services) == 0 {
+			table.Append(append(row, "-", "-"))
+			continue
 		}
-		table.Render()
-		fmt.Printf("======================\n")
+		for _, svc := range ins.Services {
+			table.Append(append(row, serviceName(svc), strings.Join(svc.Methods, ", ")))
+		}
+	}
+	table.Render()
+	for _, ins := range failed {
+		fmt.Printf("%s %s: %s\n", ins.App, ins.Address, ins.Error)
+	}
+	fmt.Printf("======================\n")
+}
+
+// serviceName formats a service as group/interface:version@protocol
+func serviceName(svc discovery.Service) string {
+	name := svc.Interface
+	if svc.Group != "" {
+		name = svc.Group + "/" + name
+	}
+	if svc.Version != "" {
+		name += ":" + svc.Version
+	}
+	if svc.Protocol != "" {
+		name += "@" + svc.Protocol
 	}
+	return name
 }
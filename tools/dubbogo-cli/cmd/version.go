@@ -27,7 +27,6 @@ import (
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/constant"
-	_ "dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/metadata/zookeeper"
 )
 
 // versionCmd represents the version command
@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bench
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Options controls how a benchmark drives the target.
+type Options struct {
+	// Concurrency is the number of workers issuing calls.
+	Concurrency int
+	// QPS is the total rate of calls, zero makes the workers call back to back.
+	QPS float64
+	// Duration stops the benchmark after it elapses, zero runs until Requests are made.
+	Duration time.Duration
+	// Requests stops the benchmark after this many calls, zero means no limit.
+	Requests uint64
+}
+
+// Invoker makes call number seq to the target.
+type Invoker func(ctx context.Context, seq uint64) error
+
+// Result is the outcome of one call.
+type Result struct {
+	Seq     uint64
+	Start   time.Time
+	Latency time.Duration
+	Code    string
+	Err     error
+}
+
+// Run drives invoke as configured by opts until the duration elapses, the requests are made or
+// ctx is done, then reports the calls that completed. Calls in flight when the duration elapses
+// are waited for, calls interrupted by ctx are left out.
+func Run(ctx context.Context, opts Options, invoke Invoker) (*Report, error) {
+	if opts.Concurrency <= 0 {
+		return nil, errors.New("concurrency must be positive")
+	}
+	if opts.QPS < 0 {
+		return nil, errors.New("qps must not be negative")
+	}
+	if opts.Duration <= 0 && opts.Requests == 0 {
+		return nil, errors.New("either a duration or a number of requests is required")
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if opts.Duration > 0 {
+		runCtx, cancel = context.WithTimeout(runCtx, opts.Duration)
+		defer cancel()
+	}
+
+	var tokens <-chan struct{}
+	if opts.QPS > 0 {
+		tokens = pace(runCtx, opts.QPS)
+	}
+
+	var (
+		seq     atomic.Uint64
+		wg      sync.WaitGroup
+		results = make([][]Result, opts.Concurrency)
+	)
+	start := time.Now()
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for {
+				if tokens != nil {
+					select {
+					case <-runCtx.Done():
+						return
+					case <-tokens:
+					}
+				} else if runCtx.Err() != nil {
+					return
+				}
+				n := seq.Add(1)
+				if opts.Requests > 0 && n > opts.Requests {
+					cancel()
+					return
+				}
+				begin := time.Now()
+				err := invoke(ctx, n)
+				latency := time.Since(begin)
+				if err != nil && ctx.Err() != nil {
+					return
+				}
+				results[i] = append(results[i], Result{Seq: n, Start: begin, Latency: latency, Code: Code(err), Err: err})
+			}
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	var all []Result
+	for _, r := range results {
+		all = append(all, r...)
+	}
+	return newReport(start, elapsed, all), nil
+}
+
+// pace emits a token every 1/qps seconds until ctx is done. Tokens are scheduled against the start
+// time, so a late tick is caught up by the following ones instead of lowering the rate.
+func pace(ctx context.Context, qps float64) <-chan struct{} {
+	tokens := make(chan struct{})
+	interval := time.Duration(float64(time.Second) / qps)
+	go func() {
+		start := time.Now()
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+		for i := int64(0); ; i++ {
+			if wait := time.Until(start.Add(time.Duration(i) * interval)); wait > 0 {
+				timer.Reset(wait)
+				select {
+				case <-ctx.Done():
+					return
+				case <-timer.C:
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case tokens <- struct{}{}:
+			}
+		}
+	}()
+	return tokens
+}
@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bench
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+func TestRunRequests(t *testing.T) {
+	var calls atomic.Int64
+	report, err := Run(context.Background(), Options{Concurrency: 4, Requests: 100}, func(ctx context.Context, seq uint64) error {
+		calls.Add(1)
+		if seq%10 == 0 {
+			return tri.NewError(tri.CodeUnavailable, errors.New("no provider"))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), calls.Load())
+	assert.Equal(t, 100, report.Count)
+	assert.Equal(t, 10, report.Failed)
+	assert.Equal(t, map[string]int{CodeOK: 90, "unavailable": 10}, report.Codes)
+	assert.Len(t, report.Errors, 1)
+	for i, res := range report.Results {
+		assert.Equal(t, uint64(i+1), res.Seq)
+	}
+}
+
+func TestRunQPS(t *testing.T) {
+	report, err := Run(context.Background(), Options{Concurrency: 2, QPS: 200, Duration: 500 * time.Millisecond}, func(context.Context, uint64) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 100, report.Count, 30)
+}
+
+func TestRunInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	report, err := Run(ctx, Options{Concurrency: 2, Duration: time.Minute}, func(ctx context.Context, seq uint64) error {
+		if seq == 5 {
+			cancel()
+		}
+		if seq >= 5 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Failed)
+	assert.GreaterOrEqual(t, report.Count, 3)
+}
+
+func TestRunInvalidOptions(t *testing.T) {
+	nop := func(context.Context, uint64) error { return nil }
+	_, err := Run(context.Background(), Options{Duration: time.Second}, nop)
+	assert.Error(t, err)
+	_, err = Run(context.Background(), Options{Concurrency: 1}, nop)
+	assert.Error(t, err)
+	_, err = Run(context.Background(), Options{Concurrency: 1, QPS: -1, Requests: 1}, nop)
+	assert.Error(t, err)
+}
@@ -0,0 +1,241 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package bench load tests dubbo and triple services through the client package.
+package bench
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"text/template"
+	"time"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+
+	"github.com/google/uuid"
+)
+
+// Payload renders the parameter types and arguments of each call from a JSON template.
+//
+// The template is either a JSON array holding the arguments, or an object like
+//
+//	{"types": ["java.lang.String", "java.lang.Long"], "args": ["user-{{.Seq}}", {{randInt 1 100}}]}
+//
+// where types may be omitted and are then inferred from the arguments. The template is executed
+// by text/template for every call with the call sequence number as .Seq, so each request can
+// carry different data.
+type Payload struct {
+	tmpl *template.Template
+	// static is the decoded payload when the template has no actions.
+	static *call
+}
+
+type call struct {
+	types []string
+	args  []hessian.Object
+}
+
+type payloadFile struct {
+	Types []string          `json:"types"`
+	Args  []json.RawMessage `json:"args"`
+}
+
+var payloadFuncs = template.FuncMap{
+	"randInt": func(min, max int) int {
+		if max <= min {
+			return min
+		}
+		return min + rand.Intn(max-min)
+	},
+	"randString": func(n int) string {
+		const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = letters[rand.Intn(len(letters))]
+		}
+		return string(b)
+	},
+	"pick": func(items ...any) any {
+		if len(items) == 0 {
+			return ""
+		}
+		return items[rand.Intn(len(items))]
+	},
+	"uuid": func() string {
+		return uuid.NewString()
+	},
+	"now": func() int64 {
+		return time.Now().UnixMilli()
+	},
+}
+
+// NewPayload parses a payload template. An empty template calls methods without arguments.
+func NewPayload(text string) (*Payload, error) {
+	if strings.TrimSpace(text) == "" {
+		text = "[]"
+	}
+	tmpl, err := template.New("payload").Funcs(payloadFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse payload template: %w", err)
+	}
+	p := &Payload{tmpl: tmpl}
+	if !strings.Contains(text, "{{") {
+		if p.static, err = decodeCall([]byte(text)); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Render returns the parameter types and arguments of call number seq.
+func (p *Payload) Render(seq uint64) ([]string, []hessian.Object, error) {
+	if p.static != nil {
+		return p.static.types, p.static.args, nil
+	}
+	var buf bytes.Buffer
+	if err := p.tmpl.Execute(&buf, struct{ Seq uint64 }{seq}); err != nil {
+		return nil, nil, fmt.Errorf("execute payload template: %w", err)
+	}
+	c, err := decodeCall(buf.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.types, c.args, nil
+}
+
+// MessagePayload renders the request message of each call of a protobuf method from a JSON
+// template, which is executed like the template of Payload, e.g.
+//
+//	{"name": "user-{{.Seq}}", "age": {{randInt 1 100}}}
+type MessagePayload struct {
+	tmpl *template.Template
+	// static is the message when the template has no actions.
+	static []byte
+}
+
+// NewMessagePayload parses a message template. An empty template sends empty messages.
+func NewMessagePayload(text string) (*MessagePayload, error) {
+	if strings.TrimSpace(text) == "" {
+		text = "{}"
+	}
+	tmpl, err := template.New("message").Funcs(payloadFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse message template: %w", err)
+	}
+	p := &MessagePayload{tmpl: tmpl}
+	if !strings.Contains(text, "{{") {
+		p.static = []byte(text)
+	}
+	return p, nil
+}
+
+// Render returns the request message in JSON of call number seq.
+func (p *MessagePayload) Render(seq uint64) ([]byte, error) {
+	if p.static != nil {
+		return p.static, nil
+	}
+	var buf bytes.Buffer
+	if err := p.tmpl.Execute(&buf, struct{ Seq uint64 }{seq}); err != nil {
+		return nil, fmt.Errorf("execute message template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeCall(data []byte) (*call, error) {
+	var file payloadFile
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &file.Args); err != nil {
+			return nil, fmt.Errorf("decode payload %s: %w", trimmed, err)
+		}
+	} else if err := json.Unmarshal(trimmed, &file); err != nil {
+		return nil, fmt.Errorf("decode payload %s: %w", trimmed, err)
+	}
+	if len(file.Types) > 0 && len(file.Types) != len(file.Args) {
+		return nil, fmt.Errorf("payload has %d types but %d args", len(file.Types), len(file.Args))
+	}
+
+	c := &call{types: file.Types, args: make([]hessian.Object, 0, len(file.Args))}
+	for _, raw := range file.Args {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("decode payload argument %s: %w", raw, err)
+		}
+		c.args = append(c.args, convert(v))
+	}
+	if len(c.types) == 0 {
+		c.types = make([]string, 0, len(c.args))
+		for _, arg := range c.args {
+			c.types = append(c.types, javaType(arg))
+		}
+	}
+	return c, nil
+}
+
+// convert turns json numbers into int64 or float64 so that they are encoded as long or double.
+func convert(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		for i := range v {
+			v[i] = convert(v[i])
+		}
+		return v
+	case map[string]any:
+		for k := range v {
+			v[k] = convert(v[k])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// javaType infers the java type of a decoded json value. Objects carrying a "class" field are
+// typed as that class, like generic calls of the dubbo java implementation.
+func javaType(v any) string {
+	switch v := v.(type) {
+	case string:
+		return "java.lang.String"
+	case int64:
+		return "java.lang.Long"
+	case float64:
+		return "java.lang.Double"
+	case bool:
+		return "java.lang.Boolean"
+	case []any:
+		return "java.util.List"
+	case map[string]any:
+		if class, ok := v["class"].(string); ok && class != "" {
+			return class
+		}
+		return "java.util.Map"
+	default:
+		return "java.lang.Object"
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bench
+
+import (
+	"testing"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadStatic(t *testing.T) {
+	p, err := NewPayload(`["alice", 18, 1.5, true, [1, 2], {"name": "bob"}, {"class": "org.apache.User", "id": 1}]`)
+	require.NoError(t, err)
+	types, args, err := p.Render(1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"java.lang.String", "java.lang.Long", "java.lang.Double", "java.lang.Boolean",
+		"java.util.List", "java.util.Map", "org.apache.User",
+	}, types)
+	assert.Equal(t, []hessian.Object{
+		"alice", int64(18), 1.5, true, []any{int64(1), int64(2)},
+		map[string]any{"name": "bob"}, map[string]any{"class": "org.apache.User", "id": int64(1)},
+	}, args)
+}
+
+func TestPayloadTemplate(t *testing.T) {
+	p, err := NewPayload(`{"types": ["java.lang.String", "int"], "args": ["user-{{.Seq}}", {{randInt 5 6}}]}`)
+	require.NoError(t, err)
+	for _, seq := range []uint64{1, 2} {
+		types, args, err := p.Render(seq)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"java.lang.String", "int"}, types)
+		assert.Equal(t, []hessian.Object{"user-" + string(rune('0'+seq)), int64(5)}, args)
+	}
+
+	p, err = NewPayload(`["{{uuid}}", {{now}}, "{{randString 8}}", "{{pick "a" "b"}}"]`)
+	require.NoError(t, err)
+	types, args, err := p.Render(1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"java.lang.String", "java.lang.Long", "java.lang.String", "java.lang.String"}, types)
+	assert.Len(t, args[0], 36)
+	assert.Len(t, args[2], 8)
+	assert.Contains(t, []hessian.Object{"a", "b"}, args[3])
+}
+
+func TestPayloadEmpty(t *testing.T) {
+	p, err := NewPayload("")
+	require.NoError(t, err)
+	types, args, err := p.Render(1)
+	require.NoError(t, err)
+	assert.Empty(t, types)
+	assert.Empty(t, args)
+}
+
+func TestPayloadInvalid(t *testing.T) {
+	_, err := NewPayload(`{"types": ["java.lang.String"], "args": []}`)
+	assert.ErrorContains(t, err, "1 types but 0 args")
+	_, err = NewPayload(`[1,`)
+	assert.Error(t, err)
+	_, err = NewPayload(`[{{.Seq]`)
+	assert.Error(t, err)
+
+	p, err := NewPayload(`[{{if eq .Seq 2}}oops{{end}}]`)
+	require.NoError(t, err)
+	_, _, err = p.Render(1)
+	assert.NoError(t, err)
+	_, _, err = p.Render(2)
+	assert.Error(t, err)
+}
+
+func TestMessagePayload(t *testing.T) {
+	p, err := NewMessagePayload(`{"service": "svc-{{.Seq}}"}`)
+	require.NoError(t, err)
+	msg, err := p.Render(3)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"service": "svc-3"}`, string(msg))
+
+	p, err = NewMessagePayload("")
+	require.NoError(t, err)
+	msg, err = p.Render(1)
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(msg))
+
+	_, err = NewMessagePayload(`{"service": "{{.Seq"}`)
+	assert.Error(t, err)
+}
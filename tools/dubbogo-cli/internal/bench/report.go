@@ -0,0 +1,266 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bench
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/filter/throttle"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+const (
+	// CodeOK is the code of successful calls.
+	CodeOK = "ok"
+	// CodeThrottled is the code of calls rejected by the client side adaptive throttling.
+	CodeThrottled = "throttled"
+
+	histogramBuckets = 10
+	// maxErrorSamples bounds the distinct error messages kept per report.
+	maxErrorSamples = 100
+)
+
+var percentiles = []float64{10, 25, 50, 75, 90, 95, 99, 99.9}
+
+// Code classifies the error of a call. Triple errors keep their status code, context errors and
+// throttling are recognized for every protocol, anything else is unknown.
+func Code(err error) string {
+	switch {
+	case err == nil:
+		return CodeOK
+	case errors.Is(err, context.DeadlineExceeded):
+		return tri.CodeDeadlineExceeded.String()
+	case errors.Is(err, context.Canceled):
+		return tri.CodeCanceled.String()
+	case throttle.IsThrottled(err):
+		return CodeThrottled
+	default:
+		return tri.CodeOf(err).String()
+	}
+}
+
+// Report summarizes the calls of a benchmark.
+type Report struct {
+	Start   time.Time      `json:"start"`
+	Total   time.Duration  `json:"total"`
+	Count   int            `json:"count"`
+	Failed  int            `json:"failed"`
+	RPS     float64        `json:"rps"`
+	Fastest time.Duration  `json:"fastest"`
+	Slowest time.Duration  `json:"slowest"`
+	Average time.Duration  `json:"average"`
+	Latency []Percentile   `json:"latencyDistribution"`
+	Buckets []Bucket       `json:"histogram"`
+	Codes   map[string]int `json:"statusCodeDistribution"`
+	Errors  map[string]int `json:"errorDistribution"`
+	Results []Result       `json:"-"`
+}
+
+// Percentile is the latency under which a percentage of calls completed.
+type Percentile struct {
+	Percentage float64       `json:"percentage"`
+	Latency    time.Duration `json:"latency"`
+}
+
+// Bucket counts the calls slower than the previous bucket and at most as slow as Mark.
+type Bucket struct {
+	Mark      time.Duration `json:"mark"`
+	Count     int           `json:"count"`
+	Frequency float64       `json:"frequency"`
+}
+
+func newReport(start time.Time, total time.Duration, results []Result) *Report {
+	sort.Slice(results, func(i, j int) bool { return results[i].Seq < results[j].Seq })
+	r := &Report{
+		Start:   start,
+		Total:   total,
+		Count:   len(results),
+		Codes:   make(map[string]int),
+		Errors:  make(map[string]int),
+		Results: results,
+	}
+	if total > 0 {
+		r.RPS = float64(len(results)) / total.Seconds()
+	}
+	if len(results) == 0 {
+		return r
+	}
+
+	latencies := make([]time.Duration, 0, len(results))
+	var sum time.Duration
+	for _, res := range results {
+		latencies = append(latencies, res.Latency)
+		sum += res.Latency
+		r.Codes[res.Code]++
+		if res.Err != nil {
+			r.Failed++
+			msg := res.Err.Error()
+			if _, ok := r.Errors[msg]; ok || len(r.Errors) < maxErrorSamples {
+				r.Errors[msg]++
+			}
+		}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	r.Fastest = latencies[0]
+	r.Slowest = latencies[len(latencies)-1]
+	r.Average = sum / time.Duration(len(latencies))
+
+	for _, p := range percentiles {
+		idx := int(math.Ceil(p/100*float64(len(latencies)))) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		r.Latency = append(r.Latency, Percentile{Percentage: p, Latency: latencies[idx]})
+	}
+
+	step := (r.Slowest - r.Fastest) / histogramBuckets
+	marks := []time.Duration{r.Fastest}
+	if step > 0 {
+		for i := 1; i < histogramBuckets; i++ {
+			marks = append(marks, r.Fastest+time.Duration(i)*step)
+		}
+		marks = append(marks, r.Slowest)
+	}
+	counts := make([]int, len(marks))
+	b := 0
+	for _, l := range latencies {
+		for l > marks[b] {
+			b++
+		}
+		counts[b]++
+	}
+	for i, mark := range marks {
+		r.Buckets = append(r.Buckets, Bucket{Mark: mark, Count: counts[i], Frequency: float64(counts[i]) / float64(len(latencies))})
+	}
+	return r
+}
+
+// WriteText prints the report for humans.
+func (r *Report) WriteText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Summary:\n")
+	fmt.Fprintf(&b, "  Count:\t%d\n", r.Count)
+	fmt.Fprintf(&b, "  Failed:\t%d\n", r.Failed)
+	fmt.Fprintf(&b, "  Total:\t%s\n", formatDuration(r.Total))
+	fmt.Fprintf(&b, "  Slowest:\t%s\n", formatDuration(r.Slowest))
+	fmt.Fprintf(&b, "  Fastest:\t%s\n", formatDuration(r.Fastest))
+	fmt.Fprintf(&b, "  Average:\t%s\n", formatDuration(r.Average))
+	fmt.Fprintf(&b, "  Requests/sec:\t%.2f\n", r.RPS)
+
+	if len(r.Buckets) > 0 {
+		fmt.Fprintf(&b, "\nResponse time histogram:\n")
+		maxCount := 0
+		for _, bucket := range r.Buckets {
+			if bucket.Count > maxCount {
+				maxCount = bucket.Count
+			}
+		}
+		for _, bucket := range r.Buckets {
+			bar := 0
+			if maxCount > 0 {
+				bar = bucket.Count * 40 / maxCount
+			}
+			fmt.Fprintf(&b, "  %-10s [%d]\t|%s\n", formatDuration(bucket.Mark), bucket.Count, strings.Repeat("∎", bar))
+		}
+
+		fmt.Fprintf(&b, "\nLatency distribution:\n")
+		for _, p := range r.Latency {
+			fmt.Fprintf(&b, "  %s %% in %s\n", strconv.FormatFloat(p.Percentage, 'f', -1, 64), formatDuration(p.Latency))
+		}
+	}
+
+	if len(r.Codes) > 0 {
+		fmt.Fprintf(&b, "\nStatus code distribution:\n")
+		for _, code := range sortedKeys(r.Codes) {
+			fmt.Fprintf(&b, "  [%s]\t%d responses\n", code, r.Codes[code])
+		}
+	}
+	if len(r.Errors) > 0 {
+		fmt.Fprintf(&b, "\nError distribution:\n")
+		for _, msg := range sortedKeys(r.Errors) {
+			fmt.Fprintf(&b, "  [%d]\t%s\n", r.Errors[msg], msg)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON prints the report as JSON, durations are in nanoseconds.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV prints one row per call, ordered by sequence number.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"seq", "start", "latency_ns", "code", "error"}); err != nil {
+		return err
+	}
+	for _, res := range r.Results {
+		msg := ""
+		if res.Err != nil {
+			msg = res.Err.Error()
+		}
+		row := []string{
+			strconv.FormatUint(res.Seq, 10),
+			res.Start.Format(time.RFC3339Nano),
+			strconv.FormatInt(int64(res.Latency), 10),
+			res.Code,
+			msg,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return fmt.Sprintf("%.2f s", d.Seconds())
+	case d >= time.Millisecond:
+		return fmt.Sprintf("%.2f ms", float64(d)/float64(time.Millisecond))
+	default:
+		return fmt.Sprintf("%.2f µs", float64(d)/float64(time.Microsecond))
+	}
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
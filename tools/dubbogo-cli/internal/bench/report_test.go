@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/filter/throttle"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+func TestCode(t *testing.T) {
+	assert.Equal(t, CodeOK, Code(nil))
+	assert.Equal(t, "deadline_exceeded", Code(fmt.Errorf("call: %w", context.DeadlineExceeded)))
+	assert.Equal(t, "canceled", Code(context.Canceled))
+	assert.Equal(t, CodeThrottled, Code(&throttle.ThrottledError{Service: "s"}))
+	assert.Equal(t, "unimplemented", Code(tri.NewError(tri.CodeUnimplemented, errors.New("no method"))))
+	assert.Equal(t, "unknown", Code(errors.New("boom")))
+}
+
+func newTestReport() *Report {
+	start := time.Unix(1700000000, 0)
+	var results []Result
+	for i := 1; i <= 100; i++ {
+		res := Result{Seq: uint64(i), Start: start, Latency: time.Duration(i) * time.Millisecond, Code: CodeOK}
+		if i > 95 {
+			res.Err = context.DeadlineExceeded
+			res.Code = Code(res.Err)
+		}
+		results = append(results, res)
+	}
+	return newReport(start, 2*time.Second, results)
+}
+
+func TestReport(t *testing.T) {
+	r := newTestReport()
+	assert.Equal(t, 100, r.Count)
+	assert.Equal(t, 5, r.Failed)
+	assert.Equal(t, 50.0, r.RPS)
+	assert.Equal(t, time.Millisecond, r.Fastest)
+	assert.Equal(t, 100*time.Millisecond, r.Slowest)
+	assert.Equal(t, 50500*time.Microsecond, r.Average)
+	assert.Equal(t, map[string]int{CodeOK: 95, "deadline_exceeded": 5}, r.Codes)
+	assert.Equal(t, map[string]int{"context deadline exceeded": 5}, r.Errors)
+
+	got := map[float64]time.Duration{}
+	for _, p := range r.Latency {
+		got[p.Percentage] = p.Latency
+	}
+	assert.Equal(t, 50*time.Millisecond, got[50])
+	assert.Equal(t, 99*time.Millisecond, got[99])
+	assert.Equal(t, 100*time.Millisecond, got[99.9])
+
+	require.Len(t, r.Buckets, 11)
+	total := 0
+	for _, b := range r.Buckets {
+		total += b.Count
+	}
+	assert.Equal(t, 100, total)
+	assert.Equal(t, 1, r.Buckets[0].Count)
+	assert.Equal(t, 100*time.Millisecond, r.Buckets[10].Mark)
+}
+
+func TestReportSingleLatency(t *testing.T) {
+	r := newReport(time.Now(), time.Second, []Result{{Seq: 1, Latency: time.Millisecond, Code: CodeOK}})
+	require.Len(t, r.Buckets, 1)
+	assert.Equal(t, 1, r.Buckets[0].Count)
+
+	r = newReport(time.Now(), time.Second, nil)
+	assert.Zero(t, r.Count)
+	assert.Empty(t, r.Buckets)
+}
+
+func TestReportOutput(t *testing.T) {
+	r := newTestReport()
+
+	var text bytes.Buffer
+	require.NoError(t, r.WriteText(&text))
+	assert.Contains(t, text.String(), "Requests/sec:\t50.00")
+	assert.Contains(t, text.String(), "99 % in 99.00 ms")
+	assert.Contains(t, text.String(), "[deadline_exceeded]\t5 responses")
+	assert.Contains(t, text.String(), "[5]\tcontext deadline exceeded")
+
+	var js bytes.Buffer
+	require.NoError(t, r.WriteJSON(&js))
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(js.Bytes(), &decoded))
+	assert.Equal(t, float64(100), decoded["count"])
+	assert.Equal(t, float64(time.Millisecond), decoded["fastest"])
+	assert.NotContains(t, decoded, "Results")
+
+	var csv bytes.Buffer
+	require.NoError(t, r.WriteCSV(&csv))
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	require.Len(t, lines, 101)
+	assert.Equal(t, "seq,start,latency_ns,code,error", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "1,"))
+	assert.True(t, strings.HasSuffix(lines[100], ",100000000,deadline_exceeded,context deadline exceeded"))
+}
@@ -0,0 +1,203 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/generic"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+)
+
+// Target describes the service under test. Either URL or Registry is required.
+type Target struct {
+	// URL connects to the providers directly, e.g. tri://127.0.0.1:20000, several are separated by ';'.
+	URL string
+	// Registry is the registry type like zookeeper or nacos, Address is the registry address.
+	Registry     string
+	Address      string
+	Namespace    string
+	RegistryType string
+
+	Protocol  string
+	Interface string
+	Group     string
+	Version   string
+	Method    string
+
+	Timeout     time.Duration
+	LoadBalance string
+	Cluster     string
+	// Retries overrides the retries of the cluster when it is not negative.
+	Retries int
+
+	// DescriptorSet is the descriptor set file of the protobuf service called by NewProtobufInvoker,
+	// Reflection resolves the service through the server reflection of the first provider of URL instead.
+	DescriptorSet string
+	Reflection    bool
+}
+
+// NewInvoker references the target by the client package, so that the filters, routers, cluster
+// and load balance configured for it take effect, and returns an invoker making generic calls of
+// the target method with the arguments rendered by payload.
+func NewInvoker(t *Target, payload *Payload) (Invoker, error) {
+	cli, refOpts, err := newReference(t)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := cli.NewGenericService(t.Interface, refOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("reference %s: %w", t.Interface, err)
+	}
+
+	return func(ctx context.Context, seq uint64) error {
+		types, args, err := payload.Render(seq)
+		if err != nil {
+			return err
+		}
+		_, err = svc.Invoke(ctx, t.Method, types, args)
+		return err
+	}, nil
+}
+
+// NewProtobufInvoker references the protobuf triple service of the target like NewInvoker, and
+// returns an invoker calling the unary target method with the messages rendered by payload. The
+// service is resolved from the descriptor set or through the server reflection of the target.
+func NewProtobufInvoker(t *Target, payload *MessagePayload) (Invoker, error) {
+	if t.DescriptorSet == "" && !t.Reflection {
+		return nil, errors.New("either a descriptor set or reflection is required")
+	}
+	if t.Reflection && t.URL == "" {
+		return nil, errors.New("reflection requires a url")
+	}
+	target := *t
+	if target.Protocol == "" {
+		target.Protocol = constant.TriProtocol
+	}
+	cli, refOpts, err := newReference(&target)
+	if err != nil {
+		return nil, err
+	}
+
+	var source generic.DescriptorSource
+	if t.DescriptorSet != "" {
+		source, err = generic.LoadDescriptorSet(t.DescriptorSet)
+	} else {
+		url, _, _ := strings.Cut(t.URL, ";")
+		source, err = generic.NewReflectionSource(cli, client.WithURL(url))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", t.Interface, err)
+	}
+	ctx := context.Background()
+	if t.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.Timeout)
+		defer cancel()
+	}
+	svc, err := generic.NewService(ctx, cli, source, t.Interface, refOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("reference %s: %w", t.Interface, err)
+	}
+	md, err := svc.Method(t.Method)
+	if err != nil {
+		return nil, err
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return nil, fmt.Errorf("method %s is streaming, only unary methods can be benchmarked", t.Method)
+	}
+	// an invalid message fails here rather than in every call
+	msg, err := payload.Render(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = svc.NewMessage(md.Input(), msg); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, seq uint64) error {
+		msg, err := payload.Render(seq)
+		if err != nil {
+			return err
+		}
+		_, err = svc.InvokeJSON(ctx, t.Method, msg)
+		return err
+	}, nil
+}
+
+// newReference creates the client of the target and the options referring to it.
+func newReference(t *Target) (*client.Client, []client.ReferenceOption, error) {
+	if t.Interface == "" || t.Method == "" {
+		return nil, nil, errors.New("interface and method are required")
+	}
+	if t.URL == "" && t.Registry == "" {
+		return nil, nil, errors.New("either a url or a registry is required")
+	}
+
+	var cliOpts []client.ClientOption
+	if t.URL == "" {
+		regOpts := []registry.Option{registry.WithRegistry(t.Registry), registry.WithAddress(t.Address)}
+		if t.Namespace != "" {
+			regOpts = append(regOpts, registry.WithNamespace(t.Namespace))
+		}
+		switch t.RegistryType {
+		case "", constant.RegistryTypeAll:
+			regOpts = append(regOpts, registry.WithRegisterServiceAndInterface())
+		case constant.RegistryTypeInterface:
+			regOpts = append(regOpts, registry.WithRegisterInterface())
+		case constant.RegistryTypeService:
+			regOpts = append(regOpts, registry.WithRegisterService())
+		default:
+			return nil, nil, fmt.Errorf("unknown registry type %q, expect interface, service or all", t.RegistryType)
+		}
+		cliOpts = append(cliOpts, client.WithClientRegistry(regOpts...))
+	}
+	cli, err := client.NewClient(cliOpts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create client: %w", err)
+	}
+
+	refOpts := []client.ReferenceOption{client.WithGroup(t.Group), client.WithVersion(t.Version)}
+	if t.URL != "" {
+		refOpts = append(refOpts, client.WithURL(t.URL))
+	}
+	if t.Protocol != "" {
+		refOpts = append(refOpts, client.WithProtocol(t.Protocol))
+	}
+	if t.Timeout > 0 {
+		refOpts = append(refOpts, client.WithRequestTimeout(t.Timeout))
+	}
+	if t.LoadBalance != "" {
+		refOpts = append(refOpts, client.WithLoadBalance(t.LoadBalance))
+	}
+	if t.Cluster != "" {
+		refOpts = append(refOpts, client.WithCluster(t.Cluster))
+	}
+	if t.Retries >= 0 {
+		refOpts = append(refOpts, client.WithRetries(t.Retries))
+	}
+	return cli, refOpts, nil
+}
@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bench
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+import (
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+	health "dubbo.apache.org/dubbo-go/v3/protocol/triple/health/triple_health"
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection"
+	rpb "dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection/triple_reflection"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+const healthService = "grpc.health.v1.Health"
+
+// newHealthServer serves the server reflection service and a health service
+// answering SERVING for the service "ok" only.
+func newHealthServer(t *testing.T) string {
+	mux := http.NewServeMux()
+	reflectionServer := reflection.NewServer()
+	mux.Handle(rpb.ServerReflectionServerReflectionInfoProcedure, tri.NewBidiStreamHandler(
+		rpb.ServerReflectionServerReflectionInfoProcedure,
+		func(ctx context.Context, stream *tri.BidiStream) error {
+			return reflectionServer.ServerReflectionInfo(ctx, &rpb.ServerReflectionServerReflectionInfoServer{BidiStream: stream})
+		},
+	))
+	mux.Handle("/"+healthService+"/Check", tri.NewUnaryHandler(
+		"/"+healthService+"/Check",
+		func() any { return new(health.HealthCheckRequest) },
+		func(_ context.Context, req *tri.Request) (*tri.Response, error) {
+			if req.Msg.(*health.HealthCheckRequest).Service != "ok" {
+				return nil, tri.NewError(tri.CodeNotFound, nil)
+			}
+			return tri.NewResponse(&health.HealthCheckResponse{Status: health.HealthCheckResponse_SERVING}), nil
+		},
+	))
+	server := httptest.NewServer(h2c.NewHandler(mux, &http2.Server{}))
+	t.Cleanup(server.Close)
+	return "tri://" + strings.TrimPrefix(server.URL, "http://")
+}
+
+func TestNewProtobufInvoker(t *testing.T) {
+	url := newHealthServer(t)
+	target := &Target{URL: url, Interface: healthService, Method: "Check", Retries: 0, Reflection: true}
+
+	payload, err := NewMessagePayload(`{"service": "ok"}`)
+	require.NoError(t, err)
+	invoke, err := NewProtobufInvoker(target, payload)
+	require.NoError(t, err)
+	require.NoError(t, invoke(context.Background(), 1))
+
+	payload, err = NewMessagePayload(`{"service": "missing-{{.Seq}}"}`)
+	require.NoError(t, err)
+	invoke, err = NewProtobufInvoker(target, payload)
+	require.NoError(t, err)
+	assert.Equal(t, tri.CodeNotFound, tri.CodeOf(invoke(context.Background(), 1)))
+}
+
+func TestNewProtobufInvokerInvalid(t *testing.T) {
+	url := newHealthServer(t)
+	payload, err := NewMessagePayload(`{"unknown": 1}`)
+	require.NoError(t, err)
+
+	_, err = NewProtobufInvoker(&Target{URL: url, Interface: healthService, Method: "Check"}, payload)
+	assert.ErrorContains(t, err, "descriptor set or reflection")
+	_, err = NewProtobufInvoker(&Target{Registry: "zookeeper", Interface: healthService, Method: "Check", Reflection: true}, payload)
+	assert.ErrorContains(t, err, "reflection requires a url")
+	_, err = NewProtobufInvoker(&Target{URL: url, Interface: healthService, Method: "Watch", Reflection: true}, payload)
+	assert.ErrorContains(t, err, "streaming")
+	_, err = NewProtobufInvoker(&Target{URL: url, Interface: healthService, Method: "Check", Reflection: true}, payload)
+	assert.ErrorContains(t, err, "grpc.health.v1.HealthCheckRequest")
+}
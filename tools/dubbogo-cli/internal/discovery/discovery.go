@@ -0,0 +1,424 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package discovery lists the providers known to a registry. It is built on
+// the registry.ServiceDiscovery, registry.Registry and report.MetadataReport
+// extensions of dubbo-go, so every registry and metadata center dubbo-go
+// supports can be queried, with application-level or interface-level
+// discovery.
+package discovery
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/metadata"
+	"dubbo.apache.org/dubbo-go/v3/metadata/info"
+	"dubbo.apache.org/dubbo-go/v3/metadata/report"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+const (
+	defaultWait = 3 * time.Second
+	// unsubscribeWait is how long the subscription is waited for to stop
+	// before the registry is destroyed.
+	unsubscribeWait = time.Second
+
+	storageTypeRemote = "remote"
+)
+
+// Options selects the registry, the metadata center and the providers to list.
+type Options struct {
+	// Registry is the registry protocol, e.g. zookeeper, nacos, etcdv3 or polaris.
+	Registry  string
+	Address   string
+	Namespace string
+	Group     string
+	Username  string
+	Password  string
+	Timeout   time.Duration
+
+	// MetadataCenter is the metadata report protocol. Application metadata
+	// stored remotely is read from it, it defaults to the registry.
+	MetadataCenter  string
+	MetadataAddress string
+
+	// Applications limits application-level discovery to these applications.
+	Applications []string
+
+	// Interface switches to interface-level discovery of the providers of
+	// the interface exported with ServiceGroup and ServiceVersion.
+	Interface      string
+	ServiceGroup   string
+	ServiceVersion string
+	// Wait is how long interface-level discovery collects notifications.
+	Wait time.Duration
+}
+
+// Instance is a provider instance and the services it exports.
+type Instance struct {
+	App         string     `json:"app,omitempty"`
+	Address     string     `json:"address"`
+	Healthy     bool       `json:"healthy"`
+	Revision    string     `json:"revision,omitempty"`
+	StorageType string     `json:"storageType,omitempty"`
+	Endpoints   []Endpoint `json:"endpoints,omitempty"`
+	Services    []Service  `json:"services,omitempty"`
+	// Error is why the metadata of the instance could not be fetched.
+	Error string `json:"error,omitempty"`
+}
+
+// Endpoint is a protocol and port the instance is serving on.
+type Endpoint struct {
+	Protocol string `json:"protocol,omitempty"`
+	Port     int    `json:"port"`
+}
+
+// Service is an exported service and its methods.
+type Service struct {
+	Interface string   `json:"interface"`
+	Group     string   `json:"group,omitempty"`
+	Version   string   `json:"version,omitempty"`
+	Protocol  string   `json:"protocol,omitempty"`
+	Port      int      `json:"port,omitempty"`
+	Methods   []string `json:"methods,omitempty"`
+}
+
+// List returns the provider instances, found by interface-level discovery
+// when an interface is given and by application-level discovery otherwise.
+func List(opts *Options) ([]*Instance, error) {
+	if opts.Registry == "" || opts.Address == "" {
+		return nil, perrors.New("registry protocol and address are required")
+	}
+	if opts.Interface != "" {
+		return listProviders(opts)
+	}
+	return listApplications(opts)
+}
+
+func (opts *Options) registryURL(protocol string) (*common.URL, error) {
+	u, err := common.NewURL(protocol+"://"+opts.Address,
+		common.WithParamsValue(constant.RegistryKey, opts.Registry),
+		common.WithParamsValue(constant.RegistryNamespaceKey, opts.Namespace),
+		common.WithParamsValue(constant.RegistryGroupKey, opts.Group),
+		common.WithUsername(opts.Username),
+		common.WithPassword(opts.Password),
+		common.WithLocation(opts.Address),
+	)
+	if err != nil {
+		return nil, perrors.WithMessagef(err, "invalid registry address %s", opts.Address)
+	}
+	if opts.Timeout > 0 {
+		u.SetParam(constant.RegistryTimeoutKey, opts.Timeout.String())
+	}
+	return u, nil
+}
+
+// metadataReport returns the metadata center, or nil if there is no
+// metadata report extension for its protocol.
+func (opts *Options) metadataReport() report.MetadataReport {
+	protocol, address := opts.MetadataCenter, opts.MetadataAddress
+	if protocol == "" {
+		protocol = opts.Registry
+	}
+	if address == "" {
+		address = opts.Address
+	}
+	factory := extension.GetMetadataReportFactory(protocol)
+	if factory == nil {
+		return nil
+	}
+	u := common.NewURLWithOptions(
+		common.WithProtocol(protocol),
+		common.WithLocation(address),
+		common.WithUsername(opts.Username),
+		common.WithPassword(opts.Password),
+		common.WithParamsValue(constant.MetadataReportGroupKey, opts.Group),
+		common.WithParamsValue(constant.MetadataReportNamespaceKey, opts.Namespace),
+		common.WithParamsValue(constant.ClientNameKey, strings.Join([]string{constant.MetadataReportPrefix, protocol, address}, "-")),
+	)
+	if opts.Timeout > 0 {
+		u.SetParam(constant.TimeoutKey, opts.Timeout.String())
+	}
+	return factory.CreateMetadataReport(u)
+}
+
+func listApplications(opts *Options) ([]*Instance, error) {
+	u, err := opts.registryURL(constant.ServiceRegistryProtocol)
+	if err != nil {
+		return nil, err
+	}
+	sd, err := extension.GetServiceDiscovery(u)
+	if err != nil {
+		return nil, err
+	}
+	defer sd.Destroy()
+
+	apps := opts.Applications
+	if len(apps) == 0 {
+		for _, v := range sd.GetServices().Values() {
+			name := v.(string)
+			// interface-level registrations share the namespace of applications in some registries
+			if strings.HasPrefix(name, constant.ProviderCategory+":") || strings.HasPrefix(name, constant.ConsumerCategory+":") {
+				continue
+			}
+			apps = append(apps, name)
+		}
+		sort.Strings(apps)
+	}
+
+	fetcher := newMetadataFetcher(opts.metadataReport())
+	var instances []*Instance
+	for _, app := range apps {
+		found := sd.GetInstances(app)
+		sort.Slice(found, func(i, j int) bool { return found[i].GetAddress() < found[j].GetAddress() })
+		for _, ins := range found {
+			instances = append(instances, fetcher.describe(ins))
+		}
+	}
+	return instances, nil
+}
+
+// metadataFetcher fetches the metadata of instances, once per revision.
+type metadataFetcher struct {
+	report report.MetadataReport
+	rpc    func(revision string, instance registry.ServiceInstance) (*info.MetadataInfo, error)
+	cache  map[string]*info.MetadataInfo
+}
+
+func newMetadataFetcher(r report.MetadataReport) *metadataFetcher {
+	return &metadataFetcher{
+		report: r,
+		rpc:    metadata.GetMetadataFromRpc,
+		cache:  make(map[string]*info.MetadataInfo),
+	}
+}
+
+func (f *metadataFetcher) describe(ins registry.ServiceInstance) *Instance {
+	md := ins.GetMetadata()
+	res := &Instance{
+		App:         ins.GetServiceName(),
+		Address:     ins.GetAddress(),
+		Healthy:     ins.IsHealthy(),
+		Revision:    md[constant.ExportedServicesRevisionPropertyName],
+		StorageType: md[constant.MetadataStorageTypePropertyName],
+	}
+	for _, ep := range ins.GetEndPoints() {
+		res.Endpoints = append(res.Endpoints, Endpoint{Protocol: ep.Protocol, Port: ep.Port})
+	}
+	if len(res.Endpoints) == 0 && ins.GetPort() > 0 {
+		res.Endpoints = append(res.Endpoints, Endpoint{Port: ins.GetPort()})
+	}
+
+	mi, err := f.fetch(ins, res.Revision, res.StorageType)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	keys := make([]string, 0, len(mi.Services))
+	for k := range mi.Services {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		si := mi.Services[k]
+		res.Services = append(res.Services, Service{
+			Interface: si.Name,
+			Group:     si.Group,
+			Version:   si.Version,
+			Protocol:  si.Protocol,
+			Port:      si.Port,
+			Methods:   splitMethods(si.Params[constant.MethodsKey]),
+		})
+	}
+	return res
+}
+
+func (f *metadataFetcher) fetch(ins registry.ServiceInstance, revision, storageType string) (*info.MetadataInfo, error) {
+	if revision == "" || revision == "0" {
+		return nil, perrors.New("instance has no metadata revision")
+	}
+	key := ins.GetServiceName() + "/" + revision
+	if mi, ok := f.cache[key]; ok {
+		return mi, nil
+	}
+
+	var (
+		mi  *info.MetadataInfo
+		err error
+	)
+	switch {
+	case storageType == storageTypeRemote:
+		if f.report == nil {
+			return nil, perrors.New("metadata is stored in the metadata center, but no metadata center is available")
+		}
+		mi, err = f.report.GetAppMetadata(ins.GetServiceName(), revision)
+	case ins.GetMetadata()[constant.MetadataServiceURLParamsPropertyName] == "":
+		return nil, perrors.New("instance exposes no metadata service")
+	default:
+		mi, err = f.rpc(revision, ins)
+	}
+	if err != nil {
+		return nil, perrors.WithMessagef(err, "fetch metadata of revision %s", revision)
+	}
+	if mi == nil {
+		return nil, perrors.Errorf("no metadata of revision %s", revision)
+	}
+	f.cache[key] = mi
+	return mi, nil
+}
+
+func listProviders(opts *Options) ([]*Instance, error) {
+	u, err := opts.registryURL(constant.RegistryProtocol)
+	if err != nil {
+		return nil, err
+	}
+	reg, err := extension.GetRegistry(opts.Registry, u)
+	if err != nil {
+		return nil, err
+	}
+	defer reg.Destroy()
+
+	sub := common.NewURLWithOptions(
+		common.WithPath(opts.Interface),
+		common.WithInterface(opts.Interface),
+		common.WithParamsValue(constant.GroupKey, opts.ServiceGroup),
+		common.WithParamsValue(constant.VersionKey, opts.ServiceVersion),
+		common.WithParamsValue(constant.CategoryKey, constant.ProviderCategory),
+		common.WithParamsValue(constant.SideKey, common.RoleType(common.CONSUMER).Role()),
+	)
+	c := &collector{providers: make(map[string]*common.URL)}
+	// some registries load the providers synchronously, the others only notify subscribers
+	if err = reg.LoadSubscribeInstances(sub, c); err != nil {
+		return nil, err
+	}
+	if instances := c.instances(); len(instances) > 0 {
+		return instances, nil
+	}
+
+	subscribed := make(chan struct{})
+	go func() {
+		defer close(subscribed)
+		_ = reg.Subscribe(sub, c)
+	}()
+	wait := opts.Wait
+	if wait <= 0 {
+		wait = defaultWait
+	}
+	time.Sleep(wait)
+	instances := c.instances()
+
+	// stop the subscription before the registry is destroyed, a registry
+	// whose Subscribe does not return on UnSubscribe is stopped by Destroy
+	_ = reg.UnSubscribe(sub, c)
+	select {
+	case <-subscribed:
+	case <-time.After(unsubscribeWait):
+	}
+	return instances, nil
+}
+
+// collector collects the providers notified by a registry.
+type collector struct {
+	mu        sync.Mutex
+	providers map[string]*common.URL
+}
+
+// Notify implements registry.NotifyListener.
+func (c *collector) Notify(event *registry.ServiceEvent) {
+	if event == nil || event.Service == nil || event.Service.Protocol == constant.EmptyProtocol {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := event.Service.Protocol + "://" + event.Service.Location + "/" + event.Service.ServiceKey()
+	if event.Action == remoting.EventTypeDel {
+		delete(c.providers, key)
+		return
+	}
+	c.providers[key] = event.Service
+}
+
+// NotifyAll implements registry.NotifyListener.
+func (c *collector) NotifyAll(events []*registry.ServiceEvent, callback func()) {
+	c.mu.Lock()
+	c.providers = make(map[string]*common.URL, len(events))
+	c.mu.Unlock()
+	for _, event := range events {
+		c.Notify(event)
+	}
+	if callback != nil {
+		callback()
+	}
+}
+
+func (c *collector) instances() []*Instance {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0, len(c.providers))
+	for k := range c.providers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	instances := make([]*Instance, 0, len(keys))
+	for _, k := range keys {
+		u := c.providers[k]
+		port, _ := strconv.Atoi(u.Port)
+		methods := u.Methods
+		if len(methods) == 0 {
+			methods = splitMethods(u.GetParam(constant.MethodsKey, ""))
+		}
+		instances = append(instances, &Instance{
+			App:       u.GetParam(constant.ApplicationKey, ""),
+			Address:   u.Location,
+			Healthy:   true,
+			Endpoints: []Endpoint{{Protocol: u.Protocol, Port: port}},
+			Services: []Service{{
+				Interface: u.Interface(),
+				Group:     u.Group(),
+				Version:   u.Version(),
+				Protocol:  u.Protocol,
+				Port:      port,
+				Methods:   methods,
+			}},
+		})
+	}
+	return instances
+}
+
+func splitMethods(methods string) []string {
+	if methods == "" {
+		return nil
+	}
+	res := strings.Split(methods, ",")
+	sort.Strings(res)
+	return res
+}
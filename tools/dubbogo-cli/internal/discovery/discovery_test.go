@@ -0,0 +1,284 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+import (
+	gxset "github.com/dubbogo/gost/container/set"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/metadata/info"
+	"dubbo.apache.org/dubbo-go/v3/metadata/report"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+type fakeServiceDiscovery struct {
+	registry.ServiceDiscovery
+	instances map[string][]registry.ServiceInstance
+}
+
+func (sd *fakeServiceDiscovery) GetServices() *gxset.HashSet {
+	services := gxset.NewSet()
+	for name := range sd.instances {
+		services.Add(name)
+	}
+	return services
+}
+
+func (sd *fakeServiceDiscovery) GetInstances(name string) []registry.ServiceInstance {
+	return sd.instances[name]
+}
+
+func (sd *fakeServiceDiscovery) Destroy() error {
+	return nil
+}
+
+type fakeMetadataReport struct {
+	report.MetadataReport
+	calls int
+}
+
+func (r *fakeMetadataReport) GetAppMetadata(app, revision string) (*info.MetadataInfo, error) {
+	r.calls++
+	return &info.MetadataInfo{
+		App:      app,
+		Revision: revision,
+		Services: map[string]*info.ServiceInfo{
+			"com.Foo:tri": {
+				Name: "com.Foo", Group: "g", Version: "1.0.0", Protocol: "tri", Port: 20000,
+				Params: map[string]string{constant.MethodsKey: "b,a"},
+			},
+		},
+	}, nil
+}
+
+type fakeMetadataReportFactory struct {
+	report *fakeMetadataReport
+}
+
+func (f *fakeMetadataReportFactory) CreateMetadataReport(*common.URL) report.MetadataReport {
+	return f.report
+}
+
+type fakeRegistry struct {
+	registry.Registry
+	events []*registry.ServiceEvent
+	// loaded are notified by LoadSubscribeInstances, events by Subscribe
+	loaded []*registry.ServiceEvent
+
+	subscribed   atomic.Bool
+	unsubscribed chan struct{}
+	// destroyedSubscribing is whether Destroy is called before Subscribe returns
+	destroyedSubscribing atomic.Bool
+	subscribing          atomic.Bool
+}
+
+func newFakeRegistry(loaded, events []*registry.ServiceEvent) *fakeRegistry {
+	return &fakeRegistry{loaded: loaded, events: events, unsubscribed: make(chan struct{})}
+}
+
+func (r *fakeRegistry) LoadSubscribeInstances(_ *common.URL, l registry.NotifyListener) error {
+	for _, e := range r.loaded {
+		l.Notify(e)
+	}
+	return nil
+}
+
+func (r *fakeRegistry) Subscribe(_ *common.URL, l registry.NotifyListener) error {
+	r.subscribed.Store(true)
+	r.subscribing.Store(true)
+	defer r.subscribing.Store(false)
+	for _, e := range r.events {
+		l.Notify(e)
+	}
+	// like BaseRegistry, notify until the subscription is stopped
+	<-r.unsubscribed
+	return nil
+}
+
+func (r *fakeRegistry) UnSubscribe(*common.URL, registry.NotifyListener) error {
+	close(r.unsubscribed)
+	return nil
+}
+
+func (r *fakeRegistry) Destroy() {
+	r.destroyedSubscribing.Store(r.subscribing.Load())
+}
+
+func newInstance(app, address string, port int, metadata map[string]string) registry.ServiceInstance {
+	return &registry.DefaultServiceInstance{
+		ServiceName: app,
+		Host:        address,
+		Port:        port,
+		Healthy:     true,
+		Metadata:    metadata,
+	}
+}
+
+func TestListApplications(t *testing.T) {
+	remote := map[string]string{
+		constant.ExportedServicesRevisionPropertyName: "r1",
+		constant.MetadataStorageTypePropertyName:      storageTypeRemote,
+		constant.ServiceInstanceEndpoints:             `[{"port":20000,"protocol":"tri"},{"port":20880,"protocol":"dubbo"}]`,
+	}
+	local := map[string]string{
+		constant.ExportedServicesRevisionPropertyName: "r2",
+		constant.MetadataStorageTypePropertyName:      "local",
+	}
+	sd := &fakeServiceDiscovery{instances: map[string][]registry.ServiceInstance{
+		"app-b": {newInstance("app-b", "10.0.0.2", 20000, remote), newInstance("app-b", "10.0.0.1", 20000, remote)},
+		"app-a": {newInstance("app-a", "10.0.0.3", 20001, local)},
+		// an interface-level registration sharing the namespace
+		"providers:com.Foo::": {newInstance("providers:com.Foo::", "10.0.0.4", 20000, nil)},
+	}}
+	extension.SetServiceDiscovery("fake-show", func(*common.URL) (registry.ServiceDiscovery, error) {
+		return sd, nil
+	})
+	r := &fakeMetadataReport{}
+	extension.SetMetadataReportFactory("fake-show", func() report.MetadataReportFactory {
+		return &fakeMetadataReportFactory{report: r}
+	})
+
+	instances, err := List(&Options{Registry: "fake-show", Address: "127.0.0.1:2181"})
+	require.NoError(t, err)
+	require.Len(t, instances, 3)
+
+	assert.Equal(t, "app-a", instances[0].App)
+	assert.Equal(t, "r2", instances[0].Revision)
+	assert.Equal(t, []Endpoint{{Port: 20001}}, instances[0].Endpoints)
+	assert.Equal(t, "instance exposes no metadata service", instances[0].Error)
+
+	assert.Equal(t, "10.0.0.1:20000", instances[1].Address)
+	assert.Equal(t, "10.0.0.2:20000", instances[2].Address)
+	for _, ins := range instances[1:] {
+		assert.Equal(t, "r1", ins.Revision)
+		assert.Equal(t, []Endpoint{{Protocol: "tri", Port: 20000}, {Protocol: "dubbo", Port: 20880}}, ins.Endpoints)
+		assert.Equal(t, []Service{{
+			Interface: "com.Foo", Group: "g", Version: "1.0.0", Protocol: "tri", Port: 20000, Methods: []string{"a", "b"},
+		}}, ins.Services)
+	}
+	// instances of a revision share its metadata
+	assert.Equal(t, 1, r.calls)
+
+	instances, err = List(&Options{Registry: "fake-show", Address: "127.0.0.1:2181", Applications: []string{"app-a"}})
+	require.NoError(t, err)
+	assert.Len(t, instances, 1)
+}
+
+func TestMetadataFetcherRPC(t *testing.T) {
+	f := newMetadataFetcher(nil)
+	f.rpc = func(revision string, _ registry.ServiceInstance) (*info.MetadataInfo, error) {
+		return &info.MetadataInfo{Revision: revision, Services: map[string]*info.ServiceInfo{
+			"com.Bar:dubbo": {Name: "com.Bar", Protocol: "dubbo", Port: 20880},
+		}}, nil
+	}
+	ins := f.describe(newInstance("app", "10.0.0.1", 20880, map[string]string{
+		constant.ExportedServicesRevisionPropertyName: "r1",
+		constant.MetadataServiceURLParamsPropertyName: `{"protocol":"dubbo","port":"20880"}`,
+	}))
+	assert.Empty(t, ins.Error)
+	assert.Equal(t, []Service{{Interface: "com.Bar", Protocol: "dubbo", Port: 20880}}, ins.Services)
+
+	ins = f.describe(newInstance("app", "10.0.0.1", 20880, map[string]string{
+		constant.ExportedServicesRevisionPropertyName: "r2",
+		constant.MetadataStorageTypePropertyName:      storageTypeRemote,
+	}))
+	assert.Contains(t, ins.Error, "no metadata center")
+
+	ins = f.describe(newInstance("app", "10.0.0.1", 20880, nil))
+	assert.Equal(t, "instance has no metadata revision", ins.Error)
+}
+
+func TestListProviders(t *testing.T) {
+	provider := func(address string) *common.URL {
+		u, err := common.NewURL("tri://" + address + "/com.Foo?interface=com.Foo&application=app&group=g&version=1.0.0&methods=b,a")
+		require.NoError(t, err)
+		return u
+	}
+	empty, err := common.NewURL("empty://127.0.0.1/com.Foo?interface=com.Foo")
+	require.NoError(t, err)
+	reg := newFakeRegistry(nil, []*registry.ServiceEvent{
+		{Action: remoting.EventTypeAdd, Service: provider("10.0.0.2:20000")},
+		{Action: remoting.EventTypeAdd, Service: provider("10.0.0.1:20000")},
+		{Action: remoting.EventTypeAdd, Service: provider("10.0.0.3:20000")},
+		{Action: remoting.EventTypeDel, Service: provider("10.0.0.3:20000")},
+		{Action: remoting.EventTypeAdd, Service: empty},
+	})
+	extension.SetRegistry("fake-show", func(*common.URL) (registry.Registry, error) {
+		return reg, nil
+	})
+
+	instances, err := List(&Options{
+		Registry:       "fake-show",
+		Address:        "127.0.0.1:2181",
+		Interface:      "com.Foo",
+		ServiceGroup:   "g",
+		ServiceVersion: "1.0.0",
+		Wait:           50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.Len(t, instances, 2)
+	assert.Equal(t, "10.0.0.1:20000", instances[0].Address)
+	assert.Equal(t, "10.0.0.2:20000", instances[1].Address)
+	assert.Equal(t, "app", instances[0].App)
+	assert.Equal(t, []Endpoint{{Protocol: "tri", Port: 20000}}, instances[0].Endpoints)
+	assert.Equal(t, []Service{{
+		Interface: "com.Foo", Group: "g", Version: "1.0.0", Protocol: "tri", Port: 20000, Methods: []string{"a", "b"},
+	}}, instances[0].Services)
+	assert.False(t, reg.destroyedSubscribing.Load(), "the subscription is stopped before the registry is destroyed")
+}
+
+func TestListProvidersLoaded(t *testing.T) {
+	u, err := common.NewURL("tri://10.0.0.1:20000/com.Foo?interface=com.Foo&application=app")
+	require.NoError(t, err)
+	reg := newFakeRegistry([]*registry.ServiceEvent{{Action: remoting.EventTypeAdd, Service: u}}, nil)
+	extension.SetRegistry("fake-loaded", func(*common.URL) (registry.Registry, error) {
+		return reg, nil
+	})
+
+	start := time.Now()
+	instances, err := List(&Options{
+		Registry:  "fake-loaded",
+		Address:   "127.0.0.1:2181",
+		Interface: "com.Foo",
+		Wait:      time.Minute,
+	})
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, "10.0.0.1:20000", instances[0].Address)
+	assert.Less(t, time.Since(start), time.Minute)
+	assert.False(t, reg.subscribed.Load())
+}
+
+func TestListRequiresRegistry(t *testing.T) {
+	_, err := List(&Options{Address: "127.0.0.1:2181"})
+	assert.Error(t, err)
+}
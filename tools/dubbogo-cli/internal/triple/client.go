@@ -0,0 +1,350 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package triple calls triple services from the command line. Services,
+// methods and message schemas are discovered through the server reflection
+// service exposed by dubbo-go triple servers, so no generated stubs or
+// local proto files are needed.
+package triple
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+
+	"golang.org/x/net/http2"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"google.golang.org/protobuf/proto"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/generic"
+	rpb "dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection/triple_reflection"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+const reflectionMethod = "ServerReflectionInfo"
+
+// NewHTTPClient returns a HTTP/2 client for triple servers, over TLS when
+// tlsConfig is not nil and over cleartext otherwise.
+func NewHTTPClient(tlsConfig *tls.Config) *http.Client {
+	if tlsConfig != nil {
+		return &http.Client{Transport: &http2.Transport{TLSClientConfig: tlsConfig}}
+	}
+	return &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+}
+
+// NewReflectionSource returns a descriptor source resolving the services
+// through the server reflection service of the triple server at baseURL.
+func NewReflectionSource(httpClient tri.HTTPClient, baseURL string) generic.DescriptorSource {
+	return generic.NewServerReflectionSource(&reflectionClient{
+		cli: tri.NewClient(httpClient, strings.TrimSuffix(baseURL, "/")+"/"+constant.ReflectionServiceInterface),
+	})
+}
+
+// reflectionClient opens the reflection streams with the HTTP client of the
+// command, so the TLS settings of the command apply to them too.
+type reflectionClient struct {
+	cli *tri.Client
+}
+
+func (r *reflectionClient) ServerReflectionInfo(ctx context.Context, _ ...client.CallOption) (rpb.ServerReflection_ServerReflectionInfoClient, error) {
+	stream, err := r.cli.CallBidiStream(ctx, reflectionMethod)
+	if err != nil {
+		return nil, err
+	}
+	return &rpb.ServerReflectionServerReflectionInfoClient{BidiStreamForClient: stream}, nil
+}
+
+// Client invokes the methods of a triple service with JSON messages.
+type Client struct {
+	service protoreflect.ServiceDescriptor
+	types   *dynamicpb.Types
+	cli     *tri.Client
+}
+
+// NewClient resolves the service from source and creates a client for the
+// provider of it exported with group and version.
+func NewClient(ctx context.Context, source generic.DescriptorSource, httpClient tri.HTTPClient, baseURL, service, group, version string) (*Client, error) {
+	sd, files, err := source.FindService(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		service: sd,
+		types:   dynamicpb.NewTypes(files),
+		cli: tri.NewClient(httpClient, strings.TrimSuffix(baseURL, "/")+"/"+service,
+			tri.WithGroup(group), tri.WithVersion(version)),
+	}, nil
+}
+
+// Service returns the descriptor of the service.
+func (c *Client) Service() protoreflect.ServiceDescriptor {
+	return c.service
+}
+
+// Method returns the descriptor of the method named name.
+func (c *Client) Method(name string) (protoreflect.MethodDescriptor, error) {
+	md := c.service.Methods().ByName(protoreflect.Name(name))
+	if md == nil {
+		methods := make([]string, 0, c.service.Methods().Len())
+		for i := 0; i < c.service.Methods().Len(); i++ {
+			methods = append(methods, string(c.service.Methods().Get(i).Name()))
+		}
+		return nil, perrors.Errorf("service %s has no method %s, available methods: %s",
+			c.service.FullName(), name, strings.Join(methods, ", "))
+	}
+	return md, nil
+}
+
+// Invoke calls method with the JSON request messages read from in, one
+// message for unary and server streaming methods and any number of them for
+// client and bidi streaming methods. Response headers, messages and trailers
+// are written to out as they arrive.
+func (c *Client) Invoke(ctx context.Context, method string, in io.Reader, out io.Writer) error {
+	md, err := c.Method(method)
+	if err != nil {
+		return err
+	}
+	reqs := &requestReader{
+		dec:   json.NewDecoder(in),
+		input: md.Input(),
+		opts:  protojson.UnmarshalOptions{Resolver: c.types},
+	}
+	p := &printer{w: out, opts: protojson.MarshalOptions{Multiline: true, Indent: "  ", Resolver: c.types}}
+
+	switch {
+	case md.IsStreamingClient() && md.IsStreamingServer():
+		err = c.bidiStream(ctx, md, reqs, p)
+	case md.IsStreamingClient():
+		err = c.clientStream(ctx, md, reqs, p)
+	case md.IsStreamingServer():
+		err = c.serverStream(ctx, md, reqs, p)
+	default:
+		err = c.unary(ctx, md, reqs, p)
+	}
+	p.summary(reqs.sent, p.received)
+	return err
+}
+
+func (c *Client) unary(ctx context.Context, md protoreflect.MethodDescriptor, reqs *requestReader, p *printer) error {
+	req, err := reqs.single()
+	if err != nil {
+		return err
+	}
+	resp := tri.NewResponse(dynamicpb.NewMessage(md.Output()))
+	if err = c.cli.CallUnary(ctx, tri.NewRequest(req), string(md.Name()), resp); err != nil {
+		return err
+	}
+	p.header(resp.Header())
+	if err = p.message(resp.Msg.(proto.Message)); err != nil {
+		return err
+	}
+	p.trailer(resp.Trailer())
+	return nil
+}
+
+func (c *Client) serverStream(ctx context.Context, md protoreflect.MethodDescriptor, reqs *requestReader, p *printer) error {
+	req, err := reqs.single()
+	if err != nil {
+		return err
+	}
+	stream, err := c.cli.CallServerStream(ctx, tri.NewRequest(req), string(md.Name()))
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	for i := 0; ; i++ {
+		msg := dynamicpb.NewMessage(md.Output())
+		ok := stream.Receive(msg)
+		if i == 0 {
+			p.header(stream.ResponseHeader())
+		}
+		if !ok {
+			break
+		}
+		if err = p.message(msg); err != nil {
+			return err
+		}
+	}
+	if err = stream.Err(); err != nil {
+		return err
+	}
+	p.trailer(stream.ResponseTrailer())
+	return nil
+}
+
+func (c *Client) clientStream(ctx context.Context, md protoreflect.MethodDescriptor, reqs *requestReader, p *printer) error {
+	// canceling aborts the call when the request stream can not be completed
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.cli.CallClientStream(ctx, string(md.Name()))
+	if err != nil {
+		return err
+	}
+	for {
+		req, err := reqs.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		// the server closed the stream, its error is returned by CloseAndReceive
+		if err = stream.Send(req); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	resp := tri.NewResponse(dynamicpb.NewMessage(md.Output()))
+	if err = stream.CloseAndReceive(resp); err != nil {
+		return err
+	}
+	p.header(resp.Header())
+	if err = p.message(resp.Msg.(proto.Message)); err != nil {
+		return err
+	}
+	p.trailer(resp.Trailer())
+	return nil
+}
+
+func (c *Client) bidiStream(ctx context.Context, md protoreflect.MethodDescriptor, reqs *requestReader, p *printer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.cli.CallBidiStream(ctx, string(md.Name()))
+	if err != nil {
+		return err
+	}
+	defer stream.CloseResponse()
+
+	sendErr := make(chan error, 1)
+	go func() {
+		defer close(sendErr)
+		for {
+			req, err := reqs.next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				// abort the call, the request stream can not be completed
+				sendErr <- err
+				cancel()
+				return
+			}
+			// the server closed the stream, its error is returned by Receive
+			if err = stream.Send(req); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				sendErr <- err
+				cancel()
+				return
+			}
+		}
+		_ = stream.CloseRequest()
+	}()
+
+	for i := 0; ; i++ {
+		msg := dynamicpb.NewMessage(md.Output())
+		err = stream.Receive(msg)
+		if i == 0 {
+			p.header(stream.ResponseHeader())
+		}
+		if err != nil {
+			break
+		}
+		if err = p.message(msg); err != nil {
+			cancel()
+			break
+		}
+	}
+	if sErr := <-sendErr; sErr != nil {
+		return sErr
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	p.trailer(stream.ResponseTrailer())
+	return nil
+}
+
+// requestReader decodes a stream of JSON request messages. Messages may be
+// separated by whitespace, so both JSON lines and pretty printed objects
+// are accepted.
+type requestReader struct {
+	dec   *json.Decoder
+	input protoreflect.MessageDescriptor
+	opts  protojson.UnmarshalOptions
+	sent  int
+}
+
+// next returns the next request message, or io.EOF if there is none.
+func (r *requestReader) next() (proto.Message, error) {
+	var raw json.RawMessage
+	if err := r.dec.Decode(&raw); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, perrors.WithMessagef(err, "read request message %d", r.sent+1)
+	}
+	msg := dynamicpb.NewMessage(r.input)
+	if err := r.opts.Unmarshal(raw, msg); err != nil {
+		return nil, perrors.WithMessagef(err, "decode request message %d as %s", r.sent+1, r.input.FullName())
+	}
+	r.sent++
+	return msg, nil
+}
+
+// single returns the only request message of a unary or server streaming
+// call. An empty input stands for an empty message.
+func (r *requestReader) single() (proto.Message, error) {
+	msg, err := r.next()
+	if errors.Is(err, io.EOF) {
+		r.sent++
+		return dynamicpb.NewMessage(r.input), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err = r.next(); !errors.Is(err, io.EOF) {
+		return nil, perrors.New("method accepts exactly one request message")
+	}
+	return msg, nil
+}
@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package triple
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	health "dubbo.apache.org/dubbo-go/v3/protocol/triple/health/triple_health"
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection"
+	rpb "dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection/triple_reflection"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+const healthService = "grpc.health.v1.Health"
+
+// newTestServer serves the server reflection service and a health service
+// echoing the request in its headers.
+func newTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	reflectionServer := reflection.NewServer()
+	mux.Handle("/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo", tri.NewBidiStreamHandler(
+		"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+		func(ctx context.Context, stream *tri.BidiStream) error {
+			return reflectionServer.ServerReflectionInfo(ctx, &rpb.ServerReflectionServerReflectionInfoServer{BidiStream: stream})
+		},
+	))
+	mux.Handle("/"+healthService+"/Check", tri.NewUnaryHandler(
+		"/"+healthService+"/Check",
+		func() any { return new(health.HealthCheckRequest) },
+		func(_ context.Context, req *tri.Request) (*tri.Response, error) {
+			resp := tri.NewResponse(&health.HealthCheckResponse{Status: health.HealthCheckResponse_SERVING})
+			resp.Header().Set("x-service", req.Msg.(*health.HealthCheckRequest).Service)
+			resp.Header().Set("x-group", req.Header().Get("tri-service-group"))
+			resp.Trailer().Set("x-trailer", "done")
+			return resp, nil
+		},
+		tri.WithGroup("g1"), tri.WithVersion("1.0.0"),
+	))
+	mux.Handle("/"+healthService+"/Watch", tri.NewServerStreamHandler(
+		"/"+healthService+"/Watch",
+		func() any { return new(health.HealthCheckRequest) },
+		func(_ context.Context, _ *tri.Request, stream *tri.ServerStream) error {
+			for _, status := range []health.HealthCheckResponse_ServingStatus{
+				health.HealthCheckResponse_NOT_SERVING, health.HealthCheckResponse_SERVING,
+			} {
+				if err := stream.Send(&health.HealthCheckResponse{Status: status}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		tri.WithGroup("g1"), tri.WithVersion("1.0.0"),
+	))
+	server := httptest.NewUnstartedServer(mux)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestClient(t *testing.T, group, version string) *Client {
+	server := newTestServer(t)
+	source := NewReflectionSource(server.Client(), server.URL)
+	cli, err := NewClient(context.Background(), source, server.Client(), server.URL, healthService, group, version)
+	require.NoError(t, err)
+	return cli
+}
+
+func TestInvokeUnary(t *testing.T) {
+	cli := newTestClient(t, "g1", "1.0.0")
+
+	var out bytes.Buffer
+	err := cli.Invoke(context.Background(), "Check", strings.NewReader(`{"service": "foo"}`), &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "x-service: foo")
+	assert.Contains(t, out.String(), "x-group: g1")
+	// protojson randomizes the spaces of its output, so the messages are matched ignoring them
+	assert.Regexp(t, `"status":\s*"SERVING"`, out.String())
+	assert.Contains(t, out.String(), "x-trailer: done")
+	assert.Contains(t, out.String(), "Sent 1 request(s) and received 1 response(s)")
+
+	err = cli.Invoke(context.Background(), "Check", strings.NewReader(`{"service": "foo"} {"service": "bar"}`), &out)
+	assert.ErrorContains(t, err, "exactly one request message")
+	err = cli.Invoke(context.Background(), "Check", strings.NewReader(`{"unknown": 1}`), &out)
+	assert.ErrorContains(t, err, "grpc.health.v1.HealthCheckRequest")
+	err = cli.Invoke(context.Background(), "Missing", strings.NewReader(""), &out)
+	assert.ErrorContains(t, err, "available methods: Check, Watch")
+
+	cli = newTestClient(t, "g2", "1.0.0")
+	err = cli.Invoke(context.Background(), "Check", strings.NewReader(""), &out)
+	assert.Equal(t, tri.CodeUnimplemented, tri.CodeOf(err))
+}
+
+func TestInvokeServerStream(t *testing.T) {
+	cli := newTestClient(t, "g1", "1.0.0")
+
+	var out bytes.Buffer
+	err := cli.Invoke(context.Background(), "Watch", strings.NewReader(""), &out)
+	require.NoError(t, err)
+	assert.Regexp(t, `"status":\s*"NOT_SERVING"`, out.String())
+	assert.Regexp(t, `"status":\s*"SERVING"`, out.String())
+	assert.Contains(t, out.String(), "Sent 1 request(s) and received 2 response(s)")
+}
+
+func TestDescribe(t *testing.T) {
+	cli := newTestClient(t, "g1", "1.0.0")
+
+	var out bytes.Buffer
+	require.NoError(t, cli.Describe(&out, ""))
+	assert.Contains(t, out.String(), "rpc Check ( .grpc.health.v1.HealthCheckRequest ) returns ( .grpc.health.v1.HealthCheckResponse );")
+	assert.Contains(t, out.String(), "rpc Watch ( .grpc.health.v1.HealthCheckRequest ) returns ( stream .grpc.health.v1.HealthCheckResponse );")
+	assert.Contains(t, out.String(), "message grpc.health.v1.HealthCheckRequest {\n  string service = 1;\n}")
+	assert.Contains(t, out.String(), "enum grpc.health.v1.HealthCheckResponse.ServingStatus {")
+
+	out.Reset()
+	require.NoError(t, cli.Describe(&out, "Check"))
+	assert.NotContains(t, out.String(), "rpc Watch")
+}
+
+func TestPrintError(t *testing.T) {
+	var out bytes.Buffer
+	PrintError(&out, tri.NewError(tri.CodeUnavailable, assert.AnError))
+	assert.Contains(t, out.String(), "ERROR:\n  Code: unavailable\n  Message: "+assert.AnError.Error())
+}
@@ -0,0 +1,244 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package triple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"google.golang.org/protobuf/proto"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+import (
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+// printer writes the result of a call in the layout of grpcurl -v.
+type printer struct {
+	w        io.Writer
+	opts     protojson.MarshalOptions
+	received int
+}
+
+func (p *printer) header(h http.Header) {
+	writeMetadata(p.w, "Response headers received:", h)
+}
+
+func (p *printer) trailer(h http.Header) {
+	writeMetadata(p.w, "Response trailers received:", h)
+}
+
+func (p *printer) message(msg proto.Message) error {
+	b, err := p.opts.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	p.received++
+	_, _ = fmt.Fprintf(p.w, "Response contents:\n%s\n\n", b)
+	return nil
+}
+
+func (p *printer) summary(sent, received int) {
+	_, _ = fmt.Fprintf(p.w, "Sent %d request(s) and received %d response(s)\n", sent, received)
+}
+
+// PrintError writes the status of a failed call together with the trailers
+// the server sent along with it.
+func PrintError(w io.Writer, err error) {
+	var triErr *tri.Error
+	if !errors.As(err, &triErr) {
+		_, _ = fmt.Fprintf(w, "ERROR:\n  %v\n", err)
+		return
+	}
+	writeMetadata(w, "Response trailers received:", triErr.Meta())
+	_, _ = fmt.Fprintf(w, "ERROR:\n  Code: %s\n  Message: %s\n", triErr.Code(), triErr.Message())
+}
+
+func writeMetadata(w io.Writer, title string, h http.Header) {
+	_, _ = fmt.Fprintln(w, title)
+	if len(h) == 0 {
+		_, _ = fmt.Fprintln(w, "(empty)")
+	}
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range h[k] {
+			_, _ = fmt.Fprintf(w, "%s: %s\n", strings.ToLower(k), v)
+		}
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// Describe writes the definition of the service, or of its method named
+// method when it is not empty, followed by the schemas of all the messages
+// and enums the methods use, in proto syntax.
+func (c *Client) Describe(w io.Writer, method string) error {
+	var methods []protoreflect.MethodDescriptor
+	if method != "" {
+		md, err := c.Method(method)
+		if err != nil {
+			return err
+		}
+		methods = append(methods, md)
+	} else {
+		for i := 0; i < c.service.Methods().Len(); i++ {
+			methods = append(methods, c.service.Methods().Get(i))
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "service %s {\n", c.service.FullName())
+	roots := make([]protoreflect.MessageDescriptor, 0, 2*len(methods))
+	for _, md := range methods {
+		_, _ = fmt.Fprintf(w, "  rpc %s ( %s.%s ) returns ( %s.%s );\n", md.Name(),
+			streamPrefix(md.IsStreamingClient()), md.Input().FullName(),
+			streamPrefix(md.IsStreamingServer()), md.Output().FullName())
+		roots = append(roots, md.Input(), md.Output())
+	}
+	_, _ = fmt.Fprintln(w, "}")
+
+	messages, enums := collectTypes(roots)
+	for _, md := range messages {
+		_, _ = fmt.Fprintln(w)
+		writeMessage(w, md)
+	}
+	for _, ed := range enums {
+		_, _ = fmt.Fprintln(w)
+		writeEnum(w, ed)
+	}
+	return nil
+}
+
+func streamPrefix(streaming bool) string {
+	if streaming {
+		return "stream "
+	}
+	return ""
+}
+
+// collectTypes returns the messages and enums reachable from roots sorted
+// by full name. Map entries are not returned, they are written inline.
+func collectTypes(roots []protoreflect.MessageDescriptor) ([]protoreflect.MessageDescriptor, []protoreflect.EnumDescriptor) {
+	seenMessages := make(map[protoreflect.FullName]protoreflect.MessageDescriptor)
+	seenEnums := make(map[protoreflect.FullName]protoreflect.EnumDescriptor)
+	queue := append([]protoreflect.MessageDescriptor(nil), roots...)
+	for len(queue) > 0 {
+		md := queue[0]
+		queue = queue[1:]
+		if _, ok := seenMessages[md.FullName()]; ok {
+			continue
+		}
+		if !md.IsMapEntry() {
+			seenMessages[md.FullName()] = md
+		}
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			if fd.IsMap() {
+				fd = fd.MapValue()
+			}
+			if fd.Message() != nil {
+				queue = append(queue, fd.Message())
+			}
+			if fd.Enum() != nil {
+				seenEnums[fd.Enum().FullName()] = fd.Enum()
+			}
+		}
+	}
+
+	messages := make([]protoreflect.MessageDescriptor, 0, len(seenMessages))
+	for _, md := range seenMessages {
+		messages = append(messages, md)
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].FullName() < messages[j].FullName() })
+	enums := make([]protoreflect.EnumDescriptor, 0, len(seenEnums))
+	for _, ed := range seenEnums {
+		enums = append(enums, ed)
+	}
+	sort.Slice(enums, func(i, j int) bool { return enums[i].FullName() < enums[j].FullName() })
+	return messages, enums
+}
+
+func writeMessage(w io.Writer, md protoreflect.MessageDescriptor) {
+	_, _ = fmt.Fprintf(w, "message %s {\n", md.FullName())
+	written := make(map[protoreflect.FullName]bool)
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		od := fd.ContainingOneof()
+		if od == nil || od.IsSynthetic() {
+			writeField(w, "  ", fd)
+			continue
+		}
+		if written[od.FullName()] {
+			continue
+		}
+		written[od.FullName()] = true
+		_, _ = fmt.Fprintf(w, "  oneof %s {\n", od.Name())
+		for j := 0; j < od.Fields().Len(); j++ {
+			writeField(w, "    ", od.Fields().Get(j))
+		}
+		_, _ = fmt.Fprintln(w, "  }")
+	}
+	_, _ = fmt.Fprintln(w, "}")
+}
+
+func writeField(w io.Writer, indent string, fd protoreflect.FieldDescriptor) {
+	label := ""
+	switch {
+	case fd.IsList():
+		label = "repeated "
+	case fd.HasOptionalKeyword():
+		label = "optional "
+	}
+	_, _ = fmt.Fprintf(w, "%s%s%s %s = %d;\n", indent, label, fieldType(fd), fd.Name(), fd.Number())
+}
+
+func fieldType(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsMap():
+		return fmt.Sprintf("map<%s, %s>", fieldType(fd.MapKey()), fieldType(fd.MapValue()))
+	case fd.Message() != nil:
+		return "." + string(fd.Message().FullName())
+	case fd.Enum() != nil:
+		return "." + string(fd.Enum().FullName())
+	default:
+		return fd.Kind().String()
+	}
+}
+
+func writeEnum(w io.Writer, ed protoreflect.EnumDescriptor) {
+	_, _ = fmt.Fprintf(w, "enum %s {\n", ed.FullName())
+	for i := 0; i < ed.Values().Len(); i++ {
+		vd := ed.Values().Get(i)
+		_, _ = fmt.Fprintf(w, "  %s = %d;\n", vd.Name(), vd.Number())
+	}
+	_, _ = fmt.Fprintln(w, "}")
+}
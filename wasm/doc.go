@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package wasm is the WebAssembly extension host of dubbo-go. It runs guest
+// modules on the pure-Go wazero runtime, so filters and routing logic can be
+// shipped to every service without recompiling the Go binary.
+//
+// A guest module exports some of the following functions, all of them take
+// no parameters and return an i32:
+//
+//	on_request  - called before the invocation, non-zero rejects the call
+//	on_response - called after the invocation
+//	route       - called to select invokers, non-zero keeps all invokers
+//
+// and imports the host functions of the "dubbo" module. Strings are passed
+// as (ptr, len) pairs in the guest memory, getters copy into a guest buffer
+// (ptr, cap) and return the full length of the value, or -1 if it is absent:
+//
+//	get_method(buf, cap) i32
+//	get_attachment(key, key_len, buf, cap) i32
+//	set_attachment(key, key_len, val, val_len)
+//	get_status() i32
+//	set_status(status)
+//	invoker_count() i32
+//	get_invoker_address(idx, buf, cap) i32
+//	get_invoker_param(idx, key, key_len, buf, cap) i32
+//	select_invoker(idx)
+//	log(msg, msg_len)
+package wasm
@@ -0,0 +1,152 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wasm
+
+import (
+	"context"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// hostModuleName is the import module name of the host functions.
+const hostModuleName = "dubbo"
+
+func instantiateHostModule(ctx context.Context, r wazero.Runtime, name string) error {
+	_, err := r.NewHostModuleBuilder(hostModuleName).
+		NewFunctionBuilder().WithFunc(getMethod).Export("get_method").
+		NewFunctionBuilder().WithFunc(getAttachment).Export("get_attachment").
+		NewFunctionBuilder().WithFunc(setAttachment).Export("set_attachment").
+		NewFunctionBuilder().WithFunc(getStatus).Export("get_status").
+		NewFunctionBuilder().WithFunc(setStatus).Export("set_status").
+		NewFunctionBuilder().WithFunc(invokerCount).Export("invoker_count").
+		NewFunctionBuilder().WithFunc(getInvokerAddress).Export("get_invoker_address").
+		NewFunctionBuilder().WithFunc(getInvokerParam).Export("get_invoker_param").
+		NewFunctionBuilder().WithFunc(selectInvoker).Export("select_invoker").
+		NewFunctionBuilder().WithFunc(guestLog(name)).Export("log").
+		Instantiate(ctx)
+	return err
+}
+
+func guestLog(name string) func(context.Context, api.Module, uint32, uint32) {
+	return func(_ context.Context, m api.Module, msg, msgLen uint32) {
+		if s, ok := readString(m, msg, msgLen); ok {
+			logger.Infof("[Wasm] module %s: %s", name, s)
+		}
+	}
+}
+
+func getMethod(ctx context.Context, m api.Module, buf, bufCap uint32) int32 {
+	return writeString(m, buf, bufCap, stateFrom(ctx).Method)
+}
+
+func getAttachment(ctx context.Context, m api.Module, key, keyLen, buf, bufCap uint32) int32 {
+	k, ok := readString(m, key, keyLen)
+	if !ok {
+		return -1
+	}
+	v, ok := stateFrom(ctx).attachment(k)
+	if !ok {
+		return -1
+	}
+	return writeString(m, buf, bufCap, v)
+}
+
+func setAttachment(ctx context.Context, m api.Module, key, keyLen, val, valLen uint32) {
+	k, ok := readString(m, key, keyLen)
+	if !ok {
+		return
+	}
+	v, ok := readString(m, val, valLen)
+	if !ok {
+		return
+	}
+	state := stateFrom(ctx)
+	if state.Attachments == nil {
+		state.Attachments = make(map[string]any)
+	}
+	state.Attachments[k] = v
+}
+
+func getStatus(ctx context.Context) int32 {
+	return stateFrom(ctx).Status
+}
+
+func setStatus(ctx context.Context, status int32) {
+	stateFrom(ctx).Status = status
+}
+
+func invokerCount(ctx context.Context) int32 {
+	return int32(len(stateFrom(ctx).Invokers))
+}
+
+func getInvokerAddress(ctx context.Context, m api.Module, idx, buf, bufCap uint32) int32 {
+	state := stateFrom(ctx)
+	if int(idx) >= len(state.Invokers) || state.Invokers[idx] == nil {
+		return -1
+	}
+	return writeString(m, buf, bufCap, state.Invokers[idx].Location)
+}
+
+func getInvokerParam(ctx context.Context, m api.Module, idx, key, keyLen, buf, bufCap uint32) int32 {
+	state := stateFrom(ctx)
+	if int(idx) >= len(state.Invokers) || state.Invokers[idx] == nil {
+		return -1
+	}
+	k, ok := readString(m, key, keyLen)
+	if !ok {
+		return -1
+	}
+	v, ok := state.Invokers[idx].GetNonDefaultParam(k)
+	if !ok {
+		return -1
+	}
+	return writeString(m, buf, bufCap, v)
+}
+
+func selectInvoker(ctx context.Context, idx uint32) {
+	state := stateFrom(ctx)
+	if int(idx) < len(state.Invokers) {
+		state.Selected = append(state.Selected, int(idx))
+	}
+}
+
+func readString(m api.Module, ptr, length uint32) (string, bool) {
+	b, ok := m.Memory().Read(ptr, length)
+	if !ok {
+		return "", false
+	}
+	return string(b), true
+}
+
+// writeString copies as much of s as fits into the guest buffer and returns the full length of s,
+// so the guest can retry with a larger buffer.
+func writeString(m api.Module, buf, bufCap uint32, s string) int32 {
+	n := uint32(len(s))
+	if n > bufCap {
+		n = bufCap
+	}
+	if n > 0 && !m.Memory().Write(buf, []byte(s[:n])) {
+		return -1
+	}
+	return int32(len(s))
+}
@@ -0,0 +1,319 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wasm
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/fsnotify/fsnotify"
+
+	perrors "github.com/pkg/errors"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+const (
+	// DefaultMemoryLimitPages limits the guest memory to 16MiB, a page is 64KiB.
+	DefaultMemoryLimitPages uint32 = 256
+	// DefaultTimeout limits the cpu time of a single guest call.
+	DefaultTimeout = 100 * time.Millisecond
+	// defaultPoolSize is the number of idle instances kept for each module version.
+	defaultPoolSize = 16
+)
+
+// ErrNotLoaded is returned when a module is called before any code is loaded.
+var ErrNotLoaded = perrors.New("wasm module is not loaded")
+
+// Limits bounds the resources a guest module can use.
+type Limits struct {
+	// MemoryLimitPages is the max number of 64KiB memory pages of an instance.
+	MemoryLimitPages uint32
+	// Timeout is the max duration of a single guest call.
+	Timeout time.Duration
+}
+
+// Module is a hot swappable guest module. Every call runs in an instance
+// taken from a pool of the currently loaded version, so an in-flight call
+// keeps its version while a new one is loaded.
+type Module struct {
+	name    string
+	limits  Limits
+	runtime wazero.Runtime
+
+	loadLock sync.Mutex
+	current  atomic.Pointer[version]
+
+	watcher *fsnotify.Watcher
+
+	// key is the key of the module shared by ModuleFromURL
+	key string
+	// stopListening removes the module from config center
+	stopListening func()
+}
+
+// version is one compiled code of a Module.
+type version struct {
+	compiled wazero.CompiledModule
+	pool     chan api.Module
+
+	lock    sync.Mutex // guards retired, so that no instance is put to the drained pool
+	retired bool
+}
+
+// NewModule creates an empty module, code is loaded by Load, LoadFile or from the config center.
+func NewModule(name string, limits Limits) (*Module, error) {
+	if limits.MemoryLimitPages == 0 {
+		limits.MemoryLimitPages = DefaultMemoryLimitPages
+	}
+	if limits.Timeout <= 0 {
+		limits.Timeout = DefaultTimeout
+	}
+	ctx := context.Background()
+	r := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(limits.MemoryLimitPages).
+		WithCloseOnContextDone(true))
+	if err := instantiateHostModule(ctx, r, name); err != nil {
+		_ = r.Close(ctx)
+		return nil, err
+	}
+	return &Module{name: name, limits: limits, runtime: r}, nil
+}
+
+// Name returns the name of the module.
+func (m *Module) Name() string {
+	return m.name
+}
+
+// Loaded reports whether any code is loaded.
+func (m *Module) Loaded() bool {
+	return m.current.Load() != nil
+}
+
+// Load compiles code and swaps it in, the previous version is released once its in-flight calls finish.
+func (m *Module) Load(code []byte) error {
+	m.loadLock.Lock()
+	defer m.loadLock.Unlock()
+
+	ctx := context.Background()
+	compiled, err := m.runtime.CompileModule(ctx, code)
+	if err != nil {
+		return perrors.WithMessagef(err, "compile wasm module %s", m.name)
+	}
+	// instantiate once to validate imports and memory limits before swapping
+	v := &version{compiled: compiled, pool: make(chan api.Module, defaultPoolSize)}
+	ins, err := v.instantiate(ctx, m.runtime)
+	if err != nil {
+		_ = compiled.Close(ctx)
+		return perrors.WithMessagef(err, "instantiate wasm module %s", m.name)
+	}
+	v.put(ins)
+
+	if old := m.current.Swap(v); old != nil {
+		old.retire()
+	}
+	logger.Infof("[Wasm] module %s loaded", m.name)
+	return nil
+}
+
+// LoadFile loads the code from path.
+func (m *Module) LoadFile(path string) error {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return perrors.WithMessagef(err, "read wasm module %s", m.name)
+	}
+	return m.Load(code)
+}
+
+// WatchFile loads the code from path, and loads it again whenever the file is rewritten.
+func (m *Module) WatchFile(path string) error {
+	if err := m.LoadFile(path); err != nil {
+		return err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	// watch the directory, so that files replaced by rename are noticed as well
+	if err = watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+	m.watcher = watcher
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(path) || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				if err := m.LoadFile(path); err != nil {
+					logger.Warnf("[Wasm] reload module %s from %s failed: %v", m.name, path, err)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Warnf("[Wasm] watch module %s failed: %v", m.name, err)
+			}
+		}
+	}()
+	return nil
+}
+
+// Process implements config_center.ConfigurationListener, the value is the base64 encoded code.
+// A deleted key keeps the loaded code, so that routing and filtering stay stable.
+func (m *Module) Process(event *config_center.ConfigChangeEvent) {
+	if event.ConfigType == remoting.EventTypeDel {
+		logger.Warnf("[Wasm] config of module %s is removed, the loaded code is kept", m.name)
+		return
+	}
+	raw, ok := event.Value.(string)
+	if !ok || raw == "" {
+		return
+	}
+	code, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		logger.Errorf("[Wasm] decode module %s from key %s failed: %v", m.name, event.Key, err)
+		return
+	}
+	if err = m.Load(code); err != nil {
+		logger.Errorf("[Wasm] load module %s from key %s failed: %v", m.name, event.Key, err)
+	}
+}
+
+// HasFunction reports whether the loaded code exports fn.
+func (m *Module) HasFunction(fn string) bool {
+	v := m.current.Load()
+	if v == nil {
+		return false
+	}
+	_, ok := v.compiled.ExportedFunctions()[fn]
+	return ok
+}
+
+// Call runs the exported function fn with state and returns its result.
+func (m *Module) Call(ctx context.Context, fn string, state *CallState) (int32, error) {
+	v := m.current.Load()
+	if v == nil {
+		return 0, ErrNotLoaded
+	}
+	ins, err := v.get(m.runtime)
+	if err != nil {
+		return 0, perrors.WithMessagef(err, "instantiate wasm module %s", m.name)
+	}
+	f := ins.ExportedFunction(fn)
+	if f == nil {
+		v.put(ins)
+		return 0, perrors.Errorf("wasm module %s does not export %s", m.name, fn)
+	}
+
+	callCtx, cancel := context.WithTimeout(withState(context.Background(), state), m.limits.Timeout)
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < m.limits.Timeout {
+		callCtx, cancel = context.WithDeadline(callCtx, deadline)
+		defer cancel()
+	}
+	res, err := f.Call(callCtx)
+	if err != nil {
+		// the instance may be closed by the timeout or left in a bad state by a trap
+		_ = ins.Close(context.Background())
+		return 0, perrors.WithMessagef(err, "call %s of wasm module %s", fn, m.name)
+	}
+	v.put(ins)
+	if len(res) == 0 {
+		return 0, nil
+	}
+	return api.DecodeI32(res[0]), nil
+}
+
+// Close releases the runtime and all instances.
+func (m *Module) Close() {
+	if m.watcher != nil {
+		_ = m.watcher.Close()
+	}
+	if m.stopListening != nil {
+		m.stopListening()
+	}
+	if v := m.current.Swap(nil); v != nil {
+		v.retire()
+	}
+	_ = m.runtime.Close(context.Background())
+}
+
+func (v *version) instantiate(ctx context.Context, r wazero.Runtime) (api.Module, error) {
+	// anonymous instances, so that the same code can be instantiated many times
+	return r.InstantiateModule(ctx, v.compiled, wazero.NewModuleConfig().WithName("").WithStartFunctions())
+}
+
+func (v *version) get(r wazero.Runtime) (api.Module, error) {
+	select {
+	case ins := <-v.pool:
+		return ins, nil
+	default:
+		return v.instantiate(context.Background(), r)
+	}
+}
+
+func (v *version) put(ins api.Module) {
+	v.lock.Lock()
+	if !v.retired {
+		select {
+		case v.pool <- ins:
+			v.lock.Unlock()
+			return
+		default:
+		}
+	}
+	v.lock.Unlock()
+	_ = ins.Close(context.Background())
+}
+
+func (v *version) retire() {
+	v.lock.Lock()
+	v.retired = true
+	v.lock.Unlock()
+	for {
+		select {
+		case ins := <-v.pool:
+			_ = ins.Close(context.Background())
+		default:
+			// in-flight calls are not affected by closing the compiled code
+			_ = v.compiled.Close(context.Background())
+			return
+		}
+	}
+}
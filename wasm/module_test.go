@@ -0,0 +1,194 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wasm
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+func newTestModule(t *testing.T, file string, limits Limits) *Module {
+	m, err := NewModule(file, limits)
+	require.NoError(t, err)
+	t.Cleanup(m.Close)
+	if file != "" {
+		require.NoError(t, m.LoadFile(filepath.Join("testdata", file)))
+	}
+	return m
+}
+
+func TestModuleCallFilter(t *testing.T) {
+	m := newTestModule(t, "filter.wasm", Limits{})
+	assert.True(t, m.HasFunction("on_request"))
+	assert.False(t, m.HasFunction("route"))
+
+	state := &CallState{Method: "GetUser", Attachments: map[string]any{}}
+	ret, err := m.Call(context.Background(), "on_request", state)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), ret)
+	assert.Equal(t, "yes", state.Attachments["wasm"])
+
+	state = &CallState{Method: "GetUser", Attachments: map[string]any{"deny": []string{"true"}}}
+	ret, err = m.Call(context.Background(), "on_request", state)
+	require.NoError(t, err)
+	assert.Equal(t, int32(403), ret)
+
+	state = &CallState{Status: StatusError}
+	_, err = m.Call(context.Background(), "on_response", state)
+	require.NoError(t, err)
+	assert.Equal(t, StatusOK, state.Status)
+
+	_, err = m.Call(context.Background(), "route", state)
+	assert.Error(t, err)
+}
+
+func TestModuleCallRouter(t *testing.T) {
+	m := newTestModule(t, "router.wasm", Limits{})
+
+	u1, _ := common.NewURL("dubbo://127.0.0.1:20000/UserProvider?zone=hangzhou")
+	u2, _ := common.NewURL("dubbo://127.0.0.1:20001/UserProvider")
+	u3, _ := common.NewURL("dubbo://127.0.0.1:20002/UserProvider?zone=shanghai")
+	state := &CallState{Invokers: []*common.URL{u1, u2, u3}}
+	ret, err := m.Call(context.Background(), "route", state)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), ret)
+	assert.Equal(t, []int{0, 2}, state.Selected)
+}
+
+func TestModuleLimits(t *testing.T) {
+	m := newTestModule(t, "loop.wasm", Limits{Timeout: 50 * time.Millisecond})
+	start := time.Now()
+	_, err := m.Call(context.Background(), "on_request", &CallState{})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// the instance killed by the timeout is not reused
+	_, err = m.Call(context.Background(), "on_request", &CallState{})
+	assert.Error(t, err)
+
+	m = newTestModule(t, "", Limits{})
+	assert.Error(t, m.LoadFile(filepath.Join("testdata", "memory.wasm")))
+	assert.False(t, m.Loaded())
+
+	m = newTestModule(t, "", Limits{MemoryLimitPages: 512})
+	assert.NoError(t, m.LoadFile(filepath.Join("testdata", "memory.wasm")))
+}
+
+func TestModuleHotSwap(t *testing.T) {
+	m := newTestModule(t, "", Limits{})
+	_, err := m.Call(context.Background(), "route", &CallState{})
+	assert.ErrorIs(t, err, ErrNotLoaded)
+
+	code, err := os.ReadFile(filepath.Join("testdata", "router.wasm"))
+	require.NoError(t, err)
+	m.Process(&config_center.ConfigChangeEvent{Value: base64.StdEncoding.EncodeToString(code), ConfigType: remoting.EventTypeAdd})
+	assert.True(t, m.HasFunction("route"))
+
+	code, err = os.ReadFile(filepath.Join("testdata", "filter.wasm"))
+	require.NoError(t, err)
+	m.Process(&config_center.ConfigChangeEvent{Value: base64.StdEncoding.EncodeToString(code), ConfigType: remoting.EventTypeUpdate})
+	assert.False(t, m.HasFunction("route"))
+	assert.True(t, m.HasFunction("on_request"))
+
+	// broken code and deleted config keep the loaded code
+	m.Process(&config_center.ConfigChangeEvent{Value: base64.StdEncoding.EncodeToString([]byte("bad")), ConfigType: remoting.EventTypeUpdate})
+	m.Process(&config_center.ConfigChangeEvent{ConfigType: remoting.EventTypeDel})
+	assert.True(t, m.HasFunction("on_request"))
+}
+
+func TestModuleWatchFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "module.wasm")
+	code, err := os.ReadFile(filepath.Join("testdata", "router.wasm"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, code, 0o600))
+
+	m := newTestModule(t, "", Limits{})
+	require.NoError(t, m.WatchFile(path))
+	assert.True(t, m.HasFunction("route"))
+
+	code, err = os.ReadFile(filepath.Join("testdata", "filter.wasm"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, code, 0o600))
+	assert.Eventually(t, func() bool {
+		return m.HasFunction("on_request")
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestModuleFromURL(t *testing.T) {
+	u, _ := common.NewURL("dubbo://127.0.0.1:20000/UserProvider")
+	m, err := ModuleFromURL(u, "wasm.filter")
+	require.NoError(t, err)
+	assert.Nil(t, m)
+
+	u.SetParam("wasm.filter.path", filepath.Join("testdata", "filter.wasm"))
+	u.SetParam("wasm.filter.timeout", "10ms")
+	m, err = ModuleFromURL(u, "wasm.filter")
+	require.NoError(t, err)
+	require.NotNil(t, m)
+	assert.Equal(t, 10*time.Millisecond, m.limits.Timeout)
+
+	same, err := ModuleFromURL(u, "wasm.filter")
+	require.NoError(t, err)
+	assert.Same(t, m, same)
+
+	u.SetParam("wasm.filter.timeout", "20ms")
+	other, err := ModuleFromURL(u, "wasm.filter")
+	require.NoError(t, err)
+	assert.NotSame(t, m, other)
+	assert.Equal(t, 20*time.Millisecond, other.limits.Timeout)
+
+	u.SetParam("wasm.filter.path", filepath.Join("testdata", "absent.wasm"))
+	_, err = ModuleFromURL(u, "wasm.filter")
+	assert.Error(t, err)
+}
+
+func TestModuleRelease(t *testing.T) {
+	u, _ := common.NewURL("dubbo://127.0.0.1:20000/UserProvider")
+	u.SetParam("wasm.router.path", filepath.Join("testdata", "filter.wasm"))
+	m, err := ModuleFromURL(u, "wasm.router")
+	require.NoError(t, err)
+	same, err := ModuleFromURL(u, "wasm.router")
+	require.NoError(t, err)
+	require.Same(t, m, same)
+
+	// the module is closed once all the users release it
+	m.Release()
+	assert.True(t, m.Loaded())
+	same.Release()
+	assert.False(t, m.Loaded())
+	other, err := ModuleFromURL(u, "wasm.router")
+	require.NoError(t, err)
+	assert.NotSame(t, m, other)
+	other.Release()
+}
@@ -0,0 +1,169 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wasm
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	conf "dubbo.apache.org/dubbo-go/v3/common/config"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+var (
+	modulesLock sync.Mutex
+	modules     = make(map[string]*moduleLoad)
+)
+
+// moduleLoad is a module shared by the urls with the same source and limits, the config center
+// and the file system are accessed without holding modulesLock, the concurrent callers wait for done.
+type moduleLoad struct {
+	done   chan struct{}
+	module *Module
+	err    error
+	refs   int
+}
+
+// ModuleFromURL returns the module configured by the url params with the given prefix, for example
+// with prefix `wasm.filter`:
+//
+//	wasm.filter.path               - file path of the code, reloaded when the file changes
+//	wasm.filter.config-key         - config center key of the base64 encoded code, reloaded on change
+//	wasm.filter.memory-limit-pages - max number of 64KiB memory pages of an instance
+//	wasm.filter.timeout            - max duration of a single guest call, like 100ms
+//
+// Modules are shared by all urls with the same source and limits, every returned module should be
+// released by Release once it is not used. It returns nil if no source is configured.
+func ModuleFromURL(url *common.URL, prefix string) (*Module, error) {
+	path := url.GetParam(prefix+constant.WasmPathSuffix, "")
+	configKey := url.GetParam(prefix+constant.WasmConfigKeySuffix, "")
+	if path == "" && configKey == "" {
+		return nil, nil
+	}
+	name := path
+	if name == "" {
+		name = constant.WasmConfigKeySuffix + ":" + configKey
+	}
+
+	limits := Limits{}
+	if pages := url.GetParam(prefix+constant.WasmMemoryLimitPagesSuffix, ""); pages != "" {
+		n, err := strconv.ParseUint(pages, 10, 32)
+		if err != nil {
+			return nil, perrors.WithMessagef(err, "parse %s", prefix+constant.WasmMemoryLimitPagesSuffix)
+		}
+		limits.MemoryLimitPages = uint32(n)
+	}
+	if timeout := url.GetParam(prefix+constant.WasmTimeoutSuffix, ""); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			return nil, perrors.WithMessagef(err, "parse %s", prefix+constant.WasmTimeoutSuffix)
+		}
+		limits.Timeout = d
+	}
+
+	// urls with the same source but different limits get their own modules
+	key := fmt.Sprintf("%s#%d#%s", name, limits.MemoryLimitPages, limits.Timeout)
+	modulesLock.Lock()
+	l, ok := modules[key]
+	if !ok {
+		l = &moduleLoad{done: make(chan struct{})}
+		modules[key] = l
+	}
+	l.refs++
+	modulesLock.Unlock()
+	if ok {
+		<-l.done
+		return l.module, l.err
+	}
+
+	l.module, l.err = loadModule(key, name, path, configKey, limits)
+	if l.err != nil {
+		// the failed load is not shared, so that the next call tries again
+		modulesLock.Lock()
+		delete(modules, key)
+		modulesLock.Unlock()
+	}
+	close(l.done)
+	return l.module, l.err
+}
+
+func loadModule(key, name, path, configKey string, limits Limits) (*Module, error) {
+	m, err := NewModule(name, limits)
+	if err != nil {
+		return nil, err
+	}
+	m.key = key
+	if path != "" {
+		if err = m.WatchFile(path); err != nil {
+			m.Close()
+			return nil, err
+		}
+		return m, nil
+	}
+
+	dynamicConfiguration := conf.GetEnvInstance().GetDynamicConfiguration()
+	if dynamicConfiguration == nil {
+		m.Close()
+		return nil, perrors.Errorf("config center is not started, wasm module %s can not be loaded", configKey)
+	}
+	m.stopListening = func() {
+		dynamicConfiguration.RemoveListener(configKey, m)
+	}
+	dynamicConfiguration.AddListener(configKey, m)
+	value, err := dynamicConfiguration.GetRule(configKey)
+	if err != nil {
+		// the module is loaded once the key is published
+		logger.Warnf("[Wasm] get module %s from config center failed: %v", configKey, err)
+	} else {
+		m.Process(&config_center.ConfigChangeEvent{Key: configKey, Value: value, ConfigType: remoting.EventTypeUpdate})
+	}
+	return m, nil
+}
+
+// Release releases the module returned by ModuleFromURL, the module is closed once it is released by all the users.
+func (m *Module) Release() {
+	if m == nil {
+		return
+	}
+	modulesLock.Lock()
+	l, ok := modules[m.key]
+	if !ok || l.module != m {
+		modulesLock.Unlock()
+		return
+	}
+	if l.refs--; l.refs > 0 {
+		modulesLock.Unlock()
+		return
+	}
+	delete(modules, m.key)
+	modulesLock.Unlock()
+	m.Close()
+}
@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wasm
+
+import (
+	"context"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+)
+
+const (
+	// StatusOK is the result status of a successful invocation.
+	StatusOK int32 = 0
+	// StatusError is the result status of a failed invocation that the guest did not touch.
+	StatusError int32 = 1
+)
+
+type stateKey struct{}
+
+// CallState is the host side data a guest call can see and modify.
+type CallState struct {
+	// Method is the name of the invoked method.
+	Method string
+	// Attachments are the invocation attachments, the guest may modify them.
+	Attachments map[string]any
+	// Status is the mutable result status, StatusOK means success.
+	Status int32
+	// Invokers are the urls of the candidate invokers of a route call.
+	Invokers []*common.URL
+	// Selected are the indexes of the invokers selected by a route call.
+	Selected []int
+}
+
+// CopyAttachments returns a shallow copy of the attachments, so the changes of a guest call
+// can be compared with the origin.
+func CopyAttachments(attachments map[string]any) map[string]any {
+	res := make(map[string]any, len(attachments))
+	for k, v := range attachments {
+		res[k] = v
+	}
+	return res
+}
+
+func withState(ctx context.Context, state *CallState) context.Context {
+	return context.WithValue(ctx, stateKey{}, state)
+}
+
+func stateFrom(ctx context.Context) *CallState {
+	if state, ok := ctx.Value(stateKey{}).(*CallState); ok {
+		return state
+	}
+	return &CallState{}
+}
+
+func (s *CallState) attachment(key string) (string, bool) {
+	v, ok := s.Attachments[key]
+	if !ok {
+		return "", false
+	}
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case []string:
+		if len(v) == 0 {
+			return "", true
+		}
+		return v[0], true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,21 @@
+;; filter.wasm rejects requests carrying the `deny` attachment, marks the
+;; others with `wasm: yes`, and clears the error of failed responses.
+(module
+  (import "dubbo" "get_attachment" (func $get_attachment (param i32 i32 i32 i32) (result i32)))
+  (import "dubbo" "set_attachment" (func $set_attachment (param i32 i32 i32 i32)))
+  (import "dubbo" "get_status" (func $get_status (result i32)))
+  (import "dubbo" "set_status" (func $set_status (param i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 0) "deny")
+  (data (i32.const 16) "wasm")
+  (data (i32.const 32) "yes")
+  (func (export "on_request") (result i32)
+    (if (i32.ge_s (call $get_attachment (i32.const 0) (i32.const 4) (i32.const 64) (i32.const 16)) (i32.const 0))
+      (then (return (i32.const 403))))
+    (call $set_attachment (i32.const 16) (i32.const 4) (i32.const 32) (i32.const 3))
+    (i32.const 0))
+  (func (export "on_response") (result i32)
+    (if (call $get_status)
+      (then (call $set_status (i32.const 0))))
+    (call $set_attachment (i32.const 16) (i32.const 4) (i32.const 32) (i32.const 3))
+    (i32.const 0)))
@@ -0,0 +1,8 @@
+;; loop.wasm never returns, it is used to test the cpu limit.
+(module
+  (func (export "on_request") (result i32)
+    (loop (br 0))
+    (i32.const 0))
+  (func (export "route") (result i32)
+    (loop (br 0))
+    (i32.const 0)))
@@ -0,0 +1,5 @@
+;; memory.wasm asks for more memory than the default limit.
+(module
+  (memory 300)
+  (func (export "on_request") (result i32)
+    (i32.const 0)))
@@ -0,0 +1,16 @@
+;; router.wasm selects the invokers having the `zone` url param.
+(module
+  (import "dubbo" "invoker_count" (func $invoker_count (result i32)))
+  (import "dubbo" "get_invoker_param" (func $get_invoker_param (param i32 i32 i32 i32 i32) (result i32)))
+  (import "dubbo" "select_invoker" (func $select_invoker (param i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 0) "zone")
+  (func (export "route") (result i32) (local $i i32)
+    (block
+      (loop
+        (br_if 1 (i32.ge_s (local.get $i) (call $invoker_count)))
+        (if (i32.ge_s (call $get_invoker_param (local.get $i) (i32.const 0) (i32.const 4) (i32.const 64) (i32.const 16)) (i32.const 0))
+          (then (call $select_invoker (local.get $i))))
+        (local.set $i (i32.add (local.get $i) (i32.const 1)))
+        (br 0)))
+    (i32.const 0)))